@@ -18,9 +18,15 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/query"
 )
 
 func CheckUserAccessToEnvironments(ctx context.Context, db database.EnvironmentAccessControlData, user model.User, environments ...string) (bool, error) {
@@ -49,3 +55,125 @@ func CheckUserAccessToEnvironments(ctx context.Context, db database.EnvironmentA
 
 	return true, nil
 }
+
+// SyncEnvironmentAccess grants the user either all environments or exactly the given environments. Access is only
+// written when it differs from what the user already has.
+func SyncEnvironmentAccess(ctx context.Context, db database.EnvironmentAccessControlData, user model.User, allEnvironments bool, environments []string) error {
+	if allEnvironments {
+		if user.AllEnvironments {
+			return nil
+		} else if err := db.GrantAllEnvironmentsForUser(ctx, user); err != nil {
+			return fmt.Errorf("grant all environments: %w", err)
+		}
+	} else if accessList, err := db.GetEnvironmentAccessListForUser(ctx, user); err != nil {
+		return fmt.Errorf("get environment access list: %w", err)
+	} else {
+		currentEnvironments := make([]string, 0, len(accessList))
+		for _, envAccess := range accessList {
+			currentEnvironments = append(currentEnvironments, envAccess.Environment)
+		}
+
+		environments = slices.Clone(environments)
+		slices.Sort(currentEnvironments)
+		slices.Sort(environments)
+
+		if user.AllEnvironments || !slices.Equal(currentEnvironments, slices.Compact(environments)) {
+			if err := db.UpdateEnvironmentListForUser(ctx, user, environments...); err != nil {
+				return fmt.Errorf("update environment access list: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EnvironmentScope describes the environments, identified by domain SID or tenant ID, whose graph data a user may read.
+// The zero value is unrestricted.
+type EnvironmentScope struct {
+	Restricted   bool
+	Environments []string
+}
+
+// GetEnvironmentScope returns the environment scope for the given user. Users with access to all environments are
+// unrestricted.
+func GetEnvironmentScope(ctx context.Context, db database.EnvironmentAccessControlData, user model.User) (EnvironmentScope, error) {
+	if user.AllEnvironments {
+		return EnvironmentScope{}, nil
+	} else if allowedList, err := db.GetEnvironmentAccessListForUser(ctx, user); err != nil {
+		return EnvironmentScope{}, err
+	} else {
+		scope := EnvironmentScope{
+			Restricted:   true,
+			Environments: make([]string, 0, len(allowedList)),
+		}
+
+		for _, envAccess := range allowedList {
+			scope.Environments = append(scope.Environments, envAccess.Environment)
+		}
+
+		return scope, nil
+	}
+}
+
+// AllowsNode returns true if the node's domainsid or tenantid belongs to an environment in scope. Nodes that belong to
+// neither a domain nor a tenant are only visible to unrestricted users.
+func (s EnvironmentScope) AllowsNode(node *graph.Node) bool {
+	if !s.Restricted {
+		return true
+	}
+
+	for _, environmentProperty := range []string{ad.DomainSID.String(), azure.TenantID.String()} {
+		if environment, err := node.Properties.Get(environmentProperty).String(); err == nil && slices.Contains(s.Environments, environment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterNodes returns the subset of nodes that are in scope
+func (s EnvironmentScope) FilterNodes(nodes graph.NodeSet) graph.NodeSet {
+	if !s.Restricted {
+		return nodes
+	}
+
+	filtered := graph.NewNodeSet()
+
+	for _, node := range nodes {
+		if s.AllowsNode(node) {
+			filtered.Add(node)
+		}
+	}
+
+	return filtered
+}
+
+// FilterPaths returns the paths whose every node is in scope
+func (s EnvironmentScope) FilterPaths(paths graph.PathSet) graph.PathSet {
+	if !s.Restricted {
+		return paths
+	}
+
+	var filtered graph.PathSet
+
+	for _, path := range paths {
+		if !slices.ContainsFunc(path.Nodes, func(node *graph.Node) bool { return !s.AllowsNode(node) }) {
+			filtered.AddPath(path)
+		}
+	}
+
+	return filtered
+}
+
+// Criteria returns graph criteria matching nodes in scope for the given qualifier, such as query.Node() or
+// query.End(). A nil criteria is returned for unrestricted scopes.
+func (s EnvironmentScope) Criteria(qualifier graph.Criteria) graph.Criteria {
+	if !s.Restricted {
+		return nil
+	}
+
+	return query.Or(
+		query.In(query.Property(qualifier, ad.DomainSID.String()), s.Environments),
+		query.In(query.Property(qualifier, azure.TenantID.String()), s.Environments),
+	)
+}
@@ -25,6 +25,9 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/database/mocks"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/dawgs/graph"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -207,3 +210,93 @@ func Test_CheckAccessToEnvironments(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetEnvironmentScope(t *testing.T) {
+	t.Parallel()
+
+	userUuid, err := uuid.NewV4()
+	require.NoError(t, err)
+
+	t.Run("All Environments Is Unrestricted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctrl   = gomock.NewController(t)
+			mockDB = mocks.NewMockDatabase(ctrl)
+		)
+
+		scope, err := api.GetEnvironmentScope(context.Background(), mockDB, model.User{Unique: model.Unique{ID: userUuid}, AllEnvironments: true})
+
+		require.NoError(t, err)
+		assert.False(t, scope.Restricted)
+		assert.Nil(t, scope.Criteria(nil))
+	})
+
+	t.Run("Access List Restricts Scope", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctrl   = gomock.NewController(t)
+			mockDB = mocks.NewMockDatabase(ctrl)
+			user   = model.User{Unique: model.Unique{ID: userUuid}}
+		)
+
+		mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), user).Return([]database.EnvironmentAccess{
+			{UserID: userUuid.String(), Environment: "S-1-5-21-1"},
+			{UserID: userUuid.String(), Environment: "tenant-1"},
+		}, nil)
+
+		scope, err := api.GetEnvironmentScope(context.Background(), mockDB, user)
+
+		require.NoError(t, err)
+		assert.True(t, scope.Restricted)
+		assert.Equal(t, []string{"S-1-5-21-1", "tenant-1"}, scope.Environments)
+	})
+}
+
+func TestEnvironmentScope_AllowsNode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		scope         = api.EnvironmentScope{Restricted: true, Environments: []string{"S-1-5-21-1", "tenant-1"}}
+		inDomain      = graph.NewNode(1, graph.AsProperties(map[string]any{ad.DomainSID.String(): "S-1-5-21-1"}), ad.User)
+		otherDomain   = graph.NewNode(2, graph.AsProperties(map[string]any{ad.DomainSID.String(): "S-1-5-21-2"}), ad.User)
+		inTenant      = graph.NewNode(3, graph.AsProperties(map[string]any{azure.TenantID.String(): "tenant-1"}), azure.User)
+		noEnvironment = graph.NewNode(4, graph.NewProperties(), ad.Entity)
+	)
+
+	assert.True(t, scope.AllowsNode(inDomain))
+	assert.False(t, scope.AllowsNode(otherDomain))
+	assert.True(t, scope.AllowsNode(inTenant))
+	assert.False(t, scope.AllowsNode(noEnvironment))
+	assert.True(t, api.EnvironmentScope{}.AllowsNode(noEnvironment))
+
+	t.Run("FilterNodes", func(t *testing.T) {
+		filtered := scope.FilterNodes(graph.NewNodeSet(inDomain, otherDomain, inTenant, noEnvironment))
+
+		assert.Equal(t, 2, filtered.Len())
+		assert.True(t, filtered.Contains(inDomain))
+		assert.True(t, filtered.Contains(inTenant))
+	})
+
+	t.Run("FilterPaths", func(t *testing.T) {
+		var paths graph.PathSet
+
+		paths.AddPath(graph.Path{
+			Nodes: []*graph.Node{inDomain, inTenant},
+			Edges: []*graph.Relationship{graph.NewRelationship(5, 1, 3, graph.NewProperties(), ad.MemberOf)},
+		})
+		paths.AddPath(graph.Path{
+			Nodes: []*graph.Node{inDomain, otherDomain, inTenant},
+			Edges: []*graph.Relationship{
+				graph.NewRelationship(6, 1, 2, graph.NewProperties(), ad.MemberOf),
+				graph.NewRelationship(7, 2, 3, graph.NewProperties(), ad.MemberOf),
+			},
+		})
+
+		filtered := scope.FilterPaths(paths)
+
+		require.Equal(t, 1, filtered.Len())
+		assert.Len(t, filtered[0].Edges, 1)
+	})
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/ctx"
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
 	"github.com/specterops/bloodhound/packages/go/crypto"
@@ -50,10 +51,42 @@ var (
 	ErrInvalidAuth                  = errors.New("invalid authentication")
 	ErrNoUserSecret                 = errors.New("user does not have a secret auth provider registered")
 	ErrUserDisabled                 = errors.New("user disabled")
+	ErrUserLocked                   = errors.New("user account temporarily locked")
+	ErrAuthTokenDisabled            = errors.New("auth token disabled")
+	ErrAuthTokenExpired             = errors.New("auth token expired")
 	ErrUserNotAuthorizedForProvider = errors.New("user not authorized for this provider")
 	ErrInvalidAuthProvider          = errors.New("invalid auth provider")
+	ErrAuthTokenMethod              = errors.New("auth token method not supported for this authorization scheme")
+	ErrWebAuthnRequired             = errors.New("webauthn assertion required")
+	ErrWebAuthnNotRegistered        = errors.New("user does not have any webauthn credentials registered")
 )
 
+const (
+	// SCIMTokenPrefix marks bearer tokens that carry a SCIM provisioning token rather than a session JWT
+	SCIMTokenPrefix = "scim."
+
+	// SCIMRoutePrefix is the path prefix of the SCIM 2.0 service provider; SCIM tokens are not accepted outside of it
+	SCIMRoutePrefix = "/scim/v2/"
+)
+
+// FormatSCIMBearerToken renders the bearer token a SCIM client presents for the given SCIM auth token
+func FormatSCIMBearerToken(authToken model.AuthToken) string {
+	return SCIMTokenPrefix + authToken.ID.String() + "." + authToken.Key
+}
+
+// ParseSCIMBearerToken splits a SCIM bearer token into its auth token ID and key
+func ParseSCIMBearerToken(bearerToken string) (uuid.UUID, string, error) {
+	if rawToken, found := strings.CutPrefix(bearerToken, SCIMTokenPrefix); !found {
+		return uuid.Nil, "", ErrInvalidAuth
+	} else if rawTokenID, key, found := strings.Cut(rawToken, "."); !found || key == "" {
+		return uuid.Nil, "", ErrInvalidAuth
+	} else if tokenID, err := uuid.FromString(rawTokenID); err != nil {
+		return uuid.Nil, "", ErrInvalidAuth
+	} else {
+		return tokenID, key, nil
+	}
+}
+
 func parseRequestDate(rawDate string) (time.Time, error) {
 	if requestDate, err := time.Parse(time.RFC3339, rawDate); err != nil {
 		if requestDate, err := time.Parse(time.RFC3339Nano, rawDate); err == nil {
@@ -68,12 +101,14 @@ func parseRequestDate(rawDate string) (time.Time, error) {
 
 type Authenticator interface {
 	LoginWithSecret(ctx context.Context, loginRequest LoginRequest) (LoginDetails, error)
+	BeginWebAuthnLogin(ctx context.Context, loginRequest LoginRequest) (WebAuthnLoginOptions, error)
 	Logout(ctx context.Context, userSession model.UserSession)
 	ValidateSecret(ctx context.Context, secret string, authSecret model.AuthSecret) error
 	ValidateRequestSignature(tokenID uuid.UUID, request *http.Request, serverTime time.Time) (auth.Context, int, error)
 	CreateSession(ctx context.Context, user model.User, authProvider any) (string, error)
-	CreateSSOSession(request *http.Request, response http.ResponseWriter, principalNameOrEmail string, ssoProvider model.SSOProvider)
+	CreateSSOSession(request *http.Request, response http.ResponseWriter, principalNameOrEmail string, ssoProvider model.SSOProvider, claims model.SSOClaims)
 	ValidateSession(ctx context.Context, jwtTokenString string) (auth.Context, error)
+	ValidateSCIMToken(ctx context.Context, bearerToken string) (auth.Context, error)
 }
 
 type authenticator struct {
@@ -95,9 +130,14 @@ func NewAuthenticator(cfg config.Configuration, db database.Database, ctxInitial
 }
 
 func (s authenticator) auditLogin(requestContext context.Context, commitID uuid.UUID, status model.AuditLogEntryStatus, user model.User, fields types.JSONUntypedObject) {
+	s.auditUserAction(requestContext, model.AuditLogActionLoginAttempt, commitID, status, user, fields)
+}
+
+// auditUserAction writes an audit log entry acted by the given user, who may not be authenticated yet
+func (s authenticator) auditUserAction(requestContext context.Context, action model.AuditLogAction, commitID uuid.UUID, status model.AuditLogEntryStatus, user model.User, fields types.JSONUntypedObject) {
 	bhCtx := ctx.Get(requestContext)
 	auditLog := model.AuditLog{
-		Action:          model.AuditLogActionLoginAttempt,
+		Action:          action,
 		Fields:          fields,
 		RequestID:       bhCtx.RequestID,
 		SourceIpAddress: bhCtx.RequestIP,
@@ -113,27 +153,152 @@ func (s authenticator) auditLogin(requestContext context.Context, commitID uuid.
 
 	err := s.db.CreateAuditLog(requestContext, auditLog)
 	if err != nil {
-		slog.WarnContext(requestContext, fmt.Sprintf("failed to write login audit log %+v", err))
+		slog.WarnContext(requestContext, fmt.Sprintf("failed to write %s audit log %+v", action, err))
 	}
 }
 
-func (s authenticator) validateSecretLogin(ctx context.Context, loginRequest LoginRequest) (model.User, string, error) {
+// authenticateSecret looks up the user named in the login request and validates their secret
+func (s authenticator) authenticateSecret(ctx context.Context, loginRequest LoginRequest) (model.User, error) {
 	if user, err := s.db.LookupUser(ctx, loginRequest.Username); err != nil {
 		if errors.Is(err, database.ErrNotFound) {
-			return model.User{}, "", ErrInvalidAuth
+			return model.User{}, ErrInvalidAuth
 		}
 
-		return model.User{}, "", FormatDatabaseError(err)
+		return model.User{}, FormatDatabaseError(err)
 	} else if user.AuthSecret == nil {
-		return user, "", ErrNoUserSecret
+		return user, ErrNoUserSecret
+	} else if user.AuthSecret.Locked() {
+		// A locked account fails exactly like a bad secret, including the cost of validating the secret, so that
+		// neither the response nor its timing reveals the lockout or whether the secret was correct
+		_ = s.ValidateSecret(ctx, loginRequest.Secret, *user.AuthSecret)
+		return user, fmt.Errorf("%w: %w", ErrInvalidAuth, ErrUserLocked)
 	} else if err := s.ValidateSecret(ctx, loginRequest.Secret, *user.AuthSecret); err != nil {
-		return user, "", err
-	} else if err = auth.ValidateTOTPSecret(loginRequest.OTP, *user.AuthSecret); err != nil {
-		return user, "", err
-	} else if sessionToken, err := s.CreateSession(ctx, user, *user.AuthSecret); err != nil {
-		return user, "", err
+		if errors.Is(err, ErrInvalidAuth) {
+			s.recordFailedLogin(ctx, user)
+		}
+
+		return user, err
+	} else {
+		return user, nil
+	}
+}
+
+// recordFailedLogin counts a failed login against the user's secret and locks the account for the configured duration
+// once the lockout threshold is reached
+func (s authenticator) recordFailedLogin(ctx context.Context, user model.User) {
+	lockout := appcfg.GetAccountLockoutParameter(ctx, s.db)
+
+	if lockout.Threshold <= 0 {
+		return
+	} else if failedLoginCount, err := s.db.RecordFailedLogin(ctx, *user.AuthSecret); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Failed to record failed login for user %s: %v", user.ID, err))
+	} else if failedLoginCount >= lockout.Threshold {
+		var (
+			lockedUntil    = time.Now().Add(lockout.Duration).UTC()
+			auditLogFields = types.JSONUntypedObject{"failed_login_count": failedLoginCount, "locked_until": lockedUntil}
+		)
+
+		if commitID, err := uuid.NewV4(); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("Error generating commit ID for account lockout: %v", err))
+		} else if err := s.db.LockAuthSecret(ctx, *user.AuthSecret, lockedUntil); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("Failed to lock account of user %s: %v", user.ID, err))
+			auditLogFields["error"] = err
+			s.auditUserAction(ctx, model.AuditLogActionLockUserAccount, commitID, model.AuditLogStatusFailure, user, auditLogFields)
+		} else {
+			s.auditUserAction(ctx, model.AuditLogActionLockUserAccount, commitID, model.AuditLogStatusSuccess, user, auditLogFields)
+		}
+	}
+}
+
+// validateSecondFactor checks the second factor of a secret login. A WebAuthn assertion is always accepted in place of
+// a one time password. Users who registered a WebAuthn credential but never activated TOTP, and users whose role
+// requires WebAuthn, must present an assertion. A user whose role requires WebAuthn but who has not registered a
+// credential yet is let in to enroll; the returned flags restrict the session until they do.
+func (s authenticator) validateSecondFactor(ctx context.Context, user model.User, loginRequest LoginRequest) (types.JSONBBoolObject, error) {
+	webAuthnRequired := appcfg.GetWebAuthnParameter(ctx, s.db).RequiredFor(user.Roles)
+
+	if credentials, err := s.db.GetWebAuthnCredentials(ctx, user.ID); err != nil {
+		return nil, FormatDatabaseError(err)
+	} else if loginRequest.WebAuthn != nil {
+		return nil, s.validateWebAuthnAssertion(ctx, user, credentials, *loginRequest.WebAuthn)
+	} else if len(credentials) > 0 && (webAuthnRequired || !user.AuthSecret.TOTPActivated) {
+		return nil, ErrWebAuthnRequired
+	} else if err := auth.ValidateTOTPSecret(loginRequest.OTP, *user.AuthSecret); err != nil {
+		return nil, err
+	} else if webAuthnRequired && len(credentials) == 0 {
+		return types.JSONBBoolObject{string(model.SessionFlagWebAuthnEnrollmentRequired): true}, nil
+	} else {
+		return nil, nil
+	}
+}
+
+// validateWebAuthnAssertion verifies an assertion made with one of the user's credentials and records the credential use.
+// The ceremony is consumed before the assertion is verified, so a challenge cannot be answered twice even if the first
+// answer was rejected.
+func (s authenticator) validateWebAuthnAssertion(ctx context.Context, user model.User, credentials model.WebAuthnCredentials, assertion WebAuthnLoginAssertion) error {
+	credentialID := assertion.Credential.ID
+	if len(assertion.Credential.RawID) > 0 {
+		credentialID = assertion.Credential.RawID.String()
+	}
+
+	credential, found := credentials.Find(credentialID)
+	if !found {
+		return auth.ErrInvalidWebAuthn
+	} else if userHandle := assertion.Credential.Response.UserHandle; len(userHandle) > 0 && !bytes.Equal(userHandle, user.ID.Bytes()) {
+		return auth.ErrInvalidWebAuthn
+	}
+
+	commitID, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	auditLogFields := types.JSONUntypedObject{"credential_id": credential.CredentialID, "credential_name": credential.Name}
+
+	if signingKeyBytes, err := s.cfg.Crypto.JWT.SigningKeyBytes(); err != nil {
+		return err
+	} else if ceremony, err := auth.ParseWebAuthnCeremonyToken(signingKeyBytes, assertion.CeremonyToken, auth.WebAuthnCeremonyGet, user.ID); err != nil {
+		return err
+	} else if err := s.db.ConsumeWebAuthnCeremony(ctx, ceremony.Id, time.Unix(ceremony.ExpiresAt, 0)); errors.Is(err, database.ErrWebAuthnCeremonyConsumed) {
+		return auth.ErrWebAuthnCeremonyConsumed
+	} else if err != nil {
+		return FormatDatabaseError(err)
+	} else if signCount, err := auth.NewWebAuthnRelyingParty(s.cfg.RootURL.AsURL()).VerifyAssertion(ceremony.Challenge, assertion.Credential, credential.PublicKey, uint32(credential.SignCount)); err != nil {
+		auditLogFields["error"] = err
+		s.auditUserAction(ctx, model.AuditLogActionUseWebAuthnCredential, commitID, model.AuditLogStatusFailure, user, auditLogFields)
+		return err
+	} else {
+		credential.SignCount = int64(signCount)
+		credential.LastUsedAt = null.TimeFrom(time.Now().UTC())
+
+		if err := s.db.UpdateWebAuthnCredentialUsage(ctx, credential); err != nil {
+			return FormatDatabaseError(err)
+		}
+
+		s.auditUserAction(ctx, model.AuditLogActionUseWebAuthnCredential, commitID, model.AuditLogStatusSuccess, user, auditLogFields)
+		return nil
+	}
+}
+
+func (s authenticator) validateSecretLogin(ctx context.Context, loginRequest LoginRequest) (model.User, string, types.JSONBBoolObject, error) {
+	if user, err := s.authenticateSecret(ctx, loginRequest); err != nil {
+		return user, "", nil, err
+	} else if sessionFlags, err := s.validateSecondFactor(ctx, user, loginRequest); err != nil {
+		if errors.Is(err, auth.ErrInvalidOTP) || errors.Is(err, auth.ErrInvalidWebAuthn) {
+			s.recordFailedLogin(ctx, user)
+		}
+
+		return user, "", nil, err
+	} else if sessionToken, err := s.createSession(ctx, user, *user.AuthSecret, sessionFlags); err != nil {
+		return user, "", nil, err
 	} else {
-		return user, sessionToken, nil
+		if user.AuthSecret.FailedLoginCount > 0 {
+			if err := s.db.ResetFailedLogins(ctx, *user.AuthSecret); err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("Failed to reset failed login count for user %s: %v", user.ID, err))
+			}
+		}
+
+		return user, sessionToken, sessionFlags, nil
 	}
 }
 
@@ -146,20 +311,60 @@ func (s authenticator) LoginWithSecret(ctx context.Context, loginRequest LoginRe
 	} else {
 		s.auditLogin(ctx, commitID, model.AuditLogStatusIntent, model.User{}, auditLogFields)
 
-		if user, sessionToken, err := s.validateSecretLogin(ctx, loginRequest); err != nil {
+		if loginRequest.WebAuthn != nil {
+			auditLogFields["second_factor"] = "webauthn"
+		}
+
+		if user, sessionToken, sessionFlags, err := s.validateSecretLogin(ctx, loginRequest); err != nil {
 			auditLogFields["error"] = err
 			s.auditLogin(ctx, commitID, model.AuditLogStatusFailure, user, auditLogFields)
 			return LoginDetails{}, err
 		} else {
 			s.auditLogin(ctx, commitID, model.AuditLogStatusSuccess, user, auditLogFields)
 			return LoginDetails{
-				User:         user,
-				SessionToken: sessionToken,
+				User:                       user,
+				SessionToken:               sessionToken,
+				WebAuthnEnrollmentRequired: sessionFlags[string(model.SessionFlagWebAuthnEnrollmentRequired)],
 			}, nil
 		}
 	}
 }
 
+// BeginWebAuthnLogin validates the secret of a user who logs in with a WebAuthn credential as their second factor and
+// returns the options to assert one of their credentials with
+func (s authenticator) BeginWebAuthnLogin(ctx context.Context, loginRequest LoginRequest) (WebAuthnLoginOptions, error) {
+	if user, err := s.authenticateSecret(ctx, loginRequest); err != nil {
+		return WebAuthnLoginOptions{}, err
+	} else if user.IsDisabled {
+		return WebAuthnLoginOptions{}, ErrUserDisabled
+	} else if credentials, err := s.db.GetWebAuthnCredentials(ctx, user.ID); err != nil {
+		return WebAuthnLoginOptions{}, FormatDatabaseError(err)
+	} else if len(credentials) == 0 {
+		return WebAuthnLoginOptions{}, ErrWebAuthnNotRegistered
+	} else if challenge, err := auth.GenerateWebAuthnChallenge(); err != nil {
+		return WebAuthnLoginOptions{}, err
+	} else if signingKeyBytes, err := s.cfg.Crypto.JWT.SigningKeyBytes(); err != nil {
+		return WebAuthnLoginOptions{}, err
+	} else if ceremonyToken, err := auth.NewWebAuthnCeremonyToken(signingKeyBytes, auth.WebAuthnCeremonyGet, user.ID, challenge, time.Now()); err != nil {
+		return WebAuthnLoginOptions{}, err
+	} else {
+		credentialIDs := make([][]byte, 0, len(credentials))
+
+		for _, credential := range credentials {
+			if credentialID, err := base64.RawURLEncoding.DecodeString(credential.CredentialID); err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("Skipping malformed webauthn credential %d for user %s", credential.ID, user.ID))
+			} else {
+				credentialIDs = append(credentialIDs, credentialID)
+			}
+		}
+
+		return WebAuthnLoginOptions{
+			PublicKey:     auth.NewWebAuthnRelyingParty(s.cfg.RootURL.AsURL()).RequestOptions(challenge, credentialIDs),
+			CeremonyToken: ceremonyToken,
+		}, nil
+	}
+}
+
 func (s authenticator) Logout(ctx context.Context, userSession model.UserSession) {
 	s.db.EndUserSession(ctx, userSession)
 }
@@ -237,6 +442,12 @@ func (s authenticator) ValidateRequestSignature(tokenID uuid.UUID, request *http
 		return auth.Context{}, http.StatusBadRequest, fmt.Errorf("malformed signature header: %w", err)
 	} else if authToken, err := s.db.GetAuthToken(request.Context(), tokenID); err != nil {
 		return handleAuthDBError(err)
+	} else if authToken.HmacMethod == auth.SCIM_BEARER {
+		return auth.Context{}, http.StatusUnauthorized, ErrAuthTokenMethod
+	} else if authToken.IsDisabled {
+		return auth.Context{}, http.StatusUnauthorized, ErrAuthTokenDisabled
+	} else if authToken.IsExpired(serverTime) {
+		return auth.Context{}, http.StatusUnauthorized, ErrAuthTokenExpired
 	} else if authContext, err := s.ctxInitializer.InitContextFromToken(request.Context(), authToken); err != nil {
 		return handleAuthDBError(err)
 	} else if user, isUser := auth.GetUserFromAuthCtx(authContext); isUser && user.IsDisabled {
@@ -291,6 +502,14 @@ func (s authenticator) ValidateRequestSignature(tokenID uuid.UUID, request *http
 
 			request.Body = readCloser
 
+			// Scoped tokens may only exercise the subset of their owner's permissions they were issued with
+			if authToken.IsScoped() {
+				authContext.PermissionScope = auth.PermissionScope{
+					Enabled:     true,
+					Permissions: authToken.Permissions,
+				}
+			}
+
 			return authContext, http.StatusOK, nil
 		}
 	}
@@ -328,7 +547,7 @@ func SetSecureBrowserCookie(request *http.Request, response http.ResponseWriter,
 	})
 }
 
-func (s authenticator) CreateSSOSession(request *http.Request, response http.ResponseWriter, principalNameOrEmail string, ssoProvider model.SSOProvider) {
+func (s authenticator) CreateSSOSession(request *http.Request, response http.ResponseWriter, principalNameOrEmail string, ssoProvider model.SSOProvider, claims model.SSOClaims) {
 	var (
 		hostURL    = *ctx.FromRequest(request).Host
 		requestCtx = request.Context()
@@ -387,6 +606,13 @@ func (s authenticator) CreateSSOSession(request *http.Request, response http.Res
 			return
 		}
 
+		if user, err = s.applySSOMappingRules(requestCtx, user, ssoProvider, claims); err != nil {
+			auditLogFields["error"] = err
+			slog.WarnContext(request.Context(), fmt.Sprintf("[SSO] Error applying mapping rules: %v", err))
+			RedirectToLoginURL(response, request, "We’re having trouble connecting. Please check your internet and try again.")
+			return
+		}
+
 		if sessionJWT, err := s.CreateSession(requestCtx, user, authProvider); err != nil {
 			auditLogFields["error"] = err
 			if locationURL := URLJoinPath(hostURL, UserDisabledPath); errors.Is(err, ErrUserDisabled) {
@@ -411,18 +637,93 @@ func (s authenticator) CreateSSOSession(request *http.Request, response http.Res
 	}
 }
 
+// applySSOMappingRules evaluates the mapping rules of an SSO provider against the claims of a login. Rules are only
+// applied while auto provisioning is enabled. The role of the matching rule with the highest precedence, or the
+// provider's default role when no rule matches, replaces the role of the user and any change is recorded in the audit
+// log. When the provider manages environments, the environments of the matching rule replace those of the user. A user
+// that matches no rule granting environments keeps the group environment mappings applied during provisioning, or is
+// left with no environments when those are disabled.
+func (s authenticator) applySSOMappingRules(ctx context.Context, user model.User, ssoProvider model.SSOProvider, claims model.SSOClaims) (model.User, error) {
+	var (
+		autoProvisionConfig = ssoProvider.Config.AutoProvision
+		roleID              = autoProvisionConfig.DefaultRoleId
+	)
+
+	if !autoProvisionConfig.Enabled || len(autoProvisionConfig.MappingRules) == 0 {
+		return user, nil
+	}
+
+	rule, matched, err := autoProvisionConfig.MatchMappingRule(claims)
+	if err != nil {
+		return user, fmt.Errorf("match mapping rule: %w", err)
+	} else if matched {
+		roleID = rule.RoleId
+	}
+
+	if role, err := s.db.GetRole(ctx, roleID); err != nil {
+		return user, fmt.Errorf("get role %d: %w", roleID, err)
+	} else if len(user.Roles) != 1 || !user.Roles.Has(role) {
+		auditLogFields := types.JSONUntypedObject{
+			"sso_provider_id": ssoProvider.ID,
+			"previous_roles":  user.Roles.Names(),
+			"roles":           []string{role.Name},
+			"matched_rule":    matched,
+		}
+
+		if matched {
+			auditLogFields["claim"] = rule.Claim
+			auditLogFields["priority"] = rule.Priority
+		}
+
+		user.Roles = model.Roles{role}
+		if err := s.db.UpdateUser(ctx, user); err != nil {
+			return user, fmt.Errorf("update user: %w", err)
+		}
+
+		if commitID, err := uuid.NewV4(); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("[SSO] Error generating commit ID for role change: %s", err))
+		} else {
+			s.auditUserAction(ctx, model.AuditLogActionSSOMappingRoleChange, commitID, model.AuditLogStatusSuccess, user, auditLogFields)
+		}
+	}
+
+	if !autoProvisionConfig.ProvisionsEnvironments() {
+		return user, nil
+	} else if matched && rule.GrantsEnvironments() {
+		if err := SyncEnvironmentAccess(ctx, s.db, user, rule.AllEnvironments, rule.Environments); err != nil {
+			return user, err
+		}
+	} else if !autoProvisionConfig.EnvironmentProvision {
+		if err := SyncEnvironmentAccess(ctx, s.db, user, false, nil); err != nil {
+			return user, err
+		}
+	}
+
+	return user, nil
+}
+
 func (s authenticator) CreateSession(ctx context.Context, user model.User, authProvider any) (string, error) {
+	return s.createSession(ctx, user, authProvider, nil)
+}
+
+func (s authenticator) createSession(requestCtx context.Context, user model.User, authProvider any, flags types.JSONBBoolObject) (string, error) {
 	if user.IsDisabled {
 		return "", ErrUserDisabled
 	}
 
-	slog.InfoContext(ctx, fmt.Sprintf("Creating session for user: %s(%s)", user.ID, user.PrincipalName))
+	slog.InfoContext(requestCtx, fmt.Sprintf("Creating session for user: %s(%s)", user.ID, user.PrincipalName))
 
-	userSession := model.UserSession{
-		User:      user,
-		UserID:    user.ID,
-		ExpiresAt: time.Now().UTC().Add(appcfg.GetSessionTTLHours(ctx, s.db)),
-	}
+	var (
+		bhCtx       = ctx.Get(requestCtx)
+		userSession = model.UserSession{
+			User:      user,
+			UserID:    user.ID,
+			ExpiresAt: time.Now().UTC().Add(appcfg.GetSessionTTLHours(requestCtx, s.db)),
+			Flags:     flags,
+			IPAddress: bhCtx.RequestIP,
+			UserAgent: bhCtx.UserAgent,
+		}
+	)
 
 	switch typedAuthProvider := authProvider.(type) {
 	case model.AuthSecret:
@@ -438,7 +739,7 @@ func (s authenticator) CreateSession(ctx context.Context, user model.User, authP
 		return "", ErrInvalidAuthProvider
 	}
 
-	if newSession, err := s.db.CreateUserSession(ctx, userSession); err != nil {
+	if newSession, err := s.db.CreateUserSession(requestCtx, userSession); err != nil {
 		return "", FormatDatabaseError(err)
 	} else if signingKeyBytes, err := s.cfg.Crypto.JWT.SigningKeyBytes(); err != nil {
 		return "", err
@@ -494,7 +795,8 @@ func (s authenticator) ValidateSession(ctx context.Context, jwtTokenString strin
 		if session.AuthProviderType == model.SessionAuthProviderSecret && session.User.AuthSecret == nil {
 			slog.InfoContext(ctx, fmt.Sprintf("No auth secret found for user ID %s", session.UserID.String()))
 			return auth.Context{}, ErrNoUserSecret
-		} else if session.AuthProviderType == model.SessionAuthProviderSecret && session.User.AuthSecret.Expired() {
+		} else if session.AuthProviderType == model.SessionAuthProviderSecret && (session.User.AuthSecret.Expired() || session.GetFlag(model.SessionFlagWebAuthnEnrollmentRequired)) {
+			// Users with an expired secret, or who must register a WebAuthn credential, may only manage their own account
 			var (
 				authManageSelfPermission = auth.Permissions().AuthManageSelf
 				permissions              model.Permissions
@@ -524,20 +826,74 @@ func (s authenticator) ValidateSession(ctx context.Context, jwtTokenString strin
 	}
 }
 
+// ValidateSCIMToken authenticates a SCIM provisioning client by its bearer token. The resulting auth context acts as the
+// token's owner and is scoped to the permissions the token was issued with.
+func (s authenticator) ValidateSCIMToken(ctx context.Context, bearerToken string) (auth.Context, error) {
+	if tokenID, key, err := ParseSCIMBearerToken(bearerToken); err != nil {
+		return auth.Context{}, err
+	} else if authToken, err := s.db.GetAuthToken(ctx, tokenID); err != nil {
+		slog.InfoContext(ctx, fmt.Sprintf("Unable to find SCIM token %s", tokenID))
+		return auth.Context{}, ErrInvalidAuth
+	} else if authToken.HmacMethod != auth.SCIM_BEARER {
+		return auth.Context{}, ErrAuthTokenMethod
+	} else if subtle.ConstantTimeCompare([]byte(key), []byte(authToken.Key)) != 1 {
+		return auth.Context{}, ErrInvalidAuth
+	} else if authToken.IsDisabled {
+		return auth.Context{}, ErrAuthTokenDisabled
+	} else if authToken.IsExpired(time.Now()) {
+		return auth.Context{}, ErrAuthTokenExpired
+	} else if authContext, err := s.ctxInitializer.InitContextFromToken(ctx, authToken); err != nil {
+		return auth.Context{}, FormatDatabaseError(err)
+	} else if user, isUser := auth.GetUserFromAuthCtx(authContext); !isUser {
+		return auth.Context{}, ErrInvalidAuth
+	} else if user.IsDisabled {
+		return auth.Context{}, ErrUserDisabled
+	} else {
+		authContext.SCIMToken = true
+		authContext.PermissionScope = auth.PermissionScope{
+			Enabled:     true,
+			Permissions: authToken.Permissions,
+		}
+
+		authToken.LastAccess = time.Now().UTC()
+
+		if err := s.db.UpdateAuthToken(ctx, authToken); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("Error updating last access on AuthToken: %v", err))
+		}
+
+		return authContext, nil
+	}
+}
+
 type LoginRequest struct {
 	LoginMethod string `json:"login_method"`
 	Username    string `json:"username"`
 	Secret      string `json:"secret,omitempty"`
 	OTP         string `json:"otp,omitempty"`
+
+	// WebAuthn carries an assertion made with one of the user's WebAuthn credentials in place of a one time password
+	WebAuthn *WebAuthnLoginAssertion `json:"webauthn,omitempty"`
+}
+
+type WebAuthnLoginAssertion struct {
+	CeremonyToken string                         `json:"ceremony_token"`
+	Credential    auth.WebAuthnAssertionResponse `json:"credential"`
+}
+
+type WebAuthnLoginOptions struct {
+	PublicKey     auth.WebAuthnRequestOptions `json:"public_key"`
+	CeremonyToken string                      `json:"ceremony_token"`
 }
 
 type LoginDetails struct {
-	User         model.User
-	SessionToken string
+	User                       model.User
+	SessionToken               string
+	WebAuthnEnrollmentRequired bool
 }
 
 type LoginResponse struct {
-	UserID       string `json:"user_id"`
-	AuthExpired  bool   `json:"auth_expired"`
-	SessionToken string `json:"session_token"`
+	UserID                     string `json:"user_id"`
+	AuthExpired                bool   `json:"auth_expired"`
+	SessionToken               string `json:"session_token"`
+	WebAuthnEnrollmentRequired bool   `json:"webauthn_enrollment_required,omitempty"`
 }
@@ -33,10 +33,13 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/auth"
 	"github.com/specterops/bloodhound/cmd/api/src/config"
 	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
 	dbMocks "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/bloodhound/cmd/api/src/test/must"
 	cryptoMocks "github.com/specterops/bloodhound/packages/go/crypto/mocks"
 	"github.com/specterops/bloodhound/packages/go/headers"
 	"github.com/specterops/bloodhound/packages/go/slicesext"
@@ -279,6 +282,81 @@ func TestValidateRequestSignature(t *testing.T) {
 		require.Equal(t, http.StatusForbidden, status)
 	})
 
+	t.Run("should return 401 when auth token is disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		req, err := http.NewRequest(http.MethodGet, "http://teapotsrus.dev", nil)
+		require.NoError(t, err)
+
+		req.Header.Add(headers.RequestDate.String(), time.Now().Format(time.RFC3339))
+		signature, err := NewRequestSignature(context.Background(), sha256.New, "token", time.Now().Format(time.RFC3339), req.Method, req.RequestURI, nil)
+		require.NoError(t, err)
+		req.Header.Add(headers.Signature.String(), base64.StdEncoding.EncodeToString(signature))
+
+		db := authenticator.db.(*dbMocks.MockDatabase)
+		db.EXPECT().GetAuthToken(gomock.Any(), gomock.Any()).Return(model.AuthToken{Key: "token", IsDisabled: true}, nil)
+
+		_, status, err := authenticator.ValidateRequestSignature(uuid.UUID{}, req, time.Now())
+		require.ErrorIs(t, err, ErrAuthTokenDisabled)
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("should return 401 when auth token has expired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		req, err := http.NewRequest(http.MethodGet, "http://teapotsrus.dev", nil)
+		require.NoError(t, err)
+
+		req.Header.Add(headers.RequestDate.String(), time.Now().Format(time.RFC3339))
+		signature, err := NewRequestSignature(context.Background(), sha256.New, "token", time.Now().Format(time.RFC3339), req.Method, req.RequestURI, nil)
+		require.NoError(t, err)
+		req.Header.Add(headers.Signature.String(), base64.StdEncoding.EncodeToString(signature))
+
+		db := authenticator.db.(*dbMocks.MockDatabase)
+		db.EXPECT().GetAuthToken(gomock.Any(), gomock.Any()).Return(model.AuthToken{Key: "token", ExpiresAt: null.TimeFrom(time.Now().Add(-time.Minute))}, nil)
+
+		_, status, err := authenticator.ValidateRequestSignature(uuid.UUID{}, req, time.Now())
+		require.ErrorIs(t, err, ErrAuthTokenExpired)
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("should scope the auth context to the permissions of a scoped token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		req, err := http.NewRequest(http.MethodGet, "http://teapotsrus.dev", nil)
+		require.NoError(t, err)
+
+		req.Header.Add(headers.RequestDate.String(), time.Now().Format(time.RFC3339))
+		signature, err := NewRequestSignature(context.Background(), sha256.New, "token", time.Now().Format(time.RFC3339), req.Method, req.RequestURI, nil)
+		require.NoError(t, err)
+		req.Header.Add(headers.Signature.String(), base64.StdEncoding.EncodeToString(signature))
+
+		scope := model.Permissions{auth.Permissions().GraphDBIngest}
+
+		db := authenticator.db.(*dbMocks.MockDatabase)
+		db.EXPECT().GetAuthToken(gomock.Any(), gomock.Any()).Return(model.AuthToken{
+			Key:         "token",
+			ExpiresAt:   null.TimeFrom(time.Now().Add(time.Hour)),
+			Permissions: scope,
+		}, nil)
+		db.EXPECT().UpdateAuthToken(gomock.Any(), gomock.Any()).Return(nil)
+
+		ctxInit := authenticator.ctxInitializer.(*dbMocks.MockAuthContextInitializer)
+		ctxInit.EXPECT().InitContextFromToken(gomock.Any(), gomock.Any()).Return(auth.Context{Owner: model.User{}}, nil)
+
+		authContext, status, err := authenticator.ValidateRequestSignature(uuid.UUID{}, req, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, authContext.PermissionScope.Enabled)
+		require.Equal(t, scope, authContext.PermissionScope.Permissions)
+	})
+
 	t.Run("should return 401 when Request-Date header time is too skewed from server", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -437,3 +515,428 @@ func TestValidateRequestSignature(t *testing.T) {
 		require.Equal(t, http.StatusOK, status)
 	})
 }
+
+func TestValidateSCIMToken(t *testing.T) {
+	var (
+		tokenID   = uuid.FromStringOrNil("33333333-3333-3333-3333-333333333333")
+		scimToken = model.AuthToken{
+			HmacMethod:  auth.SCIM_BEARER,
+			Key:         "c2NpbS1rZXk=",
+			Permissions: model.Permissions{auth.Permissions().AuthManageUsers},
+			Unique:      model.Unique{ID: tokenID},
+		}
+		owner = model.User{PrincipalName: "scim-admin", Unique: model.Unique{ID: testyUserId}}
+	)
+
+	NewTestAuthenticator := func(ctrl *gomock.Controller) authenticator {
+		return authenticator{
+			db:             dbMocks.NewMockDatabase(ctrl),
+			ctxInitializer: dbMocks.NewMockAuthContextInitializer(ctrl),
+		}
+	}
+
+	t.Run("should reject a malformed bearer token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		_, err := authenticator.ValidateSCIMToken(context.Background(), "scim.not-a-token")
+		require.ErrorIs(t, err, ErrInvalidAuth)
+	})
+
+	t.Run("should reject a token issued for request signing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		signingToken := scimToken
+		signingToken.HmacMethod = auth.HMAC_SHA2_256
+
+		authenticator.db.(*dbMocks.MockDatabase).EXPECT().GetAuthToken(gomock.Any(), tokenID).Return(signingToken, nil)
+
+		_, err := authenticator.ValidateSCIMToken(context.Background(), FormatSCIMBearerToken(scimToken))
+		require.ErrorIs(t, err, ErrAuthTokenMethod)
+	})
+
+	t.Run("should reject a mismatched key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		authenticator.db.(*dbMocks.MockDatabase).EXPECT().GetAuthToken(gomock.Any(), tokenID).Return(scimToken, nil)
+
+		_, err := authenticator.ValidateSCIMToken(context.Background(), SCIMTokenPrefix+tokenID.String()+".wrong")
+		require.ErrorIs(t, err, ErrInvalidAuth)
+	})
+
+	t.Run("should reject an expired token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		expiredToken := scimToken
+		expiredToken.ExpiresAt = null.TimeFrom(time.Now().Add(-time.Hour))
+
+		authenticator.db.(*dbMocks.MockDatabase).EXPECT().GetAuthToken(gomock.Any(), tokenID).Return(expiredToken, nil)
+
+		_, err := authenticator.ValidateSCIMToken(context.Background(), FormatSCIMBearerToken(scimToken))
+		require.ErrorIs(t, err, ErrAuthTokenExpired)
+	})
+
+	t.Run("should reject a token owned by a disabled user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		disabledOwner := owner
+		disabledOwner.IsDisabled = true
+
+		authenticator.db.(*dbMocks.MockDatabase).EXPECT().GetAuthToken(gomock.Any(), tokenID).Return(scimToken, nil)
+		authenticator.ctxInitializer.(*dbMocks.MockAuthContextInitializer).EXPECT().InitContextFromToken(gomock.Any(), scimToken).Return(auth.Context{Owner: disabledOwner}, nil)
+
+		_, err := authenticator.ValidateSCIMToken(context.Background(), FormatSCIMBearerToken(scimToken))
+		require.ErrorIs(t, err, ErrUserDisabled)
+	})
+
+	t.Run("should scope a valid token to its permissions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		authenticator := NewTestAuthenticator(ctrl)
+
+		db := authenticator.db.(*dbMocks.MockDatabase)
+		db.EXPECT().GetAuthToken(gomock.Any(), tokenID).Return(scimToken, nil)
+		db.EXPECT().UpdateAuthToken(gomock.Any(), gomock.Any()).Return(nil)
+		authenticator.ctxInitializer.(*dbMocks.MockAuthContextInitializer).EXPECT().InitContextFromToken(gomock.Any(), scimToken).Return(auth.Context{Owner: owner}, nil)
+
+		authContext, err := authenticator.ValidateSCIMToken(context.Background(), FormatSCIMBearerToken(scimToken))
+		require.NoError(t, err)
+		require.True(t, authContext.SCIMToken)
+		require.True(t, authContext.PermissionScope.Enabled)
+		require.Equal(t, scimToken.Permissions, authContext.PermissionScope.Permissions)
+	})
+}
+
+func TestValidateSecondFactor(t *testing.T) {
+	var (
+		requiredRole = model.Role{Name: "Administrator", Serial: model.Serial{ID: 1}}
+		credential   = model.WebAuthnCredential{UserID: testyUserId, Name: "Security Key", CredentialID: "Y3JlZGVudGlhbA"}
+	)
+
+	NewTestAuthenticator := func(ctrl *gomock.Controller, requiredRoleIDs []int32, credentials model.WebAuthnCredentials) authenticator {
+		db := dbMocks.NewMockDatabase(ctrl)
+		db.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.WebAuthnKey).Return(appcfg.Parameter{
+			Key:   appcfg.WebAuthnKey,
+			Value: must.NewJSONBObject(appcfg.WebAuthnParameter{RequiredRoleIDs: requiredRoleIDs}),
+		}, nil)
+		db.EXPECT().GetWebAuthnCredentials(gomock.Any(), testyUserId).Return(credentials, nil)
+
+		return authenticator{db: db}
+	}
+
+	newUser := func(totpActivated bool, roles ...model.Role) model.User {
+		user := testyUser
+		user.Roles = roles
+		user.AuthSecret = &model.AuthSecret{TOTPActivated: totpActivated, TOTPSecret: "JBSWY3DPEHPK3PXP"}
+		return user
+	}
+
+	t.Run("should allow users without a second factor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		flags, err := NewTestAuthenticator(ctrl, nil, nil).validateSecondFactor(context.Background(), newUser(false), LoginRequest{})
+		require.NoError(t, err)
+		require.Empty(t, flags)
+	})
+
+	t.Run("should require an assertion from users whose only second factor is webauthn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, err := NewTestAuthenticator(ctrl, nil, model.WebAuthnCredentials{credential}).validateSecondFactor(context.Background(), newUser(false), LoginRequest{})
+		require.ErrorIs(t, err, ErrWebAuthnRequired)
+	})
+
+	t.Run("should still accept totp from users with both factors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, err := NewTestAuthenticator(ctrl, nil, model.WebAuthnCredentials{credential}).validateSecondFactor(context.Background(), newUser(true), LoginRequest{OTP: "000000"})
+		require.ErrorIs(t, err, auth.ErrInvalidOTP)
+	})
+
+	t.Run("should not accept totp from users whose role requires webauthn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, err := NewTestAuthenticator(ctrl, []int32{requiredRole.ID}, model.WebAuthnCredentials{credential}).validateSecondFactor(context.Background(), newUser(true, requiredRole), LoginRequest{})
+		require.ErrorIs(t, err, ErrWebAuthnRequired)
+	})
+
+	t.Run("should restrict the session of users who must enroll", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		flags, err := NewTestAuthenticator(ctrl, []int32{requiredRole.ID}, nil).validateSecondFactor(context.Background(), newUser(false, requiredRole), LoginRequest{})
+		require.NoError(t, err)
+		require.True(t, flags[string(model.SessionFlagWebAuthnEnrollmentRequired)])
+	})
+
+	t.Run("should reject an assertion for an unknown credential", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loginRequest := LoginRequest{WebAuthn: &WebAuthnLoginAssertion{}}
+		loginRequest.WebAuthn.Credential.RawID = []byte("unknown")
+
+		_, err := NewTestAuthenticator(ctrl, nil, model.WebAuthnCredentials{credential}).validateSecondFactor(context.Background(), newUser(false), loginRequest)
+		require.ErrorIs(t, err, auth.ErrInvalidWebAuthn)
+	})
+
+	t.Run("should reject an assertion for a ceremony that was already used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		signingKey := []byte("signing-key")
+		testAuthenticator := NewTestAuthenticator(ctrl, nil, model.WebAuthnCredentials{credential})
+		testAuthenticator.cfg.Crypto.JWT.SigningKey = base64.StdEncoding.EncodeToString(signingKey)
+		testAuthenticator.db.(*dbMocks.MockDatabase).EXPECT().ConsumeWebAuthnCeremony(gomock.Any(), gomock.Any(), gomock.Any()).Return(database.ErrWebAuthnCeremonyConsumed)
+
+		ceremonyToken, err := auth.NewWebAuthnCeremonyToken(signingKey, auth.WebAuthnCeremonyGet, testyUserId, []byte("challenge"), time.Now())
+		require.NoError(t, err)
+
+		loginRequest := LoginRequest{WebAuthn: &WebAuthnLoginAssertion{CeremonyToken: ceremonyToken}}
+		loginRequest.WebAuthn.Credential.RawID = []byte("credential")
+
+		_, err = testAuthenticator.validateSecondFactor(context.Background(), newUser(false), loginRequest)
+		require.ErrorIs(t, err, auth.ErrWebAuthnCeremonyConsumed)
+	})
+}
+
+func TestAuthenticateSecret_Lockout(t *testing.T) {
+	var (
+		digester = config.Argon2Configuration{
+			MemoryKibibytes: 1024,
+			NumIterations:   1,
+			NumThreads:      1,
+		}.NewDigester()
+		digest, err = digester.Digest("password")
+	)
+	require.NoError(t, err)
+
+	newUser := func(lockedUntil null.Time) model.User {
+		user := testyUser
+		user.AuthSecret = &model.AuthSecret{
+			UserID:       testyUserId,
+			Digest:       digest.String(),
+			DigestMethod: digester.Method(),
+			LockedUntil:  lockedUntil,
+			Serial:       model.Serial{ID: 1},
+		}
+		return user
+	}
+
+	newTestAuthenticator := func(db *dbMocks.MockDatabase) authenticator {
+		return authenticator{db: db, secretDigester: digester, concurrencyLock: make(chan struct{}, 1)}
+	}
+
+	expectLockoutParameter := func(db *dbMocks.MockDatabase, threshold int) {
+		db.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.AccountLockoutKey).Return(appcfg.Parameter{
+			Key:   appcfg.AccountLockoutKey,
+			Value: must.NewJSONBObject(map[string]any{"threshold": threshold, "duration": "PT15M"}),
+		}, nil)
+	}
+
+	t.Run("should reject locked accounts like a bad secret", func(t *testing.T) {
+		for _, secret := range []string{"password", "wrong"} {
+			ctrl := gomock.NewController(t)
+
+			var (
+				db           = dbMocks.NewMockDatabase(ctrl)
+				user         = newUser(null.TimeFrom(time.Now().Add(time.Minute)))
+				testCtx, req = setupRequest(user)
+			)
+
+			// Failed logins are not counted against an account that is already locked
+			req.Secret = secret
+			db.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+
+			_, err := newTestAuthenticator(db).authenticateSecret(testCtx, req)
+			require.ErrorIs(t, err, ErrInvalidAuth)
+			require.ErrorIs(t, err, ErrUserLocked)
+
+			ctrl.Finish()
+		}
+	})
+
+	t.Run("should accept accounts whose lockout expired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db           = dbMocks.NewMockDatabase(ctrl)
+			user         = newUser(null.TimeFrom(time.Now().Add(-time.Minute)))
+			testCtx, req = setupRequest(user)
+		)
+
+		req.Secret = "password"
+		db.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+
+		_, err := newTestAuthenticator(db).authenticateSecret(testCtx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("should count failed logins below the threshold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db           = dbMocks.NewMockDatabase(ctrl)
+			user         = newUser(null.Time{})
+			testCtx, req = setupRequest(user)
+		)
+
+		req.Secret = "wrong"
+		db.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+		expectLockoutParameter(db, 3)
+		db.EXPECT().RecordFailedLogin(gomock.Any(), *user.AuthSecret).Return(2, nil)
+
+		_, err := newTestAuthenticator(db).authenticateSecret(testCtx, req)
+		require.ErrorIs(t, err, ErrInvalidAuth)
+	})
+
+	t.Run("should lock and audit the account once the threshold is reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db           = dbMocks.NewMockDatabase(ctrl)
+			user         = newUser(null.Time{})
+			testCtx, req = setupRequest(user)
+		)
+
+		req.Secret = "wrong"
+		db.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+		expectLockoutParameter(db, 3)
+		db.EXPECT().RecordFailedLogin(gomock.Any(), *user.AuthSecret).Return(3, nil)
+		db.EXPECT().LockAuthSecret(gomock.Any(), *user.AuthSecret, gomock.Any()).DoAndReturn(func(_ context.Context, _ model.AuthSecret, lockedUntil time.Time) error {
+			require.WithinDuration(t, time.Now().Add(15*time.Minute), lockedUntil, time.Minute)
+			return nil
+		})
+		db.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, auditLog model.AuditLog) error {
+			require.Equal(t, model.AuditLogActionLockUserAccount, auditLog.Action)
+			require.Equal(t, model.AuditLogStatusSuccess, auditLog.Status)
+			require.Equal(t, user.ID.String(), auditLog.ActorID)
+			require.Equal(t, 3, auditLog.Fields["failed_login_count"])
+			return nil
+		})
+
+		_, err := newTestAuthenticator(db).authenticateSecret(testCtx, req)
+		require.ErrorIs(t, err, ErrInvalidAuth)
+	})
+
+	t.Run("should not count failed logins when lockout is disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db           = dbMocks.NewMockDatabase(ctrl)
+			user         = newUser(null.Time{})
+			testCtx, req = setupRequest(user)
+		)
+
+		req.Secret = "wrong"
+		db.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+		expectLockoutParameter(db, 0)
+
+		_, err := newTestAuthenticator(db).authenticateSecret(testCtx, req)
+		require.ErrorIs(t, err, ErrInvalidAuth)
+	})
+}
+
+func TestApplySSOMappingRules(t *testing.T) {
+	var (
+		readOnly = model.Role{Name: "Read-Only", Serial: model.Serial{ID: 3}}
+		claims   = model.SSOClaims{"groups": {"auditors"}}
+		rules    = []model.SSOProviderMappingRule{
+			{Priority: 1, Claim: "groups", Value: "admins", RoleId: readOnly.ID, AllEnvironments: true},
+			{Priority: 2, Claim: "groups", Value: "auditors", RoleId: readOnly.ID},
+		}
+	)
+
+	newProvider := func(autoProvision model.SSOProviderAutoProvisionConfig) model.SSOProvider {
+		autoProvision.DefaultRoleId = readOnly.ID
+		autoProvision.MappingRules = rules
+		return model.SSOProvider{Serial: model.Serial{ID: 1}, Config: model.SSOProviderConfig{AutoProvision: autoProvision}}
+	}
+
+	newUser := func(allEnvironments bool) model.User {
+		user := testyUser
+		user.Roles = model.Roles{readOnly}
+		user.AllEnvironments = allEnvironments
+		return user
+	}
+
+	t.Run("rules are ignored while auto provisioning is disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db   = dbMocks.NewMockDatabase(ctrl)
+			user = newUser(true)
+		)
+
+		updated, err := authenticator{db: db}.applySSOMappingRules(context.Background(), user, newProvider(model.SSOProviderAutoProvisionConfig{}), claims)
+		require.NoError(t, err)
+		require.Equal(t, user, updated)
+	})
+
+	t.Run("a matched rule that grants environments replaces the user's environments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db   = dbMocks.NewMockDatabase(ctrl)
+			user = newUser(false)
+		)
+
+		db.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		db.EXPECT().GrantAllEnvironmentsForUser(gomock.Any(), user).Return(nil)
+
+		_, err := authenticator{db: db}.applySSOMappingRules(context.Background(), user, newProvider(model.SSOProviderAutoProvisionConfig{Enabled: true}), model.SSOClaims{"groups": {"admins"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("users that no longer match a rule granting environments lose them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db   = dbMocks.NewMockDatabase(ctrl)
+			user = newUser(true)
+		)
+
+		db.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		db.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), user).Return(nil, nil)
+		db.EXPECT().UpdateEnvironmentListForUser(gomock.Any(), user).Return(nil)
+
+		_, err := authenticator{db: db}.applySSOMappingRules(context.Background(), user, newProvider(model.SSOProviderAutoProvisionConfig{Enabled: true}), claims)
+		require.NoError(t, err)
+	})
+
+	t.Run("group environment mappings apply when no rule grants environments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			db   = dbMocks.NewMockDatabase(ctrl)
+			user = newUser(false)
+		)
+
+		// The group mappings were already synced while provisioning the user
+		db.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+
+		_, err := authenticator{db: db}.applySSOMappingRules(context.Background(), user, newProvider(model.SSOProviderAutoProvisionConfig{Enabled: true, EnvironmentProvision: true}), claims)
+		require.NoError(t, err)
+	})
+}
@@ -55,6 +55,10 @@ const (
 	URIPathVariableAssetGroupTagID                   = "asset_group_tag_id"
 	URIPathVariableAssetGroupTagSelectorID           = "asset_group_tag_selector_id"
 	URIPathVariableAssetGroupTagMemberID             = "asset_group_tag_member_id"
+	URIPathVariableAssetGroupTagSelectorRunID        = "asset_group_tag_selector_run_id"
+	URIPathVariableBlastRadiusReportID               = "blast_radius_report_id"
+	URIPathVariableAssetGroupTagWebhookID            = "asset_group_tag_webhook_id"
+	URIPathVariableAssetGroupTagWebhookDeliveryID    = "asset_group_tag_webhook_delivery_id"
 	URIPathVariableAttackPathID                      = "attack_path_id"
 	URIPathVariableClientID                          = "client_id"
 	URIPathVariableDataType                          = "data_type"
@@ -67,10 +71,13 @@ const (
 	URIPathVariablePlatformID                        = "platform_id"
 	URIPathVariableRoleID                            = "role_id"
 	URIPathVariableSAMLProviderID                    = "saml_provider_id"
+	URIPathVariableSCIMResourceID                    = "scim_resource_id"
+	URIPathVariableSessionID                         = "session_id"
 	URIPathVariableTaskID                            = "task_id"
 	URIPathVariableTenantID                          = "tenant_id"
 	URIPathVariableTokenID                           = "token_id"
 	URIPathVariableUserID                            = "user_id"
+	URIPathVariableWebAuthnCredentialID              = "webauthn_credential_id"
 	URIPathVariableSavedQueryID                      = "saved_query_id"
 	URIPathVariableSSOProviderID                     = "sso_provider_id"
 	URIPathVariableSSOProviderSlug                   = "sso_provider_slug"
@@ -52,6 +52,9 @@ const (
 	ErrorResponseDetailsNotSortable                 = "column format does not support sorting"
 	ErrorResponseEmptySortParameter                 = "empty sort_by parameter supplied"
 	ErrorResponseDetailsOTPInvalid                  = "one time password is invalid"
+	ErrorResponseDetailsWebAuthnRequired            = "a webauthn assertion is required"
+	ErrorResponseDetailsWebAuthnInvalid             = "webauthn assertion is invalid"
+	ErrorResponseDetailsWebAuthnNotRegistered       = "no webauthn credentials are registered"
 	ErrorResponseDetailsResourceNotFound            = "resource not found"
 	ErrorResponseDetailsToBeforeFrom                = "to time cannot be before from time"
 	ErrorResponseDetailsTimeRangeInvalid            = "time range provided is invalid"
@@ -55,7 +55,8 @@ func parseAuthorizationHeader(request *http.Request) (string, string, *api.Error
 // BloodHound Auth supports the following Authorization schemes:
 //
 //	`bearer`
-//	   Bearer token scheme that contains the user's authenticated session JWT as its parameter.
+//	   Bearer token scheme that contains the user's authenticated session JWT as its parameter. Parameters carrying the
+//	   SCIM token prefix are validated as SCIM provisioning tokens instead.
 //	`bhesignature`
 //	   Request signing scheme that contains the BloodHound token ID as its parameter. See: `src/api/v2/signature.go`
 func AuthMiddleware(authenticator api.Authenticator) mux.MiddlewareFunc {
@@ -67,7 +68,18 @@ func AuthMiddleware(authenticator api.Authenticator) mux.MiddlewareFunc {
 			} else {
 				switch authScheme {
 				case api.AuthorizationSchemeBearer:
-					if userAuth, err := authenticator.ValidateSession(request.Context(), schemeParameter); err != nil {
+					if strings.HasPrefix(schemeParameter, api.SCIMTokenPrefix) {
+						if !strings.HasPrefix(request.URL.Path, api.SCIMRoutePrefix) {
+							api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
+							return
+						} else if scimAuth, err := authenticator.ValidateSCIMToken(request.Context(), schemeParameter); err != nil {
+							api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
+							return
+						} else {
+							bhCtx := ctx.Get(request.Context())
+							bhCtx.AuthCtx = scimAuth
+						}
+					} else if userAuth, err := authenticator.ValidateSession(request.Context(), schemeParameter); err != nil {
 						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
 						return
 					} else {
@@ -133,6 +145,20 @@ func PermissionsCheckAtLeastOne(authorizer auth.Authorizer, permissions ...model
 	}
 }
 
+// RequireSCIMToken is a middleware func generator that returns a http.Handler which only admits requests authenticated
+// with a SCIM provisioning token.
+func RequireSCIMToken() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if bhCtx := ctx.FromRequest(request); !bhCtx.AuthCtx.SCIMToken {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
+			} else {
+				next.ServeHTTP(response, request)
+			}
+		})
+	}
+}
+
 // Helper function to pull the userID from the path variable.
 func getUserId(request *http.Request) (string, bool) {
 	if mux.Vars(request)[api.URIPathVariableUserID] != "" {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/specterops/bloodhound/cmd/api/src/api"
+	apimocks "github.com/specterops/bloodhound/cmd/api/src/api/mocks"
 	"github.com/specterops/bloodhound/cmd/api/src/auth"
 	"github.com/specterops/bloodhound/cmd/api/src/ctx"
 	dbmocks "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
@@ -156,6 +157,65 @@ func TestPermissionsCheckAll(t *testing.T) {
 		ResponseStatusCode(http.StatusForbidden)
 }
 
+func TestPermissionsCheckAll_ScopedToken(t *testing.T) {
+	var (
+		handlerReturn200 = func(response http.ResponseWriter, request *http.Request) {
+			response.WriteHeader(http.StatusOK)
+		}
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbmocks.NewMockDatabase(mockCtrl)
+		scopedCtx = ctx.Context{
+			AuthCtx: auth.Context{
+				PermissionScope: auth.PermissionScope{
+					Enabled:     true,
+					Permissions: model.Permissions{auth.Permissions().GraphDBIngest, auth.Permissions().AuthManageUsers},
+				},
+				Owner: model.User{
+					PrincipalName: "scopedToken",
+					Roles: model.Roles{
+						{
+							Name:        "Ingest",
+							Description: "Owner holds ingest and read, but not user management.",
+							Permissions: model.Permissions{auth.Permissions().GraphDBIngest, auth.Permissions().GraphDBRead},
+						},
+					},
+					Unique: model.Unique{
+						ID: uuid.FromStringOrNil("44444444-4444-4444-4444-444444444444"),
+					},
+				},
+			},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	// Permission granted to the owner and within the token scope
+	test.Request(t).
+		WithURL("http://example.com/test").
+		WithMethod(http.MethodGet).
+		WithContext(&scopedCtx).
+		OnHandler(permissionsCheckAllHandler(mockDB, handlerReturn200, auth.Permissions().GraphDBIngest)).
+		Require().
+		ResponseStatusCode(http.StatusOK)
+
+	// Permission granted to the owner but outside the token scope
+	test.Request(t).
+		WithURL("http://example.com/test").
+		WithMethod(http.MethodGet).
+		WithContext(&scopedCtx).
+		OnHandler(permissionsCheckAllHandler(mockDB, handlerReturn200, auth.Permissions().GraphDBRead)).
+		Require().
+		ResponseStatusCode(http.StatusForbidden)
+
+	// Permission within the token scope but not granted to the owner
+	test.Request(t).
+		WithURL("http://example.com/test").
+		WithMethod(http.MethodGet).
+		WithContext(&scopedCtx).
+		OnHandler(permissionsCheckAtLeastOneHandler(mockDB, handlerReturn200, auth.Permissions().AuthManageUsers)).
+		Require().
+		ResponseStatusCode(http.StatusForbidden)
+}
+
 func TestPermissionsCheckAtLeastOne(t *testing.T) {
 	var (
 		handlerReturn200 = func(response http.ResponseWriter, request *http.Request) {
@@ -281,3 +341,46 @@ func TestPermissionsCheckAtLeastOne(t *testing.T) {
 		Require().
 		ResponseStatusCode(http.StatusForbidden)
 }
+
+func TestAuthMiddleware_SCIMToken(t *testing.T) {
+	var (
+		handlerReturn200 = func(response http.ResponseWriter, request *http.Request) {
+			response.WriteHeader(http.StatusOK)
+		}
+		mockCtrl          = gomock.NewController(t)
+		mockAuthenticator = apimocks.NewMockAuthenticator(mockCtrl)
+		bearerToken       = api.SCIMTokenPrefix + "33333333-3333-3333-3333-333333333333.key"
+		scimHandler       = AuthMiddleware(mockAuthenticator)(RequireSCIMToken()(http.HandlerFunc(handlerReturn200)))
+	)
+	defer mockCtrl.Finish()
+
+	// SCIM tokens are only accepted by the SCIM service provider
+	test.Request(t).
+		WithURL("http://example.com/api/v2/bloodhound-users").
+		WithMethod(http.MethodGet).
+		WithContext(&ctx.Context{}).
+		WithHeader(headers.Authorization.String(), "Bearer "+bearerToken).
+		OnHandler(scimHandler).
+		Require().
+		ResponseStatusCode(http.StatusUnauthorized)
+
+	mockAuthenticator.EXPECT().ValidateSCIMToken(gomock.Any(), bearerToken).Return(auth.Context{Owner: model.User{}, SCIMToken: true}, nil)
+
+	test.Request(t).
+		WithURL("http://example.com/scim/v2/Users").
+		WithMethod(http.MethodGet).
+		WithContext(&ctx.Context{}).
+		WithHeader(headers.Authorization.String(), "Bearer "+bearerToken).
+		OnHandler(scimHandler).
+		Require().
+		ResponseStatusCode(http.StatusOK)
+
+	// Session authenticated requests may not use the SCIM service provider
+	test.Request(t).
+		WithURL("http://example.com/scim/v2/Users").
+		WithMethod(http.MethodGet).
+		WithContext(&ctx.Context{AuthCtx: auth.Context{Owner: model.User{}}}).
+		OnHandler(RequireSCIMToken()(http.HandlerFunc(handlerReturn200))).
+		Require().
+		ResponseStatusCode(http.StatusUnauthorized)
+}
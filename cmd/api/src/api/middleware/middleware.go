@@ -144,6 +144,7 @@ func ContextMiddleware(next http.Handler) http.Handler {
 				RequestedURL: model.AuditableURL(request.URL.String()),
 				RequestIP:    parseUserIP(request),
 				RemoteAddr:   request.RemoteAddr,
+				UserAgent:    request.UserAgent(),
 			})
 
 			// Route the request with the embedded context
@@ -62,16 +62,31 @@ func (m *MockAuthenticator) EXPECT() *MockAuthenticatorMockRecorder {
 	return m.recorder
 }
 
+// BeginWebAuthnLogin mocks base method.
+func (m *MockAuthenticator) BeginWebAuthnLogin(ctx context.Context, loginRequest api.LoginRequest) (api.WebAuthnLoginOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginWebAuthnLogin", ctx, loginRequest)
+	ret0, _ := ret[0].(api.WebAuthnLoginOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginWebAuthnLogin indicates an expected call of BeginWebAuthnLogin.
+func (mr *MockAuthenticatorMockRecorder) BeginWebAuthnLogin(ctx, loginRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginWebAuthnLogin", reflect.TypeOf((*MockAuthenticator)(nil).BeginWebAuthnLogin), ctx, loginRequest)
+}
+
 // CreateSSOSession mocks base method.
-func (m *MockAuthenticator) CreateSSOSession(request *http.Request, response http.ResponseWriter, principalNameOrEmail string, ssoProvider model.SSOProvider) {
+func (m *MockAuthenticator) CreateSSOSession(request *http.Request, response http.ResponseWriter, principalNameOrEmail string, ssoProvider model.SSOProvider, claims model.SSOClaims) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "CreateSSOSession", request, response, principalNameOrEmail, ssoProvider)
+	m.ctrl.Call(m, "CreateSSOSession", request, response, principalNameOrEmail, ssoProvider, claims)
 }
 
 // CreateSSOSession indicates an expected call of CreateSSOSession.
-func (mr *MockAuthenticatorMockRecorder) CreateSSOSession(request, response, principalNameOrEmail, ssoProvider any) *gomock.Call {
+func (mr *MockAuthenticatorMockRecorder) CreateSSOSession(request, response, principalNameOrEmail, ssoProvider, claims any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSSOSession", reflect.TypeOf((*MockAuthenticator)(nil).CreateSSOSession), request, response, principalNameOrEmail, ssoProvider)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSSOSession", reflect.TypeOf((*MockAuthenticator)(nil).CreateSSOSession), request, response, principalNameOrEmail, ssoProvider, claims)
 }
 
 // CreateSession mocks base method.
@@ -132,6 +147,21 @@ func (mr *MockAuthenticatorMockRecorder) ValidateRequestSignature(tokenID, reque
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRequestSignature", reflect.TypeOf((*MockAuthenticator)(nil).ValidateRequestSignature), tokenID, request, serverTime)
 }
 
+// ValidateSCIMToken mocks base method.
+func (m *MockAuthenticator) ValidateSCIMToken(ctx context.Context, bearerToken string) (auth.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSCIMToken", ctx, bearerToken)
+	ret0, _ := ret[0].(auth.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateSCIMToken indicates an expected call of ValidateSCIMToken.
+func (mr *MockAuthenticatorMockRecorder) ValidateSCIMToken(ctx, bearerToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSCIMToken", reflect.TypeOf((*MockAuthenticator)(nil).ValidateSCIMToken), ctx, bearerToken)
+}
+
 // ValidateSecret mocks base method.
 func (m *MockAuthenticator) ValidateSecret(ctx context.Context, secret string, authSecret model.AuthSecret) error {
 	m.ctrl.T.Helper()
@@ -46,6 +46,9 @@ func registerV2Auth(resources v2.Resources, routerInst *router.Router, permissio
 		routerInst.POST("/api/v2/login", func(response http.ResponseWriter, request *http.Request) {
 			middleware.LoginTimer()(http.HandlerFunc(loginResource.Login)).ServeHTTP(response, request)
 		}),
+		routerInst.POST("/api/v2/login/webauthn", func(response http.ResponseWriter, request *http.Request) {
+			middleware.LoginTimer()(http.HandlerFunc(loginResource.BeginWebAuthnLogin)).ServeHTTP(response, request)
+		}),
 	)
 
 	router.With(func() mux.MiddlewareFunc {
@@ -53,6 +56,8 @@ func registerV2Auth(resources v2.Resources, routerInst *router.Router, permissio
 	},
 		// Login resources
 		routerInst.GET("/api/v2/self", managementResource.GetSelf),
+		routerInst.GET("/api/v2/self/sessions", managementResource.ListSelfSessions),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/self/sessions/{%s}", api.URIPathVariableSessionID), managementResource.RevokeSelfSession),
 		routerInst.POST("/api/v2/logout", loginResource.Logout),
 
 		// Login path prefix matcher for SAML providers, order matters here due to PathPrefix
@@ -87,6 +92,9 @@ func registerV2Auth(resources v2.Resources, routerInst *router.Router, permissio
 		// Roles
 		routerInst.GET("/api/v2/roles", managementResource.ListRoles).RequirePermissions(permissions.AuthManageSelf),
 		routerInst.GET(fmt.Sprintf("/api/v2/roles/{%s}", api.URIPathVariableRoleID), managementResource.GetRole).RequirePermissions(permissions.AuthManageSelf),
+		routerInst.POST("/api/v2/roles", managementResource.CreateRole).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.PATCH(fmt.Sprintf("/api/v2/roles/{%s}", api.URIPathVariableRoleID), managementResource.UpdateRole).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/roles/{%s}", api.URIPathVariableRoleID), managementResource.DeleteRole).RequirePermissions(permissions.AuthManageUsers),
 
 		// User management for all BloodHound users
 		routerInst.GET("/api/v2/bloodhound-users", managementResource.ListUsers).RequirePermissions(permissions.AuthManageUsers),
@@ -95,18 +103,42 @@ func registerV2Auth(resources v2.Resources, routerInst *router.Router, permissio
 		routerInst.GET(fmt.Sprintf("/api/v2/bloodhound-users/{%s}", api.URIPathVariableUserID), managementResource.GetUser).RequirePermissions(permissions.AuthManageUsers),
 		routerInst.PATCH(fmt.Sprintf("/api/v2/bloodhound-users/{%s}", api.URIPathVariableUserID), managementResource.UpdateUser).RequirePermissions(permissions.AuthManageUsers),
 		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}", api.URIPathVariableUserID), managementResource.DeleteUser).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.GET(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/environments", api.URIPathVariableUserID), resources.GetUserEnvironments).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.PUT(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/environments", api.URIPathVariableUserID), resources.PutUserEnvironments).RequirePermissions(permissions.AuthManageUsers),
 
 		routerInst.PUT(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/secret", api.URIPathVariableUserID), managementResource.PutUserAuthSecret).AuthorizeUserManagementAccess().RequireUserId(),
 		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/secret", api.URIPathVariableUserID), managementResource.ExpireUserAuthSecret).AuthorizeUserManagementAccess().RequireUserId(),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/lockout", api.URIPathVariableUserID), managementResource.UnlockUser).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.GET(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/sessions", api.URIPathVariableUserID), managementResource.ListUserSessions).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/sessions", api.URIPathVariableUserID), managementResource.RevokeUserSessions).RequirePermissions(permissions.AuthManageUsers),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/sessions/{%s}", api.URIPathVariableUserID, api.URIPathVariableSessionID), managementResource.RevokeUserSession).RequirePermissions(permissions.AuthManageUsers),
 
 		routerInst.POST(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/mfa", api.URIPathVariableUserID), managementResource.EnrollMFA).AuthorizeUserManagementAccess().RequireUserId(),
 		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/mfa", api.URIPathVariableUserID), managementResource.DisenrollMFA).AuthorizeUserManagementAccess().RequireUserId(),
 		routerInst.GET(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/mfa-activation", api.URIPathVariableUserID), managementResource.GetMFAActivationStatus).AuthorizeUserManagementAccess().RequireUserId(),
 		routerInst.POST(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/mfa-activation", api.URIPathVariableUserID), managementResource.ActivateMFA).AuthorizeUserManagementAccess().RequireUserId(),
+		routerInst.POST(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/webauthn/registration", api.URIPathVariableUserID), managementResource.BeginWebAuthnRegistration).AuthorizeUserManagementAccess().RequireUserId(),
+		routerInst.GET(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/webauthn/credentials", api.URIPathVariableUserID), managementResource.ListWebAuthnCredentials).AuthorizeUserManagementAccess().RequireUserId(),
+		routerInst.POST(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/webauthn/credentials", api.URIPathVariableUserID), managementResource.RegisterWebAuthnCredential).AuthorizeUserManagementAccess().RequireUserId(),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/bloodhound-users/{%s}/webauthn/credentials/{%s}", api.URIPathVariableUserID, api.URIPathVariableWebAuthnCredentialID), managementResource.DeleteWebAuthnCredential).AuthorizeUserManagementAccess().RequireUserId(),
 
 		routerInst.POST("/api/v2/tokens", managementResource.CreateAuthToken).RequirePermissions(permissions.AuthCreateToken).AuthorizeUserManagementAccess(),
 		routerInst.GET("/api/v2/tokens", managementResource.ListAuthTokens).RequirePermissions(permissions.AuthCreateToken).AuthorizeUserManagementAccess(),
 		routerInst.DELETE(fmt.Sprintf("/api/v2/tokens/{%s}", api.URIPathVariableTokenID), managementResource.DeleteAuthToken).RequirePermissions(permissions.AuthCreateToken).AuthorizeUserManagementAccess(),
+		routerInst.POST("/api/v2/scim/tokens", managementResource.CreateSCIMToken).RequirePermissions(permissions.AuthManageUsers),
+
+		// SCIM 2.0 service provider, only reachable with a SCIM token
+		routerInst.GET("/scim/v2/ServiceProviderConfig", managementResource.GetSCIMServiceProviderConfig).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.GET("/scim/v2/Users", managementResource.ListSCIMUsers).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.POST("/scim/v2/Users", managementResource.CreateSCIMUser).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.GET(fmt.Sprintf("/scim/v2/Users/{%s}", api.URIPathVariableSCIMResourceID), managementResource.GetSCIMUser).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.PUT(fmt.Sprintf("/scim/v2/Users/{%s}", api.URIPathVariableSCIMResourceID), managementResource.ReplaceSCIMUser).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.PATCH(fmt.Sprintf("/scim/v2/Users/{%s}", api.URIPathVariableSCIMResourceID), managementResource.PatchSCIMUser).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.DELETE(fmt.Sprintf("/scim/v2/Users/{%s}", api.URIPathVariableSCIMResourceID), managementResource.DeleteSCIMUser).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.GET("/scim/v2/Groups", managementResource.ListSCIMGroups).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.GET(fmt.Sprintf("/scim/v2/Groups/{%s}", api.URIPathVariableSCIMResourceID), managementResource.GetSCIMGroup).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.PUT(fmt.Sprintf("/scim/v2/Groups/{%s}", api.URIPathVariableSCIMResourceID), managementResource.ReplaceSCIMGroup).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
+		routerInst.PATCH(fmt.Sprintf("/scim/v2/Groups/{%s}", api.URIPathVariableSCIMResourceID), managementResource.PatchSCIMGroup).RequireSCIMToken().RequirePermissions(permissions.AuthManageUsers),
 	)
 }
 
@@ -141,6 +173,7 @@ func NewV2API(resources v2.Resources, routerInst *router.Router) {
 
 		// Search API
 		routerInst.GET("/api/v2/search", resources.SearchHandler).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/search/properties", resources.PropertySearchHandler).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/available-domains", resources.GetAvailableDomains).RequirePermissions(permissions.GraphDBRead),
 
 		// Audit API
@@ -174,8 +207,31 @@ func NewV2API(resources v2.Resources, routerInst *router.Router) {
 		// Asset group management API
 		// tags
 		routerInst.GET("/api/v2/asset-group-tags", resources.GetAssetGroupTags).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/asset-group-tags", resources.CreateAssetGroupTag).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
 		routerInst.POST("/api/v2/asset-group-tags/search", resources.SearchAssetGroupTags).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.PUT("/api/v2/asset-group-tags/tiers/order", resources.ReorderAssetGroupTagTiers).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
+		// static paths must be registered before the tag id routes so they are not captured as a tag id
+		routerInst.GET("/api/v2/asset-group-tags/configuration", resources.ExportTieringConfiguration).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/asset-group-tags/configuration", resources.ImportTieringConfiguration).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET("/api/v2/asset-group-tags/certifications", resources.GetAssetGroupMemberCertifications).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/asset-group-tags/certifications", resources.CertifyAssetGroupMembers).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET("/api/v2/asset-group-tags/certifications/history", resources.GetAssetGroupMemberCertificationHistory).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/asset-group-tags/violations", resources.GetAssetGroupTagViolationCounts).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/asset-group-tags/violations/findings", resources.GetAssetGroupTagViolations).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/asset-group-tags/blast-radius-reports", resources.GetBlastRadiusReports).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/asset-group-tags/blast-radius-reports", resources.CreateBlastRadiusReport).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/blast-radius-reports/{%s}", api.URIPathVariableBlastRadiusReportID), resources.GetBlastRadiusReport).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/blast-radius-reports/{%s}/download", api.URIPathVariableBlastRadiusReportID), resources.DownloadBlastRadiusReport).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/asset-group-tags/webhooks", resources.GetAssetGroupTagWebhooks).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppReadApplicationConfiguration),
+		routerInst.POST("/api/v2/asset-group-tags/webhooks", resources.CreateAssetGroupTagWebhook).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/webhooks/{%s}", api.URIPathVariableAssetGroupTagWebhookID), resources.GetAssetGroupTagWebhook).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppReadApplicationConfiguration),
+		routerInst.PATCH(fmt.Sprintf("/api/v2/asset-group-tags/webhooks/{%s}", api.URIPathVariableAssetGroupTagWebhookID), resources.UpdateAssetGroupTagWebhook).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/asset-group-tags/webhooks/{%s}", api.URIPathVariableAssetGroupTagWebhookID), resources.DeleteAssetGroupTagWebhook).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/webhooks/{%s}/deliveries", api.URIPathVariableAssetGroupTagWebhookID), resources.GetAssetGroupTagWebhookDeliveries).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/webhooks/{%s}/deliveries/{%s}", api.URIPathVariableAssetGroupTagWebhookID, api.URIPathVariableAssetGroupTagWebhookDeliveryID), resources.GetAssetGroupTagWebhookDelivery).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.AppWriteApplicationConfiguration),
 		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}", api.URIPathVariableAssetGroupTagID), resources.GetAssetGroupTag).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.PATCH(fmt.Sprintf("/api/v2/asset-group-tags/{%s}", api.URIPathVariableAssetGroupTagID), resources.UpdateAssetGroupTag).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/asset-group-tags/{%s}", api.URIPathVariableAssetGroupTagID), resources.DeleteAssetGroupTag).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
 		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/members", api.URIPathVariableAssetGroupTagID), resources.GetAssetGroupMembersByTag).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/members/counts", api.URIPathVariableAssetGroupTagID), resources.GetAssetGroupTagMemberCountsByKind).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/members/{%s}", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagMemberID), resources.GetAssetGroupTagMemberInfo).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
@@ -185,6 +241,8 @@ func NewV2API(resources v2.Resources, routerInst *router.Router) {
 		routerInst.POST(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors", api.URIPathVariableAssetGroupTagID), resources.CreateAssetGroupTagSelector).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
 		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors/{%s}", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagSelectorID), resources.GetAssetGroupTagSelector).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
 		routerInst.PATCH(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors/{%s}", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagSelectorID), resources.UpdateAssetGroupTagSelector).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors/{%s}/runs", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagSelectorID), resources.GetAssetGroupTagSelectorRuns).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors/{%s}/runs/{%s}", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagSelectorID, api.URIPathVariableAssetGroupTagSelectorRunID), resources.GetAssetGroupTagSelectorRun).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
 		routerInst.DELETE(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors/{%s}", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagSelectorID), resources.DeleteAssetGroupTagSelector).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
 		routerInst.POST("/api/v2/asset-group-tags/preview-selectors", resources.PreviewSelectors).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBWrite),
 		routerInst.GET(fmt.Sprintf("/api/v2/asset-group-tags/{%s}/selectors/{%s}/members", api.URIPathVariableAssetGroupTagID, api.URIPathVariableAssetGroupTagSelectorID), resources.GetAssetGroupMembersBySelector).CheckFeatureFlag(resources.DB, appcfg.FeatureTierManagement).RequirePermissions(permissions.GraphDBRead),
@@ -198,7 +256,10 @@ func NewV2API(resources v2.Resources, routerInst *router.Router) {
 		routerInst.GET("/api/v2/pathfinding", resources.GetPathfindingResult).Queries("start_node", "{start_node}", "end_node", "{end_node}").RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/kinds", resources.ListKinds).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/source-kinds", resources.ListSourceKinds).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/graphs/snapshots", resources.ListGraphSnapshots).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/graphs/diff", resources.GetGraphDiff).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/shortest-path", resources.GetShortestPath).Queries(params.StartNode.String(), params.StartNode.RouteMatcher(), params.EndNode.String(), params.EndNode.RouteMatcher()).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/graphs/shortest-path/sets", resources.GetShortestPathsBetweenNodeSets).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/edge-composition", resources.GetEdgeComposition).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/relay-targets", resources.GetEdgeRelayTargets).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/acl-inheritance", resources.GetEdgeACLInheritancePath).RequirePermissions(permissions.GraphDBRead),
@@ -90,6 +90,11 @@ func (s *Route) RequireUserId() *Route {
 	return s
 }
 
+func (s *Route) RequireSCIMToken() *Route {
+	s.handler.Use(middleware.RequireSCIMToken())
+	return s
+}
+
 func (s *Route) CheckFeatureFlag(db database.Database, flagKey string) *Route {
 	s.handler.Use(middleware.FeatureFlagMiddleware(db, flagKey))
 	return s
@@ -17,6 +17,7 @@
 package v2_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -28,13 +29,18 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/api"
 	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
 	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
 	dbMocks "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
 	"github.com/specterops/bloodhound/cmd/api/src/queries"
 	"github.com/specterops/bloodhound/cmd/api/src/queries/mocks"
 	"github.com/specterops/bloodhound/cmd/api/src/utils/test"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/dawgs/graph"
 	"github.com/specterops/dawgs/ops"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -676,3 +682,46 @@ func TestResources_ListADIssuancePolicyLinkedCertTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestResources_ListADGroupMembers_RestrictedEnvironments(t *testing.T) {
+	var (
+		mockCtrl, mockGraph, mockDB, resources = setup(t)
+
+		inScope    = graph.NewNode(1, graph.AsProperties(map[string]any{ad.DomainSID.String(): "S-1-5-21-1"}), ad.Group)
+		outOfScope = graph.NewNode(2, graph.AsProperties(map[string]any{ad.DomainSID.String(): "S-1-5-21-2"}), ad.User)
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.ListADGroupMembers).
+		Run([]apitest.Case{
+			{
+				Name: "ResultsFilteredToScope",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, setupUserCtx(model.User{PrincipalName: "scoped"}))
+					apitest.SetURLVar(input, "object_id", "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+					mockGraph.EXPECT().GetEntityByObjectId(gomock.Any(), "1", gomock.Any()).Return(inScope, nil)
+					mockDB.EXPECT().
+						GetFlagByKey(gomock.Any(), "entity_panel_cache").
+						Return(appcfg.FeatureFlag{Enabled: true}, nil)
+					mockGraph.EXPECT().
+						GetADEntityQueryResult(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, params queries.EntityQueryParameters, _ bool) (any, int, error) {
+							require.NotNil(t, params.NodeFilter)
+							require.NotNil(t, params.PathFilter)
+
+							filtered := params.NodeFilter(graph.NewNodeSet(inScope, outOfScope))
+							require.Equal(t, []graph.ID{inScope.ID}, filtered.IDs())
+
+							return nil, filtered.Len(), nil
+						})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"count":1`)
+				},
+			},
+		})
+}
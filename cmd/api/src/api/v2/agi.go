@@ -406,6 +406,10 @@ func (s Resources) getAssetGroupMembers(response http.ResponseWriter, request *h
 		sortByColumns   = request.URL.Query()[api.QueryParameterSortBy]
 	)
 
+	if !s.requireAllEnvironments(response, request) {
+		return agMembers, errEnvironmentRestricted
+	}
+
 	queryParameterFilterParser := model.NewQueryParameterFilterParser()
 	if queryFilters, err := queryParameterFilterParser.ParseQueryParameterFilters(request); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
@@ -0,0 +1,190 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_GetAssetGroupTagSelectorRuns(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		selectorIds = func(input *apitest.Input) {
+			apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+			apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "2")
+		}
+		expectSelector = func() {
+			mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+			mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), 2).Return(model.AssetGroupTagSelector{ID: 2, AssetGroupTagId: 1}, nil).Times(1)
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagSelectorRuns).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidFilterColumn",
+				Input: func(input *apitest.Input) {
+					selectorIds(input)
+					apitest.AddQueryParam(input, "name", "eq:foo")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsColumnNotFilterable)
+				},
+			},
+			{
+				Name: "InvalidSortColumn",
+				Input: func(input *apitest.Input) {
+					selectorIds(input)
+					apitest.AddQueryParam(input, "sort_by", "selector_id")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsNotSortable)
+				},
+			},
+			{
+				Name:  "SelectorNotInTag",
+				Input: selectorIds,
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), 2).Return(model.AssetGroupTagSelector{ID: 2, AssetGroupTagId: 5}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, "selector is not part of asset group tag")
+				},
+			},
+			{
+				Name:  "DatabaseError",
+				Input: selectorIds,
+				Setup: func() {
+					expectSelector()
+					mockDB.EXPECT().GetAssetGroupSelectorRuns(gomock.Any(), 2, model.SQLFilter{}, model.Sort{}, 0, 100).
+						Return(nil, 0, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					selectorIds(input)
+					apitest.AddQueryParam(input, "added_count", "gte:500")
+					apitest.AddQueryParam(input, "sort_by", "-added_count")
+				},
+				Setup: func() {
+					expectSelector()
+					mockDB.EXPECT().GetAssetGroupSelectorRuns(gomock.Any(), 2, model.SQLFilter{SQLString: "added_count >= 500"}, model.Sort{{Column: "added_count", Direction: model.DescendingSortDirection}}, 0, 100).
+						Return(model.AssetGroupSelectorRuns{{ID: 9, SelectorId: 2, AssetGroupTagId: 1, StartedAt: time.Now(), NodeCount: 600, AddedCount: 550}}, 1, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := v2.AssetGroupSelectorRunsResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Runs, 1)
+					require.Equal(t, 550, result.Runs[0].AddedCount)
+					apitest.BodyContains(output, `"count":1`)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagSelectorRun(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		runIds = func(runId string) apitest.InputFunc {
+			return func(input *apitest.Input) {
+				apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+				apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "2")
+				apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorRunID, runId)
+			}
+		}
+		expectSelector = func() {
+			mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+			mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), 2).Return(model.AssetGroupTagSelector{ID: 2, AssetGroupTagId: 1}, nil).Times(1)
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagSelectorRun).
+		Run([]apitest.Case{
+			{
+				Name:  "MalformedRunId",
+				Input: runIds("abc"),
+				Setup: expectSelector,
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name:  "NotFound",
+				Input: runIds("9"),
+				Setup: func() {
+					expectSelector()
+					mockDB.EXPECT().GetAssetGroupSelectorRun(gomock.Any(), 2, int64(9)).Return(model.AssetGroupSelectorRun{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name:  "Success",
+				Input: runIds("9"),
+				Setup: func() {
+					expectSelector()
+					mockDB.EXPECT().GetAssetGroupSelectorRun(gomock.Any(), 2, int64(9)).
+						Return(model.AssetGroupSelectorRun{ID: 9, SelectorId: 2, AddedCount: 1, RemovedCount: 1, AddedNodeIds: []graph.ID{4}, RemovedNodeIds: []graph.ID{5}}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := v2.AssetGroupSelectorRunResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.Equal(t, []graph.ID{4}, result.Run.AddedNodeIds)
+					require.Equal(t, []graph.ID{5}, result.Run.RemovedNodeIds)
+				},
+			},
+		})
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/bloodhound/cmd/api/src/utils"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/bloodhound/packages/go/headers"
+)
+
+const (
+	blastRadiusReportsDefaultLimit = 50
+	blastRadiusReportFormatJson    = "json"
+	blastRadiusReportFormatCsv     = "csv"
+	blastRadiusReportCsvFilename   = "blast-radius-report-%d.csv"
+)
+
+type CreateBlastRadiusReportRequest struct {
+	WaitForAnalysis bool `json:"wait_for_analysis"`
+}
+
+type BlastRadiusReportsResponse struct {
+	Reports model.BlastRadiusReports `json:"reports"`
+}
+
+type BlastRadiusReportDownloadResponse struct {
+	Report model.BlastRadiusReport `json:"report"`
+	Result model.BlastRadiusResult `json:"result"`
+}
+
+func (s *Resources) CreateBlastRadiusReport(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	var createRequest CreateBlastRadiusReportRequest
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Create Blast Radius Report")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if err := decodeOptionalJSONBody(request, &createRequest); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if report, err := s.DB.CreateBlastRadiusReport(request.Context(), actor.ID.String(), createRequest.WaitForAnalysis); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		// A report waiting on analysis would otherwise sit in the queue until someone requests one
+		if createRequest.WaitForAnalysis {
+			if config, err := appcfg.GetScheduledAnalysisParameter(request.Context(), s.DB); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			} else if !config.Enabled {
+				if err := s.DB.RequestAnalysis(request.Context(), actor.ID.String()); err != nil {
+					api.HandleDatabaseError(request, response, err)
+					return
+				}
+			}
+		}
+
+		api.WriteBasicResponse(request.Context(), report, http.StatusAccepted, response)
+	}
+}
+
+func (s *Resources) GetBlastRadiusReports(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	var queryParams = request.URL.Query()
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Blast Radius Reports")()
+
+	if queryFilters, err := model.NewQueryParameterFilterParser().ParseQueryParameterFilters(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, blastRadiusReportsDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else if sort, err := api.ParseSortParameters(model.BlastRadiusReport{}, queryParams); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
+	} else {
+		for name, filters := range queryFilters {
+			if validPredicates, err := api.GetValidFilterPredicatesAsStrings(model.BlastRadiusReport{}, name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsColumnNotFilterable, name), request), response)
+				return
+			} else {
+				for i, filter := range filters {
+					if !slices.Contains(validPredicates, string(filter.Operator)) {
+						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s %s", api.ErrorResponseDetailsFilterPredicateNotSupported, filter.Name, filter.Operator), request), response)
+						return
+					}
+
+					queryFilters[name][i].IsStringData = model.BlastRadiusReport{}.IsStringColumn(filter.Name)
+				}
+			}
+		}
+
+		if sqlFilter, err := queryFilters.BuildSQLFilter(); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "error building SQL for filter", request), response)
+		} else if reports, count, err := s.DB.GetBlastRadiusReports(request.Context(), sqlFilter, sort, skip, limit); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteResponseWrapperWithPagination(request.Context(), BlastRadiusReportsResponse{Reports: reports}, limit, skip, count, http.StatusOK, response)
+		}
+	}
+}
+
+func (s *Resources) GetBlastRadiusReport(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Blast Radius Report")()
+
+	if reportId, err := strconv.ParseInt(mux.Vars(request)[api.URIPathVariableBlastRadiusReportID], 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if report, err := s.DB.GetBlastRadiusReport(request.Context(), reportId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), report, http.StatusOK, response)
+	}
+}
+
+func (s *Resources) DownloadBlastRadiusReport(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	var result model.BlastRadiusResult
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Download Blast Radius Report")()
+
+	if format := request.URL.Query().Get(queryParameterFormat); format != "" && format != blastRadiusReportFormatJson && format != blastRadiusReportFormatCsv {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, queryParameterFormat, fmt.Errorf("format must be one of %s or %s", blastRadiusReportFormatJson, blastRadiusReportFormatCsv)), response)
+	} else if reportId, err := strconv.ParseInt(mux.Vars(request)[api.URIPathVariableBlastRadiusReportID], 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if report, err := s.DB.GetBlastRadiusReport(request.Context(), reportId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if report.Status != model.BlastRadiusReportStatusComplete {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, fmt.Sprintf("report is %s", report.Status), request), response)
+	} else if err := report.Result.Map(&result); err != nil {
+		slog.ErrorContext(request.Context(), fmt.Sprintf("Unable to read blast radius report %d: %v", report.ID, err))
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if format != blastRadiusReportFormatCsv {
+		api.WriteBasicResponse(request.Context(), BlastRadiusReportDownloadResponse{Report: report, Result: result}, http.StatusOK, response)
+	} else {
+		response.Header().Set(headers.ContentDisposition.String(), fmt.Sprintf(utils.ContentDispositionAttachmentTemplate, fmt.Sprintf(blastRadiusReportCsvFilename, report.ID)))
+		api.WriteCSVResponse(request.Context(), result, http.StatusOK, response)
+	}
+}
+
+// decodeOptionalJSONBody decodes the request body into value, leaving value untouched when the body is empty
+func decodeOptionalJSONBody(request *http.Request, value any) error {
+	if request.Body == nil {
+		return nil
+	} else if err := json.NewDecoder(request.Body).Decode(value); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_CreateBlastRadiusReport(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.CreateBlastRadiusReport).
+		Run([]apitest.Case{
+			{
+				Name: "NoUser",
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "InvalidBody",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyString(input, `{"wait_for_analysis":`)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponsePayloadUnmarshalError)
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+				},
+				Setup: func() {
+					mockDB.EXPECT().CreateBlastRadiusReport(gomock.Any(), user.ID.String(), false).Return(model.BlastRadiusReport{}, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "SuccessEmptyBody",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+				},
+				Setup: func() {
+					mockDB.EXPECT().CreateBlastRadiusReport(gomock.Any(), user.ID.String(), false).Return(model.BlastRadiusReport{ID: 1, Status: model.BlastRadiusReportStatusPending}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusAccepted)
+
+					var report model.BlastRadiusReport
+					apitest.UnmarshalData(output, &report)
+					require.Equal(t, int64(1), report.ID)
+					require.Equal(t, model.BlastRadiusReportStatusPending, report.Status)
+				},
+			},
+			{
+				Name: "SuccessWaitForAnalysis",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateBlastRadiusReportRequest{WaitForAnalysis: true})
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": false})
+					mockDB.EXPECT().CreateBlastRadiusReport(gomock.Any(), user.ID.String(), true).Return(model.BlastRadiusReport{ID: 2, Status: model.BlastRadiusReportStatusPending, WaitForAnalysis: true}, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.ScheduledAnalysis).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+					mockDB.EXPECT().RequestAnalysis(gomock.Any(), user.ID.String()).Return(nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusAccepted)
+					apitest.BodyContains(output, `"wait_for_analysis":true`)
+				},
+			},
+		})
+}
+
+func TestResources_GetBlastRadiusReports(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetBlastRadiusReports).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidFilterColumn",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "requested_by", "eq:someone")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsColumnNotFilterable)
+				},
+			},
+			{
+				Name: "InvalidSortColumn",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "sort_by", "requested_by")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsNotSortable)
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReports(gomock.Any(), model.SQLFilter{}, model.Sort{}, 0, 50).Return(nil, 0, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "status", "eq:complete")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReports(gomock.Any(), model.SQLFilter{SQLString: "status = 'complete'"}, model.Sort{}, 0, 50).
+						Return(model.BlastRadiusReports{{ID: 1, Status: model.BlastRadiusReportStatusComplete}}, 1, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var result v2.BlastRadiusReportsResponse
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Reports, 1)
+					apitest.BodyContains(output, `"count":1`)
+				},
+			},
+		})
+}
+
+func TestResources_GetBlastRadiusReport(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetBlastRadiusReport).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableBlastRadiusReportID, "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "NotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableBlastRadiusReportID, "5")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReport(gomock.Any(), int64(5)).Return(model.BlastRadiusReport{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableBlastRadiusReportID, "5")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReport(gomock.Any(), int64(5)).Return(model.BlastRadiusReport{ID: 5, Status: model.BlastRadiusReportStatusRunning}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"status":"running"`)
+				},
+			},
+		})
+}
+
+func TestResources_DownloadBlastRadiusReport(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		target = model.BlastRadiusNode{ID: graph.ID(3), ObjectId: "S-1-5-21-3", Name: "DOMAIN ADMINS@TESTLAB.LOCAL", Kind: "Group"}
+		owned  = model.BlastRadiusNode{ID: graph.ID(1), ObjectId: "S-1-5-21-1", Name: "BOB@TESTLAB.LOCAL", Kind: "User"}
+		result = model.BlastRadiusResult{
+			OwnedCount:     1,
+			ReachableCount: 1,
+			Tiers:          []model.BlastRadiusTier{{TagId: 1, Name: "Tier Zero", Position: 1, ReachableCount: 1, Nodes: []model.BlastRadiusNode{target}}},
+			Owned:          []model.BlastRadiusPrincipal{{BlastRadiusNode: owned, ReachableCount: 1, TierZeroReachableCount: 1}},
+			TierZeroPaths:  []model.BlastRadiusPath{{Target: target, Hops: 1, Nodes: []model.BlastRadiusNode{owned, target}, EdgeKinds: []string{"GenericAll"}}},
+		}
+		resultObject, _ = types.NewJSONBObject(result)
+		completeReport  = model.BlastRadiusReport{ID: 5, Status: model.BlastRadiusReportStatusComplete, Result: resultObject}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.DownloadBlastRadiusReport).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, api.URIPathVariableBlastRadiusReportID, "5")
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidFormat",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "format", "xml")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "NotComplete",
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReport(gomock.Any(), int64(5)).Return(model.BlastRadiusReport{ID: 5, Status: model.BlastRadiusReportStatusPending}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+					apitest.BodyContains(output, "report is pending")
+				},
+			},
+			{
+				Name: "SuccessJSON",
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReport(gomock.Any(), int64(5)).Return(completeReport, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var response v2.BlastRadiusReportDownloadResponse
+					apitest.UnmarshalData(output, &response)
+					require.Equal(t, result, response.Result)
+				},
+			},
+			{
+				Name: "SuccessCSV",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "format", "csv")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetBlastRadiusReport(gomock.Any(), int64(5)).Return(completeReport, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, "tier,tier_position,node_id,object_id,name,kind,hops,path")
+					apitest.BodyContains(output, "Tier Zero,1,3,S-1-5-21-3,DOMAIN ADMINS@TESTLAB.LOCAL,Group,1,BOB@TESTLAB.LOCAL -[GenericAll]-> DOMAIN ADMINS@TESTLAB.LOCAL")
+				},
+			},
+		})
+}
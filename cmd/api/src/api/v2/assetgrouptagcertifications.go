@@ -0,0 +1,206 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/dawgs/graph"
+)
+
+const (
+	assetGroupCertificationDefaultLimit = 100
+
+	queryParameterCertified = "certified"
+
+	assetGroupCertificationActionCertify = "certify"
+	assetGroupCertificationActionRevoke  = "revoke"
+)
+
+var assetGroupCertificationHistoryActions = []model.AssetGroupHistoryAction{
+	model.AssetGroupHistoryActionCertifyNodeAuto,
+	model.AssetGroupHistoryActionCertifyNodeManual,
+	model.AssetGroupHistoryActionCertifyNodeRevoked,
+}
+
+type AssetGroupMemberCertificationsResponse struct {
+	Members model.AssetGroupMemberCertifications `json:"members"`
+}
+
+type certifyAssetGroupMembersRequest struct {
+	AssetGroupTagId int        `json:"asset_group_tag_id"`
+	MemberIds       []graph.ID `json:"member_ids"`
+	Action          string     `json:"action"`
+	Note            string     `json:"note"`
+}
+
+type CertifyAssetGroupMembersResponse struct {
+	Updated int `json:"updated"`
+}
+
+// parseOptionalAssetGroupTagIdParameter returns the asset group tag id query parameter or 0 when it is not set
+func parseOptionalAssetGroupTagIdParameter(request *http.Request) (int, error) {
+	if param := request.URL.Query().Get(api.URIPathVariableAssetGroupTagID); param == "" {
+		return 0, nil
+	} else if tagId, err := strconv.Atoi(param); err != nil {
+		return 0, err
+	} else if tagId <= 0 {
+		return 0, fmt.Errorf("invalid asset group tag id: %d", tagId)
+	} else {
+		return tagId, nil
+	}
+}
+
+func parseCertifiedParameter(request *http.Request) (model.AssetGroupCertification, error) {
+	if param := request.URL.Query().Get(queryParameterCertified); param == "" {
+		return model.AssetGroupCertificationNone, nil
+	} else if value, err := strconv.Atoi(param); err != nil {
+		return 0, err
+	} else {
+		switch certified := model.AssetGroupCertification(value); certified {
+		case model.AssetGroupCertificationRevoked, model.AssetGroupCertificationNone, model.AssetGroupCertificationManual, model.AssetGroupCertificationAuto:
+			return certified, nil
+		default:
+			return 0, fmt.Errorf("invalid certification value: %d", value)
+		}
+	}
+}
+
+func (s *Resources) GetAssetGroupMemberCertifications(response http.ResponseWriter, request *http.Request) {
+	var queryParams = request.URL.Query()
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Member Certifications")()
+
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	if tagId, err := parseOptionalAssetGroupTagIdParameter(request); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, api.URIPathVariableAssetGroupTagID, err), response)
+	} else if certified, err := parseCertifiedParameter(request); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, queryParameterCertified, err), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, assetGroupCertificationDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else if members, count, err := s.DB.GetAssetGroupMemberCertifications(request.Context(), tagId, certified, skip, limit); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteResponseWrapperWithPagination(request.Context(), AssetGroupMemberCertificationsResponse{Members: members}, limit, skip, count, http.StatusOK, response)
+	}
+}
+
+func (s *Resources) CertifyAssetGroupMembers(response http.ResponseWriter, request *http.Request) {
+	var (
+		certifyReq certifyAssetGroupMembersRequest
+		update     model.AssetGroupCertificationUpdate
+	)
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Certify Members")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if err := json.NewDecoder(request.Body).Decode(&certifyReq); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if len(certifyReq.MemberIds) == 0 {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "member_ids is required", request), response)
+	} else {
+		switch certifyReq.Action {
+		case assetGroupCertificationActionCertify:
+			update.Certification = model.AssetGroupCertificationManual
+		case assetGroupCertificationActionRevoke:
+			update.Certification = model.AssetGroupCertificationRevoked
+		default:
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("action must be one of %s or %s", assetGroupCertificationActionCertify, assetGroupCertificationActionRevoke), request), response)
+			return
+		}
+
+		update.AssetGroupTagId = certifyReq.AssetGroupTagId
+		update.MemberIds = certifyReq.MemberIds
+		if certifyReq.Note != "" {
+			update.Note = null.StringFrom(certifyReq.Note)
+		}
+
+		if update.AssetGroupTagId > 0 {
+			if _, err := s.DB.GetAssetGroupTag(request.Context(), update.AssetGroupTagId); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			}
+		}
+
+		if updated, err := s.DB.UpdateAssetGroupMemberCertifications(request.Context(), actor, update); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			// Request analysis if scheduled analysis isn't enabled so that the tag kinds reflect the new certifications
+			if updated > 0 {
+				if config, err := appcfg.GetScheduledAnalysisParameter(request.Context(), s.DB); err != nil {
+					api.HandleDatabaseError(request, response, err)
+					return
+				} else if !config.Enabled {
+					if err := s.DB.RequestAnalysis(request.Context(), actor.ID.String()); err != nil {
+						api.HandleDatabaseError(request, response, err)
+						return
+					}
+				}
+			}
+			api.WriteBasicResponse(request.Context(), CertifyAssetGroupMembersResponse{Updated: updated}, http.StatusOK, response)
+		}
+	}
+}
+
+func (s *Resources) GetAssetGroupMemberCertificationHistory(response http.ResponseWriter, request *http.Request) {
+	var (
+		queryParams = request.URL.Query()
+		sqlFilter   = model.SQLFilter{SQLString: "action IN ?", Params: []any{assetGroupCertificationHistoryActions}}
+	)
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Certification History")()
+
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	if tagId, err := parseOptionalAssetGroupTagIdParameter(request); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, api.URIPathVariableAssetGroupTagID, err), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, assetGroupCertificationDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else {
+		if tagId > 0 {
+			sqlFilter.SQLString += " AND asset_group_tag_id = ?"
+			sqlFilter.Params = append(sqlFilter.Params, tagId)
+		}
+
+		if historyRecs, count, err := s.DB.GetAssetGroupHistoryRecords(request.Context(), sqlFilter, model.Sort{{Column: "created_at", Direction: model.DescendingSortDirection}}, skip, limit); err != nil && !errors.Is(err, database.ErrNotFound) {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteResponseWrapperWithPagination(request.Context(), AssetGroupHistoryResp{Records: historyRecs}, limit, skip, count, http.StatusOK, response)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_GetAssetGroupMemberCertifications(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupMemberCertifications).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidTagId",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.URIPathVariableAssetGroupTagID, "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "InvalidCertifiedValue",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "certified", "5")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid certification value")
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupMemberCertifications(gomock.Any(), 0, model.AssetGroupCertificationNone, 0, 100).
+						Return(nil, 0, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.URIPathVariableAssetGroupTagID, "2")
+					apitest.AddQueryParam(input, "certified", "-1")
+					apitest.AddQueryParam(input, "limit", "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupMemberCertifications(gomock.Any(), 2, model.AssetGroupCertificationRevoked, 0, 1).
+						Return(model.AssetGroupMemberCertifications{{NodeId: 7, AssetGroupTagId: 2, Name: "user", Certified: model.AssetGroupCertificationRevoked}}, 3, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := v2.AssetGroupMemberCertificationsResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Members, 1)
+					require.Equal(t, graph.ID(7), result.Members[0].NodeId)
+					apitest.BodyContains(output, `"count":3`)
+				},
+			},
+		})
+}
+
+func TestResources_CertifyAssetGroupMembers(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.CertifyAssetGroupMembers).
+		Run([]apitest.Case{
+			{
+				Name: "MissingMemberIds",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"action": "certify"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "member_ids is required")
+				},
+			},
+			{
+				Name: "InvalidAction",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"member_ids": []int{1}, "action": "approve"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "action must be one of")
+				},
+			},
+			{
+				Name: "UnknownTag",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"asset_group_tag_id": 9, "member_ids": []int{1}, "action": "certify"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 9).Return(model.AssetGroupTag{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Revoke",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"member_ids": []int{1, 2}, "action": "revoke", "note": "not tier zero"})
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": false})
+					mockDB.EXPECT().UpdateAssetGroupMemberCertifications(gomock.Any(), gomock.Any(), model.AssetGroupCertificationUpdate{
+						MemberIds:     []graph.ID{1, 2},
+						Certification: model.AssetGroupCertificationRevoked,
+						Note:          null.StringFrom("not tier zero"),
+					}).Return(2, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.ScheduledAnalysis).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+					mockDB.EXPECT().RequestAnalysis(gomock.Any(), user.ID.String()).Return(nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"updated":2`)
+				},
+			},
+			{
+				Name: "CertifyWithinTagNoChanges",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"asset_group_tag_id": 1, "member_ids": []int{3}, "action": "certify"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().UpdateAssetGroupMemberCertifications(gomock.Any(), gomock.Any(), model.AssetGroupCertificationUpdate{
+						AssetGroupTagId: 1,
+						MemberIds:       []graph.ID{3},
+						Certification:   model.AssetGroupCertificationManual,
+					}).Return(0, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"updated":0`)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupMemberCertificationHistory(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		sortByCreatedAt = model.Sort{{Column: "created_at", Direction: model.DescendingSortDirection}}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupMemberCertificationHistory).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidSkip",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "skip", "-1")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, api.URIPathVariableAssetGroupTagID, "4")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupHistoryRecords(gomock.Any(), model.SQLFilter{
+						SQLString: "action IN ? AND asset_group_tag_id = ?",
+						Params: []any{[]model.AssetGroupHistoryAction{
+							model.AssetGroupHistoryActionCertifyNodeAuto,
+							model.AssetGroupHistoryActionCertifyNodeManual,
+							model.AssetGroupHistoryActionCertifyNodeRevoked,
+						}, 4},
+					}, sortByCreatedAt, 0, 100).
+						Return([]model.AssetGroupHistory{{ID: 1, Action: model.AssetGroupHistoryActionCertifyNodeManual, AssetGroupTagId: 4}}, 1, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, string(model.AssetGroupHistoryActionCertifyNodeManual))
+				},
+			},
+		})
+}
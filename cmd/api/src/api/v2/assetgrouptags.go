@@ -50,6 +50,7 @@ import (
 const (
 	assetGroupPreviewSelectorDefaultLimit = 200
 	assetGroupTagsSearchLimit             = 20
+	assetGroupTagNameLimit                = 250
 
 	includeProperties = true
 	excludeProperties = false
@@ -69,10 +70,35 @@ type GetAssetGroupTagsResponse struct {
 	Tags []AssetGroupTagView `json:"tags"`
 }
 
+type createAssetGroupTagRequest struct {
+	Name           string                  `json:"name"`
+	Description    string                  `json:"description"`
+	Type           model.AssetGroupTagType `json:"type"`
+	Position       null.Int32              `json:"position"`
+	RequireCertify null.Bool               `json:"require_certify"`
+}
+
+type patchAssetGroupTagRequest struct {
+	Name            *string    `json:"name"`
+	Description     *string    `json:"description"`
+	Position        null.Int32 `json:"position"`
+	RequireCertify  null.Bool  `json:"require_certify"`
+	AnalysisEnabled null.Bool  `json:"analysis_enabled"`
+}
+
+type reorderAssetGroupTagTiersRequest struct {
+	TierIds []int `json:"tier_ids"`
+}
+
+type reorderAssetGroupTagTiersResponse struct {
+	Tiers model.AssetGroupTags `json:"tiers"`
+}
+
 type patchAssetGroupTagSelectorRequest struct {
 	model.AssetGroupTagSelector
-	Description *string `json:"description"`
-	Disabled    *bool   `json:"disabled"`
+	Description    *string `json:"description"`
+	Disabled       *bool   `json:"disabled"`
+	ExpansionLimit *int32  `json:"expansion_limit"`
 }
 
 func (s Resources) GetAssetGroupTags(response http.ResponseWriter, request *http.Request) {
@@ -141,6 +167,184 @@ func (s Resources) GetAssetGroupTags(response http.ResponseWriter, request *http
 	}
 }
 
+// Checks that an asset group tag name is present and within the allowed length.
+func validateAssetGroupTagName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New(api.ErrorResponseAGNameTagEmpty)
+	} else if len(name) > assetGroupTagNameLimit {
+		return errors.New(api.ErrorResponseAssetGroupTagExceededNameLimit)
+	}
+	return nil
+}
+
+// isProtectedAssetGroupTag reports whether the tag is the tier in the first position or the owned tag. Analysis depends
+// on the kinds of these tags so they may not be renamed or deleted.
+func isProtectedAssetGroupTag(tag model.AssetGroupTag) bool {
+	return tag.Type == model.AssetGroupTagTypeOwned || (tag.Type == model.AssetGroupTagTypeTier && tag.Position.ValueOrZero() == model.AssetGroupTierZeroPosition)
+}
+
+func (s *Resources) CreateAssetGroupTag(response http.ResponseWriter, request *http.Request) {
+	var tagReq createAssetGroupTagRequest
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Create")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if err := json.NewDecoder(request.Body).Decode(&tagReq); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if err := validateAssetGroupTagName(tagReq.Name); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if tagReq.Type != model.AssetGroupTagTypeTier && tagReq.Type != model.AssetGroupTagTypeLabel {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseAssetGroupTagInvalid, request), response)
+	} else if tagReq.Type != model.AssetGroupTagTypeTier && (tagReq.Position.Valid || tagReq.RequireCertify.Valid) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseAssetGroupTagInvalidFields, request), response)
+	} else if existingTags, err := s.DB.GetAssetGroupTags(request.Context(), model.SQLFilter{SQLString: "type = ?", Params: []any{tagReq.Type}}); err != nil && !errors.Is(err, database.ErrNotFound) {
+		api.HandleDatabaseError(request, response, err)
+	} else if limits := appcfg.GetTieringParameters(request.Context(), s.DB); (tagReq.Type == model.AssetGroupTagTypeTier && len(existingTags) >= limits.TierLimit) ||
+		(tagReq.Type == model.AssetGroupTagTypeLabel && len(existingTags) >= limits.LabelLimit) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseAssetGroupTagExceededTagLimit, request), response)
+	} else if tag, err := s.DB.CreateAssetGroupTag(request.Context(), tagReq.Type, actor, tagReq.Name, tagReq.Description, tagReq.Position, tagReq.RequireCertify); err != nil {
+		switch {
+		case errors.Is(err, database.ErrDuplicateKindName):
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseAssetGroupTagDuplicateKindName, request), response)
+		case errors.Is(err, database.ErrPositionOutOfRange):
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseAssetGroupTagPositionOutOfRange, request), response)
+		default:
+			api.HandleDatabaseError(request, response, err)
+		}
+	} else {
+		api.WriteBasicResponse(request.Context(), tag, http.StatusCreated, response)
+	}
+}
+
+func (s *Resources) UpdateAssetGroupTag(response http.ResponseWriter, request *http.Request) {
+	var tagReq patchAssetGroupTagRequest
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Update")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if tagId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if tag, err := s.DB.GetAssetGroupTag(request.Context(), tagId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := json.NewDecoder(request.Body).Decode(&tagReq); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if tag.Type != model.AssetGroupTagTypeTier && (tagReq.Position.Valid || tagReq.RequireCertify.Valid || tagReq.AnalysisEnabled.Valid) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseAssetGroupTagInvalidFields, request), response)
+	} else if isProtectedAssetGroupTag(tag) && ((tagReq.Name != nil && *tagReq.Name != tag.Name) || (tagReq.Position.Valid && !tagReq.Position.Equal(tag.Position))) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "this tag cannot be renamed or moved", request), response)
+	} else {
+		if tagReq.Name != nil {
+			if err := validateAssetGroupTagName(*tagReq.Name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+				return
+			}
+			tag.Name = *tagReq.Name
+		}
+
+		if tagReq.Description != nil {
+			tag.Description = *tagReq.Description
+		}
+
+		if tagReq.Position.Valid {
+			tag.Position = tagReq.Position
+		}
+
+		if tagReq.RequireCertify.Valid {
+			tag.RequireCertify = tagReq.RequireCertify
+		}
+
+		if tagReq.AnalysisEnabled.Valid {
+			tag.AnalysisEnabled = tagReq.AnalysisEnabled
+		}
+
+		if tag, err := s.DB.UpdateAssetGroupTag(request.Context(), actor, tag); err != nil {
+			switch {
+			case errors.Is(err, database.ErrDuplicateAGName):
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseAssetGroupTagDuplicateKindName, request), response)
+			case errors.Is(err, database.ErrPositionOutOfRange):
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseAssetGroupTagPositionOutOfRange, request), response)
+			default:
+				api.HandleDatabaseError(request, response, err)
+			}
+		} else {
+			// Request analysis if scheduled analysis isn't enabled
+			if config, err := appcfg.GetScheduledAnalysisParameter(request.Context(), s.DB); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			} else if !config.Enabled {
+				if err := s.DB.RequestAnalysis(request.Context(), actor.ID.String()); err != nil {
+					api.HandleDatabaseError(request, response, err)
+					return
+				}
+			}
+			api.WriteBasicResponse(request.Context(), tag, http.StatusOK, response)
+		}
+	}
+}
+
+func (s *Resources) DeleteAssetGroupTag(response http.ResponseWriter, request *http.Request) {
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Delete")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if tagId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if tag, err := s.DB.GetAssetGroupTag(request.Context(), tagId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if isProtectedAssetGroupTag(tag) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "this tag cannot be deleted", request), response)
+	} else if err := s.DB.DeleteAssetGroupTag(request.Context(), actor, tag); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		// Request analysis if scheduled analysis isn't enabled
+		if config, err := appcfg.GetScheduledAnalysisParameter(request.Context(), s.DB); err != nil {
+			api.HandleDatabaseError(request, response, err)
+			return
+		} else if !config.Enabled {
+			if err := s.DB.RequestAnalysis(request.Context(), actor.ID.String()); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			}
+		}
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Resources) ReorderAssetGroupTagTiers(response http.ResponseWriter, request *http.Request) {
+	var reorderReq reorderAssetGroupTagTiersRequest
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Tiers Reorder")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if err := json.NewDecoder(request.Body).Decode(&reorderReq); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if len(reorderReq.TierIds) == 0 {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "tier_ids is required", request), response)
+	} else if tiers, err := s.DB.ReorderAssetGroupTagTiers(request.Context(), actor, reorderReq.TierIds); err != nil {
+		if errors.Is(err, database.ErrInvalidTierOrder) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+		} else {
+			api.HandleDatabaseError(request, response, err)
+		}
+	} else {
+		// Request analysis if scheduled analysis isn't enabled
+		if config, err := appcfg.GetScheduledAnalysisParameter(request.Context(), s.DB); err != nil {
+			api.HandleDatabaseError(request, response, err)
+			return
+		} else if !config.Enabled {
+			if err := s.DB.RequestAnalysis(request.Context(), actor.ID.String()); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			}
+		}
+		api.WriteBasicResponse(request.Context(), reorderAssetGroupTagTiersResponse{Tiers: tiers}, http.StatusOK, response)
+	}
+}
+
 // Checks that the selector seeds are valid.
 func validateSelectorSeeds(graph queries.Graph, seeds []model.SelectorSeed) error {
 	if len(seeds) <= 0 {
@@ -149,7 +353,7 @@ func validateSelectorSeeds(graph queries.Graph, seeds []model.SelectorSeed) erro
 	// all seeds must be of the same type
 	seedType := seeds[0].Type
 
-	if seedType != model.SelectorTypeObjectId && seedType != model.SelectorTypeCypher {
+	if seedType != model.SelectorTypeObjectId && seedType != model.SelectorTypeCypher && !seedType.IsStructured() {
 		return fmt.Errorf("invalid seed type %v", seedType)
 	}
 
@@ -161,6 +365,13 @@ func validateSelectorSeeds(graph queries.Graph, seeds []model.SelectorSeed) erro
 			if _, err := graph.PrepareCypherQuery(seed.Value, queries.DefaultQueryFitnessLowerBoundSelector); err != nil {
 				return fmt.Errorf("cypher is invalid: %v", err)
 			}
+		} else if seed.Type.IsStructured() {
+			// Structured seeds are evaluated as cypher so they are held to the same complexity limits
+			if cypherQuery, err := seed.StructuredQueryShape(); err != nil {
+				return err
+			} else if _, err := graph.PrepareCypherQuery(cypherQuery, queries.DefaultQueryFitnessLowerBoundSelector); err != nil {
+				return fmt.Errorf("seed is invalid: %v", err)
+			}
 		}
 	}
 	return nil
@@ -188,6 +399,8 @@ func (s *Resources) CreateAssetGroupTagSelector(response http.ResponseWriter, re
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
 	} else if err := validateSelectorSeeds(s.GraphQuery, sel.Seeds); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if sel.ExpansionLimit.Valid {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "expansion_limit can only be set by updating an existing selector", request), response)
 	} else if selector, err := s.DB.CreateAssetGroupTagSelector(request.Context(), assetTagId, actor, sel.Name, sel.Description, false, true, sel.AutoCertify, sel.Seeds); err != nil {
 		api.HandleDatabaseError(request, response, err)
 	} else {
@@ -250,6 +463,21 @@ func (s *Resources) UpdateAssetGroupTagSelector(response http.ResponseWriter, re
 			selector.AutoCertify = selUpdateReq.AutoCertify
 		}
 
+		// we can update ExpansionLimit on a default selector, 0 removes the limit
+		if selUpdateReq.ExpansionLimit != nil {
+			if limit := *selUpdateReq.ExpansionLimit; limit < 0 || limit > model.AssetGroupSelectorMaxExpansionLimit {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("expansion_limit must be between 1 and %d, or 0 to remove the limit", model.AssetGroupSelectorMaxExpansionLimit), request), response)
+				return
+			} else if (limit > model.AssetGroupSelectorUnprivilegedExpansionLimit || (limit == 0 && selector.ExpansionLimit.Valid)) && !s.Authorizer.AllowsPermission(ctx.FromRequest(request).AuthCtx, auth.Permissions().AppWriteApplicationConfiguration) {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, fmt.Sprintf("raising expansion_limit above %d or removing it requires permission to write the application configuration", model.AssetGroupSelectorUnprivilegedExpansionLimit), request), response)
+				return
+			} else if limit == 0 {
+				selector.ExpansionLimit = null.Int32{}
+			} else {
+				selector.ExpansionLimit = null.Int32From(limit)
+			}
+		}
+
 		if selector.IsDefault && (selUpdateReq.Name != "" || selUpdateReq.Description != nil || len(selUpdateReq.Seeds) > 0) {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "default selectors only support modifying auto_certify and disabled_at", request), response)
 			return
@@ -512,6 +740,10 @@ type GetAssetGroupTagMemberCountsResponse struct {
 }
 
 func (s *Resources) GetAssetGroupTagMemberCountsByKind(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	environmentIds := request.URL.Query()[api.QueryParameterEnvironments]
 
 	if tagId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagID]); err != nil {
@@ -551,6 +783,10 @@ type AssetGroupMember struct {
 	Name          string         `json:"name"`
 	Properties    map[string]any `json:"properties,omitempty"`
 
+	// Exposure metrics computed during analysis, see analysis.PostExposureMetrics
+	InboundExposure int `json:"inbound_exposure"`
+	OutboundImpact  int `json:"outbound_impact"`
+
 	Source          model.AssetGroupSelectorNodeSource `json:"source,omitempty"`
 	AssetGroupTagId int                                `json:"asset_group_tag_id,omitempty"`
 }
@@ -567,6 +803,10 @@ func nodeToAssetGroupMember(node *graph.Node, includeProperties bool) AssetGroup
 		Name:          displayName,
 	}
 
+	// Nodes without a path to or from them carry no exposure metrics
+	member.InboundExposure, _ = node.Properties.GetOrDefault(common.InboundExposure.String(), 0).Int()
+	member.OutboundImpact, _ = node.Properties.GetOrDefault(common.OutboundImpact.String(), 0).Int()
+
 	if includeProperties {
 		member.Properties = node.Properties.Map
 	}
@@ -576,7 +816,7 @@ func nodeToAssetGroupMember(node *graph.Node, includeProperties bool) AssetGroup
 
 func (s AssetGroupMember) IsSortable(criteria string) bool {
 	switch criteria {
-	case "id", "objectid", "name":
+	case "id", "objectid", "name", common.InboundExposure.String(), common.OutboundImpact.String():
 		return true
 	default:
 		return false
@@ -593,6 +833,10 @@ type memberInfo struct {
 }
 
 func (s *Resources) GetAssetGroupTagMemberInfo(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	var (
 		assetTagIdStr = mux.Vars(request)[api.URIPathVariableAssetGroupTagID]
 		memberStr     = mux.Vars(request)[api.URIPathVariableAssetGroupTagMemberID]
@@ -624,6 +868,10 @@ type GetAssetGroupMembersResponse struct {
 }
 
 func (s *Resources) GetAssetGroupMembersByTag(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	var (
 		members        = []AssetGroupMember{}
 		queryParams    = request.URL.Query()
@@ -670,6 +918,10 @@ func (s *Resources) GetAssetGroupMembersByTag(response http.ResponseWriter, requ
 }
 
 func (s *Resources) GetAssetGroupMembersBySelector(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	var (
 		members     = []AssetGroupMember{}
 		queryParams = request.URL.Query()
@@ -743,6 +995,10 @@ func (s *Resources) PreviewSelectors(response http.ResponseWriter, request *http
 		members = []AssetGroupMember{}
 	)
 
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	if limit, err := ParseLimitQueryParameter(request.URL.Query(), assetGroupPreviewSelectorDefaultLimit); err != nil {
 		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
 	} else if err := json.NewDecoder(request.Body).Decode(&seeds); err != nil {
@@ -755,7 +1011,7 @@ func (s *Resources) PreviewSelectors(response http.ResponseWriter, request *http
 	} else if err := validateSelectorSeeds(s.GraphQuery, seeds.Seeds); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else {
-		nodes := datapipe.FetchNodesFromSeeds(request.Context(), s.Graph, seeds.Seeds, model.AssetGroupExpansionMethodAll, limit)
+		nodes, _ := datapipe.FetchNodesFromSeeds(request.Context(), s.Graph, seeds.Seeds, model.AssetGroupExpansionMethodAll, limit)
 		for _, node := range nodes {
 			if node.Node != nil {
 				members = append(members, nodeToAssetGroupMember(node.Node, excludeProperties))
@@ -794,6 +1050,10 @@ func (s *Resources) SearchAssetGroupTags(response http.ResponseWriter, request *
 		selectors   model.AssetGroupTagSelectors
 	)
 
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	if err := json.NewDecoder(request.Body).Decode(&reqBody); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
 	} else if !validateAssetGroupTagType(reqBody.TagType) {
@@ -34,6 +34,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/api"
 	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
 	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types"
@@ -464,6 +465,29 @@ func TestResources_CreateAssetGroupTagSelector(t *testing.T) {
 					apitest.BodyContains(output, api.ErrorResponseDetailsInternalServerError)
 				},
 			},
+			{
+				Name: "ExpansionLimitOnCreate",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.BodyStruct(input, model.AssetGroupTagSelector{
+						Name: "TestSelector",
+						Seeds: []model.SelectorSeed{
+							{Type: model.SelectorTypeObjectId, Value: "S-1-5-21-1"},
+						},
+						AutoCertify:    null.BoolFrom(false),
+						ExpansionLimit: null.Int32From(10),
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "expansion_limit can only be set by updating an existing selector")
+				},
+			},
 			{
 				Name: "InvalidCypher",
 				Input: func(input *apitest.Input) {
@@ -514,6 +538,90 @@ func TestResources_CreateAssetGroupTagSelector(t *testing.T) {
 					apitest.StatusCode(output, http.StatusBadRequest)
 				},
 			},
+			{
+				Name: "InvalidPropertyMatchSeed",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.BodyStruct(input, model.AssetGroupTagSelector{
+						Name:        "TestSelector",
+						Description: "Test selector description",
+						Seeds: []model.SelectorSeed{
+							{Type: model.SelectorTypePropertyMatch, Value: "name=^svc(["},
+						},
+						IsDefault:   false,
+						AutoCertify: null.BoolFrom(false),
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid regex")
+				},
+			},
+			{
+				Name: "StructuredSeedTooComplex",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.BodyStruct(input, model.AssetGroupTagSelector{
+						Name:        "TestSelector",
+						Description: "Test selector description",
+						Seeds: []model.SelectorSeed{
+							{Type: model.SelectorTypeGroupMembers, Value: "S-1-5-21-1-512"},
+						},
+						IsDefault:   false,
+						AutoCertify: null.BoolFrom(false),
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{}, nil).Times(1)
+					mockGraphDb.EXPECT().
+						PrepareCypherQuery(gomock.Any(), int64(queries.DefaultQueryFitnessLowerBoundSelector)).
+						Return(queries.PreparedQuery{}, queries.ErrCypherQueryTooComplex).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, queries.ErrCypherQueryTooComplex.Error())
+				},
+			},
+			{
+				Name: "SuccessStructuredSeed",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.BodyStruct(input, model.AssetGroupTagSelector{
+						Name:        "TestSelector",
+						Description: "Test selector description",
+						Seeds: []model.SelectorSeed{
+							{Type: model.SelectorTypeOUContainment, Value: "OU=Servers,DC=corp,DC=local"},
+						},
+						IsDefault:   false,
+						AutoCertify: null.BoolFrom(false),
+					})
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": true})
+					mockDB.EXPECT().
+						GetConfigurationParameter(gomock.Any(), gomock.Any()).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+					mockDB.EXPECT().
+						CreateAssetGroupTagSelector(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTagSelector{Name: "TestSelector"}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{}, nil).Times(1)
+					mockGraphDb.EXPECT().
+						PrepareCypherQuery("MATCH (o)-[:Contains*1..]->(n) WHERE toUpper(o.distinguishedname) = '' RETURN n", int64(queries.DefaultQueryFitnessLowerBoundSelector)).
+						Return(queries.PreparedQuery{}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusCreated)
+				},
+			},
 			{
 				Name: "Success",
 				Input: func(input *apitest.Input) {
@@ -774,9 +882,12 @@ func TestResources_UpdateAssetGroupTagSelector(t *testing.T) {
 		resourcesInst = v2.Resources{
 			DB:         mockDB,
 			GraphQuery: mockGraphDb,
+			Authorizer: auth.NewAuthorizer(mockDB),
 		}
-		user    = setupUser()
-		userCtx = setupUserCtx(user)
+		user      = setupUser()
+		userCtx   = setupUserCtx(user)
+		adminUser = model.User{Roles: model.Roles{{Permissions: model.Permissions{auth.Permissions().AppWriteApplicationConfiguration}}}}
+		adminCtx  = setupUserCtx(adminUser)
 	)
 
 	defer mockCtrl.Finish()
@@ -1050,6 +1161,118 @@ func TestResources_UpdateAssetGroupTagSelector(t *testing.T) {
 					apitest.StatusCode(output, http.StatusOK)
 				},
 			},
+			{
+				Name: "InvalidExpansionLimit",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "1")
+					apitest.BodyString(input, `{"expansion_limit":-5}`)
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTagSelector{AssetGroupTagId: 1}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "expansion_limit must be between 1 and")
+				},
+			},
+			{
+				Name: "RaisingExpansionLimitRequiresPermission",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "1")
+					apitest.BodyString(input, fmt.Sprintf(`{"expansion_limit":%d}`, model.AssetGroupSelectorUnprivilegedExpansionLimit+1))
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTagSelector{AssetGroupTagId: 1}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, "requires permission to write the application configuration")
+				},
+			},
+			{
+				Name: "RemovingExpansionLimitRequiresPermission",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "1")
+					apitest.BodyString(input, `{"expansion_limit":0}`)
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTagSelector{AssetGroupTagId: 1, ExpansionLimit: null.Int32From(5000)}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, "requires permission to write the application configuration")
+				},
+			},
+			{
+				Name: "RaiseExpansionLimitOnDefaultSelector",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, adminCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "1")
+					apitest.BodyString(input, `{"expansion_limit":250000}`)
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": true})
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTagSelector{AssetGroupTagId: 1, IsDefault: true}, nil).Times(1)
+					mockDB.EXPECT().
+						UpdateAssetGroupTagSelector(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Cond(func(s model.AssetGroupTagSelector) bool {
+							return s.ExpansionLimit == null.Int32From(250000)
+						})).
+						Return(model.AssetGroupTagSelector{ExpansionLimit: null.Int32From(250000)}, nil).Times(1)
+					mockDB.EXPECT().
+						GetConfigurationParameter(gomock.Any(), gomock.Any()).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"expansion_limit":250000`)
+				},
+			},
+			{
+				Name: "RemoveExpansionLimit",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, adminCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagSelectorID, "1")
+					apitest.BodyString(input, `{"expansion_limit":0}`)
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": true})
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagSelectorBySelectorId(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTagSelector{AssetGroupTagId: 1, ExpansionLimit: null.Int32From(250000)}, nil).Times(1)
+					mockDB.EXPECT().
+						UpdateAssetGroupTagSelector(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Cond(func(s model.AssetGroupTagSelector) bool {
+							return !s.ExpansionLimit.Valid
+						})).
+						Return(model.AssetGroupTagSelector{}, nil).Times(1)
+					mockDB.EXPECT().
+						GetConfigurationParameter(gomock.Any(), gomock.Any()).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+				},
+			},
 		})
 }
 
@@ -1350,92 +1573,487 @@ func TestResources_DeleteAssetGroupTagSelector(t *testing.T) {
 		})
 }
 
-func TestResources_GetAssetGroupTagMemberCountsByKind(t *testing.T) {
+func TestResources_CreateAssetGroupTag(t *testing.T) {
 	var (
-		mockCtrl    = gomock.NewController(t)
-		mockDB      = mocks_db.NewMockDatabase(mockCtrl)
-		mockGraphDb = mocks_graph.NewMockGraph(mockCtrl)
-		resources   = v2.Resources{
-			DB:         mockDB,
-			GraphQuery: mockGraphDb,
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
 		}
-		assetGroupTag = model.AssetGroupTag{ID: 1, Name: "Tier Zero"}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
 	)
+
 	defer mockCtrl.Finish()
 
-	apitest.NewHarness(t, resources.GetAssetGroupTagMemberCountsByKind).
+	tieringLimits, _ := types.NewJSONBObject(map[string]any{"tier_limit": 3, "label_limit": 1})
+
+	apitest.
+		NewHarness(t, resourcesInst.CreateAssetGroupTag).
 		Run([]apitest.Case{
 			{
-				Name: "InvalidAssetGroupTagID",
+				Name: "EmptyName",
 				Input: func(input *apitest.Input) {
-					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "invalid")
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": " ", "type": model.AssetGroupTagTypeTier})
 				},
 				Test: func(output apitest.Output) {
-					apitest.StatusCode(output, http.StatusNotFound)
-					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseAGNameTagEmpty)
 				},
 			},
 			{
-				Name: "DatabaseGetAssetGroupTagError",
+				Name: "NameTooLong",
 				Input: func(input *apitest.Input) {
-					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
-				},
-				Setup: func() {
-					mockDB.EXPECT().
-						GetAssetGroupTag(gomock.Any(), gomock.Any()).
-						Return(model.AssetGroupTag{}, errors.New("GetAssetGroupTag fail"))
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": strings.Repeat("a", 251), "type": model.AssetGroupTagTypeTier})
 				},
 				Test: func(output apitest.Output) {
-					apitest.StatusCode(output, http.StatusInternalServerError)
-					apitest.BodyContains(output, api.ErrorResponseDetailsInternalServerError)
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagExceededNameLimit)
 				},
 			},
 			{
-				Name: "GraphDatabaseError",
+				Name: "OwnedTypeNotAllowed",
 				Input: func(input *apitest.Input) {
-					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
-				},
-				Setup: func() {
-					mockDB.EXPECT().
-						GetAssetGroupTag(gomock.Any(), gomock.Any()).
-						Return(assetGroupTag, nil)
-					mockGraphDb.EXPECT().
-						GetPrimaryNodeKindCounts(gomock.Any(), gomock.Any()).
-						Return(map[string]int{}, fmt.Errorf("GetAssetGroupTag Nodes fail"))
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": "owned2", "type": model.AssetGroupTagTypeOwned})
 				},
 				Test: func(output apitest.Output) {
-					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagInvalid)
 				},
 			},
 			{
-				Name: "Success with environments",
+				Name: "TierFieldsOnLabel",
 				Input: func(input *apitest.Input) {
-					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
-					apitest.AddQueryParam(input, "environments", "testenv")
-				},
-				Setup: func() {
-					mockDB.EXPECT().
-						GetAssetGroupTag(gomock.Any(), gomock.Any()).
-						Return(assetGroupTag, nil)
-					mockGraphDb.EXPECT().
-						GetPrimaryNodeKindCounts(gomock.Any(), gomock.Any(), []graph.Criteria{
-							query.Or(
-								query.In(query.NodeProperty(ad.DomainSID.String()), []string{"testenv"}),
-								query.In(query.NodeProperty(azure.TenantID.String()), []string{"testenv"}),
-							),
-						}).
-						Return(map[string]int{ad.Domain.String(): 2}, nil)
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": "label", "type": model.AssetGroupTagTypeLabel, "position": 2})
 				},
 				Test: func(output apitest.Output) {
-					apitest.StatusCode(output, http.StatusOK)
-					result := v2.GetAssetGroupTagMemberCountsResponse{}
-					apitest.UnmarshalData(output, &result)
-					require.Equal(t, 2, result.TotalCount)
-					require.Equal(t, 2, result.Counts[ad.Domain.String()])
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagInvalidFields)
 				},
 			},
 			{
-				Name: "Success",
+				Name: "TagLimitExceeded",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": "label", "type": model.AssetGroupTagTypeLabel})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTags(gomock.Any(), model.SQLFilter{SQLString: "type = ?", Params: []any{model.AssetGroupTagTypeLabel}}).
+						Return(model.AssetGroupTags{{ID: 2, Type: model.AssetGroupTagTypeLabel}}, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagExceededTagLimit)
+				},
+			},
+			{
+				Name: "DuplicateName",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": "tier", "type": model.AssetGroupTagTypeTier})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTags(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTags{{ID: 1, Type: model.AssetGroupTagTypeTier}}, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+					mockDB.EXPECT().CreateAssetGroupTag(gomock.Any(), model.AssetGroupTagTypeTier, gomock.Any(), "tier", "", null.Int32{}, null.Bool{}).
+						Return(model.AssetGroupTag{}, fmt.Errorf("%w: conflict", database.ErrDuplicateKindName)).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagDuplicateKindName)
+				},
+			},
+			{
+				Name: "PositionOutOfRange",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": "tier", "type": model.AssetGroupTagTypeTier, "position": 7})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTags(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTags{{ID: 1, Type: model.AssetGroupTagTypeTier}}, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+					mockDB.EXPECT().CreateAssetGroupTag(gomock.Any(), model.AssetGroupTagTypeTier, gomock.Any(), "tier", "", null.Int32From(7), null.Bool{}).
+						Return(model.AssetGroupTag{}, database.ErrPositionOutOfRange).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagPositionOutOfRange)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"name": "tier", "description": "desc", "type": model.AssetGroupTagTypeTier, "require_certify": true})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTags(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTags{{ID: 1, Type: model.AssetGroupTagTypeTier}}, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+					mockDB.EXPECT().CreateAssetGroupTag(gomock.Any(), model.AssetGroupTagTypeTier, gomock.Any(), "tier", "desc", null.Int32{}, null.BoolFrom(true)).
+						Return(model.AssetGroupTag{ID: 5, Name: "tier", Type: model.AssetGroupTagTypeTier, Position: null.Int32From(2)}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusCreated)
+					apitest.BodyContains(output, `"position":2`)
+				},
+			},
+		})
+}
+
+func TestResources_UpdateAssetGroupTag(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+
+		tierZero = model.AssetGroupTag{ID: 1, Name: "Tier Zero", Type: model.AssetGroupTagTypeTier, Position: null.Int32From(1)}
+		tierTwo  = model.AssetGroupTag{ID: 2, Name: "Tier Two", Type: model.AssetGroupTagTypeTier, Position: null.Int32From(2)}
+		label    = model.AssetGroupTag{ID: 3, Name: "Label", Type: model.AssetGroupTagTypeLabel}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.UpdateAssetGroupTag).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidTagUrlId",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "non-numeric")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "TierFieldsOnLabel",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "3")
+					apitest.BodyStruct(input, map[string]any{"require_certify": true})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 3).Return(label, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagInvalidFields)
+				},
+			},
+			{
+				Name: "RenameTierZero",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.BodyStruct(input, map[string]any{"name": "Renamed"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(tierZero, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+				},
+			},
+			{
+				Name: "DuplicateName",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "2")
+					apitest.BodyStruct(input, map[string]any{"name": "Tier Zero"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 2).Return(tierTwo, nil).Times(1)
+					mockDB.EXPECT().UpdateAssetGroupTag(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{}, fmt.Errorf("tag name must be unique: %w", database.ErrDuplicateAGName)).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+					apitest.BodyContains(output, api.ErrorResponseAssetGroupTagDuplicateKindName)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.BodyStruct(input, map[string]any{"description": "updated", "require_certify": true})
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": false})
+					expected := tierZero
+					expected.Description = "updated"
+					expected.RequireCertify = null.BoolFrom(true)
+
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(tierZero, nil).Times(1)
+					mockDB.EXPECT().UpdateAssetGroupTag(gomock.Any(), gomock.Any(), expected).Return(expected, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), gomock.Any()).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+					mockDB.EXPECT().RequestAnalysis(gomock.Any(), user.ID.String()).Return(nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"description":"updated"`)
+				},
+			},
+		})
+}
+
+func TestResources_DeleteAssetGroupTag(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.DeleteAssetGroupTag).
+		Run([]apitest.Case{
+			{
+				Name: "NonExistentTag",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1234")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1234).Return(model.AssetGroupTag{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "OwnedTag",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 2).
+						Return(model.AssetGroupTag{ID: 2, Type: model.AssetGroupTagTypeOwned}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, "this tag cannot be deleted")
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "3")
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": true})
+					tag := model.AssetGroupTag{ID: 3, Type: model.AssetGroupTagTypeTier, Position: null.Int32From(2)}
+
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 3).Return(tag, nil).Times(1)
+					mockDB.EXPECT().DeleteAssetGroupTag(gomock.Any(), gomock.Any(), tag).Return(nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), gomock.Any()).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNoContent)
+				},
+			},
+		})
+}
+
+func TestResources_ReorderAssetGroupTagTiers(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.ReorderAssetGroupTagTiers).
+		Run([]apitest.Case{
+			{
+				Name: "MissingTierIds",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "tier_ids is required")
+				},
+			},
+			{
+				Name: "InvalidOrder",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"tier_ids": []int{2, 1}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().ReorderAssetGroupTagTiers(gomock.Any(), gomock.Any(), []int{2, 1}).
+						Return(nil, fmt.Errorf("%w: the tier in position 1 cannot be moved", database.ErrInvalidTierOrder)).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "cannot be moved")
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"tier_ids": []int{1, 3, 2}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().ReorderAssetGroupTagTiers(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, map[string]any{"tier_ids": []int{1, 3, 2}})
+				},
+				Setup: func() {
+					value, _ := types.NewJSONBObject(map[string]any{"enabled": true})
+					mockDB.EXPECT().ReorderAssetGroupTagTiers(gomock.Any(), gomock.Any(), []int{1, 3, 2}).
+						Return(model.AssetGroupTags{
+							{ID: 1, Position: null.Int32From(1)},
+							{ID: 3, Position: null.Int32From(2)},
+							{ID: 2, Position: null.Int32From(3)},
+						}, nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), gomock.Any()).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: value}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := struct {
+						Tiers model.AssetGroupTags `json:"tiers"`
+					}{}
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Tiers, 3)
+					require.Equal(t, 3, result.Tiers[1].ID)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagMemberCountsByKind(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = mocks_db.NewMockDatabase(mockCtrl)
+		mockGraphDb = mocks_graph.NewMockGraph(mockCtrl)
+		resources   = v2.Resources{
+			DB:         mockDB,
+			GraphQuery: mockGraphDb,
+		}
+		assetGroupTag = model.AssetGroupTag{ID: 1, Name: "Tier Zero"}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetAssetGroupTagMemberCountsByKind).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidAssetGroupTagID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "invalid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "DatabaseGetAssetGroupTagError",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().
+						GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(model.AssetGroupTag{}, errors.New("GetAssetGroupTag fail"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.BodyContains(output, api.ErrorResponseDetailsInternalServerError)
+				},
+			},
+			{
+				Name: "GraphDatabaseError",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().
+						GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(assetGroupTag, nil)
+					mockGraphDb.EXPECT().
+						GetPrimaryNodeKindCounts(gomock.Any(), gomock.Any()).
+						Return(map[string]int{}, fmt.Errorf("GetAssetGroupTag Nodes fail"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success with environments",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.AddQueryParam(input, "environments", "testenv")
+				},
+				Setup: func() {
+					mockDB.EXPECT().
+						GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(assetGroupTag, nil)
+					mockGraphDb.EXPECT().
+						GetPrimaryNodeKindCounts(gomock.Any(), gomock.Any(), []graph.Criteria{
+							query.Or(
+								query.In(query.NodeProperty(ad.DomainSID.String()), []string{"testenv"}),
+								query.In(query.NodeProperty(azure.TenantID.String()), []string{"testenv"}),
+							),
+						}).
+						Return(map[string]int{ad.Domain.String(): 2}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := v2.GetAssetGroupTagMemberCountsResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.Equal(t, 2, result.TotalCount)
+					require.Equal(t, 2, result.Counts[ad.Domain.String()])
+				},
+			},
+			{
+				Name: "Success",
 				Input: func(input *apitest.Input) {
 					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
 				},
@@ -1895,6 +2513,73 @@ func Test_GetAssetGroupMembersByTag(t *testing.T) {
 					require.Equal(t, expected, result)
 				},
 			},
+			{
+				Name: "Success with exposure metrics sorted by outbound impact",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagID, "1")
+					apitest.AddQueryParam(input, "sort_by", "-outboundimpact")
+				},
+				Setup: func() {
+					params := url.Values{}
+					params.Add("sort_by", "-outboundimpact")
+
+					orderCriteria, err := api.ParseGraphSortParameters(v2.AssetGroupMember{}, params)
+					require.Nil(t, err)
+
+					mockDB.EXPECT().
+						GetAssetGroupTag(gomock.Any(), gomock.Any()).
+						Return(assetGroupTag, nil)
+					mockGraphDb.EXPECT().
+						GetFilteredAndSortedNodesPaginated(orderCriteria, gomock.Any(), gomock.Any(), gomock.Any()).
+						Return([]*graph.Node{
+							{
+								ID:    1,
+								Kinds: []graph.Kind{ad.User},
+								Properties: graph.AsProperties(map[string]any{
+									"objectid":        "OID-1",
+									"name":            "node1",
+									"inboundexposure": 12,
+									"outboundimpact":  3,
+								})},
+							{
+								ID:    2,
+								Kinds: []graph.Kind{ad.Group},
+								Properties: graph.AsProperties(map[string]any{
+									"objectid": "OID-2",
+									"name":     "node2",
+								})},
+						}, nil)
+					mockGraphDb.EXPECT().
+						CountFilteredNodes(gomock.Any(), gomock.Any()).
+						Return(int64(2), nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					expected := v2.GetAssetGroupMembersResponse{
+						Members: []v2.AssetGroupMember{
+							{
+								NodeId:          1,
+								ObjectID:        "OID-1",
+								PrimaryKind:     "User",
+								Name:            "node1",
+								InboundExposure: 12,
+								OutboundImpact:  3,
+								AssetGroupTagId: 1,
+							},
+							{
+								NodeId:          2,
+								ObjectID:        "OID-2",
+								PrimaryKind:     "Group",
+								Name:            "node2",
+								AssetGroupTagId: 1,
+							},
+						},
+					}
+					result := v2.GetAssetGroupMembersResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.Equal(t, expected, result)
+				},
+			},
 		})
 }
 
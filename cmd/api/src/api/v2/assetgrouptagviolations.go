@@ -0,0 +1,101 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+)
+
+const assetGroupTagViolationsDefaultLimit = 100
+
+type AssetGroupTagViolationCountsResponse struct {
+	Counts     model.AssetGroupTagViolationCounts `json:"counts"`
+	TotalCount int                                `json:"total_count"`
+}
+
+type AssetGroupTagViolationsResponse struct {
+	Violations model.AssetGroupTagViolations `json:"violations"`
+}
+
+func (s *Resources) GetAssetGroupTagViolationCounts(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Violation Counts")()
+
+	if counts, err := s.DB.GetAssetGroupTagViolationCounts(request.Context()); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		total := 0
+		for _, count := range counts {
+			total += count.Count
+		}
+
+		api.WriteBasicResponse(request.Context(), AssetGroupTagViolationCountsResponse{Counts: counts, TotalCount: total}, http.StatusOK, response)
+	}
+}
+
+func (s *Resources) GetAssetGroupTagViolations(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	var queryParams = request.URL.Query()
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Violations")()
+
+	if queryFilters, err := model.NewQueryParameterFilterParser().ParseQueryParameterFilters(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, assetGroupTagViolationsDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else if sort, err := api.ParseSortParameters(model.AssetGroupTagViolation{}, queryParams); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
+	} else {
+		for name, filters := range queryFilters {
+			if validPredicates, err := api.GetValidFilterPredicatesAsStrings(model.AssetGroupTagViolation{}, name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsColumnNotFilterable, name), request), response)
+				return
+			} else {
+				for i, filter := range filters {
+					if !slices.Contains(validPredicates, string(filter.Operator)) {
+						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s %s", api.ErrorResponseDetailsFilterPredicateNotSupported, filter.Name, filter.Operator), request), response)
+						return
+					}
+
+					queryFilters[name][i].IsStringData = model.AssetGroupTagViolation{}.IsStringColumn(filter.Name)
+				}
+			}
+		}
+
+		if sqlFilter, err := queryFilters.BuildSQLFilter(); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "error building SQL for filter", request), response)
+		} else if violations, count, err := s.DB.GetAssetGroupTagViolations(request.Context(), sqlFilter, sort, skip, limit); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteResponseWrapperWithPagination(request.Context(), AssetGroupTagViolationsResponse{Violations: violations}, limit, skip, count, http.StatusOK, response)
+		}
+	}
+}
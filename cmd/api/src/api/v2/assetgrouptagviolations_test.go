@@ -0,0 +1,168 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_GetAssetGroupTagViolationCounts(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagViolationCounts).
+		Run([]apitest.Case{
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagViolationCounts(gomock.Any()).Return(nil, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagViolationCounts(gomock.Any()).Return(model.AssetGroupTagViolationCounts{
+						{SourceTagId: 2, TargetTagId: 1, Count: 5, DirectCount: 3},
+						{SourceTagId: 3, TargetTagId: 1, Count: 2, DirectCount: 2},
+					}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var result v2.AssetGroupTagViolationCountsResponse
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Counts, 2)
+					require.Equal(t, 7, result.TotalCount)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagViolations(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagViolations).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidFilterColumn",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "edge_kinds", "eq:GenericAll")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsColumnNotFilterable)
+				},
+			},
+			{
+				Name: "InvalidFilterPredicate",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "source_tag_id", "gt:1")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsFilterPredicateNotSupported)
+				},
+			},
+			{
+				Name: "InvalidSortColumn",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "sort_by", "edge_kinds")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsNotSortable)
+				},
+			},
+			{
+				Name: "InvalidLimit",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "limit", "-1")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagViolations(gomock.Any(), model.SQLFilter{}, model.Sort{}, 0, 100).Return(nil, 0, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "target_tag_id", "eq:1")
+					apitest.AddQueryParam(input, "source_name", "~eq:admin")
+					apitest.AddQueryParam(input, "sort_by", "-hops")
+					apitest.AddQueryParam(input, "skip", "10")
+					apitest.AddQueryParam(input, "limit", "5")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagViolations(gomock.Any(), gomock.Any(), model.Sort{{Column: "hops", Direction: model.DescendingSortDirection}}, 10, 5).
+						DoAndReturn(func(_ any, sqlFilter model.SQLFilter, _ model.Sort, _, _ int) (model.AssetGroupTagViolations, int, error) {
+							require.Contains(t, sqlFilter.SQLString, "target_tag_id = 1")
+							require.Contains(t, sqlFilter.SQLString, "source_name like '%admin%'")
+							return model.AssetGroupTagViolations{
+								{ID: 1, SourceTagId: 2, TargetTagId: 1, SourceName: "ADMIN@CORP", TargetName: "DOMAIN ADMINS@CORP", Hops: 1, EdgeKinds: []string{"GenericAll"}},
+							}, 11, nil
+						}).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"count":11`)
+
+					var result v2.AssetGroupTagViolationsResponse
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Violations, 1)
+					require.Equal(t, []string{"GenericAll"}, []string(result.Violations[0].EdgeKinds))
+				},
+			},
+		})
+}
@@ -0,0 +1,352 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/config"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/daemons/webhook"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+)
+
+const (
+	assetGroupTagWebhookDeliveriesDefaultLimit = 50
+	assetGroupTagWebhookSecretLength           = 32
+)
+
+type CreateAssetGroupTagWebhookRequest struct {
+	Name            string     `json:"name"`
+	URL             string     `json:"url"`
+	Secret          string     `json:"secret"`
+	AssetGroupTagId null.Int32 `json:"asset_group_tag_id"`
+	Enabled         *bool      `json:"enabled"`
+}
+
+type UpdateAssetGroupTagWebhookRequest struct {
+	Name *string `json:"name"`
+	URL  *string `json:"url"`
+	// AssetGroupTagId subscribes the webhook to a single tag; 0 subscribes it to every tag
+	AssetGroupTagId *int32  `json:"asset_group_tag_id"`
+	Enabled         *bool   `json:"enabled"`
+	Secret          *string `json:"secret"`
+	RotateSecret    bool    `json:"rotate_secret"`
+}
+
+// AssetGroupTagWebhookWithSecret is returned only when a webhook's secret is created or changed
+type AssetGroupTagWebhookWithSecret struct {
+	model.AssetGroupTagWebhook
+	Secret string `json:"secret"`
+}
+
+type AssetGroupTagWebhooksResponse struct {
+	Webhooks model.AssetGroupTagWebhooks `json:"webhooks"`
+}
+
+type AssetGroupTagWebhookDeliveriesResponse struct {
+	Deliveries model.AssetGroupTagWebhookDeliveries `json:"deliveries"`
+}
+
+type AssetGroupTagWebhookDeliveryResponse struct {
+	Delivery model.AssetGroupTagWebhookDelivery         `json:"delivery"`
+	Attempts model.AssetGroupTagWebhookDeliveryAttempts `json:"attempts"`
+}
+
+// validateAssetGroupTagWebhookURL only allows absolute http and https endpoints that do not name an internal host.
+// Host names are checked again when the delivery daemon dials the resolved address.
+func validateAssetGroupTagWebhookURL(rawURL string) error {
+	if parsedURL, err := url.Parse(rawURL); err != nil {
+		return fmt.Errorf("url is invalid: %w", err)
+	} else if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return errors.New("url must use the http or https scheme")
+	} else if parsedURL.Hostname() == "" {
+		return errors.New("url must include a host")
+	} else if hostname := strings.ToLower(strings.TrimSuffix(parsedURL.Hostname(), ".")); hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return errors.New("url must not target a loopback, private or link-local address")
+	} else if addr, err := netip.ParseAddr(hostname); err == nil && webhook.IsDisallowedAddress(addr) {
+		return errors.New("url must not target a loopback, private or link-local address")
+	}
+
+	return nil
+}
+
+// validateAssetGroupTagWebhookTag checks that the tag a webhook is subscribed to exists
+func (s *Resources) validateAssetGroupTagWebhookTag(request *http.Request, assetGroupTagId null.Int32) error {
+	if !assetGroupTagId.Valid {
+		return nil
+	} else if _, err := s.DB.GetAssetGroupTag(request.Context(), int(assetGroupTagId.Int32)); errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("asset group tag %d does not exist", assetGroupTagId.Int32)
+	} else {
+		return err
+	}
+}
+
+func (s *Resources) GetAssetGroupTagWebhooks(response http.ResponseWriter, request *http.Request) {
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Webhooks")()
+
+	if queryFilters, err := model.NewQueryParameterFilterParser().ParseQueryParameterFilters(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
+	} else {
+		for name, filters := range queryFilters {
+			if validPredicates, err := api.GetValidFilterPredicatesAsStrings(model.AssetGroupTagWebhook{}, name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsColumnNotFilterable, name), request), response)
+				return
+			} else {
+				for i, filter := range filters {
+					if !slices.Contains(validPredicates, string(filter.Operator)) {
+						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s %s", api.ErrorResponseDetailsFilterPredicateNotSupported, filter.Name, filter.Operator), request), response)
+						return
+					}
+
+					queryFilters[name][i].IsStringData = model.AssetGroupTagWebhook{}.IsStringColumn(filter.Name)
+				}
+			}
+		}
+
+		if sqlFilter, err := queryFilters.BuildSQLFilter(); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "error building SQL for filter", request), response)
+		} else if webhooks, err := s.DB.GetAssetGroupTagWebhooks(request.Context(), sqlFilter); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), AssetGroupTagWebhooksResponse{Webhooks: webhooks}, http.StatusOK, response)
+		}
+	}
+}
+
+func (s *Resources) CreateAssetGroupTagWebhook(response http.ResponseWriter, request *http.Request) {
+	var createRequest CreateAssetGroupTagWebhookRequest
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Create Webhook")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if err := json.NewDecoder(request.Body).Decode(&createRequest); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if createRequest.Name = strings.TrimSpace(createRequest.Name); createRequest.Name == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "name is required", request), response)
+	} else if err := validateAssetGroupTagWebhookURL(createRequest.URL); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err := s.validateAssetGroupTagWebhookTag(request, createRequest.AssetGroupTagId); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else {
+		webhook := model.AssetGroupTagWebhook{
+			Name:            createRequest.Name,
+			URL:             createRequest.URL,
+			Secret:          createRequest.Secret,
+			AssetGroupTagId: createRequest.AssetGroupTagId,
+			Enabled:         createRequest.Enabled == nil || *createRequest.Enabled,
+			CreatedBy:       actor.ID.String(),
+		}
+
+		if webhook.Secret == "" {
+			if webhook.Secret, err = config.GenerateSecureRandomString(assetGroupTagWebhookSecretLength); err != nil {
+				slog.ErrorContext(request.Context(), fmt.Sprintf("Unable to generate webhook secret: %v", err))
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+				return
+			}
+		}
+
+		if webhook, err = s.DB.CreateAssetGroupTagWebhook(request.Context(), webhook); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), AssetGroupTagWebhookWithSecret{AssetGroupTagWebhook: webhook, Secret: webhook.Secret}, http.StatusCreated, response)
+		}
+	}
+}
+
+func (s *Resources) GetAssetGroupTagWebhook(response http.ResponseWriter, request *http.Request) {
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Webhook")()
+
+	if webhookId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagWebhookID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if webhook, err := s.DB.GetAssetGroupTagWebhook(request.Context(), webhookId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), webhook, http.StatusOK, response)
+	}
+}
+
+func (s *Resources) UpdateAssetGroupTagWebhook(response http.ResponseWriter, request *http.Request) {
+	var updateRequest UpdateAssetGroupTagWebhookRequest
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Update Webhook")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if webhookId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagWebhookID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if webhook, err := s.DB.GetAssetGroupTagWebhook(request.Context(), webhookId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := json.NewDecoder(request.Body).Decode(&updateRequest); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if updateRequest.RotateSecret && updateRequest.Secret != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "secret and rotate_secret cannot both be set", request), response)
+	} else {
+		secretChanged := updateRequest.RotateSecret || updateRequest.Secret != nil
+
+		if updateRequest.Name != nil {
+			if webhook.Name = strings.TrimSpace(*updateRequest.Name); webhook.Name == "" {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "name is required", request), response)
+				return
+			}
+		}
+
+		if updateRequest.URL != nil {
+			if err := validateAssetGroupTagWebhookURL(*updateRequest.URL); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+				return
+			}
+			webhook.URL = *updateRequest.URL
+		}
+
+		if updateRequest.AssetGroupTagId != nil {
+			if *updateRequest.AssetGroupTagId == 0 {
+				webhook.AssetGroupTagId = null.Int32{}
+			} else {
+				webhook.AssetGroupTagId = null.Int32From(*updateRequest.AssetGroupTagId)
+			}
+
+			if err := s.validateAssetGroupTagWebhookTag(request, webhook.AssetGroupTagId); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+				return
+			}
+		}
+
+		if updateRequest.Enabled != nil {
+			webhook.Enabled = *updateRequest.Enabled
+		}
+
+		if updateRequest.Secret != nil {
+			if webhook.Secret = *updateRequest.Secret; webhook.Secret == "" {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "secret cannot be empty", request), response)
+				return
+			}
+		} else if updateRequest.RotateSecret {
+			if webhook.Secret, err = config.GenerateSecureRandomString(assetGroupTagWebhookSecretLength); err != nil {
+				slog.ErrorContext(request.Context(), fmt.Sprintf("Unable to generate webhook secret: %v", err))
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+				return
+			}
+		}
+
+		webhook.UpdatedBy = actor.ID.String()
+
+		if webhook, err = s.DB.UpdateAssetGroupTagWebhook(request.Context(), webhook); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else if secretChanged {
+			api.WriteBasicResponse(request.Context(), AssetGroupTagWebhookWithSecret{AssetGroupTagWebhook: webhook, Secret: webhook.Secret}, http.StatusOK, response)
+		} else {
+			api.WriteBasicResponse(request.Context(), webhook, http.StatusOK, response)
+		}
+	}
+}
+
+func (s *Resources) DeleteAssetGroupTagWebhook(response http.ResponseWriter, request *http.Request) {
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Delete Webhook")()
+
+	if webhookId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagWebhookID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if webhook, err := s.DB.GetAssetGroupTagWebhook(request.Context(), webhookId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.DB.DeleteAssetGroupTagWebhook(request.Context(), webhook); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Resources) GetAssetGroupTagWebhookDeliveries(response http.ResponseWriter, request *http.Request) {
+	var queryParams = request.URL.Query()
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Webhook Deliveries")()
+
+	// Delivery payloads name the nodes that changed in every environment
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	if webhookId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagWebhookID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if _, err := s.DB.GetAssetGroupTagWebhook(request.Context(), webhookId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if queryFilters, err := model.NewQueryParameterFilterParser().ParseQueryParameterFilters(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, assetGroupTagWebhookDeliveriesDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else {
+		for name, filters := range queryFilters {
+			if validPredicates, err := api.GetValidFilterPredicatesAsStrings(model.AssetGroupTagWebhookDelivery{}, name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsColumnNotFilterable, name), request), response)
+				return
+			} else {
+				for i, filter := range filters {
+					if !slices.Contains(validPredicates, string(filter.Operator)) {
+						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s %s", api.ErrorResponseDetailsFilterPredicateNotSupported, filter.Name, filter.Operator), request), response)
+						return
+					}
+
+					queryFilters[name][i].IsStringData = model.AssetGroupTagWebhookDelivery{}.IsStringColumn(filter.Name)
+				}
+			}
+		}
+
+		if sqlFilter, err := queryFilters.BuildSQLFilter(); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "error building SQL for filter", request), response)
+		} else if deliveries, count, err := s.DB.GetAssetGroupTagWebhookDeliveries(request.Context(), webhookId, sqlFilter, skip, limit); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteResponseWrapperWithPagination(request.Context(), AssetGroupTagWebhookDeliveriesResponse{Deliveries: deliveries}, limit, skip, count, http.StatusOK, response)
+		}
+	}
+}
+
+func (s *Resources) GetAssetGroupTagWebhookDelivery(response http.ResponseWriter, request *http.Request) {
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Get Webhook Delivery")()
+
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	if webhookId, err := strconv.Atoi(mux.Vars(request)[api.URIPathVariableAssetGroupTagWebhookID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if deliveryId, err := strconv.ParseInt(mux.Vars(request)[api.URIPathVariableAssetGroupTagWebhookDeliveryID], 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if delivery, err := s.DB.GetAssetGroupTagWebhookDelivery(request.Context(), webhookId, deliveryId); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if attempts, err := s.DB.GetAssetGroupTagWebhookDeliveryAttempts(request.Context(), delivery.ID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), AssetGroupTagWebhookDeliveryResponse{Delivery: delivery, Attempts: attempts}, http.StatusOK, response)
+	}
+}
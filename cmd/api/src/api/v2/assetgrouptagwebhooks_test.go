@@ -0,0 +1,550 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_CreateAssetGroupTagWebhook(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.CreateAssetGroupTagWebhook).
+		Run([]apitest.Case{
+			{
+				Name: "NoUser",
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "InvalidBody",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyString(input, `{"name":`)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponsePayloadUnmarshalError)
+				},
+			},
+			{
+				Name: "MissingName",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: " ", URL: "https://example.com"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "name is required")
+				},
+			},
+			{
+				Name: "InvalidURLScheme",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "ftp://example.com"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "url must use the http or https scheme")
+				},
+			},
+			{
+				Name: "LoopbackURL",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "http://localhost:8080/hook"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "url must not target a loopback, private or link-local address")
+				},
+			},
+			{
+				Name: "MetadataURL",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "http://169.254.169.254/latest/meta-data"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "url must not target a loopback, private or link-local address")
+				},
+			},
+			{
+				Name: "UnknownTag",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "https://example.com", AssetGroupTagId: null.Int32From(9)})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 9).Return(model.AssetGroupTag{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "asset group tag 9 does not exist")
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "https://example.com", Secret: "secret"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().CreateAssetGroupTagWebhook(gomock.Any(), gomock.Any()).Return(model.AssetGroupTagWebhook{}, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "SuccessGeneratedSecret",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "https://example.com/hook", AssetGroupTagId: null.Int32From(1)})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTag(gomock.Any(), 1).Return(model.AssetGroupTag{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().CreateAssetGroupTagWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ any, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+							require.Equal(t, "soc", webhook.Name)
+							require.True(t, webhook.Enabled)
+							require.Equal(t, user.ID.String(), webhook.CreatedBy)
+							require.Len(t, webhook.Secret, 32)
+							webhook.ID = 1
+							return webhook, nil
+						}).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusCreated)
+
+					var webhook v2.AssetGroupTagWebhookWithSecret
+					apitest.UnmarshalData(output, &webhook)
+					require.Equal(t, 1, webhook.ID)
+					require.Equal(t, int32(1), webhook.AssetGroupTagId.Int32)
+					require.Len(t, webhook.Secret, 32)
+				},
+			},
+			{
+				Name: "SuccessProvidedSecretDisabled",
+				Input: func(input *apitest.Input) {
+					disabled := false
+					apitest.SetContext(input, userCtx)
+					apitest.BodyStruct(input, v2.CreateAssetGroupTagWebhookRequest{Name: "soc", URL: "http://hooks.example.com:8080/hook", Secret: "provided", Enabled: &disabled})
+				},
+				Setup: func() {
+					mockDB.EXPECT().CreateAssetGroupTagWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ any, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+							require.Equal(t, "provided", webhook.Secret)
+							require.False(t, webhook.Enabled)
+							return webhook, nil
+						}).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusCreated)
+					apitest.BodyContains(output, `"secret":"provided"`)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagWebhooks(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagWebhooks).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidFilterColumn",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "secret", "eq:value")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsColumnNotFilterable)
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhooks(gomock.Any(), model.SQLFilter{}).Return(nil, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "SuccessOmitsSecret",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "enabled", "eq:true")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhooks(gomock.Any(), model.SQLFilter{SQLString: "enabled = true"}).
+						Return(model.AssetGroupTagWebhooks{{ID: 1, Name: "soc", Secret: "hidden", Enabled: true}}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var result v2.AssetGroupTagWebhooksResponse
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Webhooks, 1)
+					require.Empty(t, result.Webhooks[0].Secret)
+					apitest.BodyNotContains(output, "hidden")
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagWebhook(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagWebhook).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "NotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(model.AssetGroupTagWebhook{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(model.AssetGroupTagWebhook{ID: 1, Name: "soc", Secret: "hidden"}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"name":"soc"`)
+					apitest.BodyNotContains(output, "hidden")
+				},
+			},
+		})
+}
+
+func TestResources_UpdateAssetGroupTagWebhook(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user     = setupUser()
+		userCtx  = setupUserCtx(user)
+		existing = model.AssetGroupTagWebhook{ID: 1, Name: "soc", URL: "https://example.com", Secret: "old", AssetGroupTagId: null.Int32From(1), Enabled: true}
+		disabled = false
+		emptyStr = ""
+		allTags  = int32(0)
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.UpdateAssetGroupTagWebhook).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedID",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "SecretAndRotate",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.BodyStruct(input, v2.UpdateAssetGroupTagWebhookRequest{Secret: &emptyStr, RotateSecret: true})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(existing, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "secret and rotate_secret cannot both be set")
+				},
+			},
+			{
+				Name: "SuccessDisableAndSubscribeToAllTags",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.BodyStruct(input, v2.UpdateAssetGroupTagWebhookRequest{Enabled: &disabled, AssetGroupTagId: &allTags})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(existing, nil).Times(1)
+					mockDB.EXPECT().UpdateAssetGroupTagWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ any, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+							require.False(t, webhook.Enabled)
+							require.False(t, webhook.AssetGroupTagId.Valid)
+							require.Equal(t, "old", webhook.Secret)
+							require.Equal(t, user.ID.String(), webhook.UpdatedBy)
+							return webhook, nil
+						}).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyNotContains(output, `"secret"`)
+				},
+			},
+			{
+				Name: "SuccessRotateSecret",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.BodyStruct(input, v2.UpdateAssetGroupTagWebhookRequest{RotateSecret: true})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(existing, nil).Times(1)
+					mockDB.EXPECT().UpdateAssetGroupTagWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ any, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+							require.NotEqual(t, "old", webhook.Secret)
+							return webhook, nil
+						}).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var webhook v2.AssetGroupTagWebhookWithSecret
+					apitest.UnmarshalData(output, &webhook)
+					require.Len(t, webhook.Secret, 32)
+				},
+			},
+		})
+}
+
+func TestResources_DeleteAssetGroupTagWebhook(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		existing = model.AssetGroupTagWebhook{ID: 1, Name: "soc"}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.DeleteAssetGroupTagWebhook).
+		Run([]apitest.Case{
+			{
+				Name: "NotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 2).Return(model.AssetGroupTagWebhook{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(existing, nil).Times(1)
+					mockDB.EXPECT().DeleteAssetGroupTagWebhook(gomock.Any(), existing).Return(nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNoContent)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagWebhookDeliveries(t *testing.T) {
+	var (
+		payload, _    = types.NewJSONBObject(model.AssetGroupTagMembershipChange{AddedCount: 1})
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagWebhookDeliveries).
+		Run([]apitest.Case{
+			{
+				Name: "WebhookNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 2).Return(model.AssetGroupTagWebhook{}, database.ErrNotFound).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "InvalidFilterPredicate",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.AddQueryParam(input, "status", "gt:failed")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(model.AssetGroupTagWebhook{ID: 1}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsFilterPredicateNotSupported)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.AddQueryParam(input, "status", "eq:failed")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), 1).Return(model.AssetGroupTagWebhook{ID: 1}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagWebhookDeliveries(gomock.Any(), 1, model.SQLFilter{SQLString: "status = 'failed'"}, 0, 50).
+						Return(model.AssetGroupTagWebhookDeliveries{{ID: 5, WebhookId: 1, Status: model.AssetGroupTagWebhookDeliveryStatusFailed, Payload: payload}}, 1, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var result v2.AssetGroupTagWebhookDeliveriesResponse
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Deliveries, 1)
+					require.Equal(t, int64(5), result.Deliveries[0].ID)
+				},
+			},
+		})
+}
+
+func TestResources_GetAssetGroupTagWebhookDelivery(t *testing.T) {
+	var (
+		payload, _    = types.NewJSONBObject(model.AssetGroupTagMembershipChange{AddedCount: 1})
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.GetAssetGroupTagWebhookDelivery).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedDeliveryID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookDeliveryID, "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookID, "1")
+					apitest.SetURLVar(input, api.URIPathVariableAssetGroupTagWebhookDeliveryID, "5")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAssetGroupTagWebhookDelivery(gomock.Any(), 1, int64(5)).Return(model.AssetGroupTagWebhookDelivery{ID: 5, WebhookId: 1, Attempts: 2, Payload: payload}, nil).Times(1)
+					mockDB.EXPECT().GetAssetGroupTagWebhookDeliveryAttempts(gomock.Any(), int64(5)).Return(model.AssetGroupTagWebhookDeliveryAttempts{
+						{ID: 1, DeliveryId: 5, Attempt: 1, Error: "connection refused"},
+						{ID: 2, DeliveryId: 5, Attempt: 2, StatusCode: null.Int32From(200)},
+					}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+
+					var result v2.AssetGroupTagWebhookDeliveryResponse
+					apitest.UnmarshalData(output, &result)
+					require.Equal(t, 2, result.Delivery.Attempts)
+					require.Len(t, result.Attempts, 2)
+					require.Equal(t, "connection refused", result.Attempts[0].Error)
+				},
+			},
+		})
+}
@@ -49,9 +49,25 @@ import (
 
 const (
 	ErrResponseDetailsNumRoles               = "a user can only have one role"
+	ErrResponseDetailsRoleNameRequired       = "role name is required"
+	ErrResponseDetailsRoleDuplicateName      = "a role with this name already exists"
+	ErrResponseDetailsRoleBuiltIn            = "built-in roles cannot be modified"
+	ErrResponseDetailsRoleInUse              = "role is assigned to one or more users"
+	ErrResponseDetailsRoleSSOProvider        = "role is provisioned by one or more sso providers"
+	ErrResponseDetailsPermissionNotFound     = "one or more permissions could not be found"
+	ErrResponseDetailsRolePermissionNotHeld  = "roles may only grant permissions held by the caller"
+	ErrResponseDetailsTokenExpiresInPast     = "token expiration must be in the future"
+	ErrResponseDetailsTokenPermissionsScope  = "token permissions must be a subset of the token owner's permissions"
+	ErrResponseDetailsTokenCallerScope       = "tokens created with a scoped token must be limited to a subset of its permissions"
 	ErrResponseDetailsInvalidCurrentPassword = "unable to verify current password"
 	ErrResponseDetailsMFAActivated           = "multi-factor authentication already active"
 	ErrResponseDetailsMFAEnrollmentRequired  = "multi-factor authentication enrollment is required before activation"
+	ErrResponseDetailsUserSelfDelete         = "User cannot delete themselves"
+)
+
+var (
+	ErrUserSelfDisable = errors.New(api.ErrorResponseUserSelfDisable)
+	ErrUserSelfDelete  = errors.New(ErrResponseDetailsUserSelfDelete)
 )
 
 type ManagementResource struct {
@@ -235,6 +251,138 @@ func (s ManagementResource) GetRole(response http.ResponseWriter, request *http.
 	}
 }
 
+// lookupPermissions resolves the requested permission IDs against the permission catalogue
+func (s ManagementResource) lookupPermissions(ctx context.Context, ids []int32) (model.Permissions, bool, error) {
+	var (
+		permissions = make(model.Permissions, 0, len(ids))
+		seen        = make(map[int32]struct{}, len(ids))
+	)
+
+	if len(ids) == 0 {
+		return permissions, true, nil
+	} else if catalogue, err := s.db.GetAllPermissions(ctx, "", model.SQLFilter{}); err != nil {
+		return nil, false, err
+	} else {
+		for _, id := range ids {
+			if _, duplicate := seen[id]; duplicate {
+				continue
+			}
+
+			seen[id] = struct{}{}
+
+			if idx := slices.IndexFunc(catalogue, func(permission model.Permission) bool { return permission.ID == id }); idx < 0 {
+				return nil, false, nil
+			} else {
+				permissions = append(permissions, catalogue[idx])
+			}
+		}
+	}
+
+	return permissions, true, nil
+}
+
+func (s ManagementResource) writeRoleError(response http.ResponseWriter, request *http.Request, err error) {
+	switch {
+	case errors.Is(err, database.ErrDuplicateRoleName):
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsRoleDuplicateName, request), response)
+	case errors.Is(err, database.ErrBuiltInRole):
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsRoleBuiltIn, request), response)
+	case errors.Is(err, database.ErrRoleInUse):
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsRoleInUse, request), response)
+	case errors.Is(err, database.ErrRoleReferencedBySSOProvider):
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsRoleSSOProvider, request), response)
+	default:
+		api.HandleDatabaseError(request, response, err)
+	}
+}
+
+func (s ManagementResource) CreateRole(response http.ResponseWriter, request *http.Request) {
+	var createRoleRequest v2.CreateRoleRequest
+
+	if err := api.ReadJSONRequestPayloadLimited(&createRoleRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if createRoleRequest.Name = strings.TrimSpace(createRoleRequest.Name); createRoleRequest.Name == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsRoleNameRequired, request), response)
+	} else if permissions, found, err := s.lookupPermissions(request.Context(), createRoleRequest.Permissions); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if !found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsPermissionNotFound, request), response)
+	} else if !callerHoldsPermissions(ctx.FromRequest(request).AuthCtx, permissions) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, ErrResponseDetailsRolePermissionNotHeld, request), response)
+	} else if role, err := s.db.CreateRole(request.Context(), model.Role{
+		Name:        createRoleRequest.Name,
+		Description: createRoleRequest.Description,
+		Permissions: permissions,
+	}); err != nil {
+		s.writeRoleError(response, request, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), role, http.StatusCreated, response)
+	}
+}
+
+func (s ManagementResource) UpdateRole(response http.ResponseWriter, request *http.Request) {
+	var (
+		updateRoleRequest v2.UpdateRoleRequest
+		rawRoleID         = mux.Vars(request)[api.URIPathVariableRoleID]
+	)
+
+	if roleID, err := strconv.ParseInt(rawRoleID, 10, 32); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if role, err := s.db.GetRole(request.Context(), int32(roleID)); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if role.BuiltIn {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsRoleBuiltIn, request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&updateRoleRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else {
+		// PATCH requests may not contain every field, only conditionally update if fields exist
+		if name := strings.TrimSpace(updateRoleRequest.Name); name != "" {
+			role.Name = name
+		}
+
+		if updateRoleRequest.Description != nil {
+			role.Description = *updateRoleRequest.Description
+		}
+
+		if updateRoleRequest.Permissions != nil {
+			if permissions, found, err := s.lookupPermissions(request.Context(), updateRoleRequest.Permissions); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			} else if !found {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsPermissionNotFound, request), response)
+				return
+			} else if !callerHoldsPermissions(ctx.FromRequest(request).AuthCtx, addedPermissions(role.Permissions, permissions)) {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, ErrResponseDetailsRolePermissionNotHeld, request), response)
+				return
+			} else {
+				role.Permissions = permissions
+			}
+		}
+
+		if updatedRole, err := s.db.UpdateRole(request.Context(), role); err != nil {
+			s.writeRoleError(response, request, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), updatedRole, http.StatusOK, response)
+		}
+	}
+}
+
+func (s ManagementResource) DeleteRole(response http.ResponseWriter, request *http.Request) {
+	rawRoleID := mux.Vars(request)[api.URIPathVariableRoleID]
+
+	if roleID, err := strconv.ParseInt(rawRoleID, 10, 32); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if role, err := s.db.GetRole(request.Context(), int32(roleID)); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if role.BuiltIn {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsRoleBuiltIn, request), response)
+	} else if err := s.db.DeleteRole(request.Context(), role); err != nil {
+		s.writeRoleError(response, request, err)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func (s ManagementResource) ListUsers(response http.ResponseWriter, request *http.Request) {
 	var (
 		order         []string
@@ -316,13 +464,15 @@ func (s ManagementResource) CreateUser(response http.ResponseWriter, request *ht
 		userTemplate.LastName = null.StringFrom(createUserRequest.LastName)
 		userTemplate.EmailAddress = null.StringFrom(createUserRequest.EmailAddress)
 		userTemplate.PrincipalName = createUserRequest.Principal
-		// EULA Acceptance does not pertain to Bloodhound Community Edition; this flag is used for Bloodhound Enterprise users.
-		userTemplate.EULAAccepted = true
+		userTemplate.AllEnvironments = createUserRequest.AllEnvironments == nil || *createUserRequest.AllEnvironments
 
 		if createUserRequest.Secret != "" {
 			if errs := validation.Validate(createUserRequest.SetUserSecretRequest); errs != nil {
 				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, errs.Error(), request), response)
 				return
+			} else if err := s.validatePasswordPolicy(request.Context(), model.User{}, createUserRequest.Secret); err != nil {
+				handlePasswordPolicyError(request, response, err)
+				return
 			} else if secretDigest, err := s.secretDigester.Digest(createUserRequest.Secret); err != nil {
 				slog.ErrorContext(request.Context(), fmt.Sprintf("Error while attempting to digest secret for user: %v", err))
 				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
@@ -362,14 +512,8 @@ func (s ManagementResource) CreateUser(response http.ResponseWriter, request *ht
 			}
 		}
 
-		if newUser, err := s.db.CreateUser(request.Context(), userTemplate); err != nil {
-			if errors.Is(err, database.ErrDuplicateUserPrincipal) {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseUserDuplicatePrincipal, request), response)
-			} else if errors.Is(err, database.ErrDuplicateEmail) {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseUserDuplicateEmail, request), response)
-			} else {
-				api.HandleDatabaseError(request, response, err)
-			}
+		if newUser, err := s.createUser(request.Context(), userTemplate); err != nil {
+			s.writeUserError(response, request, err)
 		} else {
 			api.WriteBasicResponse(request.Context(), newUser, http.StatusOK, response)
 		}
@@ -419,18 +563,12 @@ func (s ManagementResource) UpdateUser(response http.ResponseWriter, request *ht
 
 		loggedInUser, _ := auth.GetUserFromAuthCtx(authCtx.AuthCtx)
 
-		if user.IsDisabled {
-			if user.ID == loggedInUser.ID {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseUserSelfDisable, request), response)
-				return
-			} else if userSessions, err := s.db.LookupActiveSessionsByUser(request.Context(), user); err != nil {
-				api.HandleDatabaseError(request, response, err)
-				return
-			} else {
-				for _, session := range userSessions {
-					s.db.EndUserSession(request.Context(), session)
-				}
-			}
+		if err := s.endDisabledUserSessions(request.Context(), loggedInUser, user); errors.Is(err, ErrUserSelfDisable) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseUserSelfDisable, request), response)
+			return
+		} else if err != nil {
+			api.HandleDatabaseError(request, response, err)
+			return
 		}
 
 		if updateUserRequest.SAMLProviderID != "" {
@@ -485,19 +623,60 @@ func (s ManagementResource) UpdateUser(response http.ResponseWriter, request *ht
 		}
 
 		if err := s.db.UpdateUser(request.Context(), user); err != nil {
-			if errors.Is(err, database.ErrDuplicateUserPrincipal) {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseUserDuplicatePrincipal, request), response)
-			} else if errors.Is(err, database.ErrDuplicateEmail) {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseUserDuplicateEmail, request), response)
-			} else {
-				api.HandleDatabaseError(request, response, err)
-			}
+			s.writeUserError(response, request, err)
 		} else {
 			response.WriteHeader(http.StatusOK)
 		}
 	}
 }
 
+// createUser persists a new user. User provisioning, whether through the management API or SCIM, goes through here so
+// that every new user is created and audited the same way.
+func (s ManagementResource) createUser(ctx context.Context, userTemplate model.User) (model.User, error) {
+	// EULA Acceptance does not pertain to Bloodhound Community Edition; this flag is used for Bloodhound Enterprise users.
+	userTemplate.EULAAccepted = true
+
+	return s.db.CreateUser(ctx, userTemplate)
+}
+
+// endDisabledUserSessions ends every active session of the given user when it is being disabled. Actors may not
+// disable themselves.
+func (s ManagementResource) endDisabledUserSessions(ctx context.Context, actor model.User, user model.User) error {
+	if !user.IsDisabled {
+		return nil
+	} else if user.ID == actor.ID {
+		return ErrUserSelfDisable
+	} else if userSessions, err := s.db.LookupActiveSessionsByUser(ctx, user); err != nil {
+		return err
+	} else {
+		for _, session := range userSessions {
+			s.db.EndUserSession(ctx, session)
+		}
+
+		return nil
+	}
+}
+
+// deleteUser removes the given user after handing their shared saved queries to the actor so that queries shared with
+// others are not lost. Private queries are not transferred. Actors may not delete themselves.
+func (s ManagementResource) deleteUser(ctx context.Context, actor model.User, user model.User) error {
+	if user.ID == actor.ID {
+		return ErrUserSelfDelete
+	}
+
+	return s.db.DeleteUserAndTransferSavedQueries(ctx, user, actor)
+}
+
+func (s ManagementResource) writeUserError(response http.ResponseWriter, request *http.Request, err error) {
+	if errors.Is(err, database.ErrDuplicateUserPrincipal) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseUserDuplicatePrincipal, request), response)
+	} else if errors.Is(err, database.ErrDuplicateEmail) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseUserDuplicateEmail, request), response)
+	} else {
+		api.HandleDatabaseError(request, response, err)
+	}
+}
+
 func (s ManagementResource) GetUser(response http.ResponseWriter, request *http.Request) {
 	var (
 		pathVars  = mux.Vars(request)
@@ -532,15 +711,67 @@ func (s ManagementResource) DeleteUser(response http.ResponseWriter, request *ht
 		api.HandleDatabaseError(request, response, err)
 	} else if currentUser, found := auth.GetUserFromAuthCtx(bhCtx.AuthCtx); !found {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "No associated user found with request", request), response)
-	} else if userID == currentUser.ID {
-		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "User cannot delete themselves", request), response)
-	} else if err := s.db.DeleteUser(request.Context(), user); err != nil {
+	} else if err := s.deleteUser(request.Context(), currentUser, user); errors.Is(err, ErrUserSelfDelete) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsUserSelfDelete, request), response)
+	} else if err != nil {
 		api.HandleDatabaseError(request, response, err)
 	} else {
 		response.WriteHeader(http.StatusOK)
 	}
 }
 
+// validatePasswordPolicy checks a new secret against the configured password policy. The history check is skipped for
+// users that do not have a secret yet.
+func (s ManagementResource) validatePasswordPolicy(ctx context.Context, user model.User, secret string) error {
+	policy := appcfg.GetPasswordPolicyParameter(ctx, s.db)
+
+	if err := auth.ValidatePasswordLength(secret, policy.MinimumLength); err != nil {
+		return err
+	}
+
+	if policy.HistoryCount > 0 && user.AuthSecret != nil {
+		previousSecrets := []model.AuthSecret{*user.AuthSecret}
+
+		if policy.HistoryCount > 1 {
+			if history, err := s.db.GetAuthSecretHistory(ctx, user.ID, policy.HistoryCount-1); err != nil {
+				return err
+			} else {
+				for _, entry := range history {
+					previousSecrets = append(previousSecrets, model.AuthSecret{Digest: entry.Digest, DigestMethod: entry.DigestMethod})
+				}
+			}
+		}
+
+		for _, previousSecret := range previousSecrets {
+			if err := s.authenticator.ValidateSecret(ctx, secret, previousSecret); err == nil {
+				return auth.ErrPasswordReused
+			}
+		}
+	}
+
+	if policy.BreachedPasswordCheck {
+		if s.config.BreachedPasswordsFile == "" {
+			slog.WarnContext(ctx, "Breached password check is enabled but no breached passwords file is configured; skipping check")
+		} else if breached, err := auth.IsBreachedPassword(s.config.BreachedPasswordsFile, secret); err != nil {
+			return err
+		} else if breached {
+			return auth.ErrPasswordBreached
+		}
+	}
+
+	return nil
+}
+
+// handlePasswordPolicyError writes the error response for a secret rejected by validatePasswordPolicy
+func handlePasswordPolicyError(request *http.Request, response http.ResponseWriter, err error) {
+	if errors.Is(err, auth.ErrPasswordPolicy) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else {
+		slog.ErrorContext(request.Context(), fmt.Sprintf("Error while validating secret against the password policy: %v", err))
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	}
+}
+
 func (s ManagementResource) setUserSecret(ctx context.Context, user model.User, authSecret model.AuthSecret) error {
 	if user.AuthSecret != nil {
 		user.AuthSecret.Digest = authSecret.Digest
@@ -593,7 +824,9 @@ func (s ManagementResource) PutUserAuthSecret(response http.ResponseWriter, requ
 		}
 
 		passwordExpiration := appcfg.GetPasswordExpiration(request.Context(), s.db)
-		if secretDigest, err := s.secretDigester.Digest(setUserSecretRequest.Secret); err != nil {
+		if err := s.validatePasswordPolicy(request.Context(), targetUser, setUserSecretRequest.Secret); err != nil {
+			handlePasswordPolicyError(request, response, err)
+		} else if secretDigest, err := s.secretDigester.Digest(setUserSecretRequest.Secret); err != nil {
 			slog.ErrorContext(request.Context(), fmt.Sprintf("Error while attempting to digest secret for user: %v", err))
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
 		} else {
@@ -640,6 +873,26 @@ func (s ManagementResource) ExpireUserAuthSecret(response http.ResponseWriter, r
 	}
 }
 
+// UnlockUser clears the temporary lockout and failed login counter of a local user
+func (s ManagementResource) UnlockUser(response http.ResponseWriter, request *http.Request) {
+	var (
+		rawUserID = mux.Vars(request)[api.URIPathVariableUserID]
+	)
+
+	if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if targetUser, err := s.db.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if targetUser.AuthSecret == nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrNoUserSecret.Error(), request), response)
+	} else if err := s.db.UnlockAuthSecret(request.Context(), *targetUser.AuthSecret); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		// NOTE: As with ExpireUserAuthSecret, this returns a 200 rather than a 204 to retain uniformity.
+		response.WriteHeader(http.StatusOK)
+	}
+}
+
 func (s ManagementResource) ListAuthTokens(response http.ResponseWriter, request *http.Request) {
 	var (
 		order         []string
@@ -731,15 +984,111 @@ func (s ManagementResource) CreateAuthToken(response http.ResponseWriter, reques
 		api.HandleDatabaseError(request, response, err)
 	} else if err := verifyUserID(&createUserTokenRequest, user, bhCtx, s.authorizer); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, err.Error(), request), response)
+	} else if createUserTokenRequest.ExpiresAt.Valid && !createUserTokenRequest.ExpiresAt.Time.After(time.Now()) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsTokenExpiresInPast, request), response)
+	} else if permissions, found, err := s.lookupPermissions(request.Context(), createUserTokenRequest.Permissions); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if !found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsPermissionNotFound, request), response)
+	} else if !withinCallerScope(bhCtx.AuthCtx, permissions) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, ErrResponseDetailsTokenCallerScope, request), response)
+	} else if withinScope, err := s.tokenOwnerHasPermissions(request.Context(), user, createUserTokenRequest.UserID, permissions); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if !withinScope {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsTokenPermissionsScope, request), response)
 	} else if authToken, err := auth.NewUserAuthToken(createUserTokenRequest.UserID, createUserTokenRequest.TokenName, auth.HMAC_SHA2_256); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
-	} else if newAuthToken, err := s.db.CreateAuthToken(request.Context(), authToken); err != nil {
-		api.HandleDatabaseError(request, response, err)
 	} else {
-		api.WriteBasicResponse(request.Context(), newAuthToken, http.StatusOK, response)
+		authToken.ExpiresAt = createUserTokenRequest.ExpiresAt
+		authToken.Permissions = permissions
+
+		if newAuthToken, err := s.db.CreateAuthToken(request.Context(), authToken); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), newAuthToken, http.StatusOK, response)
+		}
 	}
 }
 
+// tokenOwnerHasPermissions checks that every permission requested for a scoped token is held by the token's owner, which
+// may differ from the requesting user
+func (s ManagementResource) tokenOwnerHasPermissions(ctx context.Context, requestingUser model.User, ownerID string, permissions model.Permissions) (bool, error) {
+	owner := requestingUser
+
+	if len(permissions) == 0 {
+		return true, nil
+	} else if ownerID != requestingUser.ID.String() {
+		if ownerUUID, err := uuid.FromString(ownerID); err != nil {
+			return false, database.ErrNotFound
+		} else if owner, err = s.db.GetUser(ctx, ownerUUID); err != nil {
+			return false, err
+		}
+	}
+
+	granted := owner.Roles.Permissions()
+	for _, permission := range permissions {
+		if !granted.Has(permission) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// withinCallerScope checks that a token requested by a scoped actor is itself scoped to a subset of the actor's scope, so
+// a scoped token can never be used to issue a token with more rights than it holds
+func withinCallerScope(authCtx auth.Context, permissions model.Permissions) bool {
+	if !authCtx.PermissionScope.Enabled {
+		return true
+	} else if len(permissions) == 0 {
+		return false
+	}
+
+	for _, permission := range permissions {
+		if !authCtx.PermissionScope.Permissions.Has(permission) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// callerHoldsPermissions checks that the caller holds every given permission through their own roles and, for a scoped
+// token, within its scope. Roles may not grant what the caller does not hold, or a caller able to manage users could
+// create a role with any permission and assign it.
+func callerHoldsPermissions(authCtx auth.Context, permissions model.Permissions) bool {
+	if len(permissions) == 0 {
+		return true
+	} else if user, isUser := auth.GetUserFromAuthCtx(authCtx); !isUser {
+		return false
+	} else if !withinCallerScope(authCtx, permissions) {
+		return false
+	} else {
+		held := user.Roles.Permissions()
+
+		for _, permission := range permissions {
+			if !held.Has(permission) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// addedPermissions returns the requested permissions that are not already in the existing set
+func addedPermissions(existing, requested model.Permissions) model.Permissions {
+	var added model.Permissions
+
+	for _, permission := range requested {
+		if !existing.Has(permission) {
+			added = append(added, permission)
+		}
+	}
+
+	return added
+}
+
 // This is a helper function that selects the correct user_id to use for the token being created.
 // If no user_id is passed in the request, use the authed user's ID and proceed.
 // If the request contains a user_id other than their own, check to make sure they have permissions to create tokens for other users and reject.
@@ -19,6 +19,7 @@ package auth_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
@@ -30,6 +31,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -90,6 +92,12 @@ func TestManagementResource_PutUserAuthSecret(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil).Times(2)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil).Times(2)
 
 	// Change own user secret requires current password
 	mockDB.EXPECT().UpdateAuthSecret(gomock.Any(), gomock.Any()).Return(nil).Times(1)
@@ -168,6 +176,165 @@ func TestManagementResource_PutUserAuthSecret(t *testing.T) {
 		)
 }
 
+func TestManagementResource_PutUserAuthSecret_PasswordPolicy(t *testing.T) {
+	var (
+		currentPassword  = "currentPassW0rd!"
+		previousPassword = "previousPassW0rd!"
+		breachedPassword = "breachedPassW0rd!"
+		user             = model.User{AuthSecret: defaultDigestAuthSecret(t, currentPassword), Unique: model.Unique{ID: must.NewUUIDv4()}}
+		previousSecret   = defaultDigestAuthSecret(t, previousPassword)
+		breachedFile     = filepath.Join(t.TempDir(), "breached.txt")
+	)
+
+	require.Nil(t, os.WriteFile(breachedFile, []byte(fmt.Sprintf("%X:1\n", sha1.Sum([]byte(breachedPassword)))), 0600))
+
+	newResources := func(t *testing.T) (auth.ManagementResource, *mocks.MockDatabase) {
+		mockCtrl := gomock.NewController(t)
+		mockDB := mocks.NewMockDatabase(mockCtrl)
+
+		cfg, err := config.NewDefaultConfiguration()
+		require.Nil(t, err)
+
+		cfg.Crypto.Argon2.NumIterations = 1
+		cfg.Crypto.Argon2.NumThreads = 1
+		cfg.BreachedPasswordsFile = breachedFile
+
+		return auth.NewManagementResource(cfg, mockDB, authz.NewAuthorizer(mockDB), api.NewAuthenticator(cfg, mockDB, mocks.NewMockAuthContextInitializer(mockCtrl))), mockDB
+	}
+
+	expectPasswordPolicy := func(mockDB *mocks.MockDatabase, policy appcfg.PasswordPolicyParameter) {
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordExpirationWindow).Return(appcfg.Parameter{
+			Key: appcfg.PasswordExpirationWindow,
+			Value: must.NewJSONBObject(appcfg.PasswordExpiration{
+				Duration: appcfg.DefaultPasswordExpirationWindow,
+			}),
+		}, nil)
+		mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+			Key:   appcfg.PasswordPolicyKey,
+			Value: must.NewJSONBObject(policy),
+		}, nil)
+	}
+
+	putSecret := func(t *testing.T, resources auth.ManagementResource, secret string) *httptest.ResponseRecorder {
+		payload, err := json.Marshal(v2.SetUserSecretRequest{CurrentSecret: currentPassword, Secret: secret})
+		require.Nil(t, err)
+
+		req, err := http.NewRequestWithContext(ctx.Set(context.Background(), &ctx.Context{AuthCtx: authz.Context{Owner: user}}), http.MethodPut, fmt.Sprintf(updateUserSecretPathFmt, user.ID), bytes.NewReader(payload))
+		require.Nil(t, err)
+		req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		req = mux.SetURLVars(req, map[string]string{api.URIPathVariableUserID: user.ID.String()})
+
+		response := httptest.NewRecorder()
+		resources.PutUserAuthSecret(response, req)
+
+		return response
+	}
+
+	t.Run("rejects secrets shorter than the policy minimum", func(t *testing.T) {
+		resources, mockDB := newResources(t)
+		expectPasswordPolicy(mockDB, appcfg.PasswordPolicyParameter{MinimumLength: 20})
+
+		response := putSecret(t, resources, "shortPassW0rd!")
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "at least 20 characters")
+	})
+
+	t.Run("rejects the current secret", func(t *testing.T) {
+		resources, mockDB := newResources(t)
+		expectPasswordPolicy(mockDB, appcfg.PasswordPolicyParameter{MinimumLength: 12, HistoryCount: 1})
+
+		response := putSecret(t, resources, currentPassword)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "password was used recently")
+	})
+
+	t.Run("rejects secrets in the history", func(t *testing.T) {
+		resources, mockDB := newResources(t)
+		expectPasswordPolicy(mockDB, appcfg.PasswordPolicyParameter{MinimumLength: 12, HistoryCount: 3})
+		mockDB.EXPECT().GetAuthSecretHistory(gomock.Any(), user.ID, 2).Return([]model.AuthSecretHistory{{
+			UserID:       user.ID,
+			Digest:       previousSecret.Digest,
+			DigestMethod: previousSecret.DigestMethod,
+		}}, nil)
+
+		response := putSecret(t, resources, previousPassword)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "password was used recently")
+	})
+
+	t.Run("rejects breached secrets", func(t *testing.T) {
+		resources, mockDB := newResources(t)
+		expectPasswordPolicy(mockDB, appcfg.PasswordPolicyParameter{MinimumLength: 12, BreachedPasswordCheck: true})
+
+		response := putSecret(t, resources, breachedPassword)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "breached passwords")
+	})
+
+	t.Run("accepts secrets that meet the policy", func(t *testing.T) {
+		resources, mockDB := newResources(t)
+		expectPasswordPolicy(mockDB, appcfg.PasswordPolicyParameter{MinimumLength: 12, HistoryCount: 2, BreachedPasswordCheck: true})
+		mockDB.EXPECT().GetAuthSecretHistory(gomock.Any(), user.ID, 1).Return([]model.AuthSecretHistory{{
+			UserID:       user.ID,
+			Digest:       previousSecret.Digest,
+			DigestMethod: previousSecret.DigestMethod,
+		}}, nil)
+		mockDB.EXPECT().UpdateAuthSecret(gomock.Any(), gomock.Any()).Return(nil)
+
+		response := putSecret(t, resources, "brandNewPassW0rd!")
+		require.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+func TestManagementResource_UnlockUser(t *testing.T) {
+	var (
+		lockedUser  = model.User{AuthSecret: &model.AuthSecret{FailedLoginCount: 2, LockedUntil: null.TimeFrom(time.Now().Add(time.Minute)), Serial: model.Serial{ID: 5}}, Unique: model.Unique{ID: must.NewUUIDv4()}}
+		ssoUser     = model.User{SSOProviderID: null.Int32From(1), Unique: model.Unique{ID: must.NewUUIDv4()}}
+		unlockPath  = "/api/v2/bloodhound-users/{user_id}/lockout"
+		unlockRoute = func(userID string) string { return fmt.Sprintf("/api/v2/bloodhound-users/%s/lockout", userID) }
+	)
+
+	serve := func(t *testing.T, resources auth.ManagementResource, userID string) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, unlockRoute(userID), nil)
+		require.Nil(t, err)
+
+		router := mux.NewRouter()
+		router.HandleFunc(unlockPath, resources.UnlockUser).Methods(http.MethodDelete)
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, req)
+
+		return response
+	}
+
+	t.Run("rejects a malformed user ID", func(t *testing.T) {
+		resources, _ := apitest.NewAuthManagementResource(gomock.NewController(t))
+
+		response := serve(t, resources, "not-a-uuid")
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), api.ErrorResponseDetailsIDMalformed)
+	})
+
+	t.Run("rejects users without a secret", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUser(gomock.Any(), ssoUser.ID).Return(ssoUser, nil)
+
+		response := serve(t, resources, ssoUser.ID.String())
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), api.ErrNoUserSecret.Error())
+	})
+
+	t.Run("unlocks the user", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUser(gomock.Any(), lockedUser.ID).Return(lockedUser, nil)
+		mockDB.EXPECT().UnlockAuthSecret(gomock.Any(), *lockedUser.AuthSecret).Return(nil)
+
+		response := serve(t, resources, lockedUser.ID.String())
+		require.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
 func TestManagementResource_EnableUserSAML(t *testing.T) {
 	var (
 		mockCtrl          = gomock.NewController(t)
@@ -935,7 +1102,7 @@ func TestManagementResource_GetRole(t *testing.T) {
 			expected: expected{
 				responseCode:   http.StatusOK,
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
-				responseBody:   `{"data":{"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"description":"System administrator role","id":123,"name":"Administrator","permissions":[{"authority":"read:users","created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"id":1,"name":"Read Users","updated_at":"0001-01-01T00:00:00Z"},{"authority":"write:users","created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"id":2,"name":"Write Users","updated_at":"0001-01-01T00:00:00Z"}],"updated_at":"0001-01-01T00:00:00Z"}}`,
+				responseBody:   `{"data":{"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"description":"System administrator role","id":123,"name":"Administrator","permissions":[{"authority":"read:users","created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"id":1,"name":"Read Users","updated_at":"0001-01-01T00:00:00Z"},{"authority":"write:users","created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"id":2,"name":"Write Users","updated_at":"0001-01-01T00:00:00Z"}],"built_in":false,"updated_at":"0001-01-01T00:00:00Z"}}`,
 			},
 		},
 	}
@@ -1296,12 +1463,13 @@ func TestCreateUser_Failure(t *testing.T) {
 	badRole := []int32{3}
 
 	badUser := model.User{
-		Roles:         model.Roles{},
-		PrincipalName: "Bad User",
-		FirstName:     null.StringFrom("bad"),
-		LastName:      null.StringFrom("bad"),
-		EmailAddress:  null.StringFrom("bad"),
-		EULAAccepted:  true,
+		Roles:           model.Roles{},
+		PrincipalName:   "Bad User",
+		FirstName:       null.StringFrom("bad"),
+		LastName:        null.StringFrom("bad"),
+		EmailAddress:    null.StringFrom("bad"),
+		EULAAccepted:    true,
+		AllEnvironments: true,
 	}
 
 	resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
@@ -1311,6 +1479,12 @@ func TestCreateUser_Failure(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil).AnyTimes()
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil).AnyTimes()
 	mockDB.EXPECT().GetRoles(gomock.Any(), badRole).Return(model.Roles{}, fmt.Errorf("db error"))
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Not(badRole)).Return(model.Roles{}, nil).AnyTimes()
 	mockDB.EXPECT().AppendAuditLog(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1427,6 +1601,12 @@ func TestCreateUser_FailureDuplicateEmail(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(model.User{}, database.ErrDuplicateEmail)
 
 	ctx := context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{})
@@ -1471,6 +1651,12 @@ func TestCreateUser_Success(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 
@@ -1502,6 +1688,47 @@ func TestCreateUser_Success(t *testing.T) {
 	}
 }
 
+func TestCreateUser_WithoutAllEnvironments(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	var (
+		endpoint        = "/api/v2/auth/users"
+		allEnvironments = false
+	)
+
+	resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
+	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user model.User) (model.User, error) {
+		require.False(t, user.AllEnvironments)
+		return user, nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{})
+	input := v2.CreateUserRequest{
+		UpdateUserRequest: v2.UpdateUserRequest{
+			Principal: "restricted user",
+		},
+		AllEnvironments: &allEnvironments,
+	}
+
+	if payload, err := json.Marshal(input); err != nil {
+		t.Fatal(err)
+	} else if req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payload)); err != nil {
+		t.Fatal(err)
+	} else {
+		req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		router := mux.NewRouter()
+		router.HandleFunc(endpoint, resources.CreateUser).Methods("POST")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"all_environments":false`)
+	}
+}
+
 func TestCreateUser_ResetPassword(t *testing.T) {
 	goodUser := model.User{
 		PrincipalName: "good user",
@@ -1524,6 +1751,12 @@ func TestCreateUser_ResetPassword(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil)
 
@@ -1597,6 +1830,12 @@ func TestManagementResource_UpdateUser_IDMalformed(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 
@@ -1660,6 +1899,12 @@ func TestManagementResource_UpdateUser_GetUserError(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 	mockDB.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(model.User{}, fmt.Errorf("foo"))
@@ -1724,6 +1969,12 @@ func TestManagementResource_UpdateUser_GetRolesError(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 	mockDB.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(goodUser, nil)
@@ -1815,6 +2066,12 @@ func TestManagementResource_UpdateUser_SelfDisable(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 	mockDB.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(goodUser, nil)
@@ -1957,6 +2214,12 @@ func TestManagementResource_UpdateUser_LookupActiveSessionsError(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 	mockDB.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(goodUser, nil)
@@ -2038,6 +2301,12 @@ func TestManagementResource_UpdateUser_DBError(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 	mockDB.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(goodUser, nil)
@@ -2270,7 +2539,7 @@ func TestManagementResource_GetSelf(t *testing.T) {
 			expected: expected{
 				responseCode:   http.StatusOK,
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
-				responseBody:   `{"data":{"AuthSecret":null,"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"email_address":"john.doe@example.com","eula_accepted":false,"first_name":"John","id":"00000000-0000-0000-0000-000000000000","is_disabled":false,"last_login":"0001-01-01T00:00:00Z","last_name":"Doe","principal_name":"john.doe","roles":[{"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"description":"The big boy.","id":0,"name":"Big Boy","permissions":[],"updated_at":"0001-01-01T00:00:00Z"}],"sso_provider_id":null,"updated_at":"0001-01-01T00:00:00Z","all_environments":false}}`,
+				responseBody:   `{"data":{"AuthSecret":null,"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"email_address":"john.doe@example.com","eula_accepted":false,"first_name":"John","id":"00000000-0000-0000-0000-000000000000","is_disabled":false,"last_login":"0001-01-01T00:00:00Z","last_name":"Doe","principal_name":"john.doe","roles":[{"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"description":"The big boy.","id":0,"name":"Big Boy","permissions":[],"built_in":false,"updated_at":"0001-01-01T00:00:00Z"}],"sso_provider_id":null,"updated_at":"0001-01-01T00:00:00Z","all_environments":false}}`,
 			},
 		},
 		{
@@ -2497,7 +2766,7 @@ func TestManagementResource_DeleteUser_DeleteUserError(t *testing.T) {
 
 	resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
 	mockDB.EXPECT().GetUser(gomock.Any(), userID).Return(user, nil)
-	mockDB.EXPECT().DeleteUser(gomock.Any(), user).Return(fmt.Errorf("foo"))
+	mockDB.EXPECT().DeleteUserAndTransferSavedQueries(gomock.Any(), user, adminUser).Return(fmt.Errorf("foo"))
 
 	bhCtx := ctx.Get(context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{}))
 	bhCtx.AuthCtx.Owner = adminUser
@@ -2536,11 +2805,11 @@ func TestManagementResource_DeleteUser_Success(t *testing.T) {
 		},
 	}
 
+	adminUser := model.User{AuthSecret: defaultDigestAuthSecret(t, "currentPassword"), Unique: model.Unique{ID: must.NewUUIDv4()}, Roles: model.Roles{adminRole}}
+
 	resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
 	mockDB.EXPECT().GetUser(gomock.Any(), userID).Return(user, nil)
-	mockDB.EXPECT().DeleteUser(gomock.Any(), user).Return(nil)
-
-	adminUser := model.User{AuthSecret: defaultDigestAuthSecret(t, "currentPassword"), Unique: model.Unique{ID: must.NewUUIDv4()}, Roles: model.Roles{adminRole}}
+	mockDB.EXPECT().DeleteUserAndTransferSavedQueries(gomock.Any(), user, adminUser).Return(nil)
 
 	bhCtx := ctx.Get(context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{}))
 	bhCtx.AuthCtx.Owner = adminUser
@@ -2582,6 +2851,12 @@ func TestManagementResource_UpdateUser_Success(t *testing.T) {
 			Duration: appcfg.DefaultPasswordExpirationWindow,
 		}),
 	}, nil)
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.PasswordPolicyKey).Return(appcfg.Parameter{
+		Key: appcfg.PasswordPolicyKey,
+		Value: must.NewJSONBObject(appcfg.PasswordPolicyParameter{
+			MinimumLength: appcfg.DefaultPasswordMinimumLength,
+		}),
+	}, nil)
 	mockDB.EXPECT().GetRoles(gomock.Any(), gomock.Any()).Return(model.Roles{}, nil)
 	mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(goodUser, nil).AnyTimes()
 	mockDB.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(goodUser, nil)
@@ -3473,7 +3748,7 @@ func TestManagementResource_CreateAuthToken(t *testing.T) {
 			expected: expected{
 				responseCode:   http.StatusOK,
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
-				responseBody:   `{"data":{"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"hmac_method":"hmac-sha2-256","id":"00000000-0000-0000-0000-000000000000","key":"key","last_access":"0001-01-01T00:00:00Z","name":"name","updated_at":"0001-01-01T00:00:00Z","user_id":null}}`,
+				responseBody:   `{"data":{"created_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false},"hmac_method":"hmac-sha2-256","id":"00000000-0000-0000-0000-000000000000","key":"key","last_access":"0001-01-01T00:00:00Z","expires_at":null,"is_disabled":false,"permissions":null,"name":"name","updated_at":"0001-01-01T00:00:00Z","user_id":null}}`,
 			},
 		},
 	}
@@ -4685,3 +4960,414 @@ func TestManagementResource_DeleteAuthToken(t *testing.T) {
 		})
 	}
 }
+
+func TestManagementResource_CreateAuthToken_Scoped(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+		graphRead         = model.Permission{Authority: "graphdb", Name: "Read", Serial: model.Serial{ID: 1}}
+		graphIngest       = model.Permission{Authority: "graphdb", Name: "Ingest", Serial: model.Serial{ID: 2}}
+		manageUsers       = model.Permission{Authority: "auth", Name: "ManageUsers", Serial: model.Serial{ID: 3}}
+		catalogue         = model.Permissions{graphRead, graphIngest, manageUsers}
+		user              = model.User{
+			Roles:  model.Roles{{Permissions: model.Permissions{graphRead, graphIngest}}},
+			Unique: model.Unique{ID: must.NewUUIDv4()},
+		}
+		expiresAt = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		userCtx   = context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{Owner: user}})
+		scopedCtx = context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{
+			Owner:           user,
+			PermissionScope: authz.PermissionScope{Enabled: true, Permissions: model.Permissions{graphRead}},
+		}})
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CreateAuthToken).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetContext(input, userCtx)
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "ExpiresInPast",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateUserToken{TokenName: "ingest", ExpiresAt: null.TimeFrom(time.Now().Add(-time.Hour))})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, auth.ErrResponseDetailsTokenExpiresInPast)
+				},
+			},
+			{
+				Name: "ScopedCallerUnscopedToken",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, scopedCtx)
+					apitest.BodyStruct(input, v2.CreateUserToken{TokenName: "escalate"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, auth.ErrResponseDetailsTokenCallerScope)
+				},
+			},
+			{
+				Name: "ScopedCallerPermissionOutsideScope",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, scopedCtx)
+					apitest.BodyStruct(input, v2.CreateUserToken{TokenName: "escalate", Permissions: []int32{graphRead.ID, graphIngest.ID}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, auth.ErrResponseDetailsTokenCallerScope)
+				},
+			},
+			{
+				Name: "PermissionNotHeldByOwner",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateUserToken{TokenName: "ingest", Permissions: []int32{graphIngest.ID, manageUsers.ID}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, auth.ErrResponseDetailsTokenPermissionsScope)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateUserToken{TokenName: "ingest", ExpiresAt: null.TimeFrom(expiresAt), Permissions: []int32{graphIngest.ID}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+					mockDB.EXPECT().CreateAuthToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token model.AuthToken) (model.AuthToken, error) {
+						require.Equal(t, model.Permissions{graphIngest}, token.Permissions)
+						require.True(t, token.ExpiresAt.Time.Equal(expiresAt))
+						require.Equal(t, user.ID, token.UserID.UUID)
+						return token, nil
+					})
+				},
+				Test: func(output apitest.Output) {
+					var token model.AuthToken
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &token)
+					apitest.Equal(output, 1, len(token.Permissions))
+					apitest.Equal(output, true, token.ExpiresAt.Valid)
+				},
+			},
+		})
+}
+
+func TestManagementResource_CreateRole(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+		catalogue         = model.Permissions{
+			{Authority: "graphdb", Name: "Read", Serial: model.Serial{ID: 1}},
+			{Authority: "auth", Name: "ManageUsers", Serial: model.Serial{ID: 2}},
+		}
+		admin       = model.User{Roles: model.Roles{{Permissions: catalogue}}, Unique: model.Unique{ID: must.NewUUIDv4()}}
+		userManager = model.User{Roles: model.Roles{{Permissions: model.Permissions{catalogue[1]}}}, Unique: model.Unique{ID: must.NewUUIDv4()}}
+		adminCtx    = context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{Owner: admin}})
+		scopedCtx   = context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{
+			Owner:           admin,
+			PermissionScope: authz.PermissionScope{Enabled: true, Permissions: model.Permissions{catalogue[1]}},
+		}})
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CreateRole).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetContext(input, adminCtx)
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "MissingName",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateRoleRequest{Name: "  ", Permissions: []int32{1}})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, auth.ErrResponseDetailsRoleNameRequired)
+				},
+			},
+			{
+				Name: "UnknownPermission",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateRoleRequest{Name: "AGT Reviewer", Permissions: []int32{1, 99}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, auth.ErrResponseDetailsPermissionNotFound)
+				},
+			},
+			{
+				Name: "PermissionNotHeld",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{Owner: userManager}}))
+					apitest.BodyStruct(input, v2.CreateRoleRequest{Name: "Escalation", Permissions: []int32{1, 2}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, auth.ErrResponseDetailsRolePermissionNotHeld)
+				},
+			},
+			{
+				Name: "PermissionOutsideCallerScope",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, scopedCtx)
+					apitest.BodyStruct(input, v2.CreateRoleRequest{Name: "Escalation", Permissions: []int32{1}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, auth.ErrResponseDetailsRolePermissionNotHeld)
+				},
+			},
+			{
+				Name: "DuplicateName",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateRoleRequest{Name: "AGT Reviewer", Permissions: []int32{1}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+					mockDB.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(model.Role{}, database.ErrDuplicateRoleName)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+					apitest.BodyContains(output, auth.ErrResponseDetailsRoleDuplicateName)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateRoleRequest{Name: "AGT Reviewer", Description: "Read-only graph plus certify", Permissions: []int32{1, 1}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+					mockDB.EXPECT().CreateRole(gomock.Any(), model.Role{
+						Name:        "AGT Reviewer",
+						Description: "Read-only graph plus certify",
+						Permissions: model.Permissions{catalogue[0]},
+					}).Return(model.Role{Name: "AGT Reviewer", Permissions: model.Permissions{catalogue[0]}, Serial: model.Serial{ID: 6}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					var role model.Role
+
+					apitest.StatusCode(output, http.StatusCreated)
+					apitest.UnmarshalData(output, &role)
+					apitest.Equal(output, int32(6), role.ID)
+					apitest.Equal(output, false, role.BuiltIn)
+				},
+			},
+		})
+}
+
+func TestManagementResource_UpdateRole(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+		catalogue         = model.Permissions{
+			{Authority: "graphdb", Name: "Read", Serial: model.Serial{ID: 1}},
+			{Authority: "auth", Name: "ManageUsers", Serial: model.Serial{ID: 2}},
+		}
+		customRole  = model.Role{Name: "Ingest Operator", Description: "Ingest", Permissions: model.Permissions{catalogue[0]}, Serial: model.Serial{ID: 6}}
+		builtInRole = model.Role{Name: "Administrator", BuiltIn: true, Serial: model.Serial{ID: 1}}
+		description = "Ingest plus datapipe status"
+		admin       = model.User{Roles: model.Roles{{Permissions: catalogue}}, Unique: model.Unique{ID: must.NewUUIDv4()}}
+		userManager = model.User{Roles: model.Roles{{Permissions: model.Permissions{catalogue[1]}}}, Unique: model.Unique{ID: must.NewUUIDv4()}}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.UpdateRole).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetContext(input, context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{Owner: admin}}))
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableRoleID, "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "NotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableRoleID, "42")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetRole(gomock.Any(), int32(42)).Return(model.Role{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "BuiltInRole",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableRoleID, "1")
+					apitest.BodyStruct(input, v2.UpdateRoleRequest{Name: "Renamed"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetRole(gomock.Any(), int32(1)).Return(builtInRole, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+					apitest.BodyContains(output, auth.ErrResponseDetailsRoleBuiltIn)
+				},
+			},
+			{
+				Name: "AddedPermissionNotHeld",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{Owner: userManager}}))
+					apitest.SetURLVar(input, api.URIPathVariableRoleID, "6")
+					apitest.BodyStruct(input, v2.UpdateRoleRequest{Permissions: []int32{1, 2}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetRole(gomock.Any(), int32(6)).Return(model.Role{Name: "Manager", Permissions: model.Permissions{catalogue[1]}, Serial: model.Serial{ID: 6}}, nil)
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusForbidden)
+					apitest.BodyContains(output, auth.ErrResponseDetailsRolePermissionNotHeld)
+				},
+			},
+			{
+				Name: "KeepsExistingPermissionNotHeld",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{AuthCtx: authz.Context{Owner: userManager}}))
+					apitest.SetURLVar(input, api.URIPathVariableRoleID, "6")
+					apitest.BodyStruct(input, v2.UpdateRoleRequest{Permissions: []int32{1}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetRole(gomock.Any(), int32(6)).Return(customRole, nil)
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+					mockDB.EXPECT().UpdateRole(gomock.Any(), customRole).Return(customRole, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableRoleID, "6")
+					apitest.BodyStruct(input, v2.UpdateRoleRequest{Description: &description, Permissions: []int32{1, 2}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetRole(gomock.Any(), int32(6)).Return(customRole, nil)
+					mockDB.EXPECT().GetAllPermissions(gomock.Any(), "", model.SQLFilter{}).Return(catalogue, nil)
+					mockDB.EXPECT().UpdateRole(gomock.Any(), model.Role{
+						Name:        customRole.Name,
+						Description: description,
+						Permissions: catalogue,
+						Serial:      customRole.Serial,
+					}).DoAndReturn(func(_ context.Context, role model.Role) (model.Role, error) {
+						return role, nil
+					})
+				},
+				Test: func(output apitest.Output) {
+					var role model.Role
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &role)
+					apitest.Equal(output, "Ingest Operator", role.Name)
+					apitest.Equal(output, 2, len(role.Permissions))
+				},
+			},
+		})
+}
+
+func TestManagementResource_DeleteRole(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+		customRole        = model.Role{Name: "AGT Reviewer", Serial: model.Serial{ID: 6}}
+		builtInRole       = model.Role{Name: "Administrator", BuiltIn: true, Serial: model.Serial{ID: 1}}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.DeleteRole).Run([]apitest.Case{
+		{
+			Name: "BuiltInRole",
+			Input: func(input *apitest.Input) {
+				apitest.SetURLVar(input, api.URIPathVariableRoleID, "1")
+			},
+			Setup: func() {
+				mockDB.EXPECT().GetRole(gomock.Any(), int32(1)).Return(builtInRole, nil)
+			},
+			Test: func(output apitest.Output) {
+				apitest.StatusCode(output, http.StatusConflict)
+				apitest.BodyContains(output, auth.ErrResponseDetailsRoleBuiltIn)
+			},
+		},
+		{
+			Name: "RoleInUse",
+			Input: func(input *apitest.Input) {
+				apitest.SetURLVar(input, api.URIPathVariableRoleID, "6")
+			},
+			Setup: func() {
+				mockDB.EXPECT().GetRole(gomock.Any(), int32(6)).Return(customRole, nil)
+				mockDB.EXPECT().DeleteRole(gomock.Any(), customRole).Return(database.ErrRoleInUse)
+			},
+			Test: func(output apitest.Output) {
+				apitest.StatusCode(output, http.StatusConflict)
+				apitest.BodyContains(output, auth.ErrResponseDetailsRoleInUse)
+			},
+		},
+		{
+			Name: "RoleProvisionedBySSOProvider",
+			Input: func(input *apitest.Input) {
+				apitest.SetURLVar(input, api.URIPathVariableRoleID, "6")
+			},
+			Setup: func() {
+				mockDB.EXPECT().GetRole(gomock.Any(), int32(6)).Return(customRole, nil)
+				mockDB.EXPECT().DeleteRole(gomock.Any(), customRole).Return(database.ErrRoleReferencedBySSOProvider)
+			},
+			Test: func(output apitest.Output) {
+				apitest.StatusCode(output, http.StatusConflict)
+				apitest.BodyContains(output, auth.ErrResponseDetailsRoleSSOProvider)
+			},
+		},
+		{
+			Name: "Success",
+			Input: func(input *apitest.Input) {
+				apitest.SetURLVar(input, api.URIPathVariableRoleID, "6")
+			},
+			Setup: func() {
+				mockDB.EXPECT().GetRole(gomock.Any(), int32(6)).Return(customRole, nil)
+				mockDB.EXPECT().DeleteRole(gomock.Any(), customRole).Return(nil)
+			},
+			Test: func(output apitest.Output) {
+				apitest.StatusCode(output, http.StatusNoContent)
+			},
+		},
+	})
+}
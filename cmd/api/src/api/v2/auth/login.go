@@ -52,6 +52,10 @@ func (s LoginResource) loginSecret(loginRequest api.LoginRequest, response http.
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
 		} else if errors.Is(err, auth.ErrInvalidOTP) {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsOTPInvalid, request), response)
+		} else if errors.Is(err, api.ErrWebAuthnRequired) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsWebAuthnRequired, request), response)
+		} else if errors.Is(err, auth.ErrInvalidWebAuthn) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsWebAuthnInvalid, request), response)
 		} else if errors.Is(err, api.ErrUserDisabled) {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, err.Error(), request), response)
 		} else {
@@ -60,9 +64,10 @@ func (s LoginResource) loginSecret(loginRequest api.LoginRequest, response http.
 		}
 	} else {
 		api.WriteBasicResponse(request.Context(), api.LoginResponse{
-			UserID:       loginDetails.User.ID.String(),
-			AuthExpired:  loginDetails.User.AuthSecret.Expired(),
-			SessionToken: loginDetails.SessionToken,
+			UserID:                     loginDetails.User.ID.String(),
+			AuthExpired:                loginDetails.User.AuthSecret.Expired(),
+			SessionToken:               loginDetails.SessionToken,
+			WebAuthnEnrollmentRequired: loginDetails.WebAuthnEnrollmentRequired,
 		}, http.StatusOK, response)
 	}
 }
@@ -88,6 +93,31 @@ func (s LoginResource) Login(response http.ResponseWriter, request *http.Request
 	}
 }
 
+// BeginWebAuthnLogin validates a user's secret and returns the options for asserting one of their WebAuthn credentials.
+// The assertion is then submitted to Login along with the secret in place of a one time password.
+func (s LoginResource) BeginWebAuthnLogin(response http.ResponseWriter, request *http.Request) {
+	var loginRequest api.LoginRequest
+
+	if err := api.ReadJSONRequestPayloadLimited(&loginRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if loginRequest.Username = strings.TrimSpace(loginRequest.Username); !strings.EqualFold(loginRequest.LoginMethod, auth.ProviderTypeSecret) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Login method %s is not supported.", loginRequest.LoginMethod), request), response)
+	} else if options, err := s.authenticator.BeginWebAuthnLogin(request.Context(), loginRequest); err != nil {
+		if errors.Is(err, api.ErrInvalidAuth) || errors.Is(err, api.ErrNoUserSecret) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, api.ErrorResponseDetailsAuthenticationInvalid, request), response)
+		} else if errors.Is(err, api.ErrWebAuthnNotRegistered) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsWebAuthnNotRegistered, request), response)
+		} else if errors.Is(err, api.ErrUserDisabled) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, err.Error(), request), response)
+		} else {
+			slog.ErrorContext(request.Context(), fmt.Sprintf("Error starting webauthn login for request ID %s: %v", ctx.RequestID(request), err))
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+		}
+	} else {
+		api.WriteBasicResponse(request.Context(), options, http.StatusOK, response)
+	}
+}
+
 // EULA Acceptance does not pertain to Bloodhound Community Edition; this flag is used for Bloodhound Enterprise users.
 func (s LoginResource) patchEULAAcceptance(ctx context.Context, username string) error {
 	if user, err := s.db.LookupUser(ctx, username); err != nil {
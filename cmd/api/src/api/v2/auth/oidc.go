@@ -51,6 +51,9 @@ var (
 	ErrOIDCIssuerURLInvalid = errors.New("oidc provider issuer url invalid")
 	ErrRoleIDInvalid        = errors.New("role id invalid")
 	ErrEmailMissing         = errors.New("email missing")
+
+	ErrEnvironmentMappingInvalid = errors.New("environment mapping invalid")
+	ErrMappingRuleInvalid        = errors.New("mapping rule invalid")
 )
 
 type oidcClaims struct {
@@ -61,7 +64,32 @@ type oidcClaims struct {
 	Verified          bool   `json:"email_verified"`
 	PreferredUsername string `json:"preferred_username"` // Present in Entra claims, may be an email
 
-	Roles []string `json:"roles"`
+	Roles  []string `json:"roles"`
+	Groups []string `json:"groups"`
+
+	// raw holds every claim of the ID token for evaluating mapping rules
+	raw map[string]any
+}
+
+// ssoClaims flattens the raw ID token claims. Array claims contribute one value per element and scalar claims are
+// formatted as strings.
+func (s oidcClaims) ssoClaims() model.SSOClaims {
+	claims := model.SSOClaims{}
+
+	for name, value := range s.raw {
+		switch typed := value.(type) {
+		case []any:
+			for _, element := range typed {
+				claims[name] = append(claims[name], fmt.Sprint(element))
+			}
+		case nil:
+			continue
+		default:
+			claims[name] = []string{fmt.Sprint(typed)}
+		}
+	}
+
+	return claims
 }
 
 // UpsertOIDCProviderRequest represents the body of create & update provider endpoints
@@ -84,6 +112,8 @@ func (s ManagementResource) UpdateOIDCProviderRequest(response http.ResponseWrit
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "issuer url is invalid", request), response)
 	} else if errors.Is(err, ErrRoleIDInvalid) {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "role id is invalid", request), response)
+	} else if errors.Is(err, ErrEnvironmentMappingInvalid) || errors.Is(err, ErrMappingRuleInvalid) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else if err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
 	} else if oidcProvider, err := s.db.UpdateOIDCProvider(request.Context(), ssoProvider); errors.Is(err, database.ErrDuplicateSSOProviderName) {
@@ -122,6 +152,10 @@ func updateOIDCProvider(ctx context.Context, ssoProvider model.SSOProvider, upse
 			ssoProvider.Config.AutoProvision = model.SSOProviderAutoProvisionConfig{}
 		} else if _, err := r.GetRole(ctx, upsertReq.Config.AutoProvision.DefaultRoleId); err != nil {
 			return ssoProvider, ErrRoleIDInvalid
+		} else if err := validateEnvironmentMappings(upsertReq.Config.AutoProvision.EnvironmentMappings); err != nil {
+			return ssoProvider, err
+		} else if err := validateMappingRules(ctx, upsertReq.Config.AutoProvision.MappingRules, r); err != nil {
+			return ssoProvider, err
 		} else {
 			ssoProvider.Config.AutoProvision = upsertReq.Config.AutoProvision
 		}
@@ -142,6 +176,10 @@ func (s ManagementResource) CreateOIDCProvider(response http.ResponseWriter, req
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "config is required", request), response)
 	} else if _, err := s.db.GetRole(request.Context(), upsertReq.Config.AutoProvision.DefaultRoleId); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "role id is invalid", request), response)
+	} else if err := validateEnvironmentMappings(upsertReq.Config.AutoProvision.EnvironmentMappings); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err := validateMappingRules(request.Context(), upsertReq.Config.AutoProvision.MappingRules, s.db); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else if oidcProvider, err := s.db.CreateOIDCProvider(request.Context(), upsertReq.Name, upsertReq.Issuer, upsertReq.ClientID, *upsertReq.Config); errors.Is(err, database.ErrDuplicateSSOProviderName) {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseSSOProviderDuplicateName, request), response)
 	} else if err != nil {
@@ -246,7 +284,7 @@ func (s ManagementResource) OIDCCallbackHandler(response http.ResponseWriter, re
 			}
 		}
 
-		s.authenticator.CreateSSOSession(request, response, email, ssoProvider)
+		s.authenticator.CreateSSOSession(request, response, email, ssoProvider, claims.ssoClaims())
 	}
 }
 
@@ -334,6 +372,8 @@ func getOIDCClaims(reqCtx context.Context, provider *oidc.Provider, ssoProvider
 		return claims, fmt.Errorf("id token verification: %v", err)
 	} else if err := idToken.Claims(&claims); err != nil {
 		return claims, fmt.Errorf("parse claims: %v", err)
+	} else if err := idToken.Claims(&claims.raw); err != nil {
+		return claims, fmt.Errorf("parse raw claims: %v", err)
 	} else {
 		return claims, nil
 	}
@@ -356,21 +396,28 @@ func jitOIDCUserUpsert(ctx context.Context, ssoProvider model.SSOProvider, email
 		return fmt.Errorf("invalid roles")
 	} else if user, err := u.LookupUser(ctx, email); err != nil {
 		if errors.Is(err, database.ErrNotFound) {
-			return jitOIDCUserCreate(ctx, ssoProvider, email, claims, u, roles)
+			if user, err := jitOIDCUserCreate(ctx, ssoProvider, email, claims, u, roles); err != nil {
+				return err
+			} else {
+				return jitUserEnvironmentsProvision(ctx, ssoProvider.Config.AutoProvision, user, claims.Groups, claims.ssoClaims(), u)
+			}
 		}
 		return fmt.Errorf("user lookup: %v", err)
-	} else if ssoProvider.Config.AutoProvision.RoleProvision && !user.Roles.Has(roles[0]) {
-		//  roles should only ever have 1 role
-		user.Roles = roles
-		if err := u.UpdateUser(ctx, user); err != nil {
-			return fmt.Errorf("update user: %v", err)
+	} else {
+		// Mapping rules decide the role of existing users when the session is created
+		if ssoProvider.Config.AutoProvision.RoleProvision && len(ssoProvider.Config.AutoProvision.MappingRules) == 0 && !user.Roles.Has(roles[0]) {
+			//  roles should only ever have 1 role
+			user.Roles = roles
+			if err := u.UpdateUser(ctx, user); err != nil {
+				return fmt.Errorf("update user: %v", err)
+			}
 		}
-	}
 
-	return nil
+		return jitUserEnvironmentsProvision(ctx, ssoProvider.Config.AutoProvision, user, claims.Groups, claims.ssoClaims(), u)
+	}
 }
 
-func jitOIDCUserCreate(ctx context.Context, ssoProvider model.SSOProvider, email string, claims oidcClaims, u jitUserUpserter, roles model.Roles) error {
+func jitOIDCUserCreate(ctx context.Context, ssoProvider model.SSOProvider, email string, claims oidcClaims, u jitUserUpserter, roles model.Roles) (model.User, error) {
 	user := model.User{
 		EmailAddress:  null.StringFrom(email),
 		PrincipalName: email,
@@ -381,6 +428,9 @@ func jitOIDCUserCreate(ctx context.Context, ssoProvider model.SSOProvider, email
 		LastName:      null.StringFrom("Last name not found"),
 	}
 
+	// Users start without environment access when environment provisioning grants it after creation
+	user.AllEnvironments = !ssoProvider.Config.AutoProvision.EnvironmentProvision
+
 	if claims.FirstName != "" {
 		user.FirstName = null.StringFrom(claims.FirstName)
 	}
@@ -389,8 +439,9 @@ func jitOIDCUserCreate(ctx context.Context, ssoProvider model.SSOProvider, email
 		user.LastName = null.StringFrom(claims.LastName)
 	}
 
-	if _, err := u.CreateUser(ctx, user); err != nil {
-		return fmt.Errorf("create user: %v", err)
+	if newUser, err := u.CreateUser(ctx, user); err != nil {
+		return model.User{}, fmt.Errorf("create user: %v", err)
+	} else {
+		return newUser, nil
 	}
-	return nil
 }
@@ -18,6 +18,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -127,17 +128,72 @@ func getSSOProviderConfigFromMultipartRequest(ctx context.Context, multipartForm
 		return nil, fmt.Errorf("\"config.auto_provision.role_provision\" has more than one value")
 	} else if isRoleProvisioned, err := strconv.ParseBool(roleProvision[0]); err != nil {
 		return nil, fmt.Errorf("\"config.auto_provision.role_provision\" parameter could not be converted to bool")
+	} else if isEnvironmentProvisioned, environmentMappings, err := getEnvironmentProvisionFromMultipartRequest(multipartForm); err != nil {
+		return nil, err
+	} else if mappingRules, err := getMappingRulesFromMultipartRequest(ctx, multipartForm, r); err != nil {
+		return nil, err
 	} else {
 		return &model.SSOProviderConfig{
 			AutoProvision: model.SSOProviderAutoProvisionConfig{
-				Enabled:       isAutoProvisionEnabled,
-				DefaultRoleId: defaultRole.ID,
-				RoleProvision: isRoleProvisioned,
+				Enabled:              isAutoProvisionEnabled,
+				DefaultRoleId:        defaultRole.ID,
+				RoleProvision:        isRoleProvisioned,
+				EnvironmentProvision: isEnvironmentProvisioned,
+				EnvironmentMappings:  environmentMappings,
+				MappingRules:         mappingRules,
 			},
 		}, nil
 	}
 }
 
+// getEnvironmentProvisionFromMultipartRequest reads the optional environment provisioning parameters. The environment
+// mappings are given as a JSON encoded array.
+func getEnvironmentProvisionFromMultipartRequest(multipartForm *multipart.Form) (bool, []model.SSOProviderEnvironmentMapping, error) {
+	var (
+		isEnvironmentProvisioned bool
+		environmentMappings      []model.SSOProviderEnvironmentMapping
+	)
+
+	if environmentProvision, hasEnvironmentProvision := multipartForm.Value["config.auto_provision.environment_provision"]; hasEnvironmentProvision {
+		if len(environmentProvision) > 1 {
+			return false, nil, fmt.Errorf("\"config.auto_provision.environment_provision\" has more than one value")
+		} else if parsed, err := strconv.ParseBool(environmentProvision[0]); err != nil {
+			return false, nil, fmt.Errorf("\"config.auto_provision.environment_provision\" parameter could not be converted to bool")
+		} else {
+			isEnvironmentProvisioned = parsed
+		}
+	}
+
+	if rawMappings, hasMappings := multipartForm.Value["config.auto_provision.environment_mappings"]; hasMappings {
+		if len(rawMappings) > 1 {
+			return false, nil, fmt.Errorf("\"config.auto_provision.environment_mappings\" has more than one value")
+		} else if err := json.Unmarshal([]byte(rawMappings[0]), &environmentMappings); err != nil {
+			return false, nil, fmt.Errorf("\"config.auto_provision.environment_mappings\" parameter could not be parsed")
+		} else if err := validateEnvironmentMappings(environmentMappings); err != nil {
+			return false, nil, err
+		}
+	}
+
+	return isEnvironmentProvisioned, environmentMappings, nil
+}
+
+// getMappingRulesFromMultipartRequest reads the optional mapping rules, given as a JSON encoded array
+func getMappingRulesFromMultipartRequest(ctx context.Context, multipartForm *multipart.Form, r getRoler) ([]model.SSOProviderMappingRule, error) {
+	var mappingRules []model.SSOProviderMappingRule
+
+	if rawRules, hasRules := multipartForm.Value["config.auto_provision.mapping_rules"]; !hasRules {
+		return nil, nil
+	} else if len(rawRules) > 1 {
+		return nil, fmt.Errorf("\"config.auto_provision.mapping_rules\" has more than one value")
+	} else if err := json.Unmarshal([]byte(rawRules[0]), &mappingRules); err != nil {
+		return nil, fmt.Errorf("\"config.auto_provision.mapping_rules\" parameter could not be parsed")
+	} else if err := validateMappingRules(ctx, mappingRules, r); err != nil {
+		return nil, err
+	} else {
+		return mappingRules, nil
+	}
+}
+
 // This retains support for the old saml login urls /api/{version}/login/saml/ that were added to their respective IDPs
 func (s ManagementResource) SAMLLoginRedirect(response http.ResponseWriter, request *http.Request) {
 	ssoProviderSlug := mux.Vars(request)[api.URIPathVariableSSOProviderSlug]
@@ -466,7 +522,7 @@ func (s ManagementResource) SAMLCallbackHandler(response http.ResponseWriter, re
 			}
 		}
 
-		s.authenticator.CreateSSOSession(request, response, principalName, ssoProvider)
+		s.authenticator.CreateSSOSession(request, response, principalName, ssoProvider, ssoProvider.SAMLProvider.GetSAMLClaimsFromAssertion(assertion))
 	}
 }
 
@@ -477,21 +533,28 @@ func jitSAMLUserUpsert(ctx context.Context, ssoProvider model.SSOProvider, princ
 		return fmt.Errorf("invalid roles detected")
 	} else if user, err := u.LookupUser(ctx, principalName); err != nil {
 		if errors.Is(err, database.ErrNotFound) {
-			return jitSAMLUserCreate(ctx, ssoProvider, principalName, assertion, u, roles)
+			if user, err := jitSAMLUserCreate(ctx, ssoProvider, principalName, assertion, u, roles); err != nil {
+				return err
+			} else {
+				return jitUserEnvironmentsProvision(ctx, ssoProvider.Config.AutoProvision, user, ssoProvider.SAMLProvider.GetSAMLUserGroupsFromAssertion(assertion), ssoProvider.SAMLProvider.GetSAMLClaimsFromAssertion(assertion), u)
+			}
 		}
 		return fmt.Errorf("lookup user: %v", err)
-	} else if ssoProvider.Config.AutoProvision.RoleProvision && !user.Roles.Has(roles[0]) {
-		//  roles should only ever have 1 role
-		user.Roles = roles
-		if err := u.UpdateUser(ctx, user); err != nil {
-			return fmt.Errorf("update user: %v", err)
+	} else {
+		// Mapping rules decide the role of existing users when the session is created
+		if ssoProvider.Config.AutoProvision.RoleProvision && len(ssoProvider.Config.AutoProvision.MappingRules) == 0 && !user.Roles.Has(roles[0]) {
+			//  roles should only ever have 1 role
+			user.Roles = roles
+			if err := u.UpdateUser(ctx, user); err != nil {
+				return fmt.Errorf("update user: %v", err)
+			}
 		}
-	}
 
-	return nil
+		return jitUserEnvironmentsProvision(ctx, ssoProvider.Config.AutoProvision, user, ssoProvider.SAMLProvider.GetSAMLUserGroupsFromAssertion(assertion), ssoProvider.SAMLProvider.GetSAMLClaimsFromAssertion(assertion), u)
+	}
 }
 
-func jitSAMLUserCreate(ctx context.Context, ssoProvider model.SSOProvider, principalName string, assertion *saml.Assertion, u jitUserUpserter, roles model.Roles) error {
+func jitSAMLUserCreate(ctx context.Context, ssoProvider model.SSOProvider, principalName string, assertion *saml.Assertion, u jitUserUpserter, roles model.Roles) (model.User, error) {
 	user := model.User{
 		EmailAddress:  null.StringFrom(principalName),
 		PrincipalName: principalName,
@@ -502,6 +565,9 @@ func jitSAMLUserCreate(ctx context.Context, ssoProvider model.SSOProvider, princ
 		LastName:      null.StringFrom("Last name not found"),
 	}
 
+	// Users start without environment access when environment provisioning grants it after creation
+	user.AllEnvironments = !ssoProvider.Config.AutoProvision.EnvironmentProvision
+
 	if givenName, err := ssoProvider.SAMLProvider.GetSAMLUserGivenNameFromAssertion(assertion); err == nil {
 		user.FirstName = null.StringFrom(givenName)
 	}
@@ -510,8 +576,9 @@ func jitSAMLUserCreate(ctx context.Context, ssoProvider model.SSOProvider, princ
 		user.LastName = null.StringFrom(surname)
 	}
 
-	if _, err := u.CreateUser(ctx, user); err != nil {
-		return fmt.Errorf("create user: %v", err)
+	if newUser, err := u.CreateUser(ctx, user); err != nil {
+		return model.User{}, fmt.Errorf("create user: %v", err)
+	} else {
+		return newUser, nil
 	}
-	return nil
 }
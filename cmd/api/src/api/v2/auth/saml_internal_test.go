@@ -99,13 +99,89 @@ func TestAuth_CreateSSOSession(t *testing.T) {
 		principalName, err := gothamSAML.GetSAMLUserPrincipalNameFromAssertion(testAssertion)
 		require.Nil(t, err)
 
-		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO)
+		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO, gothamSAML.GetSAMLClaimsFromAssertion(testAssertion))
 
 		require.Regexp(t, expectedCookieContent, response.Header().Get(headers.SetCookie.String()))
 		require.Equal(t, "https://example.com/ui", response.Header().Get(headers.Location.String()))
 		require.Equal(t, http.StatusFound, response.Code)
 	})
 
+	t.Run("applies the role of the matching mapping rule and audits the change", func(t *testing.T) {
+		var (
+			response    = httptest.NewRecorder()
+			readOnly    = model.Role{Name: "Read-Only", Serial: model.Serial{ID: 1}}
+			powerUser   = model.Role{Name: "Power User", Serial: model.Serial{ID: 2}}
+			mappedSSO   = gothamSSO
+			mappedUser  = user
+			roleChanged bool
+		)
+
+		mappedUser.Roles = model.Roles{readOnly}
+		mappedSSO.Config.AutoProvision = model.SSOProviderAutoProvisionConfig{
+			Enabled:       true,
+			DefaultRoleId: readOnly.ID,
+			MappingRules: []model.SSOProviderMappingRule{
+				{Priority: 10, Claim: model.XMLSOAPClaimsEmailAddress, Regex: "harl.*", RoleId: powerUser.ID, Environments: []string{"S-1-5-21-1"}},
+			},
+		}
+
+		mockDB.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(3).Do(func(_ context.Context, log model.AuditLog) {
+			if log.Action == model.AuditLogActionSSOMappingRoleChange {
+				roleChanged = true
+				require.Equal(t, []string{readOnly.Name}, log.Fields["previous_roles"])
+				require.Equal(t, []string{powerUser.Name}, log.Fields["roles"])
+				require.Equal(t, model.XMLSOAPClaimsEmailAddress, log.Fields["claim"])
+			}
+		})
+		mockDB.EXPECT().LookupUser(gomock.Any(), username).Return(mappedUser, nil)
+		mockDB.EXPECT().GetRole(gomock.Any(), powerUser.ID).Return(powerUser, nil)
+		mockDB.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Do(func(_ context.Context, updated model.User) {
+			require.Equal(t, model.Roles{powerUser}, updated.Roles)
+		}).Return(nil)
+		mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return(nil, nil)
+		mockDB.EXPECT().UpdateEnvironmentListForUser(gomock.Any(), gomock.Any(), "S-1-5-21-1").Return(nil)
+		mockDB.EXPECT().CreateUserSession(gomock.Any(), gomock.Any()).Return(model.UserSession{}, nil)
+
+		testAuthenticator.CreateSSOSession(httpRequest, response, username, mappedSSO, gothamSAML.GetSAMLClaimsFromAssertion(testAssertion))
+
+		require.True(t, roleChanged)
+		require.Equal(t, http.StatusFound, response.Code)
+		require.Equal(t, "https://example.com/ui", response.Header().Get(headers.Location.String()))
+	})
+
+	t.Run("falls back to the default role and no environments without a matching mapping rule", func(t *testing.T) {
+		var (
+			response   = httptest.NewRecorder()
+			readOnly   = model.Role{Name: "Read-Only", Serial: model.Serial{ID: 1}}
+			mappedSSO  = gothamSSO
+			mappedUser = user
+		)
+
+		mappedUser.Roles = model.Roles{readOnly}
+		mappedUser.AllEnvironments = true
+		mappedSSO.Config.AutoProvision = model.SSOProviderAutoProvisionConfig{
+			Enabled:       true,
+			DefaultRoleId: readOnly.ID,
+			MappingRules: []model.SSOProviderMappingRule{
+				{Claim: model.MicrosoftClaimsGroups, Value: "BloodHound-Admins", RoleId: 2, AllEnvironments: true},
+			},
+		}
+
+		mockDB.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(2).Do(func(_ context.Context, log model.AuditLog) {
+			require.Equal(t, model.AuditLogActionLoginAttempt, log.Action)
+		})
+		mockDB.EXPECT().LookupUser(gomock.Any(), username).Return(mappedUser, nil)
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), mappedUser).Return(nil, nil)
+		mockDB.EXPECT().UpdateEnvironmentListForUser(gomock.Any(), mappedUser).Return(nil)
+		mockDB.EXPECT().CreateUserSession(gomock.Any(), gomock.Any()).Return(model.UserSession{}, nil)
+
+		testAuthenticator.CreateSSOSession(httpRequest, response, username, mappedSSO, gothamSAML.GetSAMLClaimsFromAssertion(testAssertion))
+
+		require.Equal(t, http.StatusFound, response.Code)
+		require.Equal(t, "https://example.com/ui", response.Header().Get(headers.Location.String()))
+	})
+
 	t.Run("Forbidden 403 if user isn't in db", func(t *testing.T) {
 		response := httptest.NewRecorder()
 
@@ -121,7 +197,7 @@ func TestAuth_CreateSSOSession(t *testing.T) {
 		principalName, err := gothamSAML.GetSAMLUserPrincipalNameFromAssertion(testAssertion)
 		require.Nil(t, err)
 
-		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO)
+		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO, gothamSAML.GetSAMLClaimsFromAssertion(testAssertion))
 
 		require.Equal(t, http.StatusFound, response.Code)
 		location, err := response.Result().Location()
@@ -146,7 +222,7 @@ func TestAuth_CreateSSOSession(t *testing.T) {
 		principalName, err := gothamSAML.GetSAMLUserPrincipalNameFromAssertion(testAssertion)
 		require.Nil(t, err)
 
-		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO)
+		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO, gothamSAML.GetSAMLClaimsFromAssertion(testAssertion))
 
 		require.Equal(t, http.StatusFound, response.Code)
 		location, err := response.Result().Location()
@@ -179,7 +255,7 @@ func TestAuth_CreateSSOSession(t *testing.T) {
 		principalName, err := gothamSAML.GetSAMLUserPrincipalNameFromAssertion(testAssertion)
 		require.Nil(t, err)
 
-		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO)
+		testAuthenticator.CreateSSOSession(httpRequest, response, principalName, gothamSSO, gothamSAML.GetSAMLClaimsFromAssertion(testAssertion))
 
 		require.Equal(t, http.StatusFound, response.Code)
 		location, err := response.Result().Location()
@@ -194,3 +270,129 @@ func TestAuth_CreateSSOSession(t *testing.T) {
 		require.ErrorIs(t, err, model.ErrSAMLAssertion)
 	})
 }
+
+func TestJitSAMLUserUpsert_EnvironmentProvision(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = dbmocks.NewMockDatabase(mockCtrl)
+		testCtx  = context.Background()
+
+		defaultRole  = model.Role{Name: "Read-Only", Serial: model.Serial{ID: 1}}
+		samlProvider = model.SAMLProvider{Serial: model.Serial{ID: 1}}
+		ssoProvider  = model.SSOProvider{
+			SAMLProvider: &samlProvider,
+			Serial:       model.Serial{ID: 1},
+			Type:         model.SessionAuthProviderSAML,
+			Config: model.SSOProviderConfig{
+				AutoProvision: model.SSOProviderAutoProvisionConfig{
+					Enabled:              true,
+					DefaultRoleId:        defaultRole.ID,
+					EnvironmentProvision: true,
+					EnvironmentMappings: []model.SSOProviderEnvironmentMapping{
+						{Group: "Domain-A-Analysts", Environments: []string{"S-1-5-21-1"}},
+						{Group: "Tenant-Analysts", Environments: []string{"TENANT-1"}},
+						{Group: "BloodHound-Admins", AllEnvironments: true},
+					},
+				},
+			},
+		}
+
+		user = model.User{PrincipalName: "harls", Roles: model.Roles{defaultRole}}
+	)
+	defer mockCtrl.Finish()
+
+	assertionWithGroups := func(groups ...string) *saml.Assertion {
+		values := make([]saml.AttributeValue, 0, len(groups))
+		for _, group := range groups {
+			values = append(values, saml.AttributeValue{Type: model.XMLTypeString, Value: group})
+		}
+
+		return &saml.Assertion{
+			AttributeStatements: []saml.AttributeStatement{{
+				Attributes: []saml.Attribute{{Name: model.MicrosoftClaimsGroups, Values: values}},
+			}},
+		}
+	}
+
+	t.Run("updates the access list from mapped groups", func(t *testing.T) {
+		mockDB.EXPECT().GetAllRoles(gomock.Any(), "", model.SQLFilter{}).Return(model.Roles{defaultRole}, nil)
+		mockDB.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+		mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), user).Return(nil, nil)
+		mockDB.EXPECT().UpdateEnvironmentListForUser(gomock.Any(), user, "S-1-5-21-1", "TENANT-1").Return(nil)
+
+		require.NoError(t, jitSAMLUserUpsert(testCtx, ssoProvider, user.PrincipalName, assertionWithGroups("domain-a-analysts", "Tenant-Analysts", "Unmapped"), mockDB))
+	})
+
+	t.Run("leaves an unchanged access list alone", func(t *testing.T) {
+		mockDB.EXPECT().GetAllRoles(gomock.Any(), "", model.SQLFilter{}).Return(model.Roles{defaultRole}, nil)
+		mockDB.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+		mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), user).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+
+		require.NoError(t, jitSAMLUserUpsert(testCtx, ssoProvider, user.PrincipalName, assertionWithGroups("Domain-A-Analysts"), mockDB))
+	})
+
+	t.Run("grants all environments to mapped admin groups", func(t *testing.T) {
+		mockDB.EXPECT().GetAllRoles(gomock.Any(), "", model.SQLFilter{}).Return(model.Roles{defaultRole}, nil)
+		mockDB.EXPECT().LookupUser(gomock.Any(), user.PrincipalName).Return(user, nil)
+		mockDB.EXPECT().GrantAllEnvironmentsForUser(gomock.Any(), user).Return(nil)
+
+		require.NoError(t, jitSAMLUserUpsert(testCtx, ssoProvider, user.PrincipalName, assertionWithGroups("Domain-A-Analysts", "BloodHound-Admins"), mockDB))
+	})
+}
+
+func TestValidateEnvironmentMappings(t *testing.T) {
+	t.Run("normalizes environments", func(t *testing.T) {
+		mappings := []model.SSOProviderEnvironmentMapping{{Group: "Analysts", Environments: []string{" s-1-5-21-1 "}}}
+
+		require.NoError(t, validateEnvironmentMappings(mappings))
+		require.Equal(t, []string{"S-1-5-21-1"}, mappings[0].Environments)
+	})
+
+	t.Run("rejects a missing group", func(t *testing.T) {
+		require.ErrorIs(t, validateEnvironmentMappings([]model.SSOProviderEnvironmentMapping{{Environments: []string{"S-1-5-21-1"}}}), ErrEnvironmentMappingInvalid)
+	})
+
+	t.Run("rejects environments alongside all environments", func(t *testing.T) {
+		require.ErrorIs(t, validateEnvironmentMappings([]model.SSOProviderEnvironmentMapping{{Group: "Admins", AllEnvironments: true, Environments: []string{"S-1-5-21-1"}}}), ErrEnvironmentMappingInvalid)
+	})
+
+	t.Run("rejects a blank environment", func(t *testing.T) {
+		require.ErrorIs(t, validateEnvironmentMappings([]model.SSOProviderEnvironmentMapping{{Group: "Analysts", Environments: []string{" "}}}), ErrEnvironmentMappingInvalid)
+	})
+}
+
+func TestValidateMappingRules(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = dbmocks.NewMockDatabase(mockCtrl)
+		testCtx  = context.Background()
+	)
+	defer mockCtrl.Finish()
+
+	t.Run("normalizes environments", func(t *testing.T) {
+		rules := []model.SSOProviderMappingRule{{Claim: "groups", Value: "Analysts", RoleId: 1, Environments: []string{" s-1-5-21-1 "}}}
+
+		mockDB.EXPECT().GetRole(gomock.Any(), int32(1)).Return(model.Role{}, nil)
+
+		require.NoError(t, validateMappingRules(testCtx, rules, mockDB))
+		require.Equal(t, []string{"S-1-5-21-1"}, rules[0].Environments)
+	})
+
+	t.Run("rejects a missing claim", func(t *testing.T) {
+		require.ErrorIs(t, validateMappingRules(testCtx, []model.SSOProviderMappingRule{{Value: "Analysts", RoleId: 1}}, mockDB), ErrMappingRuleInvalid)
+	})
+
+	t.Run("rejects both a value and a regex", func(t *testing.T) {
+		require.ErrorIs(t, validateMappingRules(testCtx, []model.SSOProviderMappingRule{{Claim: "groups", Value: "Analysts", Regex: "Analysts.*", RoleId: 1}}, mockDB), ErrMappingRuleInvalid)
+	})
+
+	t.Run("rejects an invalid regex", func(t *testing.T) {
+		require.ErrorIs(t, validateMappingRules(testCtx, []model.SSOProviderMappingRule{{Claim: "groups", Regex: "(", RoleId: 1}}, mockDB), ErrMappingRuleInvalid)
+	})
+
+	t.Run("rejects an unknown role", func(t *testing.T) {
+		mockDB.EXPECT().GetRole(gomock.Any(), int32(99)).Return(model.Role{}, database.ErrNotFound)
+
+		require.ErrorIs(t, validateMappingRules(testCtx, []model.SSOProviderMappingRule{{Claim: "groups", Value: "Analysts", RoleId: 99}}, mockDB), ErrMappingRuleInvalid)
+	})
+}
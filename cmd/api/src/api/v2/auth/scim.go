@@ -0,0 +1,1091 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/api/stream"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/serde"
+	"github.com/specterops/bloodhound/cmd/api/src/utils"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+)
+
+// SCIM 2.0 schema URNs, see RFC 7643 and RFC 7644
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIM error types, see RFC 7644 section 3.12
+const (
+	SCIMErrorInvalidFilter = "invalidFilter"
+	SCIMErrorInvalidSyntax = "invalidSyntax"
+	SCIMErrorInvalidPath   = "invalidPath"
+	SCIMErrorInvalidValue  = "invalidValue"
+	SCIMErrorMutability    = "mutability"
+	SCIMErrorUniqueness    = "uniqueness"
+	SCIMErrorNoTarget      = "noTarget"
+)
+
+const (
+	SCIMResourceTypeUser  = "User"
+	SCIMResourceTypeGroup = "Group"
+
+	SCIMMaxResults = 1000
+
+	scimUsersPath  = "/scim/v2/Users"
+	scimGroupsPath = "/scim/v2/Groups"
+)
+
+var scimEqualityFilterPattern = regexp.MustCompile(`(?i)^\s*([a-z][a-z0-9.]*)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+type SCIMName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type SCIMMultiValuedAttribute struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type SCIMMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+type SCIMUser struct {
+	Schemas    []string                   `json:"schemas"`
+	ID         string                     `json:"id,omitempty"`
+	ExternalID string                     `json:"externalId,omitempty"`
+	UserName   string                     `json:"userName"`
+	Name       SCIMName                   `json:"name"`
+	Emails     []SCIMMultiValuedAttribute `json:"emails,omitempty"`
+	Active     *bool                      `json:"active,omitempty"`
+	Groups     []SCIMMultiValuedAttribute `json:"groups,omitempty"`
+	Meta       *SCIMMeta                  `json:"meta,omitempty"`
+}
+
+type SCIMGroup struct {
+	Schemas     []string                   `json:"schemas"`
+	ID          string                     `json:"id,omitempty"`
+	DisplayName string                     `json:"displayName"`
+	Members     []SCIMMultiValuedAttribute `json:"members,omitempty"`
+	Meta        *SCIMMeta                  `json:"meta,omitempty"`
+}
+
+type SCIMListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type SCIMBulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type SCIMFilterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type SCIMAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type SCIMServiceProviderConfig struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 SCIMSupported              `json:"patch"`
+	Bulk                  SCIMBulkSupport            `json:"bulk"`
+	Filter                SCIMFilterSupport          `json:"filter"`
+	ChangePassword        SCIMSupported              `json:"changePassword"`
+	Sort                  SCIMSupported              `json:"sort"`
+	ETag                  SCIMSupported              `json:"etag"`
+	AuthenticationSchemes []SCIMAuthenticationScheme `json:"authenticationSchemes"`
+}
+
+// SCIMError is both the SCIM error response body and the error SCIM handlers pass around before writing it
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+
+	statusCode int
+}
+
+func (s SCIMError) Error() string {
+	return s.Detail
+}
+
+func NewSCIMError(status int, scimType, detail string) SCIMError {
+	return SCIMError{
+		Schemas:  []string{SCIMSchemaError},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+
+		statusCode: status,
+	}
+}
+
+func writeSCIMResponse(ctx context.Context, value any, statusCode int, response http.ResponseWriter) {
+	response.Header().Set(headers.ContentType.String(), mediatypes.ApplicationScimJson.String())
+
+	if content, err := json.Marshal(value); err != nil {
+		slog.ErrorContext(ctx, fmt.Sprintf("Failed to marshal SCIM response: %v", err))
+		response.WriteHeader(http.StatusInternalServerError)
+	} else {
+		response.WriteHeader(statusCode)
+
+		if written, err := response.Write(content); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("Failed to write SCIM response with %d bytes written and error: %v", written, err))
+		}
+	}
+}
+
+// writeSCIMError writes the given error as a SCIM error response. Errors that are not already SCIM errors are mapped
+// from their database counterparts.
+func writeSCIMError(ctx context.Context, err error, response http.ResponseWriter) {
+	var scimErr SCIMError
+
+	if errors.As(err, &scimErr) {
+		// Already a SCIM error
+	} else if errors.Is(err, database.ErrNotFound) {
+		scimErr = NewSCIMError(http.StatusNotFound, "", api.ErrorResponseDetailsResourceNotFound)
+	} else if errors.Is(err, database.ErrDuplicateUserPrincipal) {
+		scimErr = NewSCIMError(http.StatusConflict, SCIMErrorUniqueness, api.ErrorResponseUserDuplicatePrincipal)
+	} else if errors.Is(err, database.ErrDuplicateEmail) {
+		scimErr = NewSCIMError(http.StatusConflict, SCIMErrorUniqueness, api.ErrorResponseUserDuplicateEmail)
+	} else {
+		slog.ErrorContext(ctx, fmt.Sprintf("Unexpected SCIM error: %v", err))
+		scimErr = NewSCIMError(http.StatusInternalServerError, "", api.ErrorResponseDetailsInternalServerError)
+	}
+
+	writeSCIMResponse(ctx, scimErr, scimErr.statusCode, response)
+}
+
+// readSCIMPayload decodes a SCIM request body. SCIM clients send application/scim+json, though some identity providers
+// fall back to application/json.
+func readSCIMPayload(value any, request *http.Request) error {
+	if !utils.HeaderMatches(request.Header, headers.ContentType.String(), mediatypes.ApplicationScimJson.String(), mediatypes.ApplicationJson.String()) {
+		return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidSyntax, "content type must be application/scim+json")
+	} else if request.Body == nil {
+		return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidSyntax, api.ErrNoRequestBody.Error())
+	} else if err := json.NewDecoder(stream.NewLimitedReader(api.DefaultAPIPayloadReadLimitBytes, request.Body)).Decode(value); err != nil {
+		return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidSyntax, fmt.Sprintf("could not decode SCIM payload: %v", err))
+	} else {
+		return nil
+	}
+}
+
+// parseSCIMEqualityFilter parses the only filter form identity providers rely on for provisioning: `attribute eq "value"`
+func parseSCIMEqualityFilter(filter string) (string, string, error) {
+	if matches := scimEqualityFilterPattern.FindStringSubmatch(filter); matches == nil {
+		return "", "", NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidFilter, fmt.Sprintf("unsupported filter: %s", filter))
+	} else if value, err := strconv.Unquote(matches[2]); err != nil {
+		return "", "", NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidFilter, fmt.Sprintf("malformed filter value: %s", matches[2]))
+	} else {
+		return strings.ToLower(matches[1]), value, nil
+	}
+}
+
+// paginateSCIMResources applies the 1-based startIndex and count query parameters to the given resources
+func paginateSCIMResources(request *http.Request, resources []any) (SCIMListResponse, error) {
+	var (
+		queryParams = request.URL.Query()
+		startIndex  = 1
+		count       = SCIMMaxResults
+	)
+
+	if rawStartIndex := queryParams.Get("startIndex"); rawStartIndex != "" {
+		if parsed, err := strconv.Atoi(rawStartIndex); err != nil {
+			return SCIMListResponse{}, NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "startIndex must be a number")
+		} else if parsed > 1 {
+			startIndex = parsed
+		}
+	}
+
+	if rawCount := queryParams.Get("count"); rawCount != "" {
+		if parsed, err := strconv.Atoi(rawCount); err != nil {
+			return SCIMListResponse{}, NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "count must be a number")
+		} else {
+			count = min(max(parsed, 0), SCIMMaxResults)
+		}
+	}
+
+	var (
+		first = min(startIndex-1, len(resources))
+		last  = min(first+count, len(resources))
+		page  = resources[first:last]
+	)
+
+	return SCIMListResponse{
+		Schemas:      []string{SCIMSchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}, nil
+}
+
+func scimLocation(request *http.Request, resourcePath string, id string) string {
+	if host := ctx.FromRequest(request).Host; host != nil {
+		return host.JoinPath(resourcePath, id).String()
+	}
+
+	return resourcePath + "/" + id
+}
+
+func scimActor(request *http.Request) model.User {
+	actor, _ := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx)
+	return actor
+}
+
+func newSCIMUser(request *http.Request, user model.User) SCIMUser {
+	var (
+		active   = !user.IsDisabled
+		scimUser = SCIMUser{
+			Schemas:  []string{SCIMSchemaUser},
+			ID:       user.ID.String(),
+			UserName: user.PrincipalName,
+			Name: SCIMName{
+				GivenName:  user.FirstName.ValueOrZero(),
+				FamilyName: user.LastName.ValueOrZero(),
+			},
+			Active: &active,
+			Meta: &SCIMMeta{
+				ResourceType: SCIMResourceTypeUser,
+				Created:      user.CreatedAt,
+				LastModified: user.UpdatedAt,
+				Location:     scimLocation(request, scimUsersPath, user.ID.String()),
+			},
+		}
+	)
+
+	if user.EmailAddress.Valid && user.EmailAddress.String != "" {
+		scimUser.Emails = []SCIMMultiValuedAttribute{{Value: user.EmailAddress.String, Type: "work", Primary: true}}
+	}
+
+	for _, role := range user.Roles {
+		roleID := strconv.Itoa(int(role.ID))
+
+		scimUser.Groups = append(scimUser.Groups, SCIMMultiValuedAttribute{
+			Value:   roleID,
+			Display: role.Name,
+			Ref:     scimLocation(request, scimGroupsPath, roleID),
+		})
+	}
+
+	return scimUser
+}
+
+// newSCIMGroup renders a BloodHound role as a SCIM group. Roles are the only grouping BloodHound has, so SCIM group
+// membership is role assignment.
+func newSCIMGroup(request *http.Request, role model.Role, users model.Users, includeMembers bool) SCIMGroup {
+	roleID := strconv.Itoa(int(role.ID))
+	group := SCIMGroup{
+		Schemas:     []string{SCIMSchemaGroup},
+		ID:          roleID,
+		DisplayName: role.Name,
+		Meta: &SCIMMeta{
+			ResourceType: SCIMResourceTypeGroup,
+			Created:      role.CreatedAt,
+			LastModified: role.UpdatedAt,
+			Location:     scimLocation(request, scimGroupsPath, roleID),
+		},
+	}
+
+	if includeMembers {
+		for _, user := range users {
+			if user.Roles.Has(role) {
+				group.Members = append(group.Members, SCIMMultiValuedAttribute{
+					Value:   user.ID.String(),
+					Display: user.PrincipalName,
+					Ref:     scimLocation(request, scimUsersPath, user.ID.String()),
+				})
+			}
+		}
+	}
+
+	return group
+}
+
+func primarySCIMEmail(emails []SCIMMultiValuedAttribute) string {
+	if idx := slices.IndexFunc(emails, func(email SCIMMultiValuedAttribute) bool { return email.Primary }); idx >= 0 {
+		return emails[idx].Value
+	} else if len(emails) > 0 {
+		return emails[0].Value
+	}
+
+	return ""
+}
+
+func scimNullString(value string) null.String {
+	return null.NewString(value, value != "")
+}
+
+// applySCIMUser replaces the BloodHound managed attributes of the given user with those of the SCIM user. Omitting
+// active leaves the user's disabled state as is.
+func applySCIMUser(user *model.User, scimUser SCIMUser) error {
+	if scimUser.UserName == "" {
+		return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "userName is required")
+	}
+
+	user.PrincipalName = scimUser.UserName
+	user.FirstName = scimNullString(scimUser.Name.GivenName)
+	user.LastName = scimNullString(scimUser.Name.FamilyName)
+
+	if email := primarySCIMEmail(scimUser.Emails); email != "" {
+		user.EmailAddress = null.StringFrom(email)
+	} else if utils.IsValidEmail(scimUser.UserName) {
+		user.EmailAddress = null.StringFrom(scimUser.UserName)
+	} else {
+		user.EmailAddress = null.String{}
+	}
+
+	if scimUser.Active != nil {
+		user.IsDisabled = !*scimUser.Active
+	}
+
+	return nil
+}
+
+// parseSCIMBoolean accepts both JSON booleans and the string booleans some identity providers send for active
+func parseSCIMBoolean(value json.RawMessage) (bool, error) {
+	var (
+		boolValue   bool
+		stringValue string
+	)
+
+	if err := json.Unmarshal(value, &boolValue); err == nil {
+		return boolValue, nil
+	} else if err := json.Unmarshal(value, &stringValue); err != nil {
+		return false, NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "expected a boolean value")
+	} else if parsed, err := strconv.ParseBool(stringValue); err != nil {
+		return false, NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "expected a boolean value")
+	} else {
+		return parsed, nil
+	}
+}
+
+func parseSCIMString(value json.RawMessage) (string, error) {
+	var stringValue string
+
+	if value == nil {
+		return "", nil
+	} else if err := json.Unmarshal(value, &stringValue); err != nil {
+		return "", NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "expected a string value")
+	}
+
+	return stringValue, nil
+}
+
+// setSCIMUserAttribute applies a single PATCH value to the given user. A nil value removes the attribute. Attributes
+// that BloodHound does not store are ignored so that identity providers may send their full user schema.
+func setSCIMUserAttribute(user *model.User, path string, value json.RawMessage) error {
+	switch attribute := strings.ToLower(path); {
+	case attribute == "active":
+		if value == nil {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, "active cannot be removed")
+		} else if active, err := parseSCIMBoolean(value); err != nil {
+			return err
+		} else {
+			user.IsDisabled = !active
+		}
+
+	case attribute == "username":
+		if userName, err := parseSCIMString(value); err != nil {
+			return err
+		} else if userName == "" {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "userName is required")
+		} else {
+			user.PrincipalName = userName
+		}
+
+	case attribute == "name.givenname":
+		if givenName, err := parseSCIMString(value); err != nil {
+			return err
+		} else {
+			user.FirstName = scimNullString(givenName)
+		}
+
+	case attribute == "name.familyname":
+		if familyName, err := parseSCIMString(value); err != nil {
+			return err
+		} else {
+			user.LastName = scimNullString(familyName)
+		}
+
+	case attribute == "name":
+		var name SCIMName
+
+		if value != nil {
+			if err := json.Unmarshal(value, &name); err != nil {
+				return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "expected a name object")
+			}
+		}
+
+		user.FirstName = scimNullString(name.GivenName)
+		user.LastName = scimNullString(name.FamilyName)
+
+	case attribute == "emails":
+		var emails []SCIMMultiValuedAttribute
+
+		if value != nil {
+			if err := json.Unmarshal(value, &emails); err != nil {
+				return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "expected a list of emails")
+			}
+		}
+
+		user.EmailAddress = scimNullString(primarySCIMEmail(emails))
+
+	case strings.HasPrefix(attribute, "emails[") && strings.HasSuffix(attribute, "].value"):
+		if email, err := parseSCIMString(value); err != nil {
+			return err
+		} else {
+			user.EmailAddress = scimNullString(email)
+		}
+	}
+
+	return nil
+}
+
+func applySCIMUserPatch(user *model.User, operation SCIMPatchOperation) error {
+	switch strings.ToLower(operation.Op) {
+	case "add", "replace":
+		if operation.Path != "" {
+			return setSCIMUserAttribute(user, operation.Path, operation.Value)
+		}
+
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "operations without a path require an object value")
+		}
+
+		for path, value := range attributes {
+			if err := setSCIMUserAttribute(user, path, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case "remove":
+		if operation.Path == "" {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorNoTarget, "remove operations require a path")
+		}
+
+		return setSCIMUserAttribute(user, operation.Path, nil)
+
+	default:
+		return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidSyntax, fmt.Sprintf("unsupported operation: %s", operation.Op))
+	}
+}
+
+func (s ManagementResource) getSCIMUser(request *http.Request) (model.User, error) {
+	if userID, err := uuid.FromString(mux.Vars(request)[api.URIPathVariableSCIMResourceID]); err != nil {
+		return model.User{}, NewSCIMError(http.StatusNotFound, "", api.ErrorResponseDetailsResourceNotFound)
+	} else {
+		return s.db.GetUser(request.Context(), userID)
+	}
+}
+
+func (s ManagementResource) getSCIMGroup(request *http.Request) (model.Role, error) {
+	if roleID, err := serde.ParseInt32(mux.Vars(request)[api.URIPathVariableSCIMResourceID]); err != nil {
+		return model.Role{}, NewSCIMError(http.StatusNotFound, "", api.ErrorResponseDetailsResourceNotFound)
+	} else {
+		return s.db.GetRole(request.Context(), roleID)
+	}
+}
+
+// saveSCIMUser persists a SCIM change to an existing user. Disabling a user ends their active sessions, as it does for
+// the management API.
+func (s ManagementResource) saveSCIMUser(request *http.Request, user model.User) error {
+	if err := s.endDisabledUserSessions(request.Context(), scimActor(request), user); errors.Is(err, ErrUserSelfDisable) {
+		return NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, api.ErrorResponseUserSelfDisable)
+	} else if err != nil {
+		return err
+	}
+
+	return s.db.UpdateUser(request.Context(), user)
+}
+
+// setSCIMUserRoles assigns the given roles to the user on behalf of a SCIM group membership change and reports whether
+// the user's roles changed. The same role change guards as the management API apply. The caller persists the change.
+func setSCIMUserRoles(actor model.User, user *model.User, roles model.Roles) (bool, error) {
+	if slices.Equal(user.Roles.IDs(), roles.IDs()) {
+		return false, nil
+	} else if user.ID == actor.ID {
+		return false, NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, api.ErrorResponseUserSelfRoleChange)
+	} else if user.SSOProviderHasRoleProvisionEnabled() {
+		return false, NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, api.ErrorResponseUserSSOProviderRoleProvisionChange)
+	}
+
+	user.Roles = roles
+	return true, nil
+}
+
+func (s ManagementResource) GetSCIMServiceProviderConfig(response http.ResponseWriter, request *http.Request) {
+	writeSCIMResponse(request.Context(), SCIMServiceProviderConfig{
+		Schemas:        []string{SCIMSchemaServiceProviderConfig},
+		Patch:          SCIMSupported{Supported: true},
+		Bulk:           SCIMBulkSupport{Supported: false},
+		Filter:         SCIMFilterSupport{Supported: true, MaxResults: SCIMMaxResults},
+		ChangePassword: SCIMSupported{Supported: false},
+		Sort:           SCIMSupported{Supported: false},
+		ETag:           SCIMSupported{Supported: false},
+		AuthenticationSchemes: []SCIMAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication with a BloodHound SCIM provisioning token",
+		}},
+	}, http.StatusOK, response)
+}
+
+func (s ManagementResource) ListSCIMUsers(response http.ResponseWriter, request *http.Request) {
+	var (
+		users  model.Users
+		filter = request.URL.Query().Get("filter")
+	)
+
+	if filter == "" {
+		if allUsers, err := s.db.GetAllUsers(request.Context(), "created_at", model.SQLFilter{}); err != nil {
+			writeSCIMError(request.Context(), err, response)
+			return
+		} else {
+			users = allUsers
+		}
+	} else if attribute, value, err := parseSCIMEqualityFilter(filter); err != nil {
+		writeSCIMError(request.Context(), err, response)
+		return
+	} else if attribute != "username" && attribute != "emails" && attribute != "emails.value" {
+		writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidFilter, fmt.Sprintf("filtering on %s is not supported", attribute)), response)
+		return
+	} else if user, err := s.db.LookupUser(request.Context(), value); errors.Is(err, database.ErrNotFound) {
+		// No match is an empty result rather than an error
+	} else if err != nil {
+		writeSCIMError(request.Context(), err, response)
+		return
+	} else if attribute == "username" && strings.EqualFold(user.PrincipalName, value) {
+		users = model.Users{user}
+	} else if attribute != "username" && strings.EqualFold(user.EmailAddress.ValueOrZero(), value) {
+		users = model.Users{user}
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, newSCIMUser(request, user))
+	}
+
+	if listResponse, err := paginateSCIMResources(request, resources); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		writeSCIMResponse(request.Context(), listResponse, http.StatusOK, response)
+	}
+}
+
+func (s ManagementResource) GetSCIMUser(response http.ResponseWriter, request *http.Request) {
+	if user, err := s.getSCIMUser(request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		writeSCIMResponse(request.Context(), newSCIMUser(request, user), http.StatusOK, response)
+	}
+}
+
+func (s ManagementResource) CreateSCIMUser(response http.ResponseWriter, request *http.Request) {
+	var (
+		scimUser SCIMUser
+
+		// SCIM does not manage environment access; new users get access to every environment as they do in the UI
+		userTemplate = model.User{AllEnvironments: true}
+	)
+
+	if err := readSCIMPayload(&scimUser, request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := applySCIMUser(&userTemplate, scimUser); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if newUser, err := s.createUser(request.Context(), userTemplate); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		created := newSCIMUser(request, newUser)
+
+		response.Header().Set(headers.Location.String(), created.Meta.Location)
+		writeSCIMResponse(request.Context(), created, http.StatusCreated, response)
+	}
+}
+
+func (s ManagementResource) ReplaceSCIMUser(response http.ResponseWriter, request *http.Request) {
+	var scimUser SCIMUser
+
+	if user, err := s.getSCIMUser(request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := readSCIMPayload(&scimUser, request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := applySCIMUser(&user, scimUser); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := s.saveSCIMUser(request, user); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		writeSCIMResponse(request.Context(), newSCIMUser(request, user), http.StatusOK, response)
+	}
+}
+
+func (s ManagementResource) PatchSCIMUser(response http.ResponseWriter, request *http.Request) {
+	var patchRequest SCIMPatchRequest
+
+	if user, err := s.getSCIMUser(request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := readSCIMPayload(&patchRequest, request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		for _, operation := range patchRequest.Operations {
+			if err := applySCIMUserPatch(&user, operation); err != nil {
+				writeSCIMError(request.Context(), err, response)
+				return
+			}
+		}
+
+		if err := s.saveSCIMUser(request, user); err != nil {
+			writeSCIMError(request.Context(), err, response)
+		} else {
+			writeSCIMResponse(request.Context(), newSCIMUser(request, user), http.StatusOK, response)
+		}
+	}
+}
+
+func (s ManagementResource) DeleteSCIMUser(response http.ResponseWriter, request *http.Request) {
+	if user, err := s.getSCIMUser(request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := s.deleteUser(request.Context(), scimActor(request), user); errors.Is(err, ErrUserSelfDelete) {
+		writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, ErrResponseDetailsUserSelfDelete), response)
+	} else if err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s ManagementResource) ListSCIMGroups(response http.ResponseWriter, request *http.Request) {
+	var (
+		queryParams    = request.URL.Query()
+		filter         = queryParams.Get("filter")
+		includeMembers = !strings.Contains(strings.ToLower(queryParams.Get("excludedAttributes")), "members")
+		users          model.Users
+	)
+
+	roles, err := s.db.GetAllRoles(request.Context(), "name", model.SQLFilter{})
+	if err != nil {
+		writeSCIMError(request.Context(), err, response)
+		return
+	}
+
+	if filter != "" {
+		if attribute, value, err := parseSCIMEqualityFilter(filter); err != nil {
+			writeSCIMError(request.Context(), err, response)
+			return
+		} else if attribute != "displayname" {
+			writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidFilter, fmt.Sprintf("filtering on %s is not supported", attribute)), response)
+			return
+		} else {
+			roles = slices.DeleteFunc(roles, func(role model.Role) bool { return !strings.EqualFold(role.Name, value) })
+		}
+	}
+
+	if includeMembers && len(roles) > 0 {
+		if users, err = s.db.GetAllUsers(request.Context(), "created_at", model.SQLFilter{}); err != nil {
+			writeSCIMError(request.Context(), err, response)
+			return
+		}
+	}
+
+	resources := make([]any, 0, len(roles))
+	for _, role := range roles {
+		resources = append(resources, newSCIMGroup(request, role, users, includeMembers))
+	}
+
+	if listResponse, err := paginateSCIMResources(request, resources); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		writeSCIMResponse(request.Context(), listResponse, http.StatusOK, response)
+	}
+}
+
+func (s ManagementResource) GetSCIMGroup(response http.ResponseWriter, request *http.Request) {
+	if role, err := s.getSCIMGroup(request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if users, err := s.db.GetAllUsers(request.Context(), "created_at", model.SQLFilter{}); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		writeSCIMResponse(request.Context(), newSCIMGroup(request, role, users, true), http.StatusOK, response)
+	}
+}
+
+func parseSCIMMembers(value json.RawMessage) ([]uuid.UUID, error) {
+	var (
+		members   []SCIMMultiValuedAttribute
+		memberIDs = make([]uuid.UUID, 0)
+	)
+
+	if value == nil {
+		return memberIDs, nil
+	} else if err := json.Unmarshal(value, &members); err != nil {
+		return nil, NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "expected a list of members")
+	}
+
+	for _, member := range members {
+		if memberID, err := uuid.FromString(member.Value); err != nil {
+			return nil, NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, fmt.Sprintf("unknown member: %s", member.Value))
+		} else {
+			memberIDs = append(memberIDs, memberID)
+		}
+	}
+
+	return memberIDs, nil
+}
+
+// scimMemberFilterPattern matches the member removal path form `members[value eq "<user id>"]`
+var scimMemberFilterPattern = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]+)"\s*]$`)
+
+// updateSCIMGroupMembers adds and removes users from the given role. Users hold a single role, so joining a group
+// replaces the user's role and leaving it clears the user's roles. Every member is validated before any change is
+// written, and all changes are written in a single transaction.
+func (s ManagementResource) updateSCIMGroupMembers(request *http.Request, role model.Role, additions []uuid.UUID, removals []uuid.UUID) error {
+	var (
+		actor   = scimActor(request)
+		updates model.Users
+	)
+
+	for _, memberID := range additions {
+		if user, err := s.db.GetUser(request.Context(), memberID); errors.Is(err, database.ErrNotFound) {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, fmt.Sprintf("unknown member: %s", memberID))
+		} else if err != nil {
+			return err
+		} else if changed, err := setSCIMUserRoles(actor, &user, model.Roles{role}); err != nil {
+			return err
+		} else if changed {
+			updates = append(updates, user)
+		}
+	}
+
+	for _, memberID := range removals {
+		if user, err := s.db.GetUser(request.Context(), memberID); errors.Is(err, database.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		} else if !user.Roles.Has(role) {
+			continue
+		} else if changed, err := setSCIMUserRoles(actor, &user, model.Roles{}); err != nil {
+			return err
+		} else if changed {
+			updates = append(updates, user)
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return s.db.UpdateUsers(request.Context(), updates)
+}
+
+// currentSCIMGroupMembers returns the IDs of every user holding the given role that is not in the keep list
+func (s ManagementResource) currentSCIMGroupMembers(ctx context.Context, role model.Role, keep []uuid.UUID) ([]uuid.UUID, error) {
+	var removals []uuid.UUID
+
+	if users, err := s.db.GetAllUsers(ctx, "created_at", model.SQLFilter{}); err != nil {
+		return nil, err
+	} else {
+		for _, user := range users {
+			if user.Roles.Has(role) && !slices.Contains(keep, user.ID) {
+				removals = append(removals, user.ID)
+			}
+		}
+	}
+
+	return removals, nil
+}
+
+// scimGroupMembershipPatch collects the membership changes of every operation in a PATCH request so that they can be
+// validated and written together once all operations have been applied
+type scimGroupMembershipPatch struct {
+	additions []uuid.UUID
+	removals  []uuid.UUID
+
+	// removeOthers is set once an operation removes every member. Current members that are not added back by a later
+	// operation are removed when the patch is written.
+	removeOthers bool
+}
+
+func (s *scimGroupMembershipPatch) add(memberIDs []uuid.UUID) {
+	for _, memberID := range memberIDs {
+		s.removals = slices.DeleteFunc(s.removals, func(removal uuid.UUID) bool { return removal == memberID })
+
+		if !slices.Contains(s.additions, memberID) {
+			s.additions = append(s.additions, memberID)
+		}
+	}
+}
+
+func (s *scimGroupMembershipPatch) remove(memberIDs []uuid.UUID) {
+	for _, memberID := range memberIDs {
+		s.additions = slices.DeleteFunc(s.additions, func(addition uuid.UUID) bool { return addition == memberID })
+
+		if !slices.Contains(s.removals, memberID) {
+			s.removals = append(s.removals, memberID)
+		}
+	}
+}
+
+func (s *scimGroupMembershipPatch) removeAll() {
+	s.additions = nil
+	s.removals = nil
+	s.removeOthers = true
+}
+
+// writeSCIMGroupPatch validates and applies the collected membership changes to the given role in a single transaction
+func (s ManagementResource) writeSCIMGroupPatch(request *http.Request, role model.Role, patch scimGroupMembershipPatch) error {
+	removals := patch.removals
+
+	if patch.removeOthers {
+		if others, err := s.currentSCIMGroupMembers(request.Context(), role, patch.additions); err != nil {
+			return err
+		} else {
+			for _, memberID := range others {
+				if !slices.Contains(removals, memberID) {
+					removals = append(removals, memberID)
+				}
+			}
+		}
+	}
+
+	if len(patch.additions) == 0 && len(removals) == 0 {
+		return nil
+	}
+
+	return s.updateSCIMGroupMembers(request, role, patch.additions, removals)
+}
+
+// applySCIMGroupPatch applies a single PATCH value to the collected membership changes of the given role. Only
+// membership may change; display names are accepted when they match the role's name.
+func applySCIMGroupPatch(patch *scimGroupMembershipPatch, role model.Role, op string, path string, value json.RawMessage) error {
+	if matches := scimMemberFilterPattern.FindStringSubmatch(path); matches != nil && op == "remove" {
+		if memberID, err := uuid.FromString(matches[1]); err != nil {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorNoTarget, fmt.Sprintf("unknown member: %s", matches[1]))
+		} else {
+			patch.remove([]uuid.UUID{memberID})
+		}
+	} else if strings.EqualFold(path, "displayName") {
+		if displayName, err := parseSCIMString(value); err != nil {
+			return err
+		} else if !strings.EqualFold(displayName, role.Name) {
+			return NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, "groups are BloodHound roles and cannot be renamed")
+		}
+	} else if !strings.EqualFold(path, "members") {
+		// Attributes BloodHound does not store are ignored, as they are for users
+		return nil
+	} else if memberIDs, err := parseSCIMMembers(value); err != nil {
+		return err
+	} else {
+		switch op {
+		case "add":
+			patch.add(memberIDs)
+
+		case "remove":
+			if value == nil {
+				// Removing the members attribute without a value removes every member
+				patch.removeAll()
+			} else {
+				patch.remove(memberIDs)
+			}
+
+		case "replace":
+			patch.removeAll()
+			patch.add(memberIDs)
+		}
+	}
+
+	return nil
+}
+
+// PatchSCIMGroup applies the operations of a PATCH request to the members of a role. The request is atomic: every
+// operation is applied and validated before any change is written, and all changes are written together.
+func (s ManagementResource) PatchSCIMGroup(response http.ResponseWriter, request *http.Request) {
+	var (
+		patchRequest SCIMPatchRequest
+		patch        scimGroupMembershipPatch
+	)
+
+	role, err := s.getSCIMGroup(request)
+	if err != nil {
+		writeSCIMError(request.Context(), err, response)
+		return
+	} else if err := readSCIMPayload(&patchRequest, request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+		return
+	}
+
+	for _, operation := range patchRequest.Operations {
+		op := strings.ToLower(operation.Op)
+
+		if op != "add" && op != "remove" && op != "replace" {
+			writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidSyntax, fmt.Sprintf("unsupported operation: %s", operation.Op)), response)
+			return
+		} else if operation.Path != "" {
+			if err := applySCIMGroupPatch(&patch, role, op, operation.Path, operation.Value); err != nil {
+				writeSCIMError(request.Context(), err, response)
+				return
+			}
+
+			continue
+		} else if op == "remove" {
+			writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorNoTarget, "remove operations require a path"), response)
+			return
+		}
+
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorInvalidValue, "operations without a path require an object value"), response)
+			return
+		}
+
+		for path, value := range attributes {
+			if err := applySCIMGroupPatch(&patch, role, op, path, value); err != nil {
+				writeSCIMError(request.Context(), err, response)
+				return
+			}
+		}
+	}
+
+	if err := s.writeSCIMGroupPatch(request, role, patch); err != nil {
+		writeSCIMError(request.Context(), err, response)
+		return
+	}
+
+	response.WriteHeader(http.StatusNoContent)
+}
+
+func (s ManagementResource) ReplaceSCIMGroup(response http.ResponseWriter, request *http.Request) {
+	var group SCIMGroup
+
+	if role, err := s.getSCIMGroup(request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := readSCIMPayload(&group, request); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if group.DisplayName != "" && !strings.EqualFold(group.DisplayName, role.Name) {
+		writeSCIMError(request.Context(), NewSCIMError(http.StatusBadRequest, SCIMErrorMutability, "groups are BloodHound roles and cannot be renamed"), response)
+	} else if rawMembers, err := json.Marshal(group.Members); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if memberIDs, err := parseSCIMMembers(rawMembers); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if removals, err := s.currentSCIMGroupMembers(request.Context(), role, memberIDs); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if err := s.updateSCIMGroupMembers(request, role, memberIDs, removals); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else if users, err := s.db.GetAllUsers(request.Context(), "created_at", model.SQLFilter{}); err != nil {
+		writeSCIMError(request.Context(), err, response)
+	} else {
+		writeSCIMResponse(request.Context(), newSCIMGroup(request, role, users, true), http.StatusOK, response)
+	}
+}
+
+// CreateSCIMToken issues a bearer token for a SCIM client. The token acts as the requesting user, is limited to user
+// management and is only accepted by the SCIM endpoints. The full bearer token is only returned here.
+func (s ManagementResource) CreateSCIMToken(response http.ResponseWriter, request *http.Request) {
+	var (
+		createSCIMTokenRequest v2.CreateSCIMTokenRequest
+		bhCtx                  = ctx.FromRequest(request)
+	)
+
+	if user, isUser := auth.GetUserFromAuthCtx(bhCtx.AuthCtx); !isUser {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&createSCIMTokenRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if createSCIMTokenRequest.ExpiresAt.Valid && !createSCIMTokenRequest.ExpiresAt.Time.After(time.Now()) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsTokenExpiresInPast, request), response)
+	} else if catalogue, err := s.db.GetAllPermissions(request.Context(), "", model.SQLFilter{}); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if idx := slices.IndexFunc(catalogue, auth.Permissions().AuthManageUsers.Equals); idx < 0 {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if !withinCallerScope(bhCtx.AuthCtx, model.Permissions{catalogue[idx]}) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, ErrResponseDetailsTokenCallerScope, request), response)
+	} else if authToken, err := auth.NewUserAuthToken(user.ID.String(), createSCIMTokenRequest.TokenName, auth.SCIM_BEARER); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		authToken.ExpiresAt = createSCIMTokenRequest.ExpiresAt
+		authToken.Permissions = model.Permissions{catalogue[idx]}
+
+		if newAuthToken, err := s.db.CreateAuthToken(request.Context(), authToken); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			newAuthToken.Key = api.FormatSCIMBearerToken(newAuthToken)
+			api.WriteBasicResponse(request.Context(), newAuthToken, http.StatusOK, response)
+		}
+	}
+}
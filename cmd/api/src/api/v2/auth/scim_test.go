@@ -0,0 +1,689 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/auth"
+	authz "github.com/specterops/bloodhound/cmd/api/src/auth"
+	bhctx "github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// scimTestClient is a minimal SCIM client talking to the SCIM handlers over HTTP, as an identity provider would
+type scimTestClient struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+func newSCIMTestClient(t *testing.T, resources auth.ManagementResource, actor model.User) scimTestClient {
+	var (
+		router       = mux.NewRouter()
+		userPath     = fmt.Sprintf("/scim/v2/Users/{%s}", api.URIPathVariableSCIMResourceID)
+		groupPath    = fmt.Sprintf("/scim/v2/Groups/{%s}", api.URIPathVariableSCIMResourceID)
+		scimIdentity = func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				requestCtx := bhctx.Set(request.Context(), &bhctx.Context{AuthCtx: authz.Context{Owner: actor, SCIMToken: true}})
+				next.ServeHTTP(response, request.WithContext(requestCtx))
+			})
+		}
+	)
+
+	router.Use(scimIdentity)
+	router.HandleFunc("/scim/v2/ServiceProviderConfig", resources.GetSCIMServiceProviderConfig).Methods(http.MethodGet)
+	router.HandleFunc("/scim/v2/Users", resources.ListSCIMUsers).Methods(http.MethodGet)
+	router.HandleFunc("/scim/v2/Users", resources.CreateSCIMUser).Methods(http.MethodPost)
+	router.HandleFunc(userPath, resources.GetSCIMUser).Methods(http.MethodGet)
+	router.HandleFunc(userPath, resources.ReplaceSCIMUser).Methods(http.MethodPut)
+	router.HandleFunc(userPath, resources.PatchSCIMUser).Methods(http.MethodPatch)
+	router.HandleFunc(userPath, resources.DeleteSCIMUser).Methods(http.MethodDelete)
+	router.HandleFunc("/scim/v2/Groups", resources.ListSCIMGroups).Methods(http.MethodGet)
+	router.HandleFunc(groupPath, resources.GetSCIMGroup).Methods(http.MethodGet)
+	router.HandleFunc(groupPath, resources.ReplaceSCIMGroup).Methods(http.MethodPut)
+	router.HandleFunc(groupPath, resources.PatchSCIMGroup).Methods(http.MethodPatch)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return scimTestClient{t: t, server: server}
+}
+
+// do sends a SCIM request and decodes the response body into result when one is given
+func (s scimTestClient) do(method, path string, body any, result any) *http.Response {
+	var payload io.Reader
+
+	if body != nil {
+		content, err := json.Marshal(body)
+		require.NoError(s.t, err)
+
+		payload = bytes.NewReader(content)
+	}
+
+	request, err := http.NewRequest(method, s.server.URL+path, payload)
+	require.NoError(s.t, err)
+	request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationScimJson.String())
+
+	response, err := s.server.Client().Do(request)
+	require.NoError(s.t, err)
+	defer response.Body.Close()
+
+	if result != nil {
+		require.NoError(s.t, json.NewDecoder(response.Body).Decode(result))
+	}
+
+	return response
+}
+
+func patchOp(operations ...auth.SCIMPatchOperation) auth.SCIMPatchRequest {
+	return auth.SCIMPatchRequest{
+		Schemas:    []string{auth.SCIMSchemaPatchOp},
+		Operations: operations,
+	}
+}
+
+func TestManagementResource_SCIMUsers(t *testing.T) {
+	var (
+		actor = model.User{PrincipalName: "scim-admin", Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		user  = model.User{
+			PrincipalName: "jdoe@example.com",
+			FirstName:     null.StringFrom("Jane"),
+			LastName:      null.StringFrom("Doe"),
+			EmailAddress:  null.StringFrom("jdoe@example.com"),
+			Unique:        model.Unique{ID: uuid.Must(uuid.NewV4())},
+		}
+		userPath = "/scim/v2/Users/" + user.ID.String()
+	)
+
+	t.Run("service provider config advertises patch and filtering", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, _ := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		var config auth.SCIMServiceProviderConfig
+		response := client.do(http.MethodGet, "/scim/v2/ServiceProviderConfig", nil, &config)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, mediatypes.ApplicationScimJson.String(), response.Header.Get(headers.ContentType.String()))
+		require.True(t, config.Patch.Supported)
+		require.True(t, config.Filter.Supported)
+		require.False(t, config.Bulk.Supported)
+	})
+
+	t.Run("create provisions an active user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, template model.User) (model.User, error) {
+			require.Equal(t, "jdoe@example.com", template.PrincipalName)
+			require.Equal(t, "Jane", template.FirstName.String)
+			require.Equal(t, "jdoe@example.com", template.EmailAddress.String)
+			require.True(t, template.EULAAccepted)
+			require.False(t, template.IsDisabled)
+
+			template.ID = user.ID
+			return template, nil
+		})
+
+		var created auth.SCIMUser
+		response := client.do(http.MethodPost, "/scim/v2/Users", map[string]any{
+			"schemas":  []string{auth.SCIMSchemaUser},
+			"userName": "jdoe@example.com",
+			"name":     map[string]string{"givenName": "Jane", "familyName": "Doe"},
+			"emails":   []map[string]any{{"value": "jdoe@example.com", "type": "work", "primary": true}},
+			"active":   true,
+		}, &created)
+
+		require.Equal(t, http.StatusCreated, response.StatusCode)
+		require.Equal(t, user.ID.String(), created.ID)
+		require.True(t, *created.Active)
+		require.Equal(t, created.Meta.Location, response.Header.Get(headers.Location.String()))
+	})
+
+	t.Run("create reports duplicate users as a uniqueness conflict", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(model.User{}, database.ErrDuplicateUserPrincipal)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPost, "/scim/v2/Users", map[string]any{"userName": "jdoe@example.com"}, &scimErr)
+
+		require.Equal(t, http.StatusConflict, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorUniqueness, scimErr.SCIMType)
+		require.Equal(t, "409", scimErr.Status)
+	})
+
+	t.Run("create requires a userName", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, _ := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPost, "/scim/v2/Users", map[string]any{"name": map[string]string{"givenName": "Jane"}}, &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorInvalidValue, scimErr.SCIMType)
+	})
+
+	t.Run("list filters by userName", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().LookupUser(gomock.Any(), "jdoe@example.com").Return(user, nil)
+		mockDB.EXPECT().LookupUser(gomock.Any(), "nobody@example.com").Return(model.User{}, database.ErrNotFound)
+
+		var found auth.SCIMListResponse
+		response := client.do(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "jdoe@example.com"`), nil, &found)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, 1, found.TotalResults)
+		require.Equal(t, []string{auth.SCIMSchemaListResponse}, found.Schemas)
+
+		var missing auth.SCIMListResponse
+		response = client.do(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "nobody@example.com"`), nil, &missing)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, 0, missing.TotalResults)
+		require.Empty(t, missing.Resources)
+	})
+
+	t.Run("list paginates all users", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Users{actor, user}, nil)
+
+		var page auth.SCIMListResponse
+		response := client.do(http.MethodGet, "/scim/v2/Users?startIndex=2&count=5", nil, &page)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, 2, page.TotalResults)
+		require.Equal(t, 2, page.StartIndex)
+		require.Equal(t, 1, page.ItemsPerPage)
+	})
+
+	t.Run("list rejects unsupported filters", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, _ := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`title co "eng"`), nil, &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorInvalidFilter, scimErr.SCIMType)
+	})
+
+	t.Run("get reports unknown users as not found", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, _ := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		response := client.do(http.MethodGet, "/scim/v2/Users/not-a-user", nil, nil)
+		require.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("patch with a path deactivates the user and ends their sessions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+		session := model.UserSession{BigSerial: model.BigSerial{ID: 1}}
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().LookupActiveSessionsByUser(gomock.Any(), gomock.Any()).Return([]model.UserSession{session}, nil)
+		mockDB.EXPECT().EndUserSession(gomock.Any(), session)
+		mockDB.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.User) error {
+			require.True(t, updated.IsDisabled)
+			return nil
+		})
+
+		var patched auth.SCIMUser
+		response := client.do(http.MethodPatch, userPath, patchOp(auth.SCIMPatchOperation{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)}), &patched)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.False(t, *patched.Active)
+	})
+
+	t.Run("patch without a path updates the listed attributes", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.User) error {
+			require.Equal(t, "Janet", updated.FirstName.String)
+			require.Equal(t, "janet@example.com", updated.EmailAddress.String)
+			require.False(t, updated.IsDisabled)
+			return nil
+		})
+
+		response := client.do(http.MethodPatch, userPath, patchOp(
+			auth.SCIMPatchOperation{Op: "replace", Value: json.RawMessage(`{"active": true, "name.givenName": "Janet"}`)},
+			auth.SCIMPatchOperation{Op: "replace", Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"janet@example.com"`)},
+		), nil)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("patch may not disable the token owner", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), actor.ID).Return(actor, nil)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPatch, "/scim/v2/Users/"+actor.ID.String(), patchOp(auth.SCIMPatchOperation{Op: "replace", Path: "active", Value: json.RawMessage(`false`)}), &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, api.ErrorResponseUserSelfDisable, scimErr.Detail)
+	})
+
+	t.Run("patch rejects unknown operations", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPatch, userPath, patchOp(auth.SCIMPatchOperation{Op: "move", Path: "active"}), &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorInvalidSyntax, scimErr.SCIMType)
+	})
+
+	t.Run("put replaces the user's attributes", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.User) error {
+			require.Equal(t, "jane.doe@example.com", updated.PrincipalName)
+			require.False(t, updated.LastName.Valid)
+			return nil
+		})
+
+		var replaced auth.SCIMUser
+		response := client.do(http.MethodPut, userPath, map[string]any{
+			"schemas":  []string{auth.SCIMSchemaUser},
+			"userName": "jane.doe@example.com",
+			"name":     map[string]string{"givenName": "Jane"},
+		}, &replaced)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, "jane.doe@example.com", replaced.UserName)
+	})
+
+	t.Run("delete removes the user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().DeleteUserAndTransferSavedQueries(gomock.Any(), user, actor).Return(nil)
+
+		response := client.do(http.MethodDelete, userPath, nil, nil)
+		require.Equal(t, http.StatusNoContent, response.StatusCode)
+	})
+
+	t.Run("delete may not remove the token owner", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), actor.ID).Return(actor, nil)
+
+		response := client.do(http.MethodDelete, "/scim/v2/Users/"+actor.ID.String(), nil, nil)
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func TestManagementResource_SCIMGroups(t *testing.T) {
+	var (
+		actor    = model.User{PrincipalName: "scim-admin", Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		readOnly = model.Role{Name: "Read-Only", Serial: model.Serial{ID: 3}}
+		user     = model.User{PrincipalName: "jdoe@example.com", Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		member   = model.User{PrincipalName: "member@example.com", Roles: model.Roles{readOnly}, Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+	)
+
+	t.Run("list filters roles by displayName", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetAllRoles(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Roles{{Name: "Administrator", Serial: model.Serial{ID: 1}}, readOnly}, nil)
+
+		var groups struct {
+			TotalResults int              `json:"totalResults"`
+			Resources    []auth.SCIMGroup `json:"Resources"`
+		}
+		response := client.do(http.MethodGet, "/scim/v2/Groups?excludedAttributes=members&filter="+url.QueryEscape(`displayName eq "read-only"`), nil, &groups)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, 1, groups.TotalResults)
+		require.Equal(t, "3", groups.Resources[0].ID)
+		require.Equal(t, "Read-Only", groups.Resources[0].DisplayName)
+	})
+
+	t.Run("get lists the role's members", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Users{user, member}, nil)
+
+		var group auth.SCIMGroup
+		response := client.do(http.MethodGet, "/scim/v2/Groups/3", nil, &group)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Len(t, group.Members, 1)
+		require.Equal(t, member.ID.String(), group.Members[0].Value)
+	})
+
+	t.Run("patch adding a member assigns the role", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().UpdateUsers(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.Users) error {
+			require.Len(t, updated, 1)
+			require.Equal(t, []int32{readOnly.ID}, updated[0].Roles.IDs())
+			return nil
+		})
+
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(auth.SCIMPatchOperation{
+			Op:    "add",
+			Path:  "members",
+			Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, user.ID)),
+		}), nil)
+
+		require.Equal(t, http.StatusNoContent, response.StatusCode)
+	})
+
+	t.Run("patch removing a member clears the role", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), member.ID).Return(member, nil)
+		mockDB.EXPECT().UpdateUsers(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.Users) error {
+			require.Len(t, updated, 1)
+			require.Empty(t, updated[0].Roles)
+			return nil
+		})
+
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(auth.SCIMPatchOperation{
+			Op:   "remove",
+			Path: fmt.Sprintf(`members[value eq "%s"]`, member.ID),
+		}), nil)
+
+		require.Equal(t, http.StatusNoContent, response.StatusCode)
+	})
+
+	t.Run("patch writes every operation together", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), member.ID).Return(member, nil)
+		mockDB.EXPECT().UpdateUsers(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.Users) error {
+			require.Len(t, updated, 2)
+			require.Equal(t, []int32{readOnly.ID}, updated[0].Roles.IDs())
+			require.Empty(t, updated[1].Roles)
+			return nil
+		}).Times(1)
+
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(
+			auth.SCIMPatchOperation{Op: "add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, user.ID))},
+			auth.SCIMPatchOperation{Op: "remove", Path: fmt.Sprintf(`members[value eq "%s"]`, member.ID)},
+		), nil)
+
+		require.Equal(t, http.StatusNoContent, response.StatusCode)
+	})
+
+	t.Run("patch with an invalid operation changes nothing", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(
+			auth.SCIMPatchOperation{Op: "add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, user.ID))},
+			auth.SCIMPatchOperation{Op: "replace", Path: "displayName", Value: json.RawMessage(`"Auditors"`)},
+		), &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorMutability, scimErr.SCIMType)
+	})
+
+	t.Run("patch with an unknown member in a later operation changes nothing", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		unknownID := uuid.Must(uuid.NewV4())
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), unknownID).Return(model.User{}, database.ErrNotFound)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(
+			auth.SCIMPatchOperation{Op: "add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, user.ID))},
+			auth.SCIMPatchOperation{Op: "add", Path: "members", Value: json.RawMessage(fmt.Sprintf(`[{"value": %q}]`, unknownID))},
+		), &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorInvalidValue, scimErr.SCIMType)
+	})
+
+	t.Run("patch may not change the token owner's role", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), actor.ID).Return(actor, nil)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(auth.SCIMPatchOperation{
+			Op:    "add",
+			Value: json.RawMessage(fmt.Sprintf(`{"members": [{"value": %q}]}`, actor.ID)),
+		}), &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, api.ErrorResponseUserSelfRoleChange, scimErr.Detail)
+	})
+
+	t.Run("patch may not rename a role", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPatch, "/scim/v2/Groups/3", patchOp(auth.SCIMPatchOperation{
+			Op:    "replace",
+			Path:  "displayName",
+			Value: json.RawMessage(`"Auditors"`),
+		}), &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorMutability, scimErr.SCIMType)
+	})
+
+	t.Run("put replaces the role's members", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		assigned := user
+		assigned.Roles = model.Roles{readOnly}
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		gomock.InOrder(
+			mockDB.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Users{user, member}, nil),
+			mockDB.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Users{assigned, {PrincipalName: member.PrincipalName, Unique: member.Unique}}, nil),
+		)
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), member.ID).Return(member, nil)
+		mockDB.EXPECT().UpdateUsers(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.Users) error {
+			require.Len(t, updated, 2)
+			require.Equal(t, []int32{readOnly.ID}, updated[0].Roles.IDs())
+			require.Empty(t, updated[1].Roles)
+			return nil
+		})
+
+		var group auth.SCIMGroup
+		response := client.do(http.MethodPut, "/scim/v2/Groups/3", map[string]any{
+			"schemas":     []string{auth.SCIMSchemaGroup},
+			"displayName": "Read-Only",
+			"members":     []map[string]string{{"value": user.ID.String()}},
+		}, &group)
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Len(t, group.Members, 1)
+		require.Equal(t, user.ID.String(), group.Members[0].Value)
+	})
+
+	t.Run("put with an unknown member changes nothing", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+		client := newSCIMTestClient(t, resources, actor)
+
+		unknownID := uuid.Must(uuid.NewV4())
+
+		mockDB.EXPECT().GetRole(gomock.Any(), readOnly.ID).Return(readOnly, nil)
+		mockDB.EXPECT().GetAllUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Users{user, member}, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetUser(gomock.Any(), unknownID).Return(model.User{}, database.ErrNotFound)
+
+		var scimErr auth.SCIMError
+		response := client.do(http.MethodPut, "/scim/v2/Groups/3", map[string]any{
+			"schemas":     []string{auth.SCIMSchemaGroup},
+			"displayName": "Read-Only",
+			"members":     []map[string]string{{"value": user.ID.String()}, {"value": unknownID.String()}},
+		}, &scimErr)
+
+		require.Equal(t, http.StatusBadRequest, response.StatusCode)
+		require.Equal(t, auth.SCIMErrorInvalidValue, scimErr.SCIMType)
+	})
+}
+
+func TestManagementResource_CreateSCIMToken(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+		actor             = model.User{PrincipalName: "admin", Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		manageUsers       = authz.Permissions().AuthManageUsers
+	)
+
+	manageUsers.ID = 7
+
+	mockDB.EXPECT().GetAllPermissions(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Permissions{authz.Permissions().GraphDBRead, manageUsers}, nil)
+	mockDB.EXPECT().CreateAuthToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, authToken model.AuthToken) (model.AuthToken, error) {
+		require.Equal(t, authz.SCIM_BEARER, authToken.HmacMethod)
+		require.Equal(t, actor.ID, authToken.UserID.UUID)
+		require.Equal(t, model.Permissions{manageUsers}, authToken.Permissions)
+		return authToken, nil
+	})
+
+	payload, err := json.Marshal(v2.CreateSCIMTokenRequest{TokenName: "okta"})
+	require.NoError(t, err)
+
+	requestCtx := bhctx.Set(context.Background(), &bhctx.Context{AuthCtx: authz.Context{Owner: actor}})
+	request, err := http.NewRequestWithContext(requestCtx, http.MethodPost, "/api/v2/scim/tokens", bytes.NewReader(payload))
+	require.NoError(t, err)
+	request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	recorder := httptest.NewRecorder()
+	resources.CreateSCIMToken(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response struct {
+		Data model.AuthToken `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.True(t, strings.HasPrefix(response.Data.Key, api.SCIMTokenPrefix+response.Data.ID.String()+"."))
+
+	tokenID, _, err := api.ParseSCIMBearerToken(response.Data.Key)
+	require.NoError(t, err)
+	require.Equal(t, response.Data.ID, tokenID)
+}
+
+func TestManagementResource_CreateSCIMToken_ScopedCaller(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+		actor             = model.User{PrincipalName: "admin", Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		manageUsers       = authz.Permissions().AuthManageUsers
+	)
+
+	manageUsers.ID = 7
+
+	mockDB.EXPECT().GetAllPermissions(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Permissions{authz.Permissions().GraphDBRead, manageUsers}, nil)
+
+	payload, err := json.Marshal(v2.CreateSCIMTokenRequest{TokenName: "okta"})
+	require.NoError(t, err)
+
+	requestCtx := bhctx.Set(context.Background(), &bhctx.Context{AuthCtx: authz.Context{
+		Owner:           actor,
+		PermissionScope: authz.PermissionScope{Enabled: true, Permissions: model.Permissions{authz.Permissions().GraphDBRead}},
+	}})
+	request, err := http.NewRequestWithContext(requestCtx, http.MethodPost, "/api/v2/scim/tokens", bytes.NewReader(payload))
+	require.NoError(t, err)
+	request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	recorder := httptest.NewRecorder()
+	resources.CreateSCIMToken(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+	require.Contains(t, recorder.Body.String(), auth.ErrResponseDetailsTokenCallerScope)
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+)
+
+// UserSessionView is the API representation of an active user session
+type UserSessionView struct {
+	ID               int64     `json:"id"`
+	IPAddress        string    `json:"ip_address"`
+	UserAgent        string    `json:"user_agent"`
+	AuthProviderType string    `json:"auth_provider_type"`
+	AuthProviderID   int32     `json:"auth_provider_id"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	Current          bool      `json:"current"`
+}
+
+type ListUserSessionsResponse struct {
+	Sessions []UserSessionView `json:"sessions"`
+}
+
+// newUserSessionViews renders the given sessions most recent first, marking the session of the current request
+func newUserSessionViews(sessions []model.UserSession, currentSessionID int64) []UserSessionView {
+	views := make([]UserSessionView, 0, len(sessions))
+
+	for _, session := range sessions {
+		views = append(views, UserSessionView{
+			ID:               session.ID,
+			IPAddress:        session.IPAddress,
+			UserAgent:        session.UserAgent,
+			AuthProviderType: session.AuthProviderType.String(),
+			AuthProviderID:   session.AuthProviderID,
+			CreatedAt:        session.CreatedAt,
+			ExpiresAt:        session.ExpiresAt,
+			Current:          session.ID == currentSessionID,
+		})
+	}
+
+	slices.SortFunc(views, func(a, b UserSessionView) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return views
+}
+
+// getActiveUserSession returns the given session if it belongs to the user and has not ended yet
+func (s ManagementResource) getActiveUserSession(ctx context.Context, userID uuid.UUID, sessionID int64) (model.UserSession, error) {
+	if session, err := s.db.GetUserSession(ctx, sessionID); err != nil {
+		return model.UserSession{}, err
+	} else if session.ID != sessionID || session.UserID != userID || session.Expired() {
+		return model.UserSession{}, database.ErrNotFound
+	} else {
+		return session, nil
+	}
+}
+
+func (s ManagementResource) writeUserSessions(response http.ResponseWriter, request *http.Request, user model.User) {
+	if sessions, err := s.db.LookupActiveSessionsByUser(request.Context(), user); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		currentSessionID := ctx.FromRequest(request).AuthCtx.Session.ID
+		api.WriteBasicResponse(request.Context(), ListUserSessionsResponse{Sessions: newUserSessionViews(sessions, currentSessionID)}, http.StatusOK, response)
+	}
+}
+
+func (s ManagementResource) revokeUserSession(response http.ResponseWriter, request *http.Request, userID uuid.UUID) {
+	rawSessionID := mux.Vars(request)[api.URIPathVariableSessionID]
+
+	if sessionID, err := strconv.ParseInt(rawSessionID, 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if session, err := s.getActiveUserSession(request.Context(), userID, sessionID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.db.RevokeUserSession(request.Context(), session); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListSelfSessions lists the active sessions of the current user
+func (s ManagementResource) ListSelfSessions(response http.ResponseWriter, request *http.Request) {
+	if user, found := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "No associated user found", request), response)
+	} else {
+		s.writeUserSessions(response, request, user)
+	}
+}
+
+// RevokeSelfSession ends one of the current user's sessions, for example one left open on another device
+func (s ManagementResource) RevokeSelfSession(response http.ResponseWriter, request *http.Request) {
+	if user, found := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "No associated user found", request), response)
+	} else {
+		s.revokeUserSession(response, request, user.ID)
+	}
+}
+
+// ListUserSessions lists the active sessions of a user
+func (s ManagementResource) ListUserSessions(response http.ResponseWriter, request *http.Request) {
+	rawUserID := mux.Vars(request)[api.URIPathVariableUserID]
+
+	if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if user, err := s.db.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		s.writeUserSessions(response, request, user)
+	}
+}
+
+// RevokeUserSession ends one of a user's sessions
+func (s ManagementResource) RevokeUserSession(response http.ResponseWriter, request *http.Request) {
+	rawUserID := mux.Vars(request)[api.URIPathVariableUserID]
+
+	if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else {
+		s.revokeUserSession(response, request, userID)
+	}
+}
+
+// RevokeUserSessions ends every active session of a user, logging them out everywhere
+func (s ManagementResource) RevokeUserSessions(response http.ResponseWriter, request *http.Request) {
+	rawUserID := mux.Vars(request)[api.URIPathVariableUserID]
+
+	if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if user, err := s.db.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.db.RevokeUserSessions(request.Context(), user); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/auth"
+	authz "github.com/specterops/bloodhound/cmd/api/src/auth"
+	bhctx "github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/must"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func serveSessionRequest(t *testing.T, handler http.HandlerFunc, method, routePath, requestPath string, authCtx authz.Context) *httptest.ResponseRecorder {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(bhctx.Set(t.Context(), &bhctx.Context{AuthCtx: authCtx}), method, requestPath, nil)
+	require.Nil(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(routePath, handler).Methods(method)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestManagementResource_ListSelfSessions(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		resources, mockDB = apitest.NewAuthManagementResource(mockCtrl)
+
+		user           = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		now            = time.Now().UTC()
+		currentSession = model.UserSession{
+			UserID:           user.ID,
+			AuthProviderType: model.SessionAuthProviderSecret,
+			ExpiresAt:        now.Add(time.Hour),
+			IPAddress:        "10.0.0.1",
+			UserAgent:        "Firefox",
+			BigSerial:        model.BigSerial{ID: 1, Basic: model.Basic{CreatedAt: now.Add(-time.Hour)}},
+		}
+		otherSession = model.UserSession{
+			UserID:           user.ID,
+			AuthProviderType: model.SessionAuthProviderOIDC,
+			AuthProviderID:   3,
+			ExpiresAt:        now.Add(time.Hour),
+			IPAddress:        "10.0.0.2",
+			UserAgent:        "Chrome",
+			BigSerial:        model.BigSerial{ID: 2, Basic: model.Basic{CreatedAt: now}},
+		}
+	)
+
+	mockDB.EXPECT().LookupActiveSessionsByUser(gomock.Any(), user).Return([]model.UserSession{currentSession, otherSession}, nil)
+
+	response := serveSessionRequest(t, resources.ListSelfSessions, http.MethodGet, "/api/v2/self/sessions", "/api/v2/self/sessions", authz.Context{Owner: user, Session: currentSession})
+	require.Equal(t, http.StatusOK, response.Code)
+
+	var body struct {
+		Data auth.ListUserSessionsResponse `json:"data"`
+	}
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+	require.Len(t, body.Data.Sessions, 2)
+
+	// Most recent session first
+	require.Equal(t, int64(2), body.Data.Sessions[0].ID)
+	require.Equal(t, "OIDC", body.Data.Sessions[0].AuthProviderType)
+	require.Equal(t, int32(3), body.Data.Sessions[0].AuthProviderID)
+	require.Equal(t, "10.0.0.2", body.Data.Sessions[0].IPAddress)
+	require.Equal(t, "Chrome", body.Data.Sessions[0].UserAgent)
+	require.False(t, body.Data.Sessions[0].Current)
+
+	require.Equal(t, int64(1), body.Data.Sessions[1].ID)
+	require.Equal(t, "Secret", body.Data.Sessions[1].AuthProviderType)
+	require.True(t, body.Data.Sessions[1].Current)
+}
+
+func TestManagementResource_RevokeSelfSession(t *testing.T) {
+	const routePath = "/api/v2/self/sessions/{session_id}"
+
+	var (
+		user      = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		otherUser = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		session   = model.UserSession{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour), BigSerial: model.BigSerial{ID: 7}}
+	)
+
+	t.Run("rejects a malformed session ID", func(t *testing.T) {
+		resources, _ := apitest.NewAuthManagementResource(gomock.NewController(t))
+
+		response := serveSessionRequest(t, resources.RevokeSelfSession, http.MethodDelete, routePath, "/api/v2/self/sessions/abc", authz.Context{Owner: user})
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), api.ErrorResponseDetailsIDMalformed)
+	})
+
+	t.Run("does not revoke sessions of other users", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUserSession(gomock.Any(), int64(7)).Return(session, nil)
+
+		response := serveSessionRequest(t, resources.RevokeSelfSession, http.MethodDelete, routePath, "/api/v2/self/sessions/7", authz.Context{Owner: otherUser})
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("does not revoke ended sessions", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+
+		endedSession := session
+		endedSession.ExpiresAt = time.Now().Add(-time.Minute)
+		mockDB.EXPECT().GetUserSession(gomock.Any(), int64(7)).Return(endedSession, nil)
+
+		response := serveSessionRequest(t, resources.RevokeSelfSession, http.MethodDelete, routePath, "/api/v2/self/sessions/7", authz.Context{Owner: user})
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("revokes the session", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUserSession(gomock.Any(), int64(7)).Return(session, nil)
+		mockDB.EXPECT().RevokeUserSession(gomock.Any(), session).Return(nil)
+
+		response := serveSessionRequest(t, resources.RevokeSelfSession, http.MethodDelete, routePath, "/api/v2/self/sessions/7", authz.Context{Owner: user})
+		require.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
+
+func TestManagementResource_UserSessions(t *testing.T) {
+	var (
+		admin   = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		user    = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		session = model.UserSession{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour), IPAddress: "10.0.0.1", BigSerial: model.BigSerial{ID: 9}}
+	)
+
+	t.Run("lists the sessions of a user", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().LookupActiveSessionsByUser(gomock.Any(), user).Return([]model.UserSession{session}, nil)
+
+		response := serveSessionRequest(t, resources.ListUserSessions, http.MethodGet, "/api/v2/bloodhound-users/{user_id}/sessions", fmt.Sprintf("/api/v2/bloodhound-users/%s/sessions", user.ID), authz.Context{Owner: admin})
+		require.Equal(t, http.StatusOK, response.Code)
+		require.Contains(t, response.Body.String(), `"ip_address":"10.0.0.1"`)
+		require.Contains(t, response.Body.String(), `"current":false`)
+	})
+
+	t.Run("revokes one session of a user", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUserSession(gomock.Any(), int64(9)).Return(session, nil)
+		mockDB.EXPECT().RevokeUserSession(gomock.Any(), session).Return(nil)
+
+		response := serveSessionRequest(t, resources.RevokeUserSession, http.MethodDelete, "/api/v2/bloodhound-users/{user_id}/sessions/{session_id}", fmt.Sprintf("/api/v2/bloodhound-users/%s/sessions/9", user.ID), authz.Context{Owner: admin})
+		require.Equal(t, http.StatusNoContent, response.Code)
+	})
+
+	t.Run("revokes every session of a user", func(t *testing.T) {
+		resources, mockDB := apitest.NewAuthManagementResource(gomock.NewController(t))
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().RevokeUserSessions(gomock.Any(), user).Return(nil)
+
+		response := serveSessionRequest(t, resources.RevokeUserSessions, http.MethodDelete, "/api/v2/bloodhound-users/{user_id}/sessions", fmt.Sprintf("/api/v2/bloodhound-users/%s/sessions", user.ID), authz.Context{Owner: admin})
+		require.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/api"
 	"github.com/specterops/bloodhound/cmd/api/src/auth"
 	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/serde"
@@ -68,6 +70,7 @@ type getAllRoler interface {
 
 type jitUserUpserter interface {
 	getAllRoler
+	database.EnvironmentAccessControlData
 
 	LookupUser(ctx context.Context, principalNameOrEmail string) (model.User, error)
 	CreateUser(ctx context.Context, user model.User) (model.User, error)
@@ -293,3 +296,69 @@ func SanitizeAndGetRoles(ctx context.Context, autoProvisionConfig model.SSOProvi
 		return model.Roles{defaultRole}, nil
 	}
 }
+
+// jitUserEnvironmentsProvision grants an auto provisioned user the environments mapped to their group claims when
+// environment provisioning is enabled. A matching mapping rule that grants environments takes precedence and is
+// applied when the session is created.
+func jitUserEnvironmentsProvision(ctx context.Context, autoProvisionConfig model.SSOProviderAutoProvisionConfig, user model.User, groups []string, claims model.SSOClaims, u jitUserUpserter) error {
+	if !autoProvisionConfig.EnvironmentProvision {
+		return nil
+	} else if rule, matched, err := autoProvisionConfig.MatchMappingRule(claims); err != nil {
+		return fmt.Errorf("match mapping rule: %v", err)
+	} else if matched && rule.GrantsEnvironments() {
+		return nil
+	} else {
+		allEnvironments, environments := autoProvisionConfig.GetEnvironmentsForGroups(groups)
+		return api.SyncEnvironmentAccess(ctx, u, user, allEnvironments, environments)
+	}
+}
+
+// validateEnvironmentMappings ensures every mapping names a group and either grants all environments or lists the
+// environments to grant. Environments are upper cased to match the domain SIDs and tenant IDs stored in the graph.
+func validateEnvironmentMappings(mappings []model.SSOProviderEnvironmentMapping) error {
+	for idx, mapping := range mappings {
+		if strings.TrimSpace(mapping.Group) == "" {
+			return fmt.Errorf("%w: mapping %d is missing a group", ErrEnvironmentMappingInvalid, idx)
+		} else if mapping.AllEnvironments && len(mapping.Environments) > 0 {
+			return fmt.Errorf("%w: mapping for group %s must not list environments when all_environments is true", ErrEnvironmentMappingInvalid, mapping.Group)
+		}
+
+		for envIdx, environment := range mapping.Environments {
+			if environment = strings.ToUpper(strings.TrimSpace(environment)); environment == "" {
+				return fmt.Errorf("%w: mapping for group %s contains a blank environment", ErrEnvironmentMappingInvalid, mapping.Group)
+			} else {
+				mappings[idx].Environments[envIdx] = environment
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMappingRules ensures every rule matches a claim by either a value or a valid regex and maps it to an existing
+// role. Environments are normalized the same way as environment mappings.
+func validateMappingRules(ctx context.Context, rules []model.SSOProviderMappingRule, r getRoler) error {
+	for idx, rule := range rules {
+		if strings.TrimSpace(rule.Claim) == "" {
+			return fmt.Errorf("%w: rule %d is missing a claim", ErrMappingRuleInvalid, idx)
+		} else if (rule.Value == "") == (rule.Regex == "") {
+			return fmt.Errorf("%w: rule for claim %s must set exactly one of value or regex", ErrMappingRuleInvalid, rule.Claim)
+		} else if _, err := regexp.Compile(rule.Regex); err != nil {
+			return fmt.Errorf("%w: rule for claim %s has an invalid regex: %v", ErrMappingRuleInvalid, rule.Claim, err)
+		} else if _, err := r.GetRole(ctx, rule.RoleId); err != nil {
+			return fmt.Errorf("%w: rule for claim %s has an invalid role id", ErrMappingRuleInvalid, rule.Claim)
+		} else if rule.AllEnvironments && len(rule.Environments) > 0 {
+			return fmt.Errorf("%w: rule for claim %s must not list environments when all_environments is true", ErrMappingRuleInvalid, rule.Claim)
+		}
+
+		for envIdx, environment := range rule.Environments {
+			if environment = strings.ToUpper(strings.TrimSpace(environment)); environment == "" {
+				return fmt.Errorf("%w: rule for claim %s contains a blank environment", ErrMappingRuleInvalid, rule.Claim)
+			} else {
+				rules[idx].Environments[envIdx] = environment
+			}
+		}
+	}
+
+	return nil
+}
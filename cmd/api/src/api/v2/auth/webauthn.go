@@ -0,0 +1,211 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+)
+
+const (
+	ErrResponseDetailsWebAuthnSelfOnly       = "webauthn credentials can only be registered by the user they belong to"
+	ErrResponseDetailsWebAuthnNameRequired   = "credential name is required"
+	ErrResponseDetailsWebAuthnDuplicate      = "this credential is already registered"
+	ErrResponseDetailsWebAuthnCeremony       = "webauthn registration has expired or is invalid"
+	ErrResponseDetailsWebAuthnRegistration   = "webauthn registration response is invalid"
+	ErrResponseDetailsWebAuthnSSOUnsupported = "Invalid operation, user is SSO"
+)
+
+type WebAuthnRegistrationOptionsRequest struct {
+	Secret string `json:"secret"`
+}
+
+type WebAuthnRegistrationOptionsResponse struct {
+	PublicKey     auth.WebAuthnCreationOptions `json:"public_key"`
+	CeremonyToken string                       `json:"ceremony_token"`
+}
+
+type WebAuthnCredentialRegistrationRequest struct {
+	Name          string                            `json:"name"`
+	CeremonyToken string                            `json:"ceremony_token"`
+	Credential    auth.WebAuthnRegistrationResponse `json:"credential"`
+}
+
+type ListWebAuthnCredentialsResponse struct {
+	Credentials model.WebAuthnCredentials `json:"credentials"`
+}
+
+// requireWebAuthnSelf returns the user named by the request path if they are a local user and the authenticated user.
+// Credentials are bound to an authenticator in the user's possession, so they cannot be registered on someone's behalf.
+func (s ManagementResource) requireWebAuthnSelf(response http.ResponseWriter, request *http.Request) (model.User, bool) {
+	rawUserID := mux.Vars(request)[api.URIPathVariableUserID]
+
+	if authedUser, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if userID != authedUser.ID {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, ErrResponseDetailsWebAuthnSelfOnly, request), response)
+	} else if user, err := s.db.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if user.SSOProviderID.Valid || user.AuthSecret == nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsWebAuthnSSOUnsupported, request), response)
+	} else {
+		return user, true
+	}
+
+	return model.User{}, false
+}
+
+// BeginWebAuthnRegistration revalidates the user's password and returns the options for registering a new credential
+func (s ManagementResource) BeginWebAuthnRegistration(response http.ResponseWriter, request *http.Request) {
+	var payload WebAuthnRegistrationOptionsRequest
+
+	if user, ok := s.requireWebAuthnSelf(response, request); !ok {
+		return
+	} else if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrContentTypeJson.Error(), request), response)
+	} else if err := api.ValidateSecret(s.secretDigester, payload.Secret, *user.AuthSecret); err != nil {
+		// As with MFA enrollment, the session is valid so an incorrect current password is a bad request
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsInvalidCurrentPassword, request), response)
+	} else if credentials, err := s.db.GetWebAuthnCredentials(request.Context(), user.ID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if challenge, err := auth.GenerateWebAuthnChallenge(); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if signingKeyBytes, err := s.config.Crypto.JWT.SigningKeyBytes(); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if ceremonyToken, err := auth.NewWebAuthnCeremonyToken(signingKeyBytes, auth.WebAuthnCeremonyCreate, user.ID, challenge, time.Now()); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		existingCredentialIDs := make([][]byte, 0, len(credentials))
+
+		for _, credential := range credentials {
+			if credentialID, err := base64.RawURLEncoding.DecodeString(credential.CredentialID); err == nil {
+				existingCredentialIDs = append(existingCredentialIDs, credentialID)
+			}
+		}
+
+		displayName := strings.TrimSpace(user.FirstName.ValueOrZero() + " " + user.LastName.ValueOrZero())
+		if displayName == "" {
+			displayName = user.PrincipalName
+		}
+
+		api.WriteBasicResponse(request.Context(), WebAuthnRegistrationOptionsResponse{
+			PublicKey:     auth.NewWebAuthnRelyingParty(s.config.RootURL.AsURL()).CreationOptions(user.ID, user.PrincipalName, displayName, challenge, existingCredentialIDs),
+			CeremonyToken: ceremonyToken,
+		}, http.StatusOK, response)
+	}
+}
+
+// RegisterWebAuthnCredential verifies the authenticator's response to the registration options and stores the new
+// credential. Each set of registration options can only be answered once. A session that was limited until the user
+// registered a credential is released.
+func (s ManagementResource) RegisterWebAuthnCredential(response http.ResponseWriter, request *http.Request) {
+	var (
+		payload WebAuthnCredentialRegistrationRequest
+		bhCtx   = ctx.FromRequest(request)
+	)
+
+	if user, ok := s.requireWebAuthnSelf(response, request); !ok {
+		return
+	} else if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrContentTypeJson.Error(), request), response)
+	} else if payload.Name = strings.TrimSpace(payload.Name); payload.Name == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsWebAuthnNameRequired, request), response)
+	} else if signingKeyBytes, err := s.config.Crypto.JWT.SigningKeyBytes(); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if ceremony, err := auth.ParseWebAuthnCeremonyToken(signingKeyBytes, payload.CeremonyToken, auth.WebAuthnCeremonyCreate, user.ID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsWebAuthnCeremony, request), response)
+	} else if err := s.db.ConsumeWebAuthnCeremony(request.Context(), ceremony.Id, time.Unix(ceremony.ExpiresAt, 0)); errors.Is(err, database.ErrWebAuthnCeremonyConsumed) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsWebAuthnCeremony, request), response)
+	} else if err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if registered, err := auth.NewWebAuthnRelyingParty(s.config.RootURL.AsURL()).VerifyRegistration(ceremony.Challenge, payload.Credential); err != nil {
+		slog.InfoContext(request.Context(), fmt.Sprintf("Rejected webauthn registration for user %s: %v", user.ID, err))
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrResponseDetailsWebAuthnRegistration, request), response)
+	} else if existing, err := s.db.GetWebAuthnCredentials(request.Context(), user.ID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if _, found := existing.Find(auth.WebAuthnBytes(registered.CredentialID).String()); found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrResponseDetailsWebAuthnDuplicate, request), response)
+	} else if credential, err := s.db.CreateWebAuthnCredential(request.Context(), model.WebAuthnCredential{
+		UserID:       user.ID,
+		Name:         payload.Name,
+		CredentialID: auth.WebAuthnBytes(registered.CredentialID).String(),
+		PublicKey:    registered.PublicKey,
+		SignCount:    int64(registered.SignCount),
+	}); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		if session := bhCtx.AuthCtx.Session; session.ID != 0 && session.GetFlag(model.SessionFlagWebAuthnEnrollmentRequired) {
+			if err := s.db.SetUserSessionFlag(request.Context(), &session, model.SessionFlagWebAuthnEnrollmentRequired, false); err != nil {
+				slog.WarnContext(request.Context(), fmt.Sprintf("Failed to release webauthn enrollment restriction on session %d: %v", session.ID, err))
+			}
+		}
+
+		api.WriteBasicResponse(request.Context(), credential, http.StatusCreated, response)
+	}
+}
+
+// ListWebAuthnCredentials lists the WebAuthn credentials registered by a user
+func (s ManagementResource) ListWebAuthnCredentials(response http.ResponseWriter, request *http.Request) {
+	rawUserID := mux.Vars(request)[api.URIPathVariableUserID]
+
+	if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if user, err := s.db.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if credentials, err := s.db.GetWebAuthnCredentials(request.Context(), user.ID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), ListWebAuthnCredentialsResponse{Credentials: credentials}, http.StatusOK, response)
+	}
+}
+
+// DeleteWebAuthnCredential removes a user's WebAuthn credential, for example after the authenticator was lost
+func (s ManagementResource) DeleteWebAuthnCredential(response http.ResponseWriter, request *http.Request) {
+	var (
+		pathVars        = mux.Vars(request)
+		rawUserID       = pathVars[api.URIPathVariableUserID]
+		rawCredentialID = pathVars[api.URIPathVariableWebAuthnCredentialID]
+	)
+
+	if userID, err := uuid.FromString(rawUserID); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if credentialID, err := strconv.ParseInt(rawCredentialID, 10, 32); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if credential, err := s.db.GetWebAuthnCredential(request.Context(), userID, int32(credentialID)); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.db.DeleteWebAuthnCredential(request.Context(), credential); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
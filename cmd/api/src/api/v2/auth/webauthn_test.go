@@ -0,0 +1,280 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/auth"
+	authz "github.com/specterops/bloodhound/cmd/api/src/auth"
+	bhctx "github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/must"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	webAuthnRegistrationPath = "/api/v2/bloodhound-users/{user_id}/webauthn/registration"
+	webAuthnCredentialsPath  = "/api/v2/bloodhound-users/{user_id}/webauthn/credentials"
+	webAuthnCredentialPath   = "/api/v2/bloodhound-users/{user_id}/webauthn/credentials/{webauthn_credential_id}"
+)
+
+func serveWebAuthnRequest(t *testing.T, handler http.HandlerFunc, method, routePath, requestPath string, actor model.User, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload []byte
+
+	if body != nil {
+		payload = must.MarshalJSON(body)
+	}
+
+	request, err := http.NewRequestWithContext(bhctx.Set(t.Context(), &bhctx.Context{AuthCtx: authz.Context{Owner: actor}}), method, requestPath, bytes.NewReader(payload))
+	require.Nil(t, err)
+	request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	router := mux.NewRouter()
+	router.HandleFunc(routePath, handler).Methods(method)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestManagementResource_BeginWebAuthnRegistration(t *testing.T) {
+	var (
+		user = model.User{
+			PrincipalName: "testy",
+			FirstName:     null.StringFrom("Testy"),
+			LastName:      null.StringFrom("McTest"),
+			AuthSecret:    defaultDigestAuthSecret(t, "password"),
+			Unique:        model.Unique{ID: must.NewUUIDv4()},
+		}
+		requestPath = fmt.Sprintf("/api/v2/bloodhound-users/%s/webauthn/registration", user.ID)
+	)
+
+	t.Run("rejects registering a credential for another user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, _ := apitest.NewAuthManagementResource(mockCtrl)
+
+		admin := model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		response := serveWebAuthnRequest(t, resources.BeginWebAuthnRegistration, http.MethodPost, webAuthnRegistrationPath, requestPath, admin, auth.WebAuthnRegistrationOptionsRequest{Secret: "password"})
+
+		require.Equal(t, http.StatusForbidden, response.Code)
+		require.Contains(t, response.Body.String(), auth.ErrResponseDetailsWebAuthnSelfOnly)
+	})
+
+	t.Run("rejects SSO users", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		ssoUser := user
+		ssoUser.SSOProviderID = null.Int32From(1)
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(ssoUser, nil)
+
+		response := serveWebAuthnRequest(t, resources.BeginWebAuthnRegistration, http.MethodPost, webAuthnRegistrationPath, requestPath, user, auth.WebAuthnRegistrationOptionsRequest{Secret: "password"})
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("rejects an invalid current password", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+
+		response := serveWebAuthnRequest(t, resources.BeginWebAuthnRegistration, http.MethodPost, webAuthnRegistrationPath, requestPath, user, auth.WebAuthnRegistrationOptionsRequest{Secret: "wrong"})
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), auth.ErrResponseDetailsInvalidCurrentPassword)
+	})
+
+	t.Run("returns creation options excluding existing credentials", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetWebAuthnCredentials(gomock.Any(), user.ID).Return(model.WebAuthnCredentials{{UserID: user.ID, CredentialID: "ZXhpc3Rpbmc"}}, nil)
+
+		response := serveWebAuthnRequest(t, resources.BeginWebAuthnRegistration, http.MethodPost, webAuthnRegistrationPath, requestPath, user, auth.WebAuthnRegistrationOptionsRequest{Secret: "password"})
+		require.Equal(t, http.StatusOK, response.Code)
+
+		var body struct {
+			Data auth.WebAuthnRegistrationOptionsResponse `json:"data"`
+		}
+		require.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+
+		require.NotEmpty(t, body.Data.CeremonyToken)
+		require.Len(t, body.Data.PublicKey.Challenge, 32)
+		require.Equal(t, "Testy McTest", body.Data.PublicKey.User.DisplayName)
+		require.Equal(t, authz.WebAuthnBytes(user.ID.Bytes()), body.Data.PublicKey.User.ID)
+		require.Len(t, body.Data.PublicKey.ExcludeCredentials, 1)
+		require.Equal(t, "ZXhpc3Rpbmc", body.Data.PublicKey.ExcludeCredentials[0].ID.String())
+	})
+}
+
+func TestManagementResource_RegisterWebAuthnCredential(t *testing.T) {
+	var (
+		user = model.User{
+			PrincipalName: "testy",
+			AuthSecret:    defaultDigestAuthSecret(t, "password"),
+			Unique:        model.Unique{ID: must.NewUUIDv4()},
+		}
+		requestPath = fmt.Sprintf("/api/v2/bloodhound-users/%s/webauthn/credentials", user.ID)
+	)
+
+	t.Run("requires a credential name", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+
+		response := serveWebAuthnRequest(t, resources.RegisterWebAuthnCredential, http.MethodPost, webAuthnCredentialsPath, requestPath, user, auth.WebAuthnCredentialRegistrationRequest{Name: "  "})
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), auth.ErrResponseDetailsWebAuthnNameRequired)
+	})
+
+	t.Run("rejects an invalid ceremony token", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+
+		response := serveWebAuthnRequest(t, resources.RegisterWebAuthnCredential, http.MethodPost, webAuthnCredentialsPath, requestPath, user, auth.WebAuthnCredentialRegistrationRequest{Name: "YubiKey", CeremonyToken: "not-a-token"})
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), auth.ErrResponseDetailsWebAuthnCeremony)
+	})
+
+	// beginRegistration begins a registration to obtain a valid ceremony token
+	beginRegistration := func(t *testing.T, resources auth.ManagementResource, mockDB *mocks.MockDatabase) string {
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().GetWebAuthnCredentials(gomock.Any(), user.ID).Return(model.WebAuthnCredentials{}, nil)
+
+		optionsResponse := serveWebAuthnRequest(t, resources.BeginWebAuthnRegistration, http.MethodPost, webAuthnRegistrationPath, fmt.Sprintf("/api/v2/bloodhound-users/%s/webauthn/registration", user.ID), user, auth.WebAuthnRegistrationOptionsRequest{Secret: "password"})
+		require.Equal(t, http.StatusOK, optionsResponse.Code)
+
+		var options struct {
+			Data auth.WebAuthnRegistrationOptionsResponse `json:"data"`
+		}
+		require.Nil(t, json.Unmarshal(optionsResponse.Body.Bytes(), &options))
+
+		return options.Data.CeremonyToken
+	}
+
+	t.Run("rejects an invalid registration response", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		payload := auth.WebAuthnCredentialRegistrationRequest{Name: "YubiKey", CeremonyToken: beginRegistration(t, resources, mockDB)}
+		payload.Credential.Type = "public-key"
+		payload.Credential.Response.ClientDataJSON = []byte(`{"type":"webauthn.create","challenge":"AAAA","origin":"http://localhost"}`)
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().ConsumeWebAuthnCeremony(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		response := serveWebAuthnRequest(t, resources.RegisterWebAuthnCredential, http.MethodPost, webAuthnCredentialsPath, requestPath, user, payload)
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), auth.ErrResponseDetailsWebAuthnRegistration)
+	})
+
+	t.Run("rejects a ceremony token that was already used", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		payload := auth.WebAuthnCredentialRegistrationRequest{Name: "YubiKey", CeremonyToken: beginRegistration(t, resources, mockDB)}
+
+		mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+		mockDB.EXPECT().ConsumeWebAuthnCeremony(gomock.Any(), gomock.Any(), gomock.Any()).Return(database.ErrWebAuthnCeremonyConsumed)
+
+		response := serveWebAuthnRequest(t, resources.RegisterWebAuthnCredential, http.MethodPost, webAuthnCredentialsPath, requestPath, user, payload)
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), auth.ErrResponseDetailsWebAuthnCeremony)
+	})
+}
+
+func TestManagementResource_ListWebAuthnCredentials(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+	user := model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+	credentials := model.WebAuthnCredentials{{UserID: user.ID, Name: "YubiKey", CredentialID: "Y3JlZGVudGlhbA", PublicKey: []byte("secret"), Serial: model.Serial{ID: 1}}}
+
+	mockDB.EXPECT().GetUser(gomock.Any(), user.ID).Return(user, nil)
+	mockDB.EXPECT().GetWebAuthnCredentials(gomock.Any(), user.ID).Return(credentials, nil)
+
+	response := serveWebAuthnRequest(t, resources.ListWebAuthnCredentials, http.MethodGet, webAuthnCredentialsPath, fmt.Sprintf("/api/v2/bloodhound-users/%s/webauthn/credentials", user.ID), user, nil)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"credential_id":"Y3JlZGVudGlhbA"`)
+	require.NotContains(t, response.Body.String(), "public_key")
+}
+
+func TestManagementResource_DeleteWebAuthnCredential(t *testing.T) {
+	var (
+		user        = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		admin       = model.User{Unique: model.Unique{ID: must.NewUUIDv4()}}
+		credential  = model.WebAuthnCredential{UserID: user.ID, Name: "YubiKey", Serial: model.Serial{ID: 7}}
+		requestPath = fmt.Sprintf("/api/v2/bloodhound-users/%s/webauthn/credentials/7", user.ID)
+	)
+
+	t.Run("rejects a malformed credential ID", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, _ := apitest.NewAuthManagementResource(mockCtrl)
+
+		response := serveWebAuthnRequest(t, resources.DeleteWebAuthnCredential, http.MethodDelete, webAuthnCredentialPath, fmt.Sprintf("/api/v2/bloodhound-users/%s/webauthn/credentials/abc", user.ID), admin, nil)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), api.ErrorResponseDetailsIDMalformed)
+	})
+
+	t.Run("returns not found for another user's credential", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		mockDB.EXPECT().GetWebAuthnCredential(gomock.Any(), user.ID, int32(7)).Return(model.WebAuthnCredential{}, database.ErrNotFound)
+
+		response := serveWebAuthnRequest(t, resources.DeleteWebAuthnCredential, http.MethodDelete, webAuthnCredentialPath, requestPath, admin, nil)
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("deletes the credential", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		resources, mockDB := apitest.NewAuthManagementResource(mockCtrl)
+
+		mockDB.EXPECT().GetWebAuthnCredential(gomock.Any(), user.ID, int32(7)).Return(credential, nil)
+		mockDB.EXPECT().DeleteWebAuthnCredential(gomock.Any(), credential).Return(nil)
+
+		response := serveWebAuthnRequest(t, resources.DeleteWebAuthnCredential, http.MethodDelete, webAuthnCredentialPath, requestPath, admin, nil)
+		require.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/utils"
 	"github.com/specterops/bloodhound/packages/go/analysis/azure"
+	azureSchema "github.com/specterops/bloodhound/packages/go/graphschema/azure"
 	"github.com/specterops/dawgs/graph"
 	"github.com/specterops/dawgs/ops"
 )
@@ -71,7 +72,7 @@ var (
 	ErrParameterRelatedEntityType = errors.New("invalid related entity type")
 )
 
-func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType, objectID string, request *http.Request) (any, int, *api.ErrorWrapper) {
+func graphRelatedEntityType(ctx context.Context, db graph.Database, scope api.EnvironmentScope, entityType, objectID string, request *http.Request) (any, int, *api.ErrorWrapper) {
 	switch relatedEntityType := azure.RelatedEntityType(entityType); relatedEntityType {
 	case azure.RelatedEntityTypeDescendentUsers, azure.RelatedEntityTypeDescendentGroups,
 		azure.RelatedEntityTypeDescendentManagementGroups, azure.RelatedEntityTypeDescendentSubscriptions,
@@ -88,6 +89,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if descendents, err := azure.ListEntityDescendentPaths(ctx, db, relatedEntityType, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			descendents = scope.FilterPaths(descendents)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(descendents), descendents.Len(), nil
 		}
 
@@ -95,6 +97,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if assignments, err := azure.ListEntityActiveAssignmentPaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			assignments = scope.FilterPaths(assignments)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(assignments), assignments.Len(), nil
 		}
 
@@ -102,18 +105,21 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if assignments, err := azure.ListEntityPIMAssignmentPaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			assignments = scope.FilterPaths(assignments)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(assignments), assignments.Len(), nil
 		}
 	case azure.RelatedEntityTypeRoleApprovers:
 		if approvers, err := azure.ListRoleApproverPaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			approvers = scope.FilterPaths(approvers)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(approvers), approvers.Len(), nil
 		}
 	case azure.RelatedEntityTypeVaultKeyReaders, azure.RelatedEntityTypeVaultSecretReaders, azure.RelatedEntityTypeVaultCertReaders, azure.RelatedEntityTypeVaultAllReaders:
 		if groupMembers, err := azure.ListKeyVaultReaderPaths(ctx, db, relatedEntityType, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			groupMembers = scope.FilterPaths(groupMembers)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(groupMembers), groupMembers.Len(), nil
 		}
 
@@ -121,6 +127,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if groupMembers, err := azure.ListEntityGroupMemberPaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			groupMembers = scope.FilterPaths(groupMembers)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(groupMembers), groupMembers.Len(), nil
 		}
 
@@ -128,6 +135,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if groupMembership, err := azure.ListEntityGroupMembershipPaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			groupMembership = scope.FilterPaths(groupMembership)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(groupMembership), groupMembership.Len(), nil
 		}
 
@@ -135,6 +143,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if userRoles, err := azure.ListEntityRolePaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			userRoles = scope.FilterPaths(userRoles)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(userRoles), userRoles.Len(), nil
 		}
 
@@ -142,6 +151,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if executionPrivileges, err := azure.ListEntityExecutionPrivilegePaths(ctx, db, objectID, graph.DirectionOutbound); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			executionPrivileges = scope.FilterPaths(executionPrivileges)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(executionPrivileges), executionPrivileges.Len(), nil
 		}
 
@@ -149,6 +159,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if executionPrivileges, err := azure.ListEntityExecutionPrivilegePaths(ctx, db, objectID, graph.DirectionInbound); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			executionPrivileges = scope.FilterPaths(executionPrivileges)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(executionPrivileges), executionPrivileges.Len(), nil
 		}
 
@@ -156,6 +167,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if objectControl, err := azure.ListEntityAbusableAppRoleAssignmentsPaths(ctx, db, objectID, graph.DirectionOutbound); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			objectControl = scope.FilterPaths(objectControl)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(objectControl), objectControl.Len(), nil
 		}
 
@@ -163,6 +175,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if objectControl, err := azure.ListEntityAbusableAppRoleAssignmentsPaths(ctx, db, objectID, graph.DirectionInbound); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			objectControl = scope.FilterPaths(objectControl)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(objectControl), objectControl.Len(), nil
 		}
 
@@ -170,6 +183,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if objectControl, err := azure.ListEntityObjectControlPaths(ctx, db, objectID, graph.DirectionOutbound); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			objectControl = scope.FilterPaths(objectControl)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(objectControl), objectControl.Len(), nil
 		}
 
@@ -177,6 +191,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if objectControl, err := azure.ListEntityObjectControlPaths(ctx, db, objectID, graph.DirectionInbound); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			objectControl = scope.FilterPaths(objectControl)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(objectControl), objectControl.Len(), nil
 		}
 	
@@ -184,6 +199,7 @@ func graphRelatedEntityType(ctx context.Context, db graph.Database, entityType,
 		if paths, err := azure.ListEntityOAuth2PermissionGrantPaths(ctx, db, objectID); err != nil {
 			return nil, 0, api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error fetching related entity type %s: %v", entityType, err), request)
 		} else {
+			paths = scope.FilterPaths(paths)
 			return bloodhoundgraph.PathSetToBloodHoundGraph(paths), paths.Len(), nil
 		}
 
@@ -202,7 +218,7 @@ func nodeSetToOrderedSlice(nodeSet graph.NodeSet) []*graph.Node {
 	return nodes
 }
 
-func listRelatedEntityType(ctx context.Context, db graph.Database, entityType, objectID string, skip, limit int) ([]azure.Node, int, error) {
+func listRelatedEntityType(ctx context.Context, db graph.Database, scope api.EnvironmentScope, entityType, objectID string, skip, limit int) ([]azure.Node, int, error) {
 	var (
 		nodeSet graph.NodeSet
 		err     error
@@ -294,6 +310,7 @@ func listRelatedEntityType(ctx context.Context, db graph.Database, entityType, o
 		return nil, 0, ErrParameterRelatedEntityType
 	}
 
+	nodeSet = scope.FilterNodes(nodeSet)
 	nodeCount := nodeSet.Len()
 
 	if skip > nodeCount {
@@ -309,7 +326,7 @@ func listRelatedEntityType(ctx context.Context, db graph.Database, entityType, o
 	return azure.FromGraphNodes(s), nodeCount, nil
 }
 
-func (s *Resources) GetAZRelatedEntities(ctx context.Context, response http.ResponseWriter, request *http.Request, objectID string) {
+func (s *Resources) GetAZRelatedEntities(ctx context.Context, response http.ResponseWriter, request *http.Request, scope api.EnvironmentScope, objectID string) {
 	var (
 		queryParams = request.URL.Query()
 		returnType  = queryParams.Get(relatedEntityReturnTypeQueryParameterName)
@@ -329,13 +346,13 @@ func (s *Resources) GetAZRelatedEntities(ctx context.Context, response http.Resp
 	} else if limit, err := ParseLimitQueryParameter(queryParams, 100); err != nil {
 		api.WriteErrorResponse(ctx, ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
 	} else if returnType == relatedEntityReturnTypeGraph {
-		if data, _, apiErr := graphRelatedEntityType(ctx, s.Graph, relatedEntityType, objectID, request); apiErr != nil {
+		if data, _, apiErr := graphRelatedEntityType(ctx, s.Graph, scope, relatedEntityType, objectID, request); apiErr != nil {
 			api.WriteErrorResponse(ctx, apiErr, response)
 		} else {
 			api.WriteJSONResponse(ctx, data, http.StatusOK, response)
 		}
 	} else {
-		if nodes, count, err := listRelatedEntityType(ctx, s.Graph, relatedEntityType, objectID, skip, limit); err != nil {
+		if nodes, count, err := listRelatedEntityType(ctx, s.Graph, scope, relatedEntityType, objectID, skip, limit); err != nil {
 			if errors.Is(err, ErrParameterSkip) {
 				api.WriteErrorResponse(ctx, api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(utils.ErrorInvalidSkip, skip), request), response)
 			} else if errors.Is(err, ErrParameterRelatedEntityType) {
@@ -426,8 +443,16 @@ func (s *Resources) GetAZEntity(response http.ResponseWriter, request *http.Requ
 
 	if objectID := queryVars.Get(objectIDQueryParameterName); objectID == "" {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("query parameter %s is required", objectIDQueryParameterName), request), response)
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.checkEnvironmentAccess(request.Context(), scope, objectID, azureSchema.Entity); err != nil {
+		if graph.IsErrNotFound(err) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "not found", request), response)
+		} else {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("db error: %v", err), request), response)
+		}
 	} else if relatedEntityTypeStr := queryVars.Get(relatedEntityTypeQueryParameterName); relatedEntityTypeStr != "" {
-		s.GetAZRelatedEntities(request.Context(), response, request, objectID)
+		s.GetAZRelatedEntities(request.Context(), response, request, scope, objectID)
 	} else if includeCounts, err := api.ParseOptionalBool(queryVars.Get(api.QueryParameterIncludeCounts), true); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
 	} else if entityInformation, err := GetAZEntityInformation(request.Context(), s.Graph, entityType, objectID, includeCounts); err != nil {
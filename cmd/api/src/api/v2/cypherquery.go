@@ -84,6 +84,15 @@ func (s Resources) CypherQuery(response http.ResponseWriter, request *http.Reque
 		return
 	}
 
+	// Arbitrary cypher can read any part of the graph so it is unavailable to users limited to specific environments
+	if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+		return
+	} else if scope.Restricted {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "Permission denied: Cypher queries are not available to users with restricted environment access.", request), response)
+		return
+	}
+
 	if preparedQuery, err = s.GraphQuery.PrepareCypherQuery(payload.Query, queries.DefaultQueryFitnessLowerBoundExplore); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 		return
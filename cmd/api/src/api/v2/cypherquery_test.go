@@ -18,6 +18,7 @@ package v2_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -34,6 +35,8 @@ import (
 
 	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
 	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
 	dbmocks "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/queries"
@@ -202,6 +205,44 @@ func TestResources_CypherQuery(t *testing.T) {
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
 			},
 		},
+		{
+			name: "Error: restricted environment access - Forbidden",
+			buildRequest: func() *http.Request {
+				payload := &v2.CypherQueryPayload{
+					Query: "query",
+				}
+				jsonPayload, err := json.Marshal(payload)
+				if err != nil {
+					t.Fatalf("error occurred while marshaling payload necessary for test: %v", err)
+				}
+
+				request := &http.Request{
+					URL: &url.URL{
+						Path: "/api/v2/graphs/cypher",
+					},
+					Body: io.NopCloser(bytes.NewReader(jsonPayload)),
+					Header: http.Header{
+						headers.ContentType.String(): []string{
+							"application/json",
+						},
+					},
+					Method: http.MethodPost,
+				}
+
+				return request.WithContext(context.WithValue(context.Background(), ctx.ValueKey, &ctx.Context{
+					AuthCtx: auth.Context{Owner: model.User{PrincipalName: "scoped"}},
+				}))
+			},
+			setupMocks: func(t *testing.T, mocks *mock) {
+				t.Helper()
+				mocks.mockDatabase.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+			},
+			expected: expected{
+				responseCode:   http.StatusForbidden,
+				responseBody:   `{"errors":[{"context":"","message":"Permission denied: Cypher queries are not available to users with restricted environment access."}],"http_status":403,"request_id":"","timestamp":"0001-01-01T00:00:00Z"}`,
+				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
+			},
+		},
 		{
 			name: "Error: NeoTimeoutError - InternalServerError",
 			buildRequest: func() *http.Request {
@@ -343,6 +384,7 @@ func TestResources_CypherQuery(t *testing.T) {
 			testCase.setupMocks(t, mocks)
 
 			resources := v2.Resources{
+				DB:         mocks.mockDatabase,
 				GraphQuery: mocks.mockGraphQuery,
 				Authorizer: auth.NewAuthorizer(mocks.mockDatabase),
 			}
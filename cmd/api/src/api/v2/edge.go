@@ -36,6 +36,10 @@ const (
 )
 
 func (s *Resources) GetEdgeRelayTargets(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	var (
 		params = request.URL.Query()
 	)
@@ -72,6 +76,10 @@ func (s *Resources) GetEdgeRelayTargets(response http.ResponseWriter, request *h
 }
 
 func (s *Resources) GetEdgeComposition(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	var (
 		params = request.URL.Query()
 	)
@@ -106,6 +114,10 @@ func (s *Resources) GetEdgeComposition(response http.ResponseWriter, request *ht
 }
 
 func (s *Resources) GetEdgeACLInheritancePath(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
 	var (
 		params = request.URL.Query()
 	)
@@ -0,0 +1,228 @@
+// Copyright 2025 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/queries"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/query"
+)
+
+const ErrResponseDetailsEnvironmentRestricted = "this endpoint requires access to all environments"
+
+var (
+	errInvalidEnvironment    = errors.New("invalid environment")
+	errEnvironmentRestricted = errors.New("environment restricted")
+)
+
+// UserEnvironmentAccess describes the environments, identified by domain SID or tenant ID, a user may read from the
+// graph
+type UserEnvironmentAccess struct {
+	AllEnvironments bool     `json:"all_environments"`
+	Environments    []string `json:"environments"`
+}
+
+// environmentScope returns the environment scope of the requesting user. Requests not made on behalf of a user are
+// unrestricted.
+func (s Resources) environmentScope(request *http.Request) (api.EnvironmentScope, error) {
+	if user, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		return api.EnvironmentScope{}, nil
+	} else {
+		return api.GetEnvironmentScope(request.Context(), s.DB, user)
+	}
+}
+
+// requireAllEnvironments writes a forbidden response and returns false when the requesting user may only read some
+// environments. It guards graph reads whose results are not narrowed to an environment scope.
+func (s Resources) requireAllEnvironments(response http.ResponseWriter, request *http.Request) bool {
+	if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+		return false
+	} else if scope.Restricted {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, ErrResponseDetailsEnvironmentRestricted, request), response)
+		return false
+	}
+
+	return true
+}
+
+// checkEnvironmentAccess returns graph.ErrNoResultsFound when the node with the given object ID lies outside of the
+// scope so that callers report it the same way as a node that does not exist
+func (s Resources) checkEnvironmentAccess(requestCtx context.Context, scope api.EnvironmentScope, objectID string, kinds ...graph.Kind) error {
+	if !scope.Restricted {
+		return nil
+	} else if node, err := s.GraphQuery.GetEntityByObjectId(requestCtx, objectID, kinds...); err != nil {
+		return err
+	} else if !scope.AllowsNode(node) {
+		return graph.ErrNoResultsFound
+	}
+
+	return nil
+}
+
+// environmentNodeFilters returns the additional node filters that limit a graph read to the scope
+func environmentNodeFilters(scope api.EnvironmentScope) []graph.Criteria {
+	if criteria := scope.Criteria(query.Node()); criteria != nil {
+		return []graph.Criteria{criteria}
+	}
+
+	return nil
+}
+
+// scopeEntityQuery limits the entities returned by a related entity query to the scope
+func scopeEntityQuery(scope api.EnvironmentScope, params queries.EntityQueryParameters) queries.EntityQueryParameters {
+	if scope.Restricted {
+		params.NodeFilter = scope.FilterNodes
+		params.PathFilter = scope.FilterPaths
+	}
+
+	return params
+}
+
+// scopeNodeCriteria limits the given node criteria to the scope
+func scopeNodeCriteria(scope api.EnvironmentScope, criteria graph.Criteria) graph.Criteria {
+	if !scope.Restricted {
+		return criteria
+	}
+
+	return query.And(criteria, scope.Criteria(query.Node()))
+}
+
+// GetUserEnvironments returns the environment access list of a user
+func (s Resources) GetUserEnvironments(response http.ResponseWriter, request *http.Request) {
+	if userID, err := uuid.FromString(mux.Vars(request)[api.URIPathVariableUserID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if user, err := s.DB.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if access, err := s.userEnvironmentAccess(request.Context(), user); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), access, http.StatusOK, response)
+	}
+}
+
+// PutUserEnvironments replaces the environment access list of a user. Granting all environments clears the list.
+func (s Resources) PutUserEnvironments(response http.ResponseWriter, request *http.Request) {
+	var payload UserEnvironmentAccess
+
+	if userID, err := uuid.FromString(mux.Vars(request)[api.URIPathVariableUserID]); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if payload.AllEnvironments && len(payload.Environments) > 0 {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "environments must be empty when all_environments is true", request), response)
+	} else if environments, err := s.validateEnvironments(request.Context(), payload.Environments); err != nil {
+		if errors.Is(err, errInvalidEnvironment) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+		} else {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error validating environments: %v", err), request), response)
+		}
+	} else if user, err := s.DB.GetUser(request.Context(), userID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if payload.AllEnvironments {
+		if err := s.DB.GrantAllEnvironmentsForUser(request.Context(), user); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), UserEnvironmentAccess{AllEnvironments: true, Environments: []string{}}, http.StatusOK, response)
+		}
+	} else if err := s.DB.UpdateEnvironmentListForUser(request.Context(), user, environments...); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), UserEnvironmentAccess{Environments: environments}, http.StatusOK, response)
+	}
+}
+
+func (s Resources) userEnvironmentAccess(requestCtx context.Context, user model.User) (UserEnvironmentAccess, error) {
+	access := UserEnvironmentAccess{
+		AllEnvironments: user.AllEnvironments,
+		Environments:    []string{},
+	}
+
+	if user.AllEnvironments {
+		return access, nil
+	} else if accessList, err := s.DB.GetEnvironmentAccessListForUser(requestCtx, user); err != nil {
+		return access, err
+	} else {
+		for _, envAccess := range accessList {
+			access.Environments = append(access.Environments, envAccess.Environment)
+		}
+
+		slices.Sort(access.Environments)
+		return access, nil
+	}
+}
+
+// validateEnvironments normalizes the given environments and ensures that each one is the object ID of a collected
+// domain or tenant
+func (s Resources) validateEnvironments(requestCtx context.Context, environments []string) ([]string, error) {
+	normalized := make([]string, 0, len(environments))
+
+	for _, environment := range environments {
+		if environment = strings.ToUpper(strings.TrimSpace(environment)); environment == "" {
+			return nil, fmt.Errorf("%w: environment must not be blank", errInvalidEnvironment)
+		} else {
+			normalized = append(normalized, environment)
+		}
+	}
+
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	if len(normalized) == 0 {
+		return normalized, nil
+	} else if nodes, err := s.GraphQuery.FetchNodesByObjectIDsAndKinds(requestCtx, graph.Kinds{ad.Domain, azure.Tenant}, normalized...); err != nil {
+		return nil, err
+	} else {
+		known := make(map[string]struct{}, nodes.Len())
+
+		for _, node := range nodes {
+			if objectID, err := node.Properties.Get(common.ObjectID.String()).String(); err == nil {
+				known[objectID] = struct{}{}
+			}
+		}
+
+		var unknown []string
+
+		for _, environment := range normalized {
+			if _, found := known[environment]; !found {
+				unknown = append(unknown, environment)
+			}
+		}
+
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: no domain or tenant found for %s", errInvalidEnvironment, strings.Join(unknown, ", "))
+		}
+
+		return normalized, nil
+	}
+}
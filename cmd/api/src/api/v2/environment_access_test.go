@@ -0,0 +1,225 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	dbMocks "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	graphMocks "github.com/specterops/bloodhound/cmd/api/src/queries/mocks"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+	"github.com/specterops/dawgs/graph"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_GetUserEnvironments(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+		userID    = uuid.Must(uuid.NewV4())
+		user      = model.User{Unique: model.Unique{ID: userID}}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetUserEnvironments).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedUserID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableUserID, "not-a-uuid")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "UserNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableUserID, userID.String())
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), userID).Return(model.User{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableUserID, userID.String())
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), userID).Return(user, nil)
+					mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), user).Return([]database.EnvironmentAccess{{Environment: "TENANT-1"}, {Environment: "S-1-5-21-1"}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					var access v2.UserEnvironmentAccess
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &access)
+					apitest.Equal(output, v2.UserEnvironmentAccess{Environments: []string{"S-1-5-21-1", "TENANT-1"}}, access)
+				},
+			},
+		})
+}
+
+func TestResources_PutUserEnvironments(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		mockGraph = graphMocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+		userID    = uuid.Must(uuid.NewV4())
+		user      = model.User{Unique: model.Unique{ID: userID}}
+
+		domain = graph.NewNode(1, graph.AsProperties(map[string]any{common.ObjectID.String(): "S-1-5-21-1"}), ad.Domain)
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.PutUserEnvironments).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetURLVar(input, api.URIPathVariableUserID, userID.String())
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "EnvironmentsWithAllEnvironments",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.UserEnvironmentAccess{AllEnvironments: true, Environments: []string{"S-1-5-21-1"}})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "environments must be empty")
+				},
+			},
+			{
+				Name: "UnknownEnvironment",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.UserEnvironmentAccess{Environments: []string{"s-1-5-21-1", "S-1-5-21-2"}})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().FetchNodesByObjectIDsAndKinds(gomock.Any(), gomock.Any(), "S-1-5-21-1", "S-1-5-21-2").Return(graph.NodeSet{domain.ID: domain}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "S-1-5-21-2")
+				},
+			},
+			{
+				Name: "UpdateEnvironmentList",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.UserEnvironmentAccess{Environments: []string{" s-1-5-21-1", "S-1-5-21-1"}})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().FetchNodesByObjectIDsAndKinds(gomock.Any(), gomock.Any(), "S-1-5-21-1").Return(graph.NodeSet{domain.ID: domain}, nil)
+					mockDB.EXPECT().GetUser(gomock.Any(), userID).Return(user, nil)
+					mockDB.EXPECT().UpdateEnvironmentListForUser(gomock.Any(), user, "S-1-5-21-1").Return(nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, "S-1-5-21-1")
+				},
+			},
+			{
+				Name: "GrantAllEnvironments",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.UserEnvironmentAccess{AllEnvironments: true})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetUser(gomock.Any(), userID).Return(user, nil)
+					mockDB.EXPECT().GrantAllEnvironmentsForUser(gomock.Any(), user).Return(nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"all_environments":true`)
+				},
+			},
+		})
+}
+
+func TestResources_RequireAllEnvironments(t *testing.T) {
+	var (
+		restrictedUser = model.User{PrincipalName: "scoped", Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		handlers       = map[string]func(resources *v2.Resources) http.HandlerFunc{
+			"GetEdgeComposition":                 func(resources *v2.Resources) http.HandlerFunc { return resources.GetEdgeComposition },
+			"GetEdgeRelayTargets":                func(resources *v2.Resources) http.HandlerFunc { return resources.GetEdgeRelayTargets },
+			"GetEdgeACLInheritancePath":          func(resources *v2.Resources) http.HandlerFunc { return resources.GetEdgeACLInheritancePath },
+			"ListGraphSnapshots":                 func(resources *v2.Resources) http.HandlerFunc { return resources.ListGraphSnapshots },
+			"GetGraphDiff":                       func(resources *v2.Resources) http.HandlerFunc { return resources.GetGraphDiff },
+			"GetAssetGroupMembersByTag":          func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupMembersByTag },
+			"GetAssetGroupMembersBySelector":     func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupMembersBySelector },
+			"GetAssetGroupTagMemberCountsByKind": func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupTagMemberCountsByKind },
+			"GetAssetGroupTagMemberInfo":         func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupTagMemberInfo },
+			"CreateBlastRadiusReport":            func(resources *v2.Resources) http.HandlerFunc { return resources.CreateBlastRadiusReport },
+			"GetBlastRadiusReports":              func(resources *v2.Resources) http.HandlerFunc { return resources.GetBlastRadiusReports },
+			"GetBlastRadiusReport":               func(resources *v2.Resources) http.HandlerFunc { return resources.GetBlastRadiusReport },
+			"DownloadBlastRadiusReport":          func(resources *v2.Resources) http.HandlerFunc { return resources.DownloadBlastRadiusReport },
+			"GetAssetGroupTagViolationCounts":    func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupTagViolationCounts },
+			"GetAssetGroupTagViolations":         func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupTagViolations },
+			"PreviewSelectors":                   func(resources *v2.Resources) http.HandlerFunc { return resources.PreviewSelectors },
+			"SearchAssetGroupTags":               func(resources *v2.Resources) http.HandlerFunc { return resources.SearchAssetGroupTags },
+			"GetAssetGroupMemberCertifications":  func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupMemberCertifications },
+			"GetAssetGroupMemberCertificationHistory": func(resources *v2.Resources) http.HandlerFunc {
+				return resources.GetAssetGroupMemberCertificationHistory
+			},
+			"ListAssetGroupMembers":             func(resources *v2.Resources) http.HandlerFunc { return resources.ListAssetGroupMembers },
+			"GetAssetGroupTagWebhookDeliveries": func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupTagWebhookDeliveries },
+			"GetAssetGroupTagWebhookDelivery":   func(resources *v2.Resources) http.HandlerFunc { return resources.GetAssetGroupTagWebhookDelivery },
+			"ListAssetGroupMemberCountsByKind":  func(resources *v2.Resources) http.HandlerFunc { return resources.ListAssetGroupMemberCountsByKind },
+		}
+	)
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			var (
+				mockCtrl  = gomock.NewController(t)
+				mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+				resources = v2.Resources{DB: mockDB}
+			)
+			defer mockCtrl.Finish()
+
+			apitest.NewHarness(t, handler(&resources)).
+				Run([]apitest.Case{
+					{
+						Name: "RestrictedUser",
+						Input: func(input *apitest.Input) {
+							apitest.SetContext(input, setupUserCtx(restrictedUser))
+						},
+						Setup: func() {
+							mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), restrictedUser).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+						},
+						Test: func(output apitest.Output) {
+							apitest.StatusCode(output, http.StatusForbidden)
+							apitest.BodyContains(output, v2.ErrResponseDetailsEnvironmentRestricted)
+						},
+					},
+				})
+		})
+	}
+}
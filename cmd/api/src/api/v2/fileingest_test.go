@@ -52,11 +52,12 @@ import (
 
 func setupUser() model.User {
 	return model.User{
-		FirstName:     null.String{NullString: sql.NullString{String: "John", Valid: true}},
-		LastName:      null.String{NullString: sql.NullString{String: "Doe", Valid: true}},
-		EmailAddress:  null.String{NullString: sql.NullString{String: "johndoe@gmail.com", Valid: true}},
-		PrincipalName: "John",
-		AuthTokens:    model.AuthTokens{},
+		FirstName:       null.String{NullString: sql.NullString{String: "John", Valid: true}},
+		LastName:        null.String{NullString: sql.NullString{String: "Doe", Valid: true}},
+		EmailAddress:    null.String{NullString: sql.NullString{String: "johndoe@gmail.com", Valid: true}},
+		PrincipalName:   "John",
+		AllEnvironments: true,
+		AuthTokens:      model.AuthTokens{},
 	}
 }
 
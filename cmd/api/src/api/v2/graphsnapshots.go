@@ -0,0 +1,111 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+)
+
+const (
+	graphDiffQueryParameterFrom     = "from"
+	graphDiffQueryParameterTo       = "to"
+	graphDiffQueryParameterNodeKind = "node_kind"
+	graphDiffQueryParameterEdgeKind = "edge_kind"
+	graphDiffQueryParameterTier     = "tier"
+
+	graphDiffDefaultLimit = 100
+	graphDiffMaxLimit     = 1000
+)
+
+var graphDiffKindRegex = regexp.MustCompile(`^\w+$`)
+
+type GraphSnapshotsResponse struct {
+	Snapshots model.GraphSnapshots `json:"snapshots"`
+}
+
+// ListGraphSnapshots returns the completed graph snapshots available for diffing, newest first
+func (s Resources) ListGraphSnapshots(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	if snapshots, err := s.DB.GetGraphSnapshots(request.Context()); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), GraphSnapshotsResponse{Snapshots: snapshots}, http.StatusOK, response)
+	}
+}
+
+// GetGraphDiff returns the nodes and edges added and removed between the graph snapshots of two analysis runs
+func (s Resources) GetGraphDiff(response http.ResponseWriter, request *http.Request) {
+	if !s.requireAllEnvironments(response, request) {
+		return
+	}
+
+	var (
+		queryParams = request.URL.Query()
+		fromRunID   = queryParams.Get(graphDiffQueryParameterFrom)
+		toRunID     = queryParams.Get(graphDiffQueryParameterTo)
+		filter      = model.GraphDiffFilter{
+			NodeKinds: queryParams[graphDiffQueryParameterNodeKind],
+			EdgeKinds: queryParams[graphDiffQueryParameterEdgeKind],
+			TagKind:   queryParams.Get(graphDiffQueryParameterTier),
+		}
+	)
+
+	if fromRunID == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsMissingRequiredQueryParameter, graphDiffQueryParameterFrom), request), response)
+	} else if toRunID == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsMissingRequiredQueryParameter, graphDiffQueryParameterTo), request), response)
+	} else if err := validateGraphDiffFilter(filter); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsBadQueryParameters, err), request), response)
+	} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+	} else if limit, err := ParseLimitQueryParameter(queryParams, graphDiffDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else if limit > graphDiffMaxLimit {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, fmt.Errorf("limit must not exceed %d", graphDiffMaxLimit)), response)
+	} else if from, err := s.DB.GetGraphSnapshotByRunID(request.Context(), fromRunID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if to, err := s.DB.GetGraphSnapshotByRunID(request.Context(), toRunID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if diff, err := s.DB.GetGraphSnapshotDiff(request.Context(), from, to, filter, skip, limit); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), diff, http.StatusOK, response)
+	}
+}
+
+func validateGraphDiffFilter(filter model.GraphDiffFilter) error {
+	for _, kind := range slices.Concat(filter.NodeKinds, filter.EdgeKinds) {
+		if !graphDiffKindRegex.MatchString(kind) {
+			return fmt.Errorf("invalid kind: %s", kind)
+		}
+	}
+
+	if filter.TagKind != "" && !graphDiffKindRegex.MatchString(filter.TagKind) {
+		return fmt.Errorf("invalid tier: %s", filter.TagKind)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_ListGraphSnapshots(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.ListGraphSnapshots).
+		Run([]apitest.Case{
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetGraphSnapshots(gomock.Any()).Return(nil, errors.New("db error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Setup: func() {
+					mockDB.EXPECT().GetGraphSnapshots(gomock.Any()).Return(model.GraphSnapshots{{ID: 2, RunID: "run-2"}, {ID: 1, RunID: "run-1"}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					var result v2.GraphSnapshotsResponse
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &result)
+					require.Len(t, result.Snapshots, 2)
+					require.Equal(t, "run-2", result.Snapshots[0].RunID)
+				},
+			},
+		})
+}
+
+func TestResources_GetGraphDiff(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+
+		from = model.GraphSnapshot{ID: 1, RunID: "run-1"}
+		to   = model.GraphSnapshot{ID: 2, RunID: "run-2"}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetGraphDiff).
+		Run([]apitest.Case{
+			{
+				Name: "MissingFrom",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "to", "run-2")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "missing required query parameter: from")
+				},
+			},
+			{
+				Name: "MissingTo",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "missing required query parameter: to")
+				},
+			},
+			{
+				Name: "InvalidNodeKind",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+					apitest.AddQueryParam(input, "to", "run-2")
+					apitest.AddQueryParam(input, "node_kind", "User;")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid kind: User;")
+				},
+			},
+			{
+				Name: "InvalidTier",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+					apitest.AddQueryParam(input, "to", "run-2")
+					apitest.AddQueryParam(input, "tier", "Tag Tier Zero")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid tier")
+				},
+			},
+			{
+				Name: "LimitTooLarge",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+					apitest.AddQueryParam(input, "to", "run-2")
+					apitest.AddQueryParam(input, "limit", "1001")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "limit must not exceed 1000")
+				},
+			},
+			{
+				Name: "FromSnapshotNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+					apitest.AddQueryParam(input, "to", "run-2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetGraphSnapshotByRunID(gomock.Any(), "run-1").Return(model.GraphSnapshot{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "DiffError",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+					apitest.AddQueryParam(input, "to", "run-2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetGraphSnapshotByRunID(gomock.Any(), "run-1").Return(from, nil)
+					mockDB.EXPECT().GetGraphSnapshotByRunID(gomock.Any(), "run-2").Return(to, nil)
+					mockDB.EXPECT().GetGraphSnapshotDiff(gomock.Any(), from, to, gomock.Any(), 0, 100).Return(model.GraphDiff{}, errors.New("db error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "from", "run-1")
+					apitest.AddQueryParam(input, "to", "run-2")
+					apitest.AddQueryParam(input, "node_kind", "User")
+					apitest.AddQueryParam(input, "node_kind", "Computer")
+					apitest.AddQueryParam(input, "edge_kind", "MemberOf")
+					apitest.AddQueryParam(input, "tier", "Tag_Tier_Zero")
+					apitest.AddQueryParam(input, "skip", "10")
+					apitest.AddQueryParam(input, "limit", "5")
+				},
+				Setup: func() {
+					filter := model.GraphDiffFilter{
+						NodeKinds: []string{"User", "Computer"},
+						EdgeKinds: []string{"MemberOf"},
+						TagKind:   "Tag_Tier_Zero",
+					}
+
+					mockDB.EXPECT().GetGraphSnapshotByRunID(gomock.Any(), "run-1").Return(from, nil)
+					mockDB.EXPECT().GetGraphSnapshotByRunID(gomock.Any(), "run-2").Return(to, nil)
+					mockDB.EXPECT().GetGraphSnapshotDiff(gomock.Any(), from, to, filter, 10, 5).Return(model.GraphDiff{
+						From: from,
+						To:   to,
+						Nodes: model.GraphDiffNodes{
+							Added:      model.GraphSnapshotNodes{{ObjectID: "S-1-5-21-1", Kind: "User", Name: "ALICE", Tags: "Tag_Tier_Zero"}},
+							AddedCount: 1,
+						},
+						Edges: model.GraphDiffEdges{
+							Removed:      model.GraphSnapshotEdges{{StartObjectID: "S-1-5-21-1", Kind: "MemberOf", EndObjectID: "S-1-5-21-512"}},
+							RemovedCount: 1,
+						},
+					}, nil)
+				},
+				Test: func(output apitest.Output) {
+					var result model.GraphDiff
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &result)
+					require.Equal(t, 1, result.Nodes.AddedCount)
+					require.Equal(t, "S-1-5-21-1", result.Nodes.Added[0].ObjectID)
+					require.Equal(t, 1, result.Edges.RemovedCount)
+					require.Equal(t, "MemberOf", result.Edges.Removed[0].Kind)
+				},
+			},
+		})
+}
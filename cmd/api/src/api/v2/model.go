@@ -40,6 +40,18 @@ type ListRolesResponse struct {
 	Roles model.Roles `json:"roles"`
 }
 
+type CreateRoleRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Permissions []int32 `json:"permissions"`
+}
+
+type UpdateRoleRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Permissions []int32 `json:"permissions"`
+}
+
 type ListUsersResponse struct {
 	Users model.Users `json:"users"`
 }
@@ -75,6 +87,7 @@ type UpdateUserRequest struct {
 type CreateUserRequest struct {
 	UpdateUserRequest
 	SetUserSecretRequest
+	AllEnvironments *bool `json:"all_environments,omitempty"`
 }
 
 type DeleteSAMLProviderResponse struct {
@@ -88,8 +101,15 @@ type SetUserSecretRequest struct {
 }
 
 type CreateUserToken struct {
-	TokenName string `json:"token_name"`
-	UserID    string `json:"user_id"`
+	TokenName   string    `json:"token_name"`
+	UserID      string    `json:"user_id"`
+	ExpiresAt   null.Time `json:"expires_at"`
+	Permissions []int32   `json:"permissions"`
+}
+
+type CreateSCIMTokenRequest struct {
+	TokenName string    `json:"token_name"`
+	ExpiresAt null.Time `json:"expires_at"`
 }
 
 type CreateOIDCProviderRequest struct {
@@ -17,16 +17,27 @@
 package v2
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/specterops/bloodhound/cmd/api/src/api"
 	"github.com/specterops/bloodhound/cmd/api/src/api/bloodhoundgraph"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
 	"github.com/specterops/bloodhound/cmd/api/src/queries"
+	"github.com/specterops/bloodhound/packages/go/analysis/tiering"
 	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
 	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
 	"github.com/specterops/bloodhound/packages/go/params"
 	"github.com/specterops/bloodhound/packages/go/slicesext"
 	"github.com/specterops/dawgs/graph"
@@ -45,10 +56,12 @@ func (s Resources) GetPathfindingResult(response http.ResponseWriter, request *h
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Missing query parameter: start_node", request), response)
 	} else if endNodeObjectID == "" {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Missing query parameter: end_node", request), response)
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
 	} else if paths, err := s.GraphQuery.GetAllShortestPaths(request.Context(), startNodeObjectID, endNodeObjectID, nil); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Error: %v", err), request), response)
 	} else {
-		api.WriteBasicResponse(request.Context(), bloodhoundgraph.PathSetToBloodHoundGraph(paths), http.StatusOK, response)
+		api.WriteBasicResponse(request.Context(), bloodhoundgraph.PathSetToBloodHoundGraph(scope.FilterPaths(paths)), http.StatusOK, response)
 	}
 }
 
@@ -135,13 +148,291 @@ func (s Resources) GetShortestPath(response http.ResponseWriter, request *http.R
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Missing query parameter: end_node", request), response)
 	} else if kindFilter, err := parseRelationshipKindsParamFilter(relationshipKindsParam); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
-	} else if paths, err := s.GraphQuery.GetAllShortestPaths(request.Context(), startNode, endNode, kindFilter); err != nil {
+	} else if exclusion, err := parsePathExclusionParams(queryParams, time.Now()); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.checkPathEndpointsEnvironmentAccess(request.Context(), scope, startNode, endNode); err != nil {
+		if graph.IsErrNotFound(err) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "Path not found", request), response)
+		} else {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+		}
+	} else if paths, err := s.fetchShortestPaths(request.Context(), startNode, endNode, kindFilter, scopePathExclusion(scope, exclusion)); errors.Is(err, queries.ErrPathSearchTooLarge) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
 	} else {
 		writeShortestPathsResult(paths, response, request)
 	}
 }
 
+// fetchShortestPaths only falls back to the slower per-hop search when node exclusions were requested
+func (s Resources) fetchShortestPaths(ctx context.Context, startNode, endNode string, kindFilter graph.Criteria, exclusion graph.Criteria) (graph.PathSet, error) {
+	if exclusion == nil {
+		return s.GraphQuery.GetAllShortestPaths(ctx, startNode, endNode, kindFilter)
+	}
+
+	return s.GraphQuery.GetAllShortestPathsWithExclusions(ctx, startNode, endNode, kindFilter, exclusion)
+}
+
+// checkPathEndpointsEnvironmentAccess ensures that both ends of a requested path are in scope. Path exclusions are never
+// applied to the start or end node so they must be checked up front.
+func (s Resources) checkPathEndpointsEnvironmentAccess(ctx context.Context, scope api.EnvironmentScope, startNode, endNode string) error {
+	if err := s.checkEnvironmentAccess(ctx, scope, startNode, ad.Entity, azure.Entity); err != nil {
+		return err
+	}
+
+	return s.checkEnvironmentAccess(ctx, scope, endNode, ad.Entity, azure.Entity)
+}
+
+// scopePathExclusion additionally excludes every node outside of the scope from traversal
+func scopePathExclusion(scope api.EnvironmentScope, exclusion graph.Criteria) graph.Criteria {
+	if !scope.Restricted {
+		return exclusion
+	}
+
+	outOfScope := query.Not(scope.Criteria(query.End()))
+
+	if exclusion == nil {
+		return outOfScope
+	}
+
+	return query.Or(exclusion, outOfScope)
+}
+
+const olderThanOperator = "olderthan"
+
+// parsePathExclusionParams translates the exclude_nodes, exclude_kinds and exclude_property query parameters into
+// criteria matching the far end of a traversed relationship. Excluded nodes are OR'd together so that a node matching
+// any exclusion is avoided. A nil criteria is returned when no exclusions were requested.
+func parsePathExclusionParams(queryParams url.Values, now time.Time) (graph.Criteria, error) {
+	var exclusions []graph.Criteria
+
+	if excludeNodesParam := queryParams.Get(params.ExcludeNodes.String()); excludeNodesParam != "" {
+		objectIDs := strings.Split(strings.ReplaceAll(excludeNodesParam, " ", ""), ",")
+		exclusions = append(exclusions, query.In(query.EndProperty(common.ObjectID.String()), objectIDs))
+	}
+
+	if excludeKindsParam := queryParams.Get(params.ExcludeKinds.String()); excludeKindsParam != "" {
+		if excludeKinds, err := parseExcludeKindsParam(excludeKindsParam); err != nil {
+			return nil, err
+		} else {
+			exclusions = append(exclusions, query.KindIn(query.End(), excludeKinds...))
+		}
+	}
+
+	for _, excludePropertyParam := range queryParams[params.ExcludeProperty.String()] {
+		if propertyExclusion, err := parseExcludePropertyParam(excludePropertyParam, now); err != nil {
+			return nil, err
+		} else {
+			exclusions = append(exclusions, propertyExclusion)
+		}
+	}
+
+	if len(exclusions) == 0 {
+		return nil, nil
+	}
+
+	return query.Or(exclusions...), nil
+}
+
+func parseExcludeKindsParam(excludeKindsParam string) (graph.Kinds, error) {
+	if !params.ExcludeKinds.Regexp().MatchString(excludeKindsParam) {
+		return nil, fmt.Errorf("invalid query parameter 'exclude_kinds': acceptable values should match the format: Kind1,Kind2")
+	}
+
+	var (
+		validKinds   = graph.Kinds(ad.NodeKinds()).Concatenate(azure.NodeKinds())
+		excludeKinds graph.Kinds
+	)
+
+	for _, kindStr := range strings.Split(strings.ReplaceAll(excludeKindsParam, " ", ""), ",") {
+		kind := graph.StringKind(kindStr)
+
+		if !validKinds.ContainsOneOf(kind) {
+			return nil, fmt.Errorf("invalid query parameter 'exclude_kinds': acceptable node kinds are: %v", validKinds.Strings())
+		}
+
+		excludeKinds = append(excludeKinds, kind)
+	}
+
+	return excludeKinds, nil
+}
+
+// parseExcludePropertyParam parses a property predicate in the form property:operator:value. In addition to the
+// standard filter operators, olderthan matches epoch timestamp properties that are older than the given number of days.
+func parseExcludePropertyParam(excludePropertyParam string, now time.Time) (graph.Criteria, error) {
+	subgroups := params.ExcludeProperty.Regexp().FindStringSubmatch(excludePropertyParam)
+	if len(subgroups) == 0 {
+		return nil, fmt.Errorf("invalid query parameter 'exclude_property': acceptable values should match the format: property:eq|neq|gt|gte|lt|lte|olderthan:value")
+	}
+
+	var (
+		propertyName = subgroups[1]
+		operator     = subgroups[2]
+		rawValue     = subgroups[3]
+		propertyRef  = query.EndProperty(propertyName)
+		predicate    graph.Criteria
+	)
+
+	if operator == olderThanOperator {
+		if days, err := strconv.Atoi(rawValue); err != nil || days < 0 {
+			return nil, fmt.Errorf("invalid query parameter 'exclude_property': %s expects a non-negative number of days", olderThanOperator)
+		} else {
+			predicate = query.LessThan(propertyRef, now.AddDate(0, 0, -days).Unix())
+		}
+	} else if filterOperator, err := model.ParseFilterOperator(operator); err != nil {
+		return nil, fmt.Errorf("invalid query parameter 'exclude_property': %w", err)
+	} else {
+		predicate = model.QueryParameterFilter{
+			Name:     propertyName,
+			Operator: filterOperator,
+			Value:    rawValue,
+		}.BuildGDBPropertyFilter(propertyRef)
+	}
+
+	// Exclusions are negated during traversal; requiring the property to exist keeps nodes without it from being
+	// dropped by a null comparison
+	return query.And(query.Exists(propertyRef), predicate), nil
+}
+
+var (
+	errInvalidNodeSet = errors.New("invalid node set")
+)
+
+// ShortestPathNodeSet describes a set of nodes by any combination of object IDs, asset group tag kinds and the node
+// results of saved queries
+type ShortestPathNodeSet struct {
+	ObjectIDs     []string `json:"object_ids"`
+	TagKinds      []string `json:"tag_kinds"`
+	SavedQueryIDs []int64  `json:"saved_query_ids"`
+}
+
+func (s ShortestPathNodeSet) IsEmpty() bool {
+	return len(s.ObjectIDs) == 0 && len(s.TagKinds) == 0 && len(s.SavedQueryIDs) == 0
+}
+
+type ShortestPathsBetweenNodeSetsRequest struct {
+	Sources           ShortestPathNodeSet `json:"sources"`
+	Targets           ShortestPathNodeSet `json:"targets"`
+	RelationshipKinds string              `json:"relationship_kinds"`
+}
+
+// savedQueryNodeIDs runs the cypher of a saved query the user can access and returns the IDs of all nodes in its result
+func (s Resources) savedQueryNodeIDs(ctx context.Context, user model.User, savedQueryID int64) ([]graph.ID, error) {
+	if savedQuery, err := s.DB.GetSavedQuery(ctx, savedQueryID); err != nil {
+		return nil, err
+	} else if isAccessibleToUser, err := s.canUserAccessQuery(ctx, savedQuery, user); err != nil {
+		return nil, err
+	} else if !isAccessibleToUser {
+		return nil, database.ErrNotFound
+	} else if preparedQuery, err := s.GraphQuery.PrepareCypherQuery(savedQuery.Query, queries.DefaultQueryFitnessLowerBoundExplore); err != nil {
+		return nil, fmt.Errorf("%w: saved query %d: %v", errInvalidNodeSet, savedQueryID, err)
+	} else if preparedQuery.HasMutation {
+		return nil, fmt.Errorf("%w: saved query %d must not modify the graph", errInvalidNodeSet, savedQueryID)
+	} else if graphResponse, err := s.GraphQuery.RawCypherQuery(ctx, preparedQuery, false); err != nil {
+		return nil, err
+	} else if len(graphResponse.Nodes) > queries.MaxShortestPathNodeSetSize {
+		return nil, fmt.Errorf("%w: saved query %d returned more than %d nodes", errInvalidNodeSet, savedQueryID, queries.MaxShortestPathNodeSetSize)
+	} else {
+		nodeIDs := make([]graph.ID, 0, len(graphResponse.Nodes))
+
+		for rawNodeID := range graphResponse.Nodes {
+			if nodeID, err := strconv.ParseUint(rawNodeID, 10, 64); err != nil {
+				return nil, fmt.Errorf("saved query %d returned a malformed node id: %w", savedQueryID, err)
+			} else {
+				nodeIDs = append(nodeIDs, graph.ID(nodeID))
+			}
+		}
+
+		return nodeIDs, nil
+	}
+}
+
+// nodeSetCriteria translates a ShortestPathNodeSet into node criteria matching any member of the set
+func (s Resources) nodeSetCriteria(ctx context.Context, user model.User, nodeSet ShortestPathNodeSet) (graph.Criteria, error) {
+	var criteria []graph.Criteria
+
+	if len(nodeSet.ObjectIDs) > queries.MaxShortestPathNodeSetSize {
+		return nil, fmt.Errorf("%w: more than %d object ids", errInvalidNodeSet, queries.MaxShortestPathNodeSetSize)
+	} else if len(nodeSet.ObjectIDs) > 0 {
+		criteria = append(criteria, query.In(query.NodeProperty(common.ObjectID.String()), nodeSet.ObjectIDs))
+	}
+
+	if len(nodeSet.TagKinds) > 0 {
+		tieringEnabled := appcfg.GetTieringEnabled(ctx, s.DB)
+
+		for _, tagKind := range nodeSet.TagKinds {
+			if !strings.HasPrefix(tagKind, model.AssetGroupTagKindPrefix) {
+				return nil, fmt.Errorf("%w: tag kind %s must start with %s", errInvalidNodeSet, tagKind, model.AssetGroupTagKindPrefix)
+			}
+
+			criteria = append(criteria, tiering.SearchTagNodes(tieringEnabled, graph.StringKind(tagKind)))
+		}
+	}
+
+	for _, savedQueryID := range nodeSet.SavedQueryIDs {
+		if nodeIDs, err := s.savedQueryNodeIDs(ctx, user, savedQueryID); err != nil {
+			return nil, err
+		} else if len(nodeIDs) > 0 {
+			criteria = append(criteria, query.InIDs(query.NodeID(), nodeIDs...))
+		}
+	}
+
+	if len(criteria) == 0 {
+		// Saved queries may return no nodes, in which case nothing in the graph should match
+		return query.InIDs(query.NodeID()), nil
+	}
+
+	return query.Or(criteria...), nil
+}
+
+func handleNodeSetError(response http.ResponseWriter, request *http.Request, err error) {
+	if errors.Is(err, errInvalidNodeSet) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if errors.Is(err, database.ErrNotFound) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "saved query not found", request), response)
+	} else {
+		api.HandleDatabaseError(request, response, err)
+	}
+}
+
+// GetShortestPathsBetweenNodeSets returns the deduplicated set of shortest paths from any member of the source set to
+// any member of the target set
+func (s Resources) GetShortestPathsBetweenNodeSets(response http.ResponseWriter, request *http.Request) {
+	var (
+		payload    ShortestPathsBetweenNodeSetsRequest
+		requestCtx = request.Context()
+	)
+
+	if user, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusBadRequest, "no associated user found", request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else if payload.Sources.IsEmpty() {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusBadRequest, "Missing sources: at least one object id, tag kind or saved query id is required", request), response)
+	} else if payload.Targets.IsEmpty() {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusBadRequest, "Missing targets: at least one object id, tag kind or saved query id is required", request), response)
+	} else if kindFilter, err := parseRelationshipKindsParamFilter(payload.RelationshipKinds); err != nil {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if sourceCriteria, err := s.nodeSetCriteria(requestCtx, user, payload.Sources); err != nil {
+		handleNodeSetError(response, request, err)
+	} else if targetCriteria, err := s.nodeSetCriteria(requestCtx, user, payload.Targets); err != nil {
+		handleNodeSetError(response, request, err)
+	} else if paths, err := s.GraphQuery.GetAllShortestPathsBetweenNodeSets(requestCtx, scopeNodeCriteria(scope, sourceCriteria), scopeNodeCriteria(scope, targetCriteria), kindFilter); errors.Is(err, queries.ErrNodeSetTooLarge) {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err != nil {
+		api.WriteErrorResponse(requestCtx, api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+	} else {
+		// Relationship criteria only constrain the ends of an all shortest paths match so paths through nodes outside of
+		// the scope are dropped here
+		writeShortestPathsResult(scope.FilterPaths(paths), response, request)
+	}
+}
+
 const (
 	searchParameterQuery = "query"
 	searchParameterType  = "type"
@@ -169,10 +460,12 @@ func (s *Resources) GetSearchResult(response http.ResponseWriter, request *http.
 			}
 		}
 
-		if nodes, err := s.GraphQuery.SearchByNameOrObjectID(request.Context(), searchValue, searchType); err != nil {
+		if scope, err := s.environmentScope(request); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else if nodes, err := s.GraphQuery.SearchByNameOrObjectID(request.Context(), searchValue, searchType); err != nil {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Error getting search results: %v", err), request), response)
 		} else {
-			api.WriteBasicResponse(request.Context(), bloodhoundgraph.NodeSetToBloodHoundGraph(nodes), http.StatusOK, response)
+			api.WriteBasicResponse(request.Context(), bloodhoundgraph.NodeSetToBloodHoundGraph(scope.FilterNodes(nodes)), http.StatusOK, response)
 		}
 	}
 }
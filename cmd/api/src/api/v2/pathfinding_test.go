@@ -18,14 +18,23 @@ package v2_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/specterops/bloodhound/cmd/api/src/api"
 	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
 	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/bloodhound/cmd/api/src/queries"
 	mocks_graph "github.com/specterops/bloodhound/cmd/api/src/queries/mocks"
 	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
 	"github.com/specterops/dawgs/graph"
 	"go.uber.org/mock/gomock"
 )
@@ -170,6 +179,113 @@ func TestResources_GetShortestPath(t *testing.T) {
 					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
 				},
 			},
+			{
+				Name: "InvalidExcludeKinds",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "exclude_kinds", "User,NotAKind")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "invalid query parameter 'exclude_kinds': acceptable node kinds are")
+				},
+			},
+			{
+				Name: "MalformedExcludeProperty",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "exclude_property", "enabled=false")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "invalid query parameter 'exclude_property': acceptable values should match the format")
+				},
+			},
+			{
+				Name: "InvalidExcludePropertyDays",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "exclude_property", "lastlogontimestamp:olderthan:ninety")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "olderthan expects a non-negative number of days")
+				},
+			},
+			{
+				Name: "GraphDBGetShortestPathsWithExclusionsError",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "exclude_nodes", "compromisedID")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetAllShortestPathsWithExclusions(gomock.Any(), "someID", "someOtherID", gomock.Any(), gomock.Not(gomock.Nil())).
+						Return(nil, errors.New("graph error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "graph error")
+				},
+			},
+			{
+				Name: "GraphDBGetShortestPathsWithExclusionsTooLarge",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "exclude_nodes", "compromisedID")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetAllShortestPathsWithExclusions(gomock.Any(), "someID", "someOtherID", gomock.Any(), gomock.Not(gomock.Nil())).
+						Return(nil, fmt.Errorf("%w: more than %d shortest paths", queries.ErrPathSearchTooLarge, queries.MaxShortestPathResults))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "path search is too large")
+				},
+			},
+			{
+				Name: "SuccessWithExclusions",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "exclude_nodes", "compromisedID, remediatedID")
+					apitest.AddQueryParam(input, "exclude_kinds", "Computer")
+					apitest.AddQueryParam(input, "exclude_property", "enabled:eq:false")
+					apitest.AddQueryParam(input, "exclude_property", "lastlogontimestamp:olderthan:90")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetAllShortestPathsWithExclusions(gomock.Any(), "someID", "someOtherID", gomock.Any(), gomock.Not(gomock.Nil())).
+						Return(graph.NewPathSet(graph.Path{
+							Nodes: []*graph.Node{
+								graph.NewNode(1, graph.NewProperties(), ad.User),
+								graph.NewNode(2, graph.NewProperties(), ad.Group),
+							},
+							Edges: []*graph.Relationship{
+								graph.NewRelationship(3, 1, 2, graph.NewProperties(), ad.MemberOf),
+							},
+						}), nil)
+				},
+				Test: func(output apitest.Output) {
+					var result model.UnifiedGraph
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &result)
+					apitest.Equal(output, 2, len(result.Nodes))
+					apitest.Equal(output, 1, len(result.Edges))
+				},
+			},
 			{
 				Name: "GraphDBGetShortestPathsError",
 				Input: func(input *apitest.Input) {
@@ -331,6 +447,352 @@ func TestResources_GetShortestPath(t *testing.T) {
 		})
 }
 
+func TestResources_GetShortestPath_RestrictedEnvironments(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks_db.NewMockDatabase(mockCtrl)
+		mockGraph = mocks_graph.NewMockGraph(mockCtrl)
+		resources = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+		userCtx   = setupUserCtx(model.User{PrincipalName: "scoped"})
+
+		inScope    = graph.NewNode(1, graph.AsProperties(map[string]any{ad.DomainSID.String(): "S-1-5-21-1"}), ad.User)
+		outOfScope = graph.NewNode(2, graph.AsProperties(map[string]any{ad.DomainSID.String(): "S-1-5-21-2"}), ad.Group)
+		path       = graph.Path{
+			Nodes: []*graph.Node{inScope, inScope},
+			Edges: []*graph.Relationship{
+				graph.NewRelationship(3, 1, 1, graph.NewProperties(), ad.MemberOf),
+			},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetShortestPath).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetContext(input, userCtx)
+			apitest.AddQueryParam(input, "start_node", "start")
+			apitest.AddQueryParam(input, "end_node", "end")
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "EndpointOutOfScope",
+				Setup: func() {
+					mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+					mockGraph.EXPECT().GetEntityByObjectId(gomock.Any(), "start", gomock.Any(), gomock.Any()).Return(inScope, nil)
+					mockGraph.EXPECT().GetEntityByObjectId(gomock.Any(), "end", gomock.Any(), gomock.Any()).Return(outOfScope, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, "Path not found")
+				},
+			},
+			{
+				Name: "TraversalExcludesOutOfScopeNodes",
+				Setup: func() {
+					mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+					mockGraph.EXPECT().GetEntityByObjectId(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(inScope, nil).Times(2)
+					mockGraph.EXPECT().GetAllShortestPathsWithExclusions(gomock.Any(), "start", "end", gomock.Any(), gomock.Not(gomock.Nil())).Return(graph.PathSet{path}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+				},
+			},
+		})
+}
+
+func TestResources_GetShortestPathsBetweenNodeSets(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks_db.NewMockDatabase(mockCtrl)
+		mockGraph = mocks_graph.NewMockGraph(mockCtrl)
+		resources = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+		user      = setupUser()
+		userCtx   = setupUserCtx(user)
+
+		path = graph.Path{
+			Nodes: []*graph.Node{
+				graph.NewNode(1, graph.NewProperties(), ad.User),
+				graph.NewNode(2, graph.NewProperties(), ad.Group),
+			},
+			Edges: []*graph.Relationship{
+				graph.NewRelationship(3, 1, 2, graph.NewProperties(), ad.MemberOf),
+			},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.GetShortestPathsBetweenNodeSets).
+		Run([]apitest.Case{
+			{
+				Name: "NoUser",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "no associated user found")
+				},
+			},
+			{
+				Name: "MalformedPayload",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyString(input, `{"sources": []}`)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponsePayloadUnmarshalError)
+				},
+			},
+			{
+				Name: "MissingSources",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Targets: v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Missing sources")
+				},
+			},
+			{
+				Name: "MissingTargets",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{ObjectIDs: []string{"source"}},
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Missing targets")
+				},
+			},
+			{
+				Name: "InvalidRelationshipKinds",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources:           v2.ShortestPathNodeSet{ObjectIDs: []string{"source"}},
+						Targets:           v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+						RelationshipKinds: "in:NotAKind",
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid query parameter 'relationship_kinds'")
+				},
+			},
+			{
+				Name: "InvalidTagKind",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{ObjectIDs: []string{"source"}},
+						Targets: v2.ShortestPathNodeSet{TagKinds: []string{"Tier_Zero"}},
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureTierManagement).Return(appcfg.FeatureFlag{Enabled: true}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "tag kind Tier_Zero must start with Tag_")
+				},
+			},
+			{
+				Name: "SavedQueryNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{SavedQueryIDs: []int64{1}},
+						Targets: v2.ShortestPathNodeSet{TagKinds: []string{"Tag_Tier_Zero"}},
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(model.SavedQuery{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, "saved query not found")
+				},
+			},
+			{
+				Name: "SavedQueryNotAccessible",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{SavedQueryIDs: []int64{1}},
+						Targets: v2.ShortestPathNodeSet{TagKinds: []string{"Tag_Tier_Zero"}},
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(model.SavedQuery{UserID: "someone-else", BigSerial: model.BigSerial{ID: 1}}, nil)
+					mockDB.EXPECT().IsSavedQuerySharedToUserOrPublic(gomock.Any(), int64(1), user.ID).Return(false, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "SavedQueryMutation",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{SavedQueryIDs: []int64{1}},
+						Targets: v2.ShortestPathNodeSet{TagKinds: []string{"Tag_Tier_Zero"}},
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(model.SavedQuery{UserID: user.ID.String(), Query: "match (n) set n.x = 1"}, nil)
+					mockGraph.EXPECT().PrepareCypherQuery(gomock.Any(), gomock.Any()).Return(queries.PreparedQuery{HasMutation: true}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "must not modify the graph")
+				},
+			},
+			{
+				Name: "TooManyObjectIDs",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{ObjectIDs: make([]string, queries.MaxShortestPathNodeSetSize+1)},
+						Targets: v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+					})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "object ids")
+				},
+			},
+			{
+				Name: "SavedQueryTooLarge",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{SavedQueryIDs: []int64{1}},
+						Targets: v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+					})
+				},
+				Setup: func() {
+					nodes := make(map[string]model.UnifiedNode, queries.MaxShortestPathNodeSetSize+1)
+					for nodeID := 0; nodeID <= queries.MaxShortestPathNodeSetSize; nodeID++ {
+						nodes[strconv.Itoa(nodeID)] = model.UnifiedNode{}
+					}
+
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(model.SavedQuery{UserID: user.ID.String(), Query: "match (n) return n"}, nil)
+					mockGraph.EXPECT().PrepareCypherQuery(gomock.Any(), gomock.Any()).Return(queries.PreparedQuery{}, nil)
+					mockGraph.EXPECT().RawCypherQuery(gomock.Any(), gomock.Any(), false).Return(model.UnifiedGraph{Nodes: nodes}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "returned more than")
+				},
+			},
+			{
+				Name: "NodeSetTooLarge",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{TagKinds: []string{"Tag_Tier_Zero"}},
+						Targets: v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureTierManagement).Return(appcfg.FeatureFlag{Enabled: true}, nil)
+					mockGraph.EXPECT().
+						GetAllShortestPathsBetweenNodeSets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, fmt.Errorf("%w: sources match more than %d nodes", queries.ErrNodeSetTooLarge, queries.MaxShortestPathNodeSetSize))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "node set is too large")
+				},
+			},
+			{
+				Name: "GraphDBError",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{ObjectIDs: []string{"source"}},
+						Targets: v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+					})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetAllShortestPathsBetweenNodeSets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, errors.New("graph error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.BodyContains(output, "graph error")
+				},
+			},
+			{
+				Name: "NoPathFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources: v2.ShortestPathNodeSet{ObjectIDs: []string{"source"}},
+						Targets: v2.ShortestPathNodeSet{ObjectIDs: []string{"target"}},
+					})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetAllShortestPathsBetweenNodeSets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(graph.NewPathSet(), nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, v2.ShortestPathsBetweenNodeSetsRequest{
+						Sources:           v2.ShortestPathNodeSet{ObjectIDs: []string{"source"}, SavedQueryIDs: []int64{1}},
+						Targets:           v2.ShortestPathNodeSet{TagKinds: []string{"Tag_Tier_Zero"}},
+						RelationshipKinds: "in:MemberOf",
+					})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(model.SavedQuery{UserID: user.ID.String(), Query: "match (n) return n"}, nil)
+					mockGraph.EXPECT().PrepareCypherQuery(gomock.Any(), gomock.Any()).Return(queries.PreparedQuery{}, nil)
+					mockGraph.EXPECT().RawCypherQuery(gomock.Any(), gomock.Any(), false).Return(model.UnifiedGraph{Nodes: map[string]model.UnifiedNode{"1": {}}}, nil)
+					mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureTierManagement).Return(appcfg.FeatureFlag{Enabled: true}, nil)
+					mockGraph.EXPECT().
+						GetAllShortestPathsBetweenNodeSets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(graph.NewPathSet(path), nil)
+				},
+				Test: func(output apitest.Output) {
+					var result model.UnifiedGraph
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &result)
+					apitest.Equal(output, 2, len(result.Nodes))
+					apitest.Equal(output, 1, len(result.Edges))
+				},
+			},
+		})
+}
+
 func TestResources_GetSearchResult(t *testing.T) {
 	var (
 		mockCtrl  = gomock.NewController(t)
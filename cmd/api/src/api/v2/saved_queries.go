@@ -481,6 +481,20 @@ func (s Resources) CreateSavedQuery(response http.ResponseWriter, request *http.
 	}
 }
 
+// canUserEditQuery - Users other than the owner can update and delete a query if they are an admin and the query is
+// public, or the query was shared to them or one of their roles with edit rights.
+func (s Resources) canUserEditQuery(ctx context.Context, savedQueryID int64, user model.User) (bool, error) {
+	if user.Roles.Has(model.Role{Name: auth.RoleAdministrator}) {
+		if isPublic, err := s.DB.IsSavedQueryPublic(ctx, savedQueryID); err != nil {
+			return false, err
+		} else if isPublic {
+			return true, nil
+		}
+	}
+
+	return s.DB.IsSavedQueryEditableByUser(ctx, savedQueryID, user.ID)
+}
+
 func (s Resources) UpdateSavedQuery(response http.ResponseWriter, request *http.Request) {
 	var (
 		rawSavedQueryID = mux.Vars(request)[api.URIPathVariableSavedQueryID]
@@ -502,17 +516,12 @@ func (s Resources) UpdateSavedQuery(response http.ResponseWriter, request *http.
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
 		return
 	} else if savedQuery.UserID != user.ID.String() {
-		if !user.Roles.Has(model.Role{Name: auth.RoleAdministrator}) {
+		if canEdit, err := s.canUserEditQuery(request.Context(), savedQuery.ID, user); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+			return
+		} else if !canEdit {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "query does not exist", request), response)
 			return
-		} else {
-			if isPublic, err := s.DB.IsSavedQueryPublic(request.Context(), savedQuery.ID); err != nil {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
-				return
-			} else if !isPublic {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "query does not exist", request), response)
-				return
-			}
 		}
 	}
 
@@ -548,13 +557,10 @@ func (s Resources) DeleteSavedQuery(response http.ResponseWriter, request *http.
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
 	} else {
 		if !savedQueryBelongsToUser {
-			if _, isAdmin := user.Roles.FindByName(auth.RoleAdministrator); !isAdmin {
-				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "User does not have permission to delete this query", request), response)
-				return
-			} else if isPublicQuery, err := s.DB.IsSavedQueryPublic(request.Context(), savedQueryID); err != nil {
+			if canEdit, err := s.canUserEditQuery(request.Context(), savedQueryID, user); err != nil {
 				api.HandleDatabaseError(request, response, err)
 				return
-			} else if !isPublicQuery {
+			} else if !canEdit {
 				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "User does not have permission to delete this query", request), response)
 				return
 			}
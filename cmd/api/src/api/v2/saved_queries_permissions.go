@@ -36,24 +36,34 @@ import (
 
 type ShareSavedQueriesResponse []model.SavedQueriesPermissions
 
+// SavedQueryPermissionRequest shares a saved query publicly or to users and roles. Users and roles are granted read
+// access unless CanEdit is set, which also allows them to update and delete the query. Public queries are read only.
 type SavedQueryPermissionRequest struct {
 	UserIDs []uuid.UUID `json:"user_ids"`
+	RoleIDs []int32     `json:"role_ids"`
 	Public  bool        `json:"public"`
+	CanEdit bool        `json:"can_edit"`
+}
+
+// hasRecipients returns true if the request shares the query to any users or roles
+func (s SavedQueryPermissionRequest) hasRecipients() bool {
+	return len(s.UserIDs) > 0 || len(s.RoleIDs) > 0
 }
 
 var (
 	ErrInvalidSelfShare   = errors.New("invalidSelfShare")
 	ErrForbidden          = errors.New("forbidden")
 	ErrInvalidPublicShare = errors.New("invalidPublicShare")
+	ErrUnknownRole        = errors.New("unknownRole")
 )
 
 func CanUpdateSavedQueriesPermission(user model.User, savedQueryBelongsToUser bool, createRequest SavedQueryPermissionRequest, dbSavedQueryScope database.SavedQueryScopeMap) error {
 	if user.Roles.Has(model.Role{Name: auth.RoleAdministrator}) {
 		if createRequest.Public && savedQueryBelongsToUser {
 			return nil
-		} else if len(createRequest.UserIDs) == 0 && (savedQueryBelongsToUser || dbSavedQueryScope[model.SavedQueryScopePublic]) {
+		} else if !createRequest.hasRecipients() && (savedQueryBelongsToUser || dbSavedQueryScope[model.SavedQueryScopePublic]) {
 			return nil
-		} else if len(createRequest.UserIDs) > 0 && !createRequest.Public {
+		} else if createRequest.hasRecipients() && !createRequest.Public {
 			if dbSavedQueryScope[model.SavedQueryScopePublic] {
 				return ErrInvalidPublicShare
 			}
@@ -67,7 +77,7 @@ func CanUpdateSavedQueriesPermission(user model.User, savedQueryBelongsToUser bo
 			}
 		}
 	} else if savedQueryBelongsToUser && !dbSavedQueryScope[model.SavedQueryScopePublic] {
-		if len(createRequest.UserIDs) > 0 && !createRequest.Public {
+		if createRequest.hasRecipients() && !createRequest.Public {
 			for _, sharedUserID := range createRequest.UserIDs {
 				if sharedUserID == user.ID {
 					return ErrInvalidSelfShare
@@ -83,6 +93,9 @@ type SavedQueryPermissionResponse struct {
 	QueryID         int64       `json:"query_id"`
 	Public          bool        `json:"public"`
 	SharedToUserIDs []uuid.UUID `json:"shared_to_user_ids"`
+	SharedToRoleIDs []int32     `json:"shared_to_role_ids"`
+	EditorUserIDs   []uuid.UUID `json:"editor_user_ids"`
+	EditorRoleIDs   []int32     `json:"editor_role_ids"`
 }
 
 func (s *SavedQueryPermissionResponse) appendUserId(userId uuid.NullUUID) {
@@ -94,6 +107,24 @@ func (s *SavedQueryPermissionResponse) appendUserId(userId uuid.NullUUID) {
 	}
 }
 
+// appendPermission adds the user or role recipient of a saved query permission, including it in the editors when the
+// permission grants edit rights
+func (s *SavedQueryPermissionResponse) appendPermission(permission model.SavedQueriesPermissions) {
+	s.appendUserId(permission.SharedToUserID)
+
+	if permission.SharedToRoleID.Valid {
+		s.SharedToRoleIDs = append(s.SharedToRoleIDs, permission.SharedToRoleID.Int32)
+	}
+
+	if permission.CanEdit {
+		if permission.SharedToUserID.Valid {
+			s.EditorUserIDs = append(s.EditorUserIDs, permission.SharedToUserID.UUID)
+		} else if permission.SharedToRoleID.Valid {
+			s.EditorRoleIDs = append(s.EditorRoleIDs, permission.SharedToRoleID.Int32)
+		}
+	}
+}
+
 // GetSavedQueryPermissions - returns the query permissions for users who own the query or admins.
 // Public queries will return for any user with no attached user ids.
 // QueryPermissions indicate if the query is public or private, and if private, who the query is shared with.
@@ -117,10 +148,13 @@ func (s Resources) GetSavedQueryPermissions(response http.ResponseWriter, reques
 			QueryID:         savedQueryID,
 			Public:          savedQueryPermissions[0].Public,
 			SharedToUserIDs: make([]uuid.UUID, 0),
+			SharedToRoleIDs: make([]int32, 0),
+			EditorUserIDs:   make([]uuid.UUID, 0),
+			EditorRoleIDs:   make([]int32, 0),
 		}
 		if !savedQueryPermissionResponse.Public {
 			for _, savedQueryPermission := range savedQueryPermissions {
-				savedQueryPermissionResponse.appendUserId(savedQueryPermission.SharedToUserID)
+				savedQueryPermissionResponse.appendPermission(savedQueryPermission)
 			}
 		}
 		api.WriteBasicResponse(request.Context(), savedQueryPermissionResponse, http.StatusOK, response)
@@ -154,6 +188,10 @@ func (s Resources) ShareSavedQueries(response http.ResponseWriter, request *http
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else if createRequest.Public && len(createRequest.UserIDs) > 0 {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Public cannot be true while user_ids is populated", request), response)
+	} else if createRequest.Public && len(createRequest.RoleIDs) > 0 {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Public cannot be true while role_ids is populated", request), response)
+	} else if createRequest.CanEdit && !createRequest.hasRecipients() {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "can_edit requires user_ids or role_ids to be populated", request), response)
 	} else if savedQueryBelongsToUser, err := s.DB.SavedQueryBelongsToUser(request.Context(), user.ID, savedQueryID); errors.Is(err, database.ErrNotFound) {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "Query does not exist", request), response)
 	} else if err != nil {
@@ -181,36 +219,73 @@ func (s Resources) ShareSavedQueries(response http.ResponseWriter, request *http
 				}
 			}
 			// Query set to private
-		} else if len(createRequest.UserIDs) == 0 {
+		} else if !createRequest.hasRecipients() {
 			if err := s.DB.DeleteSavedQueryPermissionsForUsers(request.Context(), savedQueryID); err != nil {
 				api.HandleDatabaseError(request, response, err)
 			} else {
 				response.WriteHeader(http.StatusNoContent)
 			}
 			// Sharing a query
-		} else if len(createRequest.UserIDs) > 0 && !createRequest.Public {
+		} else if createRequest.hasRecipients() && !createRequest.Public {
 			if dbSavedQueryScope[model.SavedQueryScopePublic] {
 				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Public query cannot be shared to users. You must set your query to private first", request), response)
+			} else if savedPermissions, err := s.shareSavedQuery(request.Context(), savedQueryID, createRequest); errors.Is(err, ErrUnknownRole) {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "role_ids contains a role that does not exist", request), response)
+			} else if err != nil {
+				api.HandleDatabaseError(request, response, err)
 			} else {
-				if savedPermissions, err := s.DB.CreateSavedQueryPermissionsToUsers(request.Context(), savedQueryID, createRequest.UserIDs...); err != nil {
-					api.HandleDatabaseError(request, response, err)
-				} else {
-					api.WriteBasicResponse(request.Context(), savedPermissions, http.StatusCreated, response)
+				api.WriteBasicResponse(request.Context(), savedPermissions, http.StatusCreated, response)
+			}
+		}
+	}
+}
+
+// shareSavedQuery grants the users and roles of the request access to a saved query
+func (s Resources) shareSavedQuery(ctx context.Context, savedQueryID int64, createRequest SavedQueryPermissionRequest) ([]model.SavedQueriesPermissions, error) {
+	var savedPermissions []model.SavedQueriesPermissions
+
+	if len(createRequest.RoleIDs) > 0 {
+		if roles, err := s.DB.GetRoles(ctx, createRequest.RoleIDs); err != nil {
+			return nil, err
+		} else {
+			for _, roleID := range createRequest.RoleIDs {
+				if !slices.Contains(roles.IDs(), roleID) {
+					return nil, ErrUnknownRole
 				}
 			}
 		}
 	}
+
+	if len(createRequest.UserIDs) > 0 {
+		if userPermissions, err := s.DB.CreateSavedQueryPermissionsToUsers(ctx, savedQueryID, createRequest.CanEdit, createRequest.UserIDs...); err != nil {
+			return nil, err
+		} else {
+			savedPermissions = append(savedPermissions, userPermissions...)
+		}
+	}
+
+	if len(createRequest.RoleIDs) > 0 {
+		if rolePermissions, err := s.DB.CreateSavedQueryPermissionsToRoles(ctx, savedQueryID, createRequest.CanEdit, createRequest.RoleIDs...); err != nil {
+			return nil, err
+		} else {
+			savedPermissions = append(savedPermissions, rolePermissions...)
+		}
+	}
+
+	return savedPermissions, nil
 }
 
 // DeleteSavedQueryPermissionsRequest represents the payload sent to the unshare endpoint
 type DeleteSavedQueryPermissionsRequest struct {
 	UserIds []uuid.UUID `json:"user_ids"`
+	RoleIDs []int32     `json:"role_ids"`
 }
 
 // DeleteSavedQueryPermissions allows an owner of a shared query, a user that has a saved query shared to them, or an admin, to remove sharing privileges.
 // A user who owns a query may unshare a query from anyone they have shared to
 // A user who had a query shared to them may unshare that query from themselves
 // And admins may unshare queries that have been shared to other users
+// Only owners and admins may unshare a query from roles
 func (s Resources) DeleteSavedQueryPermissions(response http.ResponseWriter, request *http.Request) {
 	var (
 		rawSavedQueryID = mux.Vars(request)[api.URIPathVariableSavedQueryID]
@@ -225,7 +300,8 @@ func (s Resources) DeleteSavedQueryPermissions(response http.ResponseWriter, req
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else {
 		// Check if the user is attempting to unshare a query from themselves
-		if slices.Contains(deleteRequest.UserIds, user.ID) {
+		isSelfUnshare := slices.Contains(deleteRequest.UserIds, user.ID)
+		if isSelfUnshare {
 			if isShared, err := s.DB.IsSavedQuerySharedToUser(request.Context(), savedQueryID, user.ID); err != nil {
 				api.HandleDatabaseError(request, response, err)
 				return
@@ -234,8 +310,10 @@ func (s Resources) DeleteSavedQueryPermissions(response http.ResponseWriter, req
 				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "User cannot unshare a query from themselves that is not shared to them", request), response)
 				return
 			}
-		} else {
-			// User is attempting to unshare a query from another user
+		}
+
+		if !isSelfUnshare || len(deleteRequest.RoleIDs) > 0 {
+			// User is attempting to unshare a query from other users or roles
 			isAdmin := user.Roles.Has(model.Role{Name: auth.RoleAdministrator})
 			if !isAdmin {
 				// If a user is not admin, then they need to own the query in order to unshare it
@@ -250,10 +328,18 @@ func (s Resources) DeleteSavedQueryPermissions(response http.ResponseWriter, req
 
 		}
 
-		// Unshare the queries
-		if err = s.DB.DeleteSavedQueryPermissionsForUsers(request.Context(), savedQueryID, deleteRequest.UserIds...); err != nil {
-			api.HandleDatabaseError(request, response, err)
-			return
+		// Unshare the queries. Without any users or roles, every share of the query is removed
+		if len(deleteRequest.UserIds) > 0 || len(deleteRequest.RoleIDs) == 0 {
+			if err = s.DB.DeleteSavedQueryPermissionsForUsers(request.Context(), savedQueryID, deleteRequest.UserIds...); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			}
+		}
+		if len(deleteRequest.RoleIDs) > 0 {
+			if err = s.DB.DeleteSavedQueryPermissionsForRoles(request.Context(), savedQueryID, deleteRequest.RoleIDs...); err != nil {
+				api.HandleDatabaseError(request, response, err)
+				return
+			}
 		}
 		response.WriteHeader(http.StatusNoContent)
 	}
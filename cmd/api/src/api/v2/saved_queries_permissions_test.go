@@ -37,6 +37,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/auth"
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/test/must"
 	"github.com/specterops/bloodhound/cmd/api/src/utils/test"
@@ -592,7 +593,7 @@ func TestResources_ShareSavedQueriesPermissions_SavingPermissionsErrors(t *testi
 		model.SavedQueryScopePublic: false,
 		model.SavedQueryScopeShared: false,
 	}, nil)
-	mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), userId2, userId3).Return(nil, fmt.Errorf("Error!"))
+	mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), false, userId2, userId3).Return(nil, fmt.Errorf("Error!"))
 
 	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "PUT", fmt.Sprintf(endpoint, savedQueryId), must.MarshalJSONReader(payload))
 	require.Nil(t, err)
@@ -660,7 +661,7 @@ func TestResources_ShareSavedQueriesPermissions_NonAdmin(t *testing.T) {
 			model.SavedQueryScopePublic: false,
 			model.SavedQueryScopeShared: false,
 		}, nil)
-		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), userId2).Return([]model.SavedQueriesPermissions{
+		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), false, userId2).Return([]model.SavedQueriesPermissions{
 			{
 				QueryID:        int64(1),
 				Public:         false,
@@ -691,7 +692,7 @@ func TestResources_ShareSavedQueriesPermissions_NonAdmin(t *testing.T) {
 			model.SavedQueryScopePublic: false,
 			model.SavedQueryScopeShared: true,
 		}, nil)
-		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), userId2, userId3).Return([]model.SavedQueriesPermissions{
+		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), false, userId2, userId3).Return([]model.SavedQueriesPermissions{
 			{
 				QueryID:        int64(1),
 				Public:         false,
@@ -773,7 +774,7 @@ func TestResources_ShareSavedQueriesPermissions_NonAdmin(t *testing.T) {
 			model.SavedQueryScopePublic: false,
 			model.SavedQueryScopeShared: true,
 		}, nil)
-		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), userId2).Return([]model.SavedQueriesPermissions{
+		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), false, userId2).Return([]model.SavedQueriesPermissions{
 			{
 				QueryID:        int64(1),
 				Public:         false,
@@ -932,7 +933,7 @@ func TestResources_ShareSavedQueriesPermissions_NonAdmin(t *testing.T) {
 			model.SavedQueryScopePublic: false,
 			model.SavedQueryScopeShared: false,
 		}, nil)
-		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), userId2, userId3).Return([]model.SavedQueriesPermissions{
+		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), false, userId2, userId3).Return([]model.SavedQueriesPermissions{
 			{
 				QueryID:        int64(1),
 				Public:         false,
@@ -1210,7 +1211,7 @@ func TestResources_ShareSavedQueriesPermissions_Admin(t *testing.T) {
 			model.SavedQueryScopePublic: false,
 			model.SavedQueryScopeShared: false,
 		}, nil)
-		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), nonAdminUserId, nonAdminUserId2).Return([]model.SavedQueriesPermissions{
+		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), gomock.Any(), false, nonAdminUserId, nonAdminUserId2).Return([]model.SavedQueriesPermissions{
 			{
 				QueryID:        int64(1),
 				Public:         false,
@@ -1760,7 +1761,7 @@ func TestResources_GetPermissionsForSavedQuery(t *testing.T) {
 			expect: expected{
 				responseCode:   http.StatusOK,
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
-				responseBody:   fmt.Sprintf(`{"data":{"query_id":1,"public":false,"shared_to_user_ids":["%s"]}}`, user2Id),
+				responseBody:   fmt.Sprintf(`{"data":{"query_id":1,"public":false,"shared_to_user_ids":["%s"],"shared_to_role_ids":[],"editor_user_ids":[],"editor_role_ids":[]}}`, user2Id),
 			},
 		},
 		{
@@ -1782,7 +1783,7 @@ func TestResources_GetPermissionsForSavedQuery(t *testing.T) {
 			expect: expected{
 				responseCode:   http.StatusOK,
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
-				responseBody:   fmt.Sprintf(`{"data":{"query_id":1,"public":false,"shared_to_user_ids":["%s"]}}`, user2Id),
+				responseBody:   fmt.Sprintf(`{"data":{"query_id":1,"public":false,"shared_to_user_ids":["%s"],"shared_to_role_ids":[],"editor_user_ids":[],"editor_role_ids":[]}}`, user2Id),
 			},
 		},
 		{
@@ -1804,7 +1805,34 @@ func TestResources_GetPermissionsForSavedQuery(t *testing.T) {
 			expect: expected{
 				responseCode:   http.StatusOK,
 				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
-				responseBody:   `{"data":{"query_id":2,"public":true,"shared_to_user_ids":[]}}`,
+				responseBody:   `{"data":{"query_id":2,"public":true,"shared_to_user_ids":[],"shared_to_role_ids":[],"editor_user_ids":[],"editor_role_ids":[]}}`,
+			},
+		},
+		{
+			name: "success - shared to roles with edit rights",
+			fields: fields{
+				setupMocks: func(t *testing.T, mock *mock) {
+					t.Helper()
+					mock.mockDatabase.EXPECT().GetSavedQueryPermissions(gomock.Any(), int64(1)).Return([]model.SavedQueriesPermissions{
+						{QueryID: 1, SharedToUserID: uuid.NullUUID{UUID: user2Id, Valid: true}, CanEdit: true},
+						{QueryID: 1, SharedToRoleID: null.Int32From(3)},
+						{QueryID: 1, SharedToRoleID: null.Int32From(4), CanEdit: true},
+					}, nil)
+					mock.mockDatabase.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(testSavedQuery1, nil)
+				},
+			},
+			args: args{
+				buildRequest: func() *http.Request {
+					req, err := http.NewRequestWithContext(createContextWithOwnerId(user1Id), http.MethodGet, "/api/v2/saved-queries/1/permissions", nil)
+					require.NoError(t, err)
+					req = mux.SetURLVars(req, map[string]string{api.URIPathVariableSavedQueryID: "1"})
+					return req
+				},
+			},
+			expect: expected{
+				responseCode:   http.StatusOK,
+				responseHeader: http.Header{"Content-Type": []string{"application/json"}},
+				responseBody:   fmt.Sprintf(`{"data":{"query_id":1,"public":false,"shared_to_user_ids":["%s"],"shared_to_role_ids":[3,4],"editor_user_ids":["%s"],"editor_role_ids":[4]}}`, user2Id, user2Id),
 			},
 		},
 	}
@@ -1833,3 +1861,148 @@ func TestResources_GetPermissionsForSavedQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestResources_ShareSavedQueriesPermissions_Roles(t *testing.T) {
+	endpoint := "/api/v2/saved-queries/%s/permissions"
+	savedQueryId := "1"
+	userId, err := uuid.NewV4()
+	require.Nil(t, err)
+	userId2, err := uuid.NewV4()
+	require.Nil(t, err)
+
+	ownedScope := database.SavedQueryScopeMap{
+		model.SavedQueryScopeOwned:  true,
+		model.SavedQueryScopePublic: false,
+		model.SavedQueryScopeShared: false,
+	}
+
+	shareSavedQuery := func(t *testing.T, resources v2.Resources, payload v2.SavedQueryPermissionRequest) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "PUT", fmt.Sprintf(endpoint, savedQueryId), must.MarshalJSONReader(payload))
+		require.Nil(t, err)
+		req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/v2/saved-queries/{saved_query_id}/permissions", resources.ShareSavedQueries).Methods("PUT")
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, req)
+		return response
+	}
+
+	t.Run("Query set to public and shared to role(s) at same time error", func(t *testing.T) {
+		var (
+			mockCtrl  = gomock.NewController(t)
+			mockDB    = mocks.NewMockDatabase(mockCtrl)
+			resources = v2.Resources{DB: mockDB}
+		)
+
+		response := shareSavedQuery(t, resources, v2.SavedQueryPermissionRequest{RoleIDs: []int32{3}, Public: true})
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "Public cannot be true while role_ids is populated")
+	})
+
+	t.Run("Edit rights without recipients error", func(t *testing.T) {
+		var (
+			mockCtrl  = gomock.NewController(t)
+			mockDB    = mocks.NewMockDatabase(mockCtrl)
+			resources = v2.Resources{DB: mockDB}
+		)
+
+		response := shareSavedQuery(t, resources, v2.SavedQueryPermissionRequest{CanEdit: true})
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "can_edit requires user_ids or role_ids to be populated")
+	})
+
+	t.Run("Shared to unknown role error", func(t *testing.T) {
+		var (
+			mockCtrl  = gomock.NewController(t)
+			mockDB    = mocks.NewMockDatabase(mockCtrl)
+			resources = v2.Resources{DB: mockDB}
+		)
+
+		mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), userId, int64(1)).Return(true, nil)
+		mockDB.EXPECT().GetScopeForSavedQuery(gomock.Any(), int64(1), userId).Return(ownedScope, nil)
+		mockDB.EXPECT().GetRoles(gomock.Any(), []int32{3, 99}).Return(model.Roles{{Serial: model.Serial{ID: 3}}}, nil)
+
+		response := shareSavedQuery(t, resources, v2.SavedQueryPermissionRequest{RoleIDs: []int32{3, 99}})
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "role_ids contains a role that does not exist")
+	})
+
+	t.Run("Shared to users and roles with edit rights success", func(t *testing.T) {
+		var (
+			mockCtrl  = gomock.NewController(t)
+			mockDB    = mocks.NewMockDatabase(mockCtrl)
+			resources = v2.Resources{DB: mockDB}
+		)
+
+		mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), userId, int64(1)).Return(true, nil)
+		mockDB.EXPECT().GetScopeForSavedQuery(gomock.Any(), int64(1), userId).Return(ownedScope, nil)
+		mockDB.EXPECT().GetRoles(gomock.Any(), []int32{3}).Return(model.Roles{{Serial: model.Serial{ID: 3}}}, nil)
+		mockDB.EXPECT().CreateSavedQueryPermissionsToUsers(gomock.Any(), int64(1), true, userId2).Return([]model.SavedQueriesPermissions{
+			{QueryID: 1, SharedToUserID: database.NullUUID(userId2), CanEdit: true},
+		}, nil)
+		mockDB.EXPECT().CreateSavedQueryPermissionsToRoles(gomock.Any(), int64(1), true, int32(3)).Return([]model.SavedQueriesPermissions{
+			{QueryID: 1, SharedToRoleID: null.Int32From(3), CanEdit: true},
+		}, nil)
+
+		response := shareSavedQuery(t, resources, v2.SavedQueryPermissionRequest{UserIDs: []uuid.UUID{userId2}, RoleIDs: []int32{3}, CanEdit: true})
+		require.Equal(t, http.StatusCreated, response.Code)
+
+		var body struct {
+			Data []model.SavedQueriesPermissions `json:"data"`
+		}
+		require.Nil(t, json.Unmarshal(response.Body.Bytes(), &body))
+		require.Len(t, body.Data, 2)
+		require.Equal(t, userId2, body.Data[0].SharedToUserID.UUID)
+		require.Equal(t, int32(3), body.Data[1].SharedToRoleID.Int32)
+		require.True(t, body.Data[1].CanEdit)
+	})
+}
+
+func TestResources_DeleteSavedQueryPermissions_Roles(t *testing.T) {
+	endpoint := "/api/v2/saved-queries/{%s}/permissions"
+	savedQueryId := "1"
+	userId, err := uuid.NewV4()
+	require.Nil(t, err)
+
+	deleteSavedQueryPermissions := func(t *testing.T, resources v2.Resources, payload v2.DeleteSavedQueryPermissionsRequest) *httptest.ResponseRecorder {
+		req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), http.MethodDelete, fmt.Sprintf(endpoint, savedQueryId), must.MarshalJSONReader(payload))
+		require.Nil(t, err)
+
+		req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		req = mux.SetURLVars(req, map[string]string{api.URIPathVariableSavedQueryID: savedQueryId})
+
+		response := httptest.NewRecorder()
+		http.HandlerFunc(resources.DeleteSavedQueryPermissions).ServeHTTP(response, req)
+		return response
+	}
+
+	t.Run("owner can unshare their query from roles", func(t *testing.T) {
+		var (
+			mockCtrl  = gomock.NewController(t)
+			mockDB    = mocks.NewMockDatabase(mockCtrl)
+			resources = v2.Resources{DB: mockDB}
+		)
+
+		mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), userId, int64(1)).Return(true, nil)
+		mockDB.EXPECT().DeleteSavedQueryPermissionsForRoles(gomock.Any(), int64(1), int32(3), int32(4)).Return(nil)
+
+		response := deleteSavedQueryPermissions(t, resources, v2.DeleteSavedQueryPermissionsRequest{RoleIDs: []int32{3, 4}})
+		require.Equal(t, http.StatusNoContent, response.Code)
+	})
+
+	t.Run("user cannot unshare roles from a query they do not own", func(t *testing.T) {
+		var (
+			mockCtrl  = gomock.NewController(t)
+			mockDB    = mocks.NewMockDatabase(mockCtrl)
+			resources = v2.Resources{DB: mockDB}
+		)
+
+		mockDB.EXPECT().IsSavedQuerySharedToUser(gomock.Any(), int64(1), userId).Return(true, nil)
+		mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), userId, int64(1)).Return(false, nil)
+
+		response := deleteSavedQueryPermissions(t, resources, v2.DeleteSavedQueryPermissionsRequest{UserIds: []uuid.UUID{userId}, RoleIDs: []int32{3}})
+		require.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
@@ -458,6 +458,9 @@ func TestResources_UpdateSavedQuery_QueryBelongsToAnotherUser(t *testing.T) {
 	// query belongs to another user
 	mockDB.EXPECT().GetSavedQuery(gomock.Any(), gomock.Any()).Return(model.SavedQuery{UserID: "notThisUser"}, nil)
 
+	// query is not shared to the user with edit rights
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
 	var payload any
 
 	// context owner is not an admin
@@ -501,6 +504,9 @@ func TestResources_UpdateSavedQuery_Admin_NonPublicQuery(t *testing.T) {
 	// query is not public
 	mockDB.EXPECT().IsSavedQueryPublic(gomock.Any(), gomock.Any()).Return(false, nil)
 
+	// query is not shared to the user with edit rights
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
 	var payload any
 
 	// context owner is an admin
@@ -537,6 +543,9 @@ func TestResources_UpdateSavedQuery_NoQueryMatch(t *testing.T) {
 
 	mockDB.EXPECT().GetSavedQuery(gomock.Any(), gomock.Any()).Return(model.SavedQuery{}, nil)
 
+	// query is not shared to the user with edit rights
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
 	userId, err := uuid2.NewV4()
 	require.NoError(t, err)
 
@@ -882,6 +891,60 @@ func TestResources_UpdateSavedQuery_AdminPublicQuery_Success(t *testing.T) {
 	assert.JSONEq(t, `{"data":{"user_id":"ac83d188-cb30-430b-953a-9e0ecab45e2c","name":"foo","query":"bar","description":"baz","id":1,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":{"Time":"0001-01-01T00:00:00Z","Valid":false}}}`, response.Body.String())
 }
 
+func TestResources_UpdateSavedQuery_SharedWithEditRights_Success(t *testing.T) {
+	// Setup
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	endpoint := "/api/v2/saved-queries/{%s}"
+	savedQueryId := "1"
+
+	userId, err := uuid2.NewV4()
+	require.NoError(t, err)
+
+	savedQuery := model.SavedQuery{
+		UserID: "notThisUser",
+		Name:   "foo",
+		Query:  "bar",
+		BigSerial: model.BigSerial{
+			ID: int64(1),
+		},
+	}
+
+	// query belongs to another user
+	mockDB.EXPECT().GetSavedQuery(gomock.Any(), int64(1)).Return(savedQuery, nil)
+
+	// query is shared to the user, directly or through a role, with edit rights
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), int64(1), userId).Return(true, nil)
+
+	mockDB.EXPECT().UpdateSavedQuery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated model.SavedQuery) (model.SavedQuery, error) {
+		// ownership does not change when an editor updates the query
+		assert.Equal(t, "notThisUser", updated.UserID)
+		assert.Equal(t, "notBar", updated.Query)
+		return updated, nil
+	})
+
+	// context owner is not an admin
+	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "PUT", fmt.Sprintf(endpoint, "1"), must.MarshalJSONReader(map[string]any{"query": "notBar"}))
+	require.NoError(t, err)
+
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+	req = mux.SetURLVars(req, map[string]string{api.URIPathVariableSavedQueryID: savedQueryId})
+
+	handler := http.HandlerFunc(resources.UpdateSavedQuery)
+
+	// Act
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
 func TestResources_DeleteSavedQuery_NotAUserAuth(t *testing.T) {
 	// Setup
 	bhCtx := ctx.Context{
@@ -1006,6 +1069,9 @@ func TestResources_DeleteSavedQuery_UserNotAdmin(t *testing.T) {
 
 	mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
 
+	// query is not shared to the user with edit rights
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
 	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "DELETE", fmt.Sprintf(endpoint, savedQueryId), nil)
 	require.NoError(t, err)
 
@@ -1080,6 +1146,9 @@ func TestResources_DeleteSavedQuery_NotPublicQueryAndUserIsAdmin(t *testing.T) {
 	mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
 	mockDB.EXPECT().IsSavedQueryPublic(gomock.Any(), gomock.Any()).Return(false, nil)
 
+	// query is not shared to the user with edit rights
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
 	req, err := http.NewRequestWithContext(createContextWithAdminOwnerId(userId), "DELETE", fmt.Sprintf(endpoint, savedQueryId), nil)
 	require.NoError(t, err)
 
@@ -1245,6 +1314,42 @@ func TestResources_DeleteSavedQuery_PublicQueryAndUserIsAdmin(t *testing.T) {
 	require.Equal(t, "", response.Body.String())
 }
 
+func TestResources_DeleteSavedQuery_SharedWithEditRights(t *testing.T) {
+	// Setup
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	userId, err := uuid2.NewV4()
+	require.NoError(t, err)
+
+	endpoint := "/api/v2/saved-queries/%s"
+	savedQueryId := "1"
+
+	mockDB.EXPECT().SavedQueryBelongsToUser(gomock.Any(), userId, int64(1)).Return(false, nil)
+	mockDB.EXPECT().IsSavedQueryEditableByUser(gomock.Any(), int64(1), userId).Return(true, nil)
+	mockDB.EXPECT().DeleteSavedQuery(gomock.Any(), int64(1)).Return(nil)
+
+	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "DELETE", fmt.Sprintf(endpoint, savedQueryId), nil)
+	require.NoError(t, err)
+
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+	req = mux.SetURLVars(req, map[string]string{api.URIPathVariableSavedQueryID: savedQueryId})
+
+	handler := http.HandlerFunc(resources.DeleteSavedQuery)
+
+	// Act
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	require.Equal(t, "", response.Body.String())
+}
+
 func TestResources_DeleteSavedQuery(t *testing.T) {
 	// Setup
 	var (
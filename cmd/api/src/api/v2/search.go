@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/specterops/bloodhound/cmd/api/src/api"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
@@ -45,7 +47,70 @@ func (s Resources) SearchHandler(response http.ResponseWriter, request *http.Req
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid query parameter: %v", err), request), response)
 	} else if nodeKinds, err := analysis.ParseKinds(nodeTypes...); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Invalid type parameter", request), response)
-	} else if result, err := s.GraphQuery.SearchNodesByName(ctx, nodeKinds, searchQuery, skip, limit); err != nil {
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if result, err := s.GraphQuery.SearchNodesByName(ctx, nodeKinds, searchQuery, skip, limit, environmentNodeFilters(scope)...); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Graph error: %v", err), request), response)
+	} else {
+		api.WriteBasicResponse(request.Context(), result, http.StatusOK, response)
+	}
+}
+
+const maxPropertySearchFields = 10
+
+var propertySearchFieldRegex = regexp.MustCompile(`^\w+$`)
+
+func parsePropertySearchFields(fieldsParam string) ([]string, error) {
+	if fieldsParam == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(strings.ReplaceAll(fieldsParam, " ", ""), ",")
+
+	if len(fields) > maxPropertySearchFields {
+		return nil, fmt.Errorf("at most %d fields may be searched", maxPropertySearchFields)
+	}
+
+	for _, field := range fields {
+		if !propertySearchFieldRegex.MatchString(field) {
+			return nil, fmt.Errorf("invalid field name: %q", field)
+		}
+	}
+
+	return fields, nil
+}
+
+// parsePropertySearchKinds returns no kinds when no types are given so that nodes of every kind, including OpenGraph
+// kinds, are searched
+func parsePropertySearchKinds(nodeTypes []string) (graph.Kinds, error) {
+	if len(nodeTypes) == 0 {
+		return nil, nil
+	}
+
+	return analysis.ParseKinds(nodeTypes...)
+}
+
+// PropertySearchHandler searches the selected node properties, or a default set of common properties, for the search
+// value. Unlike SearchHandler, nodes of any kind are searched unless types are given.
+func (s Resources) PropertySearchHandler(response http.ResponseWriter, request *http.Request) {
+	var (
+		queryParams = request.URL.Query()
+		searchQuery = queryParams.Get("q")
+		nodeTypes   = queryParams["type"]
+		ctx         = request.Context()
+	)
+
+	if searchQuery == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Invalid search parameter", request), response)
+	} else if nodeKinds, err := parsePropertySearchKinds(nodeTypes); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Invalid type parameter", request), response)
+	} else if fields, err := parsePropertySearchFields(queryParams.Get("fields")); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid fields parameter: %v", err), request), response)
+	} else if skip, limit, _, err := utils.GetPageParamsForGraphQuery(context.Background(), queryParams); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("Invalid query parameter: %v", err), request), response)
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if result, err := s.GraphQuery.SearchNodesByProperties(ctx, nodeKinds, fields, searchQuery, skip, limit, environmentNodeFilters(scope)...); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("Graph error: %v", err), request), response)
 	} else {
 		api.WriteBasicResponse(request.Context(), result, http.StatusOK, response)
@@ -59,7 +124,9 @@ func (s *Resources) GetAvailableDomains(response http.ResponseWriter, request *h
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
 	} else if filterCriteria, err := domains.GetFilterCriteria(request); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
-	} else if nodes, err := s.GraphQuery.GetFilteredAndSortedNodes(sortItems, filterCriteria); err != nil {
+	} else if scope, err := s.environmentScope(request); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if nodes, err := s.GraphQuery.GetFilteredAndSortedNodes(sortItems, scopeNodeCriteria(scope, filterCriteria)); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsInternalServerError, err), request), response)
 	} else {
 		api.WriteBasicResponse(request.Context(), setNodeProperties(nodes), http.StatusOK, response)
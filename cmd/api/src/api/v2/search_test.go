@@ -24,6 +24,9 @@ import (
 
 	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
 	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	dbMocks "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
 	graphMocks "github.com/specterops/bloodhound/cmd/api/src/queries/mocks"
 	"github.com/specterops/dawgs/graph"
 	"go.uber.org/mock/gomock"
@@ -100,6 +103,148 @@ func TestResources_SearchHandler(t *testing.T) {
 		})
 }
 
+func TestResources_SearchHandler_RestrictedEnvironments(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		mockGraph = graphMocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.SearchHandler).
+		Run([]apitest.Case{
+			{
+				Name: "EnvironmentAccessListError",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, setupUserCtx(model.User{PrincipalName: "scoped"}))
+					apitest.AddQueryParam(input, "q", "search value")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "SearchIsFilteredToEnvironments",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, setupUserCtx(model.User{PrincipalName: "scoped"}))
+					apitest.AddQueryParam(input, "q", "search value")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetEnvironmentAccessListForUser(gomock.Any(), gomock.Any()).Return([]database.EnvironmentAccess{{Environment: "S-1-5-21-1"}}, nil)
+					mockGraph.EXPECT().
+						SearchNodesByName(gomock.Any(), gomock.Any(), "search value", gomock.Any(), gomock.Any(), gomock.Not(gomock.Nil())).
+						Return([]model.SearchResult{}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+				},
+			},
+		})
+}
+
+func TestResources_PropertySearchHandler(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockGraph = graphMocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{GraphQuery: mockGraph}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.PropertySearchHandler).
+		Run([]apitest.Case{
+			{
+				Name: "EmptySearchQueryFailure",
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Invalid search parameter")
+				},
+			},
+			{
+				Name: "ParseKindsError",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "q", "search value")
+					apitest.AddQueryParam(input, "type", "invalidKind")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Invalid type parameter")
+				},
+			},
+			{
+				Name: "InvalidFieldName",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "q", "search value")
+					apitest.AddQueryParam(input, "fields", "description,not-a-field")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Invalid fields parameter: invalid field name")
+				},
+			},
+			{
+				Name: "TooManyFields",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "q", "search value")
+					apitest.AddQueryParam(input, "fields", "a,b,c,d,e,f,g,h,i,j,k")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "at most 10 fields may be searched")
+				},
+			},
+			{
+				Name: "GetPageParamsFailure",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "q", "search value")
+					apitest.AddQueryParam(input, "skip", "notAnInt")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "Invalid query parameter")
+				},
+			},
+			{
+				Name: "GraphDBSearchNodesError",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "q", "search value")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						SearchNodesByProperties(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, errors.New("graph error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.BodyContains(output, "Graph error:")
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "q", "svc_sql")
+					apitest.AddQueryParam(input, "fields", "description, serviceprincipalnames")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						SearchNodesByProperties(gomock.Any(), graph.Kinds(nil), []string{"description", "serviceprincipalnames"}, "svc_sql", 0, gomock.Any()).
+						Return([]model.PropertySearchResult{{
+							SearchResult:      model.SearchResult{ObjectID: "S-1-5-21-1", Name: "SVC_SQL@TESTLAB.LOCAL"},
+							MatchedProperties: []string{"description"},
+							Score:             2,
+						}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"matched_properties":["description"]`)
+				},
+			},
+		})
+}
+
 func TestResources_GetAvailableDomains(t *testing.T) {
 	var (
 		mockCtrl         = gomock.NewController(t)
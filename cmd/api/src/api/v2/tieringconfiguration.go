@@ -0,0 +1,194 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/specterops/bloodhound/cmd/api/src/api"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/specterops/bloodhound/cmd/api/src/ctx"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	bhUtils "github.com/specterops/bloodhound/cmd/api/src/utils"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	queryParameterFormat = "format"
+	queryParameterDryRun = "dry_run"
+	queryParameterPrune  = "prune"
+
+	tieringConfigurationFormatJson = "json"
+	tieringConfigurationFormatYaml = "yaml"
+
+	tieringConfigurationYamlFilename = "tiering_configuration.yaml"
+)
+
+var tieringConfigurationYamlMediaTypes = []string{"application/yaml", "application/x-yaml", "text/yaml"}
+
+type ImportTieringConfigurationResponse struct {
+	DryRun bool `json:"dry_run"`
+	model.TieringConfigurationPlan
+}
+
+func decodeTieringConfiguration(request *http.Request) (model.TieringConfiguration, error) {
+	var config model.TieringConfiguration
+
+	switch {
+	case bhUtils.HeaderMatches(request.Header, headers.ContentType.String(), mediatypes.ApplicationJson.String()):
+		decoder := json.NewDecoder(request.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&config); err != nil {
+			return config, fmt.Errorf("%w: %v", model.ErrInvalidTieringConfiguration, err)
+		}
+	case bhUtils.HeaderMatches(request.Header, headers.ContentType.String(), tieringConfigurationYamlMediaTypes...):
+		decoder := yaml.NewDecoder(request.Body)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+			return config, fmt.Errorf("%w: %v", model.ErrInvalidTieringConfiguration, err)
+		}
+	default:
+		return config, fmt.Errorf("invalid content-type: %s", request.Header[headers.ContentType.String()])
+	}
+
+	return config, nil
+}
+
+// validateTieringConfiguration applies the same tag name and seed rules as the individual tag and selector endpoints
+func (s *Resources) validateTieringConfiguration(config model.TieringConfiguration) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	for _, tag := range config.Tags {
+		if err := validateAssetGroupTagName(tag.Name); err != nil {
+			return fmt.Errorf("%w: tag %s: %v", model.ErrInvalidTieringConfiguration, tag.Name, err)
+		}
+
+		for _, selector := range tag.Selectors {
+			if err := validateSelectorSeeds(s.GraphQuery, selector.ToSelectorSeeds()); err != nil {
+				return fmt.Errorf("%w: selector %s in tag %s: %v", model.ErrInvalidTieringConfiguration, selector.Name, tag.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// exceedsTieringLimits reports whether the plan grows the number of tiers or labels past the configured limits.
+// Configurations that were already over a limit may still be imported as long as they do not add to it.
+func exceedsTieringLimits(current model.TieringConfiguration, plan model.TieringConfigurationPlan, limits appcfg.TieringParameters) bool {
+	var currentTiers, currentLabels int
+	for _, tag := range current.Tags {
+		switch tag.Type {
+		case model.AssetGroupTag{Type: model.AssetGroupTagTypeTier}.ToType():
+			currentTiers++
+		case model.AssetGroupTag{Type: model.AssetGroupTagTypeLabel}.ToType():
+			currentLabels++
+		}
+	}
+
+	return (len(plan.TierOrder) > limits.TierLimit && len(plan.TierOrder) > currentTiers) ||
+		(plan.Labels > limits.LabelLimit && plan.Labels > currentLabels)
+}
+
+func (s *Resources) ExportTieringConfiguration(response http.ResponseWriter, request *http.Request) {
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Export Configuration")()
+
+	if format := request.URL.Query().Get(queryParameterFormat); format != "" && format != tieringConfigurationFormatJson && format != tieringConfigurationFormatYaml {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, queryParameterFormat, fmt.Errorf("format must be one of %s or %s", tieringConfigurationFormatJson, tieringConfigurationFormatYaml)), response)
+	} else if config, err := s.DB.GetTieringConfiguration(request.Context()); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if format != tieringConfigurationFormatYaml {
+		api.WriteBasicResponse(request.Context(), config, http.StatusOK, response)
+	} else if data, err := yaml.Marshal(config); err != nil {
+		slog.ErrorContext(request.Context(), fmt.Sprintf("Unable to marshal tiering configuration: %v", err))
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		api.WriteBinaryResponse(request.Context(), data, tieringConfigurationYamlFilename, http.StatusOK, response)
+	}
+}
+
+func (s *Resources) ImportTieringConfiguration(response http.ResponseWriter, request *http.Request) {
+	var queryParams = request.URL.Query()
+	defer measure.ContextMeasure(request.Context(), slog.LevelDebug, "Asset Group Tag Import Configuration")()
+
+	if actor, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		slog.Error("Unable to get user from auth context")
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "unknown user", request), response)
+	} else if dryRun, err := api.ParseOptionalBool(queryParams.Get(queryParameterDryRun), false); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, queryParameterDryRun, err), response)
+	} else if prune, err := api.ParseOptionalBool(queryParams.Get(queryParameterPrune), false); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, queryParameterPrune, err), response)
+	} else if request.Body == nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "request body cannot be empty", request), response)
+	} else {
+		request.Body = http.MaxBytesReader(response, request.Body, api.DefaultAPIPayloadReadLimitBytes)
+		defer request.Body.Close()
+
+		if desired, err := decodeTieringConfiguration(request); err != nil {
+			if errors.Is(err, model.ErrInvalidTieringConfiguration) {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+			} else {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnsupportedMediaType, fmt.Sprintf("%s; Content type must be application/json or application/yaml", err.Error()), request), response)
+			}
+		} else if err := s.validateTieringConfiguration(desired); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+		} else if current, err := s.DB.GetTieringConfiguration(request.Context()); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else if plan, err := model.PlanTieringConfiguration(current, desired, prune); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+		} else if exceedsTieringLimits(current, plan, appcfg.GetTieringParameters(request.Context(), s.DB)) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseAssetGroupTagExceededTagLimit, request), response)
+		} else if dryRun {
+			api.WriteBasicResponse(request.Context(), ImportTieringConfigurationResponse{DryRun: true, TieringConfigurationPlan: plan}, http.StatusOK, response)
+		} else if plan, err := s.DB.ImportTieringConfiguration(request.Context(), actor, desired, prune); err != nil {
+			switch {
+			case errors.Is(err, model.ErrInvalidTieringConfiguration), errors.Is(err, database.ErrInvalidTierOrder):
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+			case errors.Is(err, database.ErrDuplicateKindName), errors.Is(err, database.ErrDuplicateAGName):
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, api.ErrorResponseAssetGroupTagDuplicateKindName, request), response)
+			default:
+				api.HandleDatabaseError(request, response, err)
+			}
+		} else {
+			// Request analysis if scheduled analysis isn't enabled so that tagging reflects the imported configuration
+			if len(plan.Changes) > 0 {
+				if config, err := appcfg.GetScheduledAnalysisParameter(request.Context(), s.DB); err != nil {
+					api.HandleDatabaseError(request, response, err)
+					return
+				} else if !config.Enabled {
+					if err := s.DB.RequestAnalysis(request.Context(), actor.ID.String()); err != nil {
+						api.HandleDatabaseError(request, response, err)
+						return
+					}
+				}
+			}
+			api.WriteBasicResponse(request.Context(), ImportTieringConfigurationResponse{TieringConfigurationPlan: plan}, http.StatusOK, response)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	v2 "github.com/specterops/bloodhound/cmd/api/src/api/v2"
+	"github.com/specterops/bloodhound/cmd/api/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	mocks_db "github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupTieringConfiguration() model.TieringConfiguration {
+	return model.NewTieringConfiguration(
+		model.AssetGroupTags{
+			{ID: 1, Type: model.AssetGroupTagTypeTier, Name: "Tier Zero", Position: null.Int32From(1), RequireCertify: null.BoolFrom(false), AnalysisEnabled: null.BoolFrom(true)},
+			{ID: 2, Type: model.AssetGroupTagTypeOwned, Name: "Owned"},
+		},
+		map[int]model.AssetGroupTagSelectors{
+			1: {{ID: 1, AssetGroupTagId: 1, Name: "Domain Admins", IsDefault: true, AutoCertify: null.BoolFrom(true), Seeds: []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "S-1-5-512"}}}},
+		},
+	)
+}
+
+func TestResources_ExportTieringConfiguration(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+	)
+
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resourcesInst.ExportTieringConfiguration).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidFormat",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "format", "xml")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "format must be one of json or yaml")
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(model.TieringConfiguration{}, errors.New("failure")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "SuccessJson",
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := model.TieringConfiguration{}
+					apitest.UnmarshalData(output, &result)
+					require.Equal(t, model.TieringConfigurationVersion, result.Version)
+					require.Len(t, result.Tags, 2)
+					require.Equal(t, "Domain Admins", result.Tags[0].Selectors[0].Name)
+				},
+			},
+			{
+				Name: "SuccessYaml",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "format", "yaml")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, "version: 1")
+					apitest.BodyContains(output, "name: Tier Zero")
+					apitest.BodyContains(output, "value: S-1-5-512")
+				},
+			},
+		})
+}
+
+func TestResources_ImportTieringConfiguration(t *testing.T) {
+	var (
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks_db.NewMockDatabase(mockCtrl)
+		resourcesInst = v2.Resources{
+			DB: mockDB,
+		}
+		user    = setupUser()
+		userCtx = setupUserCtx(user)
+
+		withNewLabel = func() model.TieringConfiguration {
+			config := setupTieringConfiguration()
+			config.Tags = append(config.Tags, model.TieringConfigurationTag{
+				Name:      "Crown Jewels",
+				Type:      "label",
+				Selectors: []model.TieringConfigurationSelector{{Name: "Databases", Seeds: []model.TieringConfigurationSeed{{Type: "object_id", Value: "S-1-9"}}}},
+			})
+			return config
+		}
+		labelYaml = `version: 1
+tags:
+  - name: Tier Zero
+    type: tier
+    selectors:
+      - name: Domain Admins
+        auto_certify: true
+        seeds:
+          - type: object_id
+            value: S-1-5-512
+  - name: Crown Jewels
+    type: label
+    selectors:
+      - name: Databases
+        seeds:
+          - type: object_id
+            value: S-1-9
+`
+	)
+
+	defer mockCtrl.Finish()
+
+	tieringLimits, _ := types.NewJSONBObject(map[string]any{"tier_limit": 3, "label_limit": 1})
+	scheduledAnalysis, _ := types.NewJSONBObject(map[string]any{"enabled": false})
+
+	apitest.
+		NewHarness(t, resourcesInst.ImportTieringConfiguration).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidDryRun",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.AddQueryParam(input, "dry_run", "maybe")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "UnsupportedContentType",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), "text/plain")
+					apitest.BodyString(input, "tags")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusUnsupportedMediaType)
+				},
+			},
+			{
+				Name: "UnknownField",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, map[string]any{"version": 1, "tiers": []string{}})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "unknown field")
+				},
+			},
+			{
+				Name: "UnsupportedVersion",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, model.TieringConfiguration{Version: 7})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "unsupported version 7")
+				},
+			},
+			{
+				Name: "InvalidPlan",
+				Input: func(input *apitest.Input) {
+					config := setupTieringConfiguration()
+					config.Tags[0].Type = "label"
+					config.Tags[0].Expansion, config.Tags[0].Position, config.Tags[0].RequireCertify, config.Tags[0].AnalysisEnabled = "", nil, nil, nil
+
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, config)
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "cannot change type")
+				},
+			},
+			{
+				Name: "ExceedsLabelLimit",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, withNewLabel())
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{}, errors.New("not found")).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+				},
+			},
+			{
+				Name: "DryRun",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.AddQueryParam(input, "dry_run", "true")
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, withNewLabel())
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := v2.ImportTieringConfigurationResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.True(t, result.DryRun)
+					require.Equal(t, []model.TieringConfigurationChange{
+						{Action: model.TieringConfigurationActionCreate, Object: model.TieringConfigurationObjectTag, Tag: "Crown Jewels"},
+						{Action: model.TieringConfigurationActionCreate, Object: model.TieringConfigurationObjectSelector, Tag: "Crown Jewels", Selector: "Databases"},
+					}, result.Changes)
+				},
+			},
+			{
+				Name: "ImportDuplicateName",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.BodyStruct(input, withNewLabel())
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+					mockDB.EXPECT().ImportTieringConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), false).
+						Return(model.TieringConfigurationPlan{}, database.ErrDuplicateKindName).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+				},
+			},
+			{
+				Name: "ImportYamlSuccess",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, userCtx)
+					apitest.AddQueryParam(input, "prune", "true")
+					apitest.SetHeader(input, headers.ContentType.String(), "application/yaml")
+					apitest.BodyString(input, labelYaml)
+				},
+				Setup: func() {
+					plan := model.TieringConfigurationPlan{
+						Changes:   []model.TieringConfigurationChange{{Action: model.TieringConfigurationActionCreate, Object: model.TieringConfigurationObjectTag, Tag: "Crown Jewels"}},
+						TierOrder: []string{"Tier Zero"},
+					}
+
+					mockDB.EXPECT().GetTieringConfiguration(gomock.Any()).Return(setupTieringConfiguration(), nil).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.TierManagementParameterKey).
+						Return(appcfg.Parameter{Key: appcfg.TierManagementParameterKey, Value: tieringLimits}, nil).Times(1)
+					mockDB.EXPECT().ImportTieringConfiguration(gomock.Any(), user, gomock.Any(), true).
+						DoAndReturn(func(_ any, _ model.User, desired model.TieringConfiguration, _ bool) (model.TieringConfigurationPlan, error) {
+							require.Len(t, desired.Tags, 2)
+							require.Equal(t, "S-1-9", desired.Tags[1].Selectors[0].Seeds[0].Value)
+							return plan, nil
+						}).Times(1)
+					mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.ScheduledAnalysis).
+						Return(appcfg.Parameter{Key: appcfg.ScheduledAnalysis, Value: scheduledAnalysis}, nil).Times(1)
+					mockDB.EXPECT().RequestAnalysis(gomock.Any(), user.ID.String()).Return(nil).Times(1)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					result := v2.ImportTieringConfigurationResponse{}
+					apitest.UnmarshalData(output, &result)
+					require.False(t, result.DryRun)
+					require.Len(t, result.Changes, 1)
+				},
+			},
+		})
+}
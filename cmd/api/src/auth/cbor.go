@@ -0,0 +1,320 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	cborMajorUnsigned = 0
+	cborMajorNegative = 1
+	cborMajorBytes    = 2
+	cborMajorText     = 3
+	cborMajorArray    = 4
+	cborMajorMap      = 5
+	cborMajorTag      = 6
+	cborMajorSimple   = 7
+
+	// cborMaxDepth bounds nesting so that a hostile payload cannot exhaust the stack
+	cborMaxDepth = 16
+)
+
+var (
+	ErrCBORTruncated    = errors.New("cbor: unexpected end of data")
+	ErrCBORUnsupported  = errors.New("cbor: unsupported data item")
+	ErrCBORDuplicateKey = errors.New("cbor: duplicate map key")
+	ErrCBORNonCanonical = errors.New("cbor: non-canonical encoding")
+)
+
+// cborDecode decodes the first CBOR data item in data and returns it along with the bytes that follow it. Only the
+// CTAP2 canonical encodings produced by WebAuthn authenticators are accepted: lengths, integers and floats must use their
+// shortest form, and map keys must be unique and sorted by their encoded length and then bytewise. Integers decode to
+// int64, byte strings to []byte, text to string, arrays to []any and maps to map[any]any keyed by int64 or string.
+func cborDecode(data []byte) (any, []byte, error) {
+	return cborDecodeItem(data, 0)
+}
+
+func cborDecodeItem(data []byte, depth int) (any, []byte, error) {
+	if depth > cborMaxDepth {
+		return nil, nil, ErrCBORUnsupported
+	} else if len(data) == 0 {
+		return nil, nil, ErrCBORTruncated
+	}
+
+	var (
+		major = data[0] >> 5
+		info  = data[0] & 0x1f
+	)
+
+	if major == cborMajorSimple {
+		return cborDecodeSimple(info, data[1:])
+	}
+
+	argument, rest, err := cborDecodeArgument(info, data[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborMajorUnsigned:
+		if argument > math.MaxInt64 {
+			return nil, nil, ErrCBORUnsupported
+		}
+
+		return int64(argument), rest, nil
+
+	case cborMajorNegative:
+		if argument > math.MaxInt64 {
+			return nil, nil, ErrCBORUnsupported
+		}
+
+		return -1 - int64(argument), rest, nil
+
+	case cborMajorBytes, cborMajorText:
+		if argument > uint64(len(rest)) {
+			return nil, nil, ErrCBORTruncated
+		}
+
+		value := rest[:argument]
+
+		if major == cborMajorText {
+			return string(value), rest[argument:], nil
+		}
+
+		return append([]byte(nil), value...), rest[argument:], nil
+
+	case cborMajorArray:
+		// Every item is at least one byte long, which bounds the allocation by the remaining input
+		if argument > uint64(len(rest)) {
+			return nil, nil, ErrCBORTruncated
+		}
+
+		items := make([]any, 0, argument)
+
+		for i := uint64(0); i < argument; i++ {
+			var item any
+
+			if item, rest, err = cborDecodeItem(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, rest, nil
+
+	case cborMajorMap:
+		if argument > uint64(len(rest)) {
+			return nil, nil, ErrCBORTruncated
+		}
+
+		var (
+			items       = make(map[any]any, argument)
+			previousKey []byte
+		)
+
+		for i := uint64(0); i < argument; i++ {
+			var (
+				key, value any
+				encodedKey = rest
+			)
+
+			if key, rest, err = cborDecodeItem(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, fmt.Errorf("%w: map key of type %T", ErrCBORUnsupported, key)
+			}
+
+			encodedKey = encodedKey[:len(encodedKey)-len(rest)]
+
+			if _, exists := items[key]; exists {
+				return nil, nil, fmt.Errorf("%w: %v", ErrCBORDuplicateKey, key)
+			} else if previousKey != nil && !cborCanonicalKeyLess(previousKey, encodedKey) {
+				return nil, nil, fmt.Errorf("%w: map key %v is out of order", ErrCBORNonCanonical, key)
+			}
+
+			previousKey = encodedKey
+
+			if value, rest, err = cborDecodeItem(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+
+			items[key] = value
+		}
+
+		return items, rest, nil
+
+	case cborMajorTag:
+		// Tags carry no meaning for WebAuthn structures; return the tagged item itself
+		return cborDecodeItem(rest, depth+1)
+
+	default:
+		return nil, nil, ErrCBORUnsupported
+	}
+}
+
+// cborCanonicalKeyLess reports whether the encoded key a sorts before the encoded key b: shorter encodings sort first
+// and encodings of the same length sort bytewise
+func cborCanonicalKeyLess(a, b []byte) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	return bytes.Compare(a, b) < 0
+}
+
+func cborDecodeArgument(info byte, data []byte) (uint64, []byte, error) {
+	var (
+		argument uint64
+		rest     []byte
+		minimum  uint64
+	)
+
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		if len(data) < 1 {
+			return 0, nil, ErrCBORTruncated
+		}
+
+		argument, rest, minimum = uint64(data[0]), data[1:], 24
+	case info == 25:
+		if len(data) < 2 {
+			return 0, nil, ErrCBORTruncated
+		}
+
+		argument, rest, minimum = uint64(binary.BigEndian.Uint16(data)), data[2:], math.MaxUint8+1
+	case info == 26:
+		if len(data) < 4 {
+			return 0, nil, ErrCBORTruncated
+		}
+
+		argument, rest, minimum = uint64(binary.BigEndian.Uint32(data)), data[4:], math.MaxUint16+1
+	case info == 27:
+		if len(data) < 8 {
+			return 0, nil, ErrCBORTruncated
+		}
+
+		argument, rest, minimum = binary.BigEndian.Uint64(data), data[8:], math.MaxUint32+1
+	default:
+		// Indefinite lengths (31) and the reserved values are never produced by authenticators
+		return 0, nil, ErrCBORUnsupported
+	}
+
+	// A value that fits a shorter encoding must use it
+	if argument < minimum {
+		return 0, nil, fmt.Errorf("%w: argument %d is not in its shortest form", ErrCBORNonCanonical, argument)
+	}
+
+	return argument, rest, nil
+}
+
+func cborDecodeSimple(info byte, data []byte) (any, []byte, error) {
+	switch info {
+	case 20:
+		return false, data, nil
+	case 21:
+		return true, data, nil
+	case 22, 23:
+		return nil, data, nil
+	case 25:
+		if len(data) < 2 {
+			return nil, nil, ErrCBORTruncated
+		}
+
+		return float64(float16ToFloat32(binary.BigEndian.Uint16(data))), data[2:], nil
+	case 26:
+		if len(data) < 4 {
+			return nil, nil, ErrCBORTruncated
+		}
+
+		value := float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+
+		if cborFloatFits(value, 11, 16, -24) {
+			return nil, nil, fmt.Errorf("%w: float %v is not in its shortest form", ErrCBORNonCanonical, value)
+		}
+
+		return value, data[4:], nil
+	case 27:
+		if len(data) < 8 {
+			return nil, nil, ErrCBORTruncated
+		}
+
+		value := math.Float64frombits(binary.BigEndian.Uint64(data))
+
+		if cborFloatFits(value, 24, 128, -149) {
+			return nil, nil, fmt.Errorf("%w: float %v is not in its shortest form", ErrCBORNonCanonical, value)
+		}
+
+		return value, data[8:], nil
+	default:
+		return nil, nil, ErrCBORUnsupported
+	}
+}
+
+// cborFloatFits reports whether value is exactly representable by a binary floating point format with the given
+// significand precision in bits, largest binary exponent and smallest subnormal exponent. Zero, infinities and NaN fit
+// every format since canonical CBOR encodes them as half precision.
+func cborFloatFits(value float64, precision, maxExponent, minExponent int) bool {
+	if value == 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return true
+	}
+
+	fraction, exponent := math.Frexp(math.Abs(value))
+
+	if exponent > maxExponent {
+		return false
+	}
+
+	// The value fits when it is a whole multiple of the format's least significant bit at this exponent
+	scaled := math.Ldexp(fraction, exponent-max(exponent-precision, minExponent))
+	return scaled == math.Trunc(scaled)
+}
+
+func float16ToFloat32(half uint16) float32 {
+	var (
+		sign     = uint32(half>>15) << 31
+		exponent = uint32(half>>10) & 0x1f
+		mantissa = uint32(half & 0x3ff)
+	)
+
+	switch exponent {
+	case 0:
+		// Subnormal half precision values are exactly representable as scaled single precision values
+		value := float32(mantissa) / (1 << 24)
+
+		if sign != 0 {
+			return -value
+		}
+
+		return value
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mantissa<<13)
+	default:
+		return math.Float32frombits(sign | (exponent+112)<<23 | mantissa<<13)
+	}
+}
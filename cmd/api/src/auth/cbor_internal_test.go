@@ -0,0 +1,120 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORDecode(t *testing.T) {
+	t.Run("canonical map", func(t *testing.T) {
+		// {1: 2, 3: -7, -1: 1, "fmt": "none"}
+		decoded, rest, err := cborDecode([]byte{0xa4, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e'})
+
+		require.NoError(t, err)
+		require.Empty(t, rest)
+		require.Equal(t, map[any]any{int64(1): int64(2), int64(3): int64(-7), int64(-1): int64(1), "fmt": "none"}, decoded)
+	})
+
+	t.Run("duplicate map keys are rejected", func(t *testing.T) {
+		// {1: 2, 1: 3}
+		_, _, err := cborDecode([]byte{0xa2, 0x01, 0x02, 0x01, 0x03})
+		require.ErrorIs(t, err, ErrCBORDuplicateKey)
+
+		// {"a": 1, "b": 2, "a": 3}
+		_, _, err = cborDecode([]byte{0xa3, 0x61, 'a', 0x01, 0x61, 'b', 0x02, 0x61, 'a', 0x03})
+		require.ErrorIs(t, err, ErrCBORDuplicateKey)
+	})
+
+	t.Run("unsorted map keys are rejected", func(t *testing.T) {
+		// {3: 1, 1: 2}
+		_, _, err := cborDecode([]byte{0xa2, 0x03, 0x01, 0x01, 0x02})
+		require.ErrorIs(t, err, ErrCBORNonCanonical)
+
+		// {"authData": h'', "fmt": "none"} sorts the longer key first
+		_, _, err = cborDecode([]byte{0xa2, 0x68, 'a', 'u', 't', 'h', 'D', 'a', 't', 'a', 0x40, 0x63, 'f', 'm', 't', 0x64, 'n', 'o', 'n', 'e'})
+		require.ErrorIs(t, err, ErrCBORNonCanonical)
+	})
+
+	t.Run("arguments must use their shortest form", func(t *testing.T) {
+		for description, encoded := range map[string][]byte{
+			"23 in one extra byte":               {0x18, 0x17},
+			"255 in two extra bytes":             {0x19, 0x00, 0xff},
+			"65535 in four extra bytes":          {0x1a, 0x00, 0x00, 0xff, 0xff},
+			"4294967295 in eight extra bytes":    {0x1b, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff},
+			"-1 in one extra byte":               {0x38, 0x00},
+			"byte string with a one byte length": {0x58, 0x01, 0x00},
+			"empty map with a one byte length":   {0xb8, 0x00},
+		} {
+			_, _, err := cborDecode(encoded)
+			require.ErrorIs(t, err, ErrCBORNonCanonical, description)
+		}
+
+		for _, encoded := range [][]byte{
+			{0x18, 0x18},
+			{0x19, 0x01, 0x00},
+			{0x1a, 0x00, 0x01, 0x00, 0x00},
+			{0x1b, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00},
+		} {
+			_, _, err := cborDecode(encoded)
+			require.NoError(t, err, "%x", encoded)
+		}
+	})
+
+	t.Run("floats must use their shortest form", func(t *testing.T) {
+		for description, encoded := range map[string][]byte{
+			"1.5 as single precision":          {0xfa, 0x3f, 0xc0, 0x00, 0x00},
+			"infinity as single precision":     {0xfa, 0x7f, 0x80, 0x00, 0x00},
+			"1.5 as double precision":          {0xfb, 0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			"float32(0.1) as double precision": {0xfb, 0x3f, 0xb9, 0x99, 0x99, 0xa0, 0x00, 0x00, 0x00},
+		} {
+			_, _, err := cborDecode(encoded)
+			require.ErrorIs(t, err, ErrCBORNonCanonical, description)
+		}
+
+		decoded, _, err := cborDecode([]byte{0xf9, 0x3e, 0x00})
+		require.NoError(t, err)
+		require.Equal(t, 1.5, decoded)
+
+		decoded, _, err = cborDecode([]byte{0xfa, 0x3d, 0xcc, 0xcc, 0xcd})
+		require.NoError(t, err)
+		require.Equal(t, float64(float32(0.1)), decoded)
+
+		decoded, _, err = cborDecode([]byte{0xfb, 0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a})
+		require.NoError(t, err)
+		require.Equal(t, 0.1, decoded)
+	})
+}
+
+func TestCBORFloatFits(t *testing.T) {
+	// Half precision: 11 bit significand, values below 2^16 and subnormals down to 2^-24
+	require.True(t, cborFloatFits(65504, 11, 16, -24))
+	require.False(t, cborFloatFits(65536, 11, 16, -24))
+	require.True(t, cborFloatFits(math.Ldexp(1, -24), 11, 16, -24))
+	require.False(t, cborFloatFits(math.Ldexp(1, -25), 11, 16, -24))
+	require.False(t, cborFloatFits(1+math.Ldexp(1, -11), 11, 16, -24))
+	require.True(t, cborFloatFits(1+math.Ldexp(1, -10), 11, 16, -24))
+
+	// Single precision
+	require.True(t, cborFloatFits(float64(float32(0.1)), 24, 128, -149))
+	require.False(t, cborFloatFits(0.1, 24, 128, -149))
+	require.True(t, cborFloatFits(math.MaxFloat32, 24, 128, -149))
+	require.True(t, cborFloatFits(math.SmallestNonzeroFloat32, 24, 128, -149))
+}
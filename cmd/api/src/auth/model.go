@@ -39,6 +39,7 @@ const (
 	ProviderTypeOIDC   = "oidc"
 
 	HMAC_SHA2_256 = "hmac-sha2-256"
+	SCIM_BEARER   = "scim-bearer"
 )
 
 type SessionData struct {
@@ -58,6 +59,13 @@ type PermissionOverrides struct {
 	Permissions model.Permissions
 }
 
+// PermissionScope narrows the permissions granted to an actor. Unlike PermissionOverrides, a scope never grants a
+// permission the actor does not already hold; it is used to restrict scoped API tokens to a subset of their owner's rights.
+type PermissionScope struct {
+	Enabled     bool
+	Permissions model.Permissions
+}
+
 type SimpleIdentity struct {
 	ID    uuid.UUID
 	Name  string
@@ -119,6 +127,10 @@ func getPermissions(ctx Context) (model.Permissions, bool) {
 }
 
 func hasPermission(ctx Context, requiredPermission model.Permission, grantedPermissions model.Permissions) bool {
+	if ctx.PermissionScope.Enabled && !ctx.PermissionScope.Permissions.Has(requiredPermission) {
+		return false
+	}
+
 	if ctx.PermissionOverrides.Enabled {
 		return ctx.PermissionOverrides.Permissions.Has(requiredPermission)
 	}
@@ -173,8 +185,10 @@ func (s Authorizer) AuditLogUnauthorizedAccess(request *http.Request) {
 
 type Context struct {
 	PermissionOverrides PermissionOverrides
+	PermissionScope     PermissionScope
 	Owner               any
 	Session             model.UserSession
+	SCIMToken           bool
 }
 
 func (s Context) Authenticated() bool {
@@ -211,7 +225,7 @@ func NewUserAuthToken(ownerId string, tokenName string, hmacMethod string) (mode
 		Name:       null.StringFrom(tokenName),
 	}
 
-	if hmacMethod != HMAC_SHA2_256 {
+	if hmacMethod != HMAC_SHA2_256 && hmacMethod != SCIM_BEARER {
 		return authToken, fmt.Errorf("HMAC method %s is not supported", hmacMethod)
 	}
 
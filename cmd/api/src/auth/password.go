@@ -0,0 +1,203 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrPasswordPolicy   = errors.New("password does not meet the password policy")
+	ErrPasswordTooShort = fmt.Errorf("%w: password is too short", ErrPasswordPolicy)
+	ErrPasswordReused   = fmt.Errorf("%w: password was used recently", ErrPasswordPolicy)
+	ErrPasswordBreached = fmt.Errorf("%w: password appears in a list of breached passwords", ErrPasswordPolicy)
+
+	ErrInvalidBreachedPasswordFile = errors.New("breached password file is not a Have I Been Pwned SHA-1 download ordered by hash")
+)
+
+const (
+	// breachedPasswordFileSamples is the number of lines spread across the breached password file that are checked for
+	// their format and order on top of the first and last lines
+	breachedPasswordFileSamples = 64
+
+	// maxBreachedPasswordLineLength bounds the tail of the file read to find its last line
+	maxBreachedPasswordLineLength = 4096
+)
+
+var breachedPasswordLinePattern = regexp.MustCompile(`^[0-9A-Fa-f]{40}(:[0-9]+)?$`)
+
+// ValidatePasswordLength returns ErrPasswordTooShort if the password has fewer than minimumLength characters
+func ValidatePasswordLength(password string, minimumLength int) error {
+	if utf8.RuneCountInString(password) < minimumLength {
+		return fmt.Errorf("%w: at least %d characters are required", ErrPasswordTooShort, minimumLength)
+	}
+
+	return nil
+}
+
+// IsBreachedPassword looks the password up in the breached password file at the given path. The file must be a Have I
+// Been Pwned SHA-1 download ordered by hash: one hex encoded SHA-1 digest per line, optionally followed by a ":<count>"
+// suffix. Since these files hold close to a billion entries, the lookup binary searches the file by byte offset rather
+// than reading it, so each check only reads a few dozen lines.
+func IsBreachedPassword(path, password string) (bool, error) {
+	fin, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed opening breached password file: %w", err)
+	}
+	defer fin.Close()
+
+	stat, err := fin.Stat()
+	if err != nil {
+		return false, fmt.Errorf("failed reading breached password file: %w", err)
+	}
+
+	var (
+		passwordDigest = sha1.Sum([]byte(password))
+		hexDigest      = strings.ToUpper(hex.EncodeToString(passwordDigest[:]))
+		size           = stat.Size()
+		low, high      = int64(0), size
+	)
+
+	// Find the smallest offset at which the next line holds a digest that does not sort before the password's digest
+	for low < high {
+		middle := low + (high-low)/2
+
+		if digest, found, err := readBreachedDigestAt(fin, size, middle); err != nil {
+			return false, err
+		} else if found && digest < hexDigest {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+
+	if digest, found, err := readBreachedDigestAt(fin, size, low); err != nil {
+		return false, err
+	} else {
+		return found && digest == hexDigest, nil
+	}
+}
+
+// ValidateBreachedPasswordFile checks that the file at the given path can be searched by IsBreachedPassword. A plain text
+// or unsorted file would never match, so the first and last lines and a sample of the lines in between must be SHA-1
+// digests in ascending order.
+func ValidateBreachedPasswordFile(path string) error {
+	fin, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed opening breached password file: %w", err)
+	}
+	defer fin.Close()
+
+	stat, err := fin.Stat()
+	if err != nil {
+		return fmt.Errorf("failed reading breached password file: %w", err)
+	}
+
+	size := stat.Size()
+	if size == 0 {
+		return fmt.Errorf("%w: file is empty", ErrInvalidBreachedPasswordFile)
+	}
+
+	lastLineOffset, err := findLastBreachedLineOffset(fin, size)
+	if err != nil {
+		return err
+	}
+
+	offsets := make([]int64, 0, breachedPasswordFileSamples+1)
+	for sample := range int64(breachedPasswordFileSamples) {
+		offsets = append(offsets, min(size*sample/breachedPasswordFileSamples, lastLineOffset))
+	}
+
+	var previousDigest string
+
+	for _, offset := range append(offsets, lastLineOffset) {
+		if digest, found, err := readBreachedDigestAt(fin, size, offset); err != nil {
+			return err
+		} else if !found {
+			return fmt.Errorf("%w: no line found at offset %d", ErrInvalidBreachedPasswordFile, offset)
+		} else if digest < previousDigest {
+			return fmt.Errorf("%w: digests are not in ascending order at offset %d", ErrInvalidBreachedPasswordFile, offset)
+		} else {
+			previousDigest = digest
+		}
+	}
+
+	return nil
+}
+
+// findLastBreachedLineOffset returns the offset at which the last line of the breached password file starts
+func findLastBreachedLineOffset(fin io.ReaderAt, size int64) (int64, error) {
+	var (
+		start = max(0, size-maxBreachedPasswordLineLength)
+		tail  = make([]byte, size-start)
+	)
+
+	if _, err := fin.ReadAt(tail, start); err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("failed reading breached password file: %w", err)
+	}
+
+	if lineEnd := strings.LastIndexByte(strings.TrimRight(string(tail), "\r\n"), '\n'); lineEnd >= 0 {
+		return start + int64(lineEnd) + 1, nil
+	} else if start > 0 {
+		return 0, fmt.Errorf("%w: last line is longer than %d bytes", ErrInvalidBreachedPasswordFile, maxBreachedPasswordLineLength)
+	} else {
+		return 0, nil
+	}
+}
+
+// readBreachedDigestAt returns the upper case digest on the first line of the breached password file that starts at or
+// after the given offset. Found is false when no line starts there. Lines that are not a SHA-1 digest with an optional
+// count fail with ErrInvalidBreachedPasswordFile.
+func readBreachedDigestAt(fin io.ReaderAt, size, offset int64) (string, bool, error) {
+	start := offset
+	if start > 0 {
+		// A line starts at the offset if the byte before it ends the previous line
+		start--
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(fin, start, size-start))
+
+	if offset > 0 {
+		if _, err := reader.ReadString('\n'); errors.Is(err, io.EOF) {
+			return "", false, nil
+		} else if err != nil {
+			return "", false, fmt.Errorf("failed reading breached password file: %w", err)
+		}
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, fmt.Errorf("failed reading breached password file: %w", err)
+	} else if line = strings.TrimRight(line, "\r\n"); line == "" {
+		return "", false, nil
+	} else if !breachedPasswordLinePattern.MatchString(line) {
+		// The line itself is left out of the error since a plain text file would hold passwords
+		return "", false, fmt.Errorf("%w: malformed line after offset %d", ErrInvalidBreachedPasswordFile, offset)
+	}
+
+	digest, _, _ := strings.Cut(line, ":")
+	return strings.ToUpper(digest), true, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePasswordLength(t *testing.T) {
+	require.Nil(t, auth.ValidatePasswordLength("abcdefghijkl", 12))
+	require.Nil(t, auth.ValidatePasswordLength("ääääääääääää", 12))
+
+	err := auth.ValidatePasswordLength("abcdefghijk", 12)
+	require.ErrorIs(t, err, auth.ErrPasswordTooShort)
+	require.True(t, errors.Is(err, auth.ErrPasswordPolicy))
+}
+
+// writeBreachedPasswordFile writes the passwords as a Have I Been Pwned SHA-1 download ordered by hash
+func writeBreachedPasswordFile(t *testing.T, lineEnding string, passwords ...string) string {
+	t.Helper()
+
+	var (
+		breachedFile = filepath.Join(t.TempDir(), "breached.txt")
+		lines        = make([]string, 0, len(passwords))
+	)
+
+	for idx, password := range passwords {
+		digest := sha1.Sum([]byte(password))
+		lines = append(lines, fmt.Sprintf("%X:%d", digest, idx+1))
+	}
+
+	slices.Sort(lines)
+	require.Nil(t, os.WriteFile(breachedFile, []byte(strings.Join(lines, lineEnding)+lineEnding), 0600))
+
+	return breachedFile
+}
+
+func TestIsBreachedPassword(t *testing.T) {
+	var passwords []string
+
+	for idx := range 1000 {
+		passwords = append(passwords, fmt.Sprintf("breached-password-%d", idx))
+	}
+
+	for _, lineEnding := range []string{"\n", "\r\n"} {
+		breachedFile := writeBreachedPasswordFile(t, lineEnding, passwords...)
+
+		t.Run(fmt.Sprintf("matches every breached password with line ending %q", lineEnding), func(t *testing.T) {
+			for _, password := range passwords {
+				breached, err := auth.IsBreachedPassword(breachedFile, password)
+				require.Nil(t, err)
+				require.True(t, breached, password)
+			}
+		})
+
+		t.Run(fmt.Sprintf("does not match other passwords with line ending %q", lineEnding), func(t *testing.T) {
+			for idx := range 1000 {
+				breached, err := auth.IsBreachedPassword(breachedFile, fmt.Sprintf("safe-password-%d", idx))
+				require.Nil(t, err)
+				require.False(t, breached)
+			}
+		})
+	}
+
+	t.Run("matches lower case digests", func(t *testing.T) {
+		var (
+			breachedFile = filepath.Join(t.TempDir(), "breached.txt")
+			// SHA-1 of "correct horse battery staple"
+			contents = "0000000000000000000000000000000000000000:1\nabf7aad6438836dbe526aa231abde2d0eef74d42:42\n"
+		)
+
+		require.Nil(t, os.WriteFile(breachedFile, []byte(contents), 0600))
+
+		breached, err := auth.IsBreachedPassword(breachedFile, "correct horse battery staple")
+		require.Nil(t, err)
+		require.True(t, breached)
+	})
+
+	t.Run("does not match in an empty file", func(t *testing.T) {
+		breachedFile := filepath.Join(t.TempDir(), "breached.txt")
+		require.Nil(t, os.WriteFile(breachedFile, nil, 0600))
+
+		breached, err := auth.IsBreachedPassword(breachedFile, "password")
+		require.Nil(t, err)
+		require.False(t, breached)
+	})
+
+	t.Run("fails when the file is missing", func(t *testing.T) {
+		_, err := auth.IsBreachedPassword(filepath.Join(t.TempDir(), "missing.txt"), "password")
+		require.Error(t, err)
+	})
+}
+
+func TestValidateBreachedPasswordFile(t *testing.T) {
+	var passwords []string
+
+	for idx := range 1000 {
+		passwords = append(passwords, fmt.Sprintf("breached-password-%d", idx))
+	}
+
+	writeFile := func(t *testing.T, contents string) string {
+		breachedFile := filepath.Join(t.TempDir(), "breached.txt")
+		require.Nil(t, os.WriteFile(breachedFile, []byte(contents), 0600))
+
+		return breachedFile
+	}
+
+	t.Run("accepts a sorted SHA-1 download", func(t *testing.T) {
+		for _, lineEnding := range []string{"\n", "\r\n"} {
+			require.Nil(t, auth.ValidateBreachedPasswordFile(writeBreachedPasswordFile(t, lineEnding, passwords...)))
+		}
+
+		require.Nil(t, auth.ValidateBreachedPasswordFile(writeBreachedPasswordFile(t, "\n", "password")))
+		require.Nil(t, auth.ValidateBreachedPasswordFile(writeFile(t, "0000000000000000000000000000000000000000\nabf7aad6438836dbe526aa231abde2d0eef74d42")))
+	})
+
+	t.Run("rejects a plain text password list", func(t *testing.T) {
+		breachedFile := writeFile(t, strings.Join(passwords, "\n")+"\n")
+
+		require.ErrorIs(t, auth.ValidateBreachedPasswordFile(breachedFile), auth.ErrInvalidBreachedPasswordFile)
+
+		_, err := auth.IsBreachedPassword(breachedFile, passwords[0])
+		require.ErrorIs(t, err, auth.ErrInvalidBreachedPasswordFile)
+	})
+
+	t.Run("rejects an unsorted file", func(t *testing.T) {
+		var lines []string
+
+		for _, password := range passwords {
+			lines = append(lines, fmt.Sprintf("%X:1", sha1.Sum([]byte(password))))
+		}
+
+		slices.Sort(lines)
+		slices.Reverse(lines)
+
+		require.ErrorIs(t, auth.ValidateBreachedPasswordFile(writeFile(t, strings.Join(lines, "\n")+"\n")), auth.ErrInvalidBreachedPasswordFile)
+	})
+
+	t.Run("rejects a file whose last line is out of order", func(t *testing.T) {
+		contents := "1111111111111111111111111111111111111111:1\n2222222222222222222222222222222222222222:1\n0000000000000000000000000000000000000000:1\n"
+		require.ErrorIs(t, auth.ValidateBreachedPasswordFile(writeFile(t, contents)), auth.ErrInvalidBreachedPasswordFile)
+	})
+
+	t.Run("rejects an NTLM download", func(t *testing.T) {
+		contents := "00000000000000000000000000000000:1\nFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:1\n"
+		require.ErrorIs(t, auth.ValidateBreachedPasswordFile(writeFile(t, contents)), auth.ErrInvalidBreachedPasswordFile)
+	})
+
+	t.Run("rejects an empty file", func(t *testing.T) {
+		require.ErrorIs(t, auth.ValidateBreachedPasswordFile(writeFile(t, "")), auth.ErrInvalidBreachedPasswordFile)
+	})
+
+	t.Run("fails when the file is missing", func(t *testing.T) {
+		require.Error(t, auth.ValidateBreachedPasswordFile(filepath.Join(t.TempDir(), "missing.txt")))
+	})
+}
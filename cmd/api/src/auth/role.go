@@ -34,7 +34,9 @@ type RoleTemplate struct {
 	Permissions model.Permissions
 }
 
-// Roles Note: Not the source of truth, changes here must be added to a migration *.sql file to update the roles & roles_permissions table
+// Roles returns the built-in role templates. These roles are flagged as built_in and cannot be modified through the API;
+// custom roles are managed at runtime via /api/v2/roles.
+// Note: Not the source of truth, changes here must be added to a migration *.sql file to update the roles & roles_permissions table
 func Roles() map[string]RoleTemplate {
 	permissions := Permissions()
 
@@ -0,0 +1,586 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	WebAuthnCeremonyCreate = "webauthn.create"
+	WebAuthnCeremonyGet    = "webauthn.get"
+
+	// WebAuthnCeremonyTimeout is how long a client has to complete a registration or assertion after requesting options
+	WebAuthnCeremonyTimeout = 5 * time.Minute
+
+	WebAuthnRelyingPartyName = "BloodHound"
+
+	webAuthnChallengeLength = 32
+	webAuthnCredentialType  = "public-key"
+
+	webAuthnFlagUserPresent            = 0x01
+	webAuthnFlagUserVerified           = 0x04
+	webAuthnFlagAttestedCredentialData = 0x40
+
+	// COSE algorithm identifiers, see https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+	COSEAlgorithmES256 int64 = -7
+	COSEAlgorithmEdDSA int64 = -8
+	COSEAlgorithmRS256 int64 = -257
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	webAuthnMinimumRSABits = 2048
+)
+
+var (
+	ErrInvalidWebAuthn = errors.New("invalid webauthn response")
+
+	// The errors below all wrap ErrInvalidWebAuthn
+	ErrWebAuthnChallenge          = fmt.Errorf("%w: challenge mismatch", ErrInvalidWebAuthn)
+	ErrWebAuthnCeremonyExpired    = fmt.Errorf("%w: ceremony expired or invalid", ErrInvalidWebAuthn)
+	ErrWebAuthnCeremonyConsumed   = fmt.Errorf("%w: ceremony has already been used", ErrInvalidWebAuthn)
+	ErrWebAuthnUnsupportedKey     = fmt.Errorf("%w: unsupported credential public key", ErrInvalidWebAuthn)
+	ErrWebAuthnSignature          = fmt.Errorf("%w: invalid assertion signature", ErrInvalidWebAuthn)
+	ErrWebAuthnSignCountRegressed = fmt.Errorf("%w: signature counter did not increase; the authenticator may have been cloned", ErrInvalidWebAuthn)
+
+	// WebAuthnSupportedAlgorithms lists the COSE algorithms offered to authenticators during registration, in order of preference
+	WebAuthnSupportedAlgorithms = []int64{COSEAlgorithmES256, COSEAlgorithmEdDSA, COSEAlgorithmRS256}
+)
+
+// WebAuthnBytes is binary data that is serialized as unpadded base64url, the encoding used by the WebAuthn JSON
+// serialization of credentials and options.
+type WebAuthnBytes []byte
+
+func (s WebAuthnBytes) String() string {
+	return base64.RawURLEncoding.EncodeToString(s)
+}
+
+func (s WebAuthnBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *WebAuthnBytes) UnmarshalJSON(data []byte) error {
+	var encoded string
+
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	} else if decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "=")); err != nil {
+		return fmt.Errorf("%w: malformed base64url value", ErrInvalidWebAuthn)
+	} else {
+		*s = decoded
+		return nil
+	}
+}
+
+// WebAuthnRelyingParty identifies this server to authenticators. The relying party ID is the host name of the root
+// URL and the origin is the root URL's scheme and host.
+type WebAuthnRelyingParty struct {
+	ID     string
+	Name   string
+	Origin string
+}
+
+func NewWebAuthnRelyingParty(rootURL url.URL) WebAuthnRelyingParty {
+	return WebAuthnRelyingParty{
+		ID:     rootURL.Hostname(),
+		Name:   WebAuthnRelyingPartyName,
+		Origin: rootURL.Scheme + "://" + rootURL.Host,
+	}
+}
+
+type WebAuthnRelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	ID          WebAuthnBytes `json:"id"`
+	Name        string        `json:"name"`
+	DisplayName string        `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int64  `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type string        `json:"type"`
+	ID   WebAuthnBytes `json:"id"`
+}
+
+func NewWebAuthnCredentialDescriptor(credentialID []byte) WebAuthnCredentialDescriptor {
+	return WebAuthnCredentialDescriptor{
+		Type: webAuthnCredentialType,
+		ID:   credentialID,
+	}
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions is the JSON form of PublicKeyCredentialCreationOptions passed to navigator.credentials.create()
+type WebAuthnCreationOptions struct {
+	RelyingParty           WebAuthnRelyingPartyEntity     `json:"rp"`
+	User                   WebAuthnUserEntity             `json:"user"`
+	Challenge              WebAuthnBytes                  `json:"challenge"`
+	PublicKeyParameters    []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions is the JSON form of PublicKeyCredentialRequestOptions passed to navigator.credentials.get()
+type WebAuthnRequestOptions struct {
+	Challenge        WebAuthnBytes                  `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RelyingPartyID   string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+// CreationOptions builds the options for registering a new credential for the given user. Existing credentials are
+// excluded so that an authenticator cannot be registered twice. Attestation is not requested; credentials are trusted
+// on registration by an authenticated user.
+func (s WebAuthnRelyingParty) CreationOptions(userID uuid.UUID, userName, displayName string, challenge []byte, existingCredentialIDs [][]byte) WebAuthnCreationOptions {
+	options := WebAuthnCreationOptions{
+		RelyingParty: WebAuthnRelyingPartyEntity{ID: s.ID, Name: s.Name},
+		User: WebAuthnUserEntity{
+			ID:          userID.Bytes(),
+			Name:        userName,
+			DisplayName: displayName,
+		},
+		Challenge:          challenge,
+		Timeout:            WebAuthnCeremonyTimeout.Milliseconds(),
+		ExcludeCredentials: make([]WebAuthnCredentialDescriptor, 0, len(existingCredentialIDs)),
+		AuthenticatorSelection: WebAuthnAuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}
+
+	for _, algorithm := range WebAuthnSupportedAlgorithms {
+		options.PublicKeyParameters = append(options.PublicKeyParameters, WebAuthnCredentialParameter{Type: webAuthnCredentialType, Algorithm: algorithm})
+	}
+
+	for _, credentialID := range existingCredentialIDs {
+		options.ExcludeCredentials = append(options.ExcludeCredentials, NewWebAuthnCredentialDescriptor(credentialID))
+	}
+
+	return options
+}
+
+// RequestOptions builds the options for asserting one of the given credentials
+func (s WebAuthnRelyingParty) RequestOptions(challenge []byte, credentialIDs [][]byte) WebAuthnRequestOptions {
+	options := WebAuthnRequestOptions{
+		Challenge:        challenge,
+		Timeout:          WebAuthnCeremonyTimeout.Milliseconds(),
+		RelyingPartyID:   s.ID,
+		AllowCredentials: make([]WebAuthnCredentialDescriptor, 0, len(credentialIDs)),
+		UserVerification: "preferred",
+	}
+
+	for _, credentialID := range credentialIDs {
+		options.AllowCredentials = append(options.AllowCredentials, NewWebAuthnCredentialDescriptor(credentialID))
+	}
+
+	return options
+}
+
+// WebAuthnRegistrationResponse is the JSON serialization of the PublicKeyCredential returned by navigator.credentials.create()
+type WebAuthnRegistrationResponse struct {
+	ID       string        `json:"id"`
+	RawID    WebAuthnBytes `json:"rawId"`
+	Type     string        `json:"type"`
+	Response struct {
+		ClientDataJSON    WebAuthnBytes `json:"clientDataJSON"`
+		AttestationObject WebAuthnBytes `json:"attestationObject"`
+	} `json:"response"`
+}
+
+// WebAuthnAssertionResponse is the JSON serialization of the PublicKeyCredential returned by navigator.credentials.get()
+type WebAuthnAssertionResponse struct {
+	ID       string        `json:"id"`
+	RawID    WebAuthnBytes `json:"rawId"`
+	Type     string        `json:"type"`
+	Response struct {
+		ClientDataJSON    WebAuthnBytes `json:"clientDataJSON"`
+		AuthenticatorData WebAuthnBytes `json:"authenticatorData"`
+		Signature         WebAuthnBytes `json:"signature"`
+		UserHandle        WebAuthnBytes `json:"userHandle,omitempty"`
+	} `json:"response"`
+}
+
+// WebAuthnRegisteredCredential is a credential that passed registration verification and may be stored for the user
+type WebAuthnRegisteredCredential struct {
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    uint32
+	UserVerified bool
+}
+
+type webAuthnClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type webAuthnAuthenticatorData struct {
+	RPIDHash            []byte
+	Flags               byte
+	SignCount           uint32
+	CredentialID        []byte
+	CredentialPublicKey []byte
+}
+
+// GenerateWebAuthnChallenge returns a new random challenge for a registration or assertion ceremony
+func GenerateWebAuthnChallenge() ([]byte, error) {
+	challenge := make([]byte, webAuthnChallengeLength)
+
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// VerifyRegistration validates the response to a registration ceremony against the challenge that was issued. The
+// attestation statement is not verified, matching the "none" attestation conveyance requested by CreationOptions.
+func (s WebAuthnRelyingParty) VerifyRegistration(challenge []byte, response WebAuthnRegistrationResponse) (WebAuthnRegisteredCredential, error) {
+	if response.Type != webAuthnCredentialType {
+		return WebAuthnRegisteredCredential{}, fmt.Errorf("%w: unexpected credential type %q", ErrInvalidWebAuthn, response.Type)
+	} else if err := s.verifyClientData(response.Response.ClientDataJSON, WebAuthnCeremonyCreate, challenge); err != nil {
+		return WebAuthnRegisteredCredential{}, err
+	}
+
+	decoded, _, err := cborDecode(response.Response.AttestationObject)
+	if err != nil {
+		return WebAuthnRegisteredCredential{}, fmt.Errorf("%w: malformed attestation object: %w", ErrInvalidWebAuthn, err)
+	}
+
+	attestationObject, ok := decoded.(map[any]any)
+	if !ok {
+		return WebAuthnRegisteredCredential{}, fmt.Errorf("%w: malformed attestation object", ErrInvalidWebAuthn)
+	}
+
+	rawAuthenticatorData, ok := attestationObject["authData"].([]byte)
+	if !ok {
+		return WebAuthnRegisteredCredential{}, fmt.Errorf("%w: attestation object is missing authenticator data", ErrInvalidWebAuthn)
+	}
+
+	if authenticatorData, err := s.verifyAuthenticatorData(rawAuthenticatorData); err != nil {
+		return WebAuthnRegisteredCredential{}, err
+	} else if authenticatorData.Flags&webAuthnFlagAttestedCredentialData == 0 {
+		return WebAuthnRegisteredCredential{}, fmt.Errorf("%w: authenticator data is missing the attested credential", ErrInvalidWebAuthn)
+	} else if len(response.RawID) > 0 && !bytes.Equal(response.RawID, authenticatorData.CredentialID) {
+		return WebAuthnRegisteredCredential{}, fmt.Errorf("%w: credential ID does not match the attested credential", ErrInvalidWebAuthn)
+	} else if _, _, err := parseCOSEKey(authenticatorData.CredentialPublicKey); err != nil {
+		return WebAuthnRegisteredCredential{}, err
+	} else {
+		return WebAuthnRegisteredCredential{
+			CredentialID: authenticatorData.CredentialID,
+			PublicKey:    authenticatorData.CredentialPublicKey,
+			SignCount:    authenticatorData.SignCount,
+			UserVerified: authenticatorData.Flags&webAuthnFlagUserVerified != 0,
+		}, nil
+	}
+}
+
+// VerifyAssertion validates the response to an assertion ceremony using the stored COSE public key of the credential
+// and returns the authenticator's new signature counter.
+func (s WebAuthnRelyingParty) VerifyAssertion(challenge []byte, response WebAuthnAssertionResponse, publicKey []byte, storedSignCount uint32) (uint32, error) {
+	if response.Type != webAuthnCredentialType {
+		return 0, fmt.Errorf("%w: unexpected credential type %q", ErrInvalidWebAuthn, response.Type)
+	} else if err := s.verifyClientData(response.Response.ClientDataJSON, WebAuthnCeremonyGet, challenge); err != nil {
+		return 0, err
+	} else if authenticatorData, err := s.verifyAuthenticatorData(response.Response.AuthenticatorData); err != nil {
+		return 0, err
+	} else if err := verifyCOSESignature(publicKey, response.Response.AuthenticatorData, response.Response.ClientDataJSON, response.Response.Signature); err != nil {
+		return 0, err
+	} else if err := verifyWebAuthnSignCount(authenticatorData.SignCount, storedSignCount); err != nil {
+		return 0, err
+	} else {
+		return authenticatorData.SignCount, nil
+	}
+}
+
+// verifyWebAuthnSignCount checks that an authenticator's signature counter moved forward. Authenticators that do not
+// implement a counter, such as most synced passkeys, always report zero; the counter can then not detect a cloned
+// authenticator or a replayed assertion, which is why every ceremony challenge must also be consumed exactly once. A
+// counter that was ever reported as nonzero must strictly increase, and may not fall back to zero.
+func verifyWebAuthnSignCount(signCount, storedSignCount uint32) error {
+	if signCount == 0 && storedSignCount == 0 {
+		return nil
+	} else if signCount <= storedSignCount {
+		return ErrWebAuthnSignCountRegressed
+	}
+
+	return nil
+}
+
+func (s WebAuthnRelyingParty) verifyClientData(rawClientData []byte, ceremony string, challenge []byte) error {
+	var clientData webAuthnClientData
+
+	if err := json.Unmarshal(rawClientData, &clientData); err != nil {
+		return fmt.Errorf("%w: malformed client data", ErrInvalidWebAuthn)
+	} else if clientData.Type != ceremony {
+		return fmt.Errorf("%w: unexpected client data type %q", ErrInvalidWebAuthn, clientData.Type)
+	} else if responseChallenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(clientData.Challenge, "=")); err != nil {
+		return ErrWebAuthnChallenge
+	} else if subtle.ConstantTimeCompare(responseChallenge, challenge) != 1 {
+		return ErrWebAuthnChallenge
+	} else if clientData.Origin != s.Origin {
+		return fmt.Errorf("%w: unexpected origin %q", ErrInvalidWebAuthn, clientData.Origin)
+	} else if clientData.CrossOrigin {
+		return fmt.Errorf("%w: cross origin ceremonies are not allowed", ErrInvalidWebAuthn)
+	}
+
+	return nil
+}
+
+func (s WebAuthnRelyingParty) verifyAuthenticatorData(rawAuthenticatorData []byte) (webAuthnAuthenticatorData, error) {
+	expectedRPIDHash := sha256.Sum256([]byte(s.ID))
+
+	if authenticatorData, err := parseWebAuthnAuthenticatorData(rawAuthenticatorData); err != nil {
+		return webAuthnAuthenticatorData{}, err
+	} else if subtle.ConstantTimeCompare(authenticatorData.RPIDHash, expectedRPIDHash[:]) != 1 {
+		return webAuthnAuthenticatorData{}, fmt.Errorf("%w: relying party ID mismatch", ErrInvalidWebAuthn)
+	} else if authenticatorData.Flags&webAuthnFlagUserPresent == 0 {
+		return webAuthnAuthenticatorData{}, fmt.Errorf("%w: user presence was not asserted", ErrInvalidWebAuthn)
+	} else {
+		return authenticatorData, nil
+	}
+}
+
+func parseWebAuthnAuthenticatorData(data []byte) (webAuthnAuthenticatorData, error) {
+	const (
+		headerLength     = 37 // rpIdHash (32) + flags (1) + signCount (4)
+		aaguidLength     = 16
+		credIDSizeLength = 2
+	)
+
+	if len(data) < headerLength {
+		return webAuthnAuthenticatorData{}, fmt.Errorf("%w: authenticator data is too short", ErrInvalidWebAuthn)
+	}
+
+	authenticatorData := webAuthnAuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+
+	if authenticatorData.Flags&webAuthnFlagAttestedCredentialData != 0 {
+		rest := data[headerLength:]
+
+		if len(rest) < aaguidLength+credIDSizeLength {
+			return webAuthnAuthenticatorData{}, fmt.Errorf("%w: attested credential data is too short", ErrInvalidWebAuthn)
+		}
+
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[aaguidLength:]))
+		rest = rest[aaguidLength+credIDSizeLength:]
+
+		if len(rest) < credentialIDLength {
+			return webAuthnAuthenticatorData{}, fmt.Errorf("%w: attested credential data is too short", ErrInvalidWebAuthn)
+		}
+
+		authenticatorData.CredentialID = append([]byte(nil), rest[:credentialIDLength]...)
+		rest = rest[credentialIDLength:]
+
+		// The credential public key is a COSE_Key map, possibly followed by extension data
+		if _, remaining, err := cborDecode(rest); err != nil {
+			return webAuthnAuthenticatorData{}, fmt.Errorf("%w: malformed credential public key: %w", ErrInvalidWebAuthn, err)
+		} else {
+			authenticatorData.CredentialPublicKey = append([]byte(nil), rest[:len(rest)-len(remaining)]...)
+		}
+	}
+
+	return authenticatorData, nil
+}
+
+func parseCOSEKey(rawKey []byte) (crypto.PublicKey, int64, error) {
+	decoded, _, err := cborDecode(rawKey)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrWebAuthnUnsupportedKey, err)
+	}
+
+	coseKey, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, 0, ErrWebAuthnUnsupportedKey
+	}
+
+	var (
+		keyType, _   = coseKey[int64(1)].(int64)
+		algorithm, _ = coseKey[int64(3)].(int64)
+		curve, _     = coseKey[int64(-1)].(int64)
+		x, _         = coseKey[int64(-2)].([]byte)
+		y, _         = coseKey[int64(-3)].([]byte)
+	)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == COSEAlgorithmES256 && curve == coseCurveP256:
+		if len(x) != 32 || len(y) != 32 {
+			return nil, 0, ErrWebAuthnUnsupportedKey
+		} else if _, err := ecdh.P256().NewPublicKey(append(append([]byte{0x04}, x...), y...)); err != nil {
+			// Rejects points that are not on the curve
+			return nil, 0, fmt.Errorf("%w: %w", ErrWebAuthnUnsupportedKey, err)
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, algorithm, nil
+
+	case keyType == coseKeyTypeOKP && algorithm == COSEAlgorithmEdDSA && curve == coseCurveEd25519:
+		if len(x) != ed25519.PublicKeySize {
+			return nil, 0, ErrWebAuthnUnsupportedKey
+		}
+
+		return ed25519.PublicKey(x), algorithm, nil
+
+	case keyType == coseKeyTypeRSA && algorithm == COSEAlgorithmRS256:
+		// For RSA keys the modulus and exponent occupy the -1 and -2 labels
+		modulus, _ := coseKey[int64(-1)].([]byte)
+		exponent := new(big.Int).SetBytes(x)
+
+		if publicKey := (&rsa.PublicKey{N: new(big.Int).SetBytes(modulus)}); publicKey.N.BitLen() < webAuthnMinimumRSABits {
+			return nil, 0, ErrWebAuthnUnsupportedKey
+		} else if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, 0, ErrWebAuthnUnsupportedKey
+		} else {
+			publicKey.E = int(exponent.Int64())
+			return publicKey, algorithm, nil
+		}
+
+	default:
+		return nil, 0, fmt.Errorf("%w: key type %d with algorithm %d", ErrWebAuthnUnsupportedKey, keyType, algorithm)
+	}
+}
+
+func verifyCOSESignature(rawKey, authenticatorData, clientDataJSON, signature []byte) error {
+	var (
+		clientDataHash = sha256.Sum256(clientDataJSON)
+		signedData     = append(append([]byte(nil), authenticatorData...), clientDataHash[:]...)
+		digest         = sha256.Sum256(signedData)
+	)
+
+	publicKey, _, err := parseCOSEKey(rawKey)
+	if err != nil {
+		return err
+	}
+
+	switch typedKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(typedKey, digest[:], signature) {
+			return ErrWebAuthnSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(typedKey, signedData, signature) {
+			return ErrWebAuthnSignature
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(typedKey, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrWebAuthnSignature
+		}
+	default:
+		return ErrWebAuthnUnsupportedKey
+	}
+
+	return nil
+}
+
+// WebAuthnCeremonyData are the claims of a ceremony token. Ceremony tokens carry the challenge issued with a set of
+// options back to the server, signed with the session signing key, so that no ceremony state needs to be stored until
+// the challenge is answered. Each token has a unique ID that the caller must consume when the token is presented so
+// that its challenge can only be answered once.
+type WebAuthnCeremonyData struct {
+	jwt.StandardClaims
+	Ceremony  string        `json:"ceremony"`
+	Challenge WebAuthnBytes `json:"challenge"`
+}
+
+// NewWebAuthnCeremonyToken signs a ceremony token binding the challenge to the ceremony type and user
+func NewWebAuthnCeremonyToken(signingKey []byte, ceremony string, userID uuid.UUID, challenge []byte, now time.Time) (string, error) {
+	ceremonyID, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	claims := WebAuthnCeremonyData{
+		StandardClaims: jwt.StandardClaims{
+			Id:        ceremonyID.String(),
+			Subject:   userID.String(),
+			IssuedAt:  now.UTC().Unix(),
+			ExpiresAt: now.UTC().Add(WebAuthnCeremonyTimeout).Unix(),
+		},
+		Ceremony:  ceremony,
+		Challenge: challenge,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(signingKey)
+}
+
+// ParseWebAuthnCeremonyToken validates a ceremony token issued for the given ceremony type and user and returns its
+// claims. The token is not consumed; see WebAuthnCeremonyData.
+func ParseWebAuthnCeremonyToken(signingKey []byte, ceremonyToken, ceremony string, userID uuid.UUID) (WebAuthnCeremonyData, error) {
+	claims := WebAuthnCeremonyData{}
+
+	if token, err := jwt.ParseWithClaims(ceremonyToken, &claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrWebAuthnCeremonyExpired
+		}
+
+		return signingKey, nil
+	}); err != nil || !token.Valid {
+		return WebAuthnCeremonyData{}, ErrWebAuthnCeremonyExpired
+	} else if claims.Ceremony != ceremony || claims.Subject != userID.String() || claims.Id == "" || len(claims.Challenge) == 0 {
+		return WebAuthnCeremonyData{}, ErrWebAuthnCeremonyExpired
+	} else {
+		return claims, nil
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
+	"github.com/stretchr/testify/require"
+)
+
+// testAuthenticator emulates a WebAuthn authenticator holding a single credential
+type testAuthenticator struct {
+	credentialID []byte
+	signer       crypto.Signer
+	coseKey      []byte
+	signCount    uint32
+	counterless  bool // Emulates authenticators that always report a zero signature counter
+}
+
+func newES256TestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	return &testAuthenticator{
+		credentialID: []byte("es256-credential"),
+		signer:       key,
+		coseKey: cborMap(
+			cborInt(1), cborInt(2),
+			cborInt(3), cborInt(-7),
+			cborInt(-1), cborInt(1),
+			cborInt(-2), cborBytes(key.X.FillBytes(make([]byte, 32))),
+			cborInt(-3), cborBytes(key.Y.FillBytes(make([]byte, 32))),
+		),
+	}
+}
+
+func newEd25519TestAuthenticator(t *testing.T) *testAuthenticator {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+
+	return &testAuthenticator{
+		credentialID: []byte("ed25519-credential"),
+		signer:       privateKey,
+		coseKey: cborMap(
+			cborInt(1), cborInt(1),
+			cborInt(3), cborInt(-8),
+			cborInt(-1), cborInt(6),
+			cborInt(-2), cborBytes(publicKey),
+		),
+	}
+}
+
+func (s *testAuthenticator) authenticatorData(rpID string, attested bool) []byte {
+	var (
+		rpIDHash = sha256.Sum256([]byte(rpID))
+		flags    = byte(0x01 | 0x04)
+		data     = append([]byte(nil), rpIDHash[:]...)
+	)
+
+	if attested {
+		flags |= 0x40
+	}
+
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, s.signCount)
+
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(s.credentialID)))
+		data = append(data, s.credentialID...)
+		data = append(data, s.coseKey...)
+	}
+
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremony string, challenge []byte, origin string) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":      ceremony,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    origin,
+	})
+	require.Nil(t, err)
+
+	return data
+}
+
+func (s *testAuthenticator) register(t *testing.T, rpID, origin string, challenge []byte) auth.WebAuthnRegistrationResponse {
+	var response auth.WebAuthnRegistrationResponse
+
+	response.ID = base64.RawURLEncoding.EncodeToString(s.credentialID)
+	response.RawID = s.credentialID
+	response.Type = "public-key"
+	response.Response.ClientDataJSON = clientDataJSON(t, auth.WebAuthnCeremonyCreate, challenge, origin)
+	response.Response.AttestationObject = cborMap(
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborMap(),
+		cborText("authData"), cborBytes(s.authenticatorData(rpID, true)),
+	)
+
+	return response
+}
+
+func (s *testAuthenticator) assert(t *testing.T, rpID, origin string, challenge []byte) auth.WebAuthnAssertionResponse {
+	var response auth.WebAuthnAssertionResponse
+
+	if !s.counterless {
+		s.signCount++
+	}
+
+	authenticatorData := s.authenticatorData(rpID, false)
+	clientData := clientDataJSON(t, auth.WebAuthnCeremonyGet, challenge, origin)
+	clientDataHash := sha256.Sum256(clientData)
+	signedData := append(append([]byte(nil), authenticatorData...), clientDataHash[:]...)
+
+	var (
+		signature []byte
+		err       error
+	)
+
+	if _, isEd25519 := s.signer.(ed25519.PrivateKey); isEd25519 {
+		signature, err = s.signer.Sign(rand.Reader, signedData, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(signedData)
+		signature, err = s.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	require.Nil(t, err)
+
+	response.ID = base64.RawURLEncoding.EncodeToString(s.credentialID)
+	response.RawID = s.credentialID
+	response.Type = "public-key"
+	response.Response.ClientDataJSON = clientData
+	response.Response.AuthenticatorData = authenticatorData
+	response.Response.Signature = signature
+
+	return response
+}
+
+func cborHeader(major byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return []byte{major<<5 | byte(argument)}
+	case argument <= 0xff:
+		return []byte{major<<5 | 24, byte(argument)}
+	default:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(argument))
+	}
+}
+
+func cborInt(value int64) []byte {
+	if value < 0 {
+		return cborHeader(1, uint64(-1-value))
+	}
+
+	return cborHeader(0, uint64(value))
+}
+
+func cborBytes(value []byte) []byte {
+	return append(cborHeader(2, uint64(len(value))), value...)
+}
+
+func cborText(value string) []byte {
+	return append(cborHeader(3, uint64(len(value))), value...)
+}
+
+func cborMap(items ...[]byte) []byte {
+	encoded := cborHeader(5, uint64(len(items)/2))
+
+	for _, item := range items {
+		encoded = append(encoded, item...)
+	}
+
+	return encoded
+}
+
+func TestWebAuthnRelyingParty_RegistrationAndAssertion(t *testing.T) {
+	rootURL, err := url.Parse("https://bloodhound.example.com:8443/ui")
+	require.Nil(t, err)
+
+	relyingParty := auth.NewWebAuthnRelyingParty(*rootURL)
+	require.Equal(t, "bloodhound.example.com", relyingParty.ID)
+	require.Equal(t, "https://bloodhound.example.com:8443", relyingParty.Origin)
+
+	for name, newAuthenticator := range map[string]func(t *testing.T) *testAuthenticator{
+		"ES256":   newES256TestAuthenticator,
+		"Ed25519": newEd25519TestAuthenticator,
+	} {
+		t.Run(name, func(t *testing.T) {
+			authenticator := newAuthenticator(t)
+
+			challenge, err := auth.GenerateWebAuthnChallenge()
+			require.Nil(t, err)
+
+			registered, err := relyingParty.VerifyRegistration(challenge, authenticator.register(t, relyingParty.ID, relyingParty.Origin, challenge))
+			require.Nil(t, err)
+			require.Equal(t, authenticator.credentialID, registered.CredentialID)
+			require.Equal(t, authenticator.coseKey, registered.PublicKey)
+			require.True(t, registered.UserVerified)
+
+			challenge, err = auth.GenerateWebAuthnChallenge()
+			require.Nil(t, err)
+
+			signCount, err := relyingParty.VerifyAssertion(challenge, authenticator.assert(t, relyingParty.ID, relyingParty.Origin, challenge), registered.PublicKey, registered.SignCount)
+			require.Nil(t, err)
+			require.Equal(t, uint32(1), signCount)
+		})
+	}
+}
+
+func TestWebAuthnRelyingParty_VerifyRegistration_Invalid(t *testing.T) {
+	var (
+		relyingParty  = auth.WebAuthnRelyingParty{ID: "bloodhound.example.com", Origin: "https://bloodhound.example.com"}
+		authenticator = newES256TestAuthenticator(t)
+		challenge     = []byte("registration-challenge")
+	)
+
+	t.Run("challenge mismatch", func(t *testing.T) {
+		_, err := relyingParty.VerifyRegistration([]byte("other-challenge"), authenticator.register(t, relyingParty.ID, relyingParty.Origin, challenge))
+		require.ErrorIs(t, err, auth.ErrWebAuthnChallenge)
+	})
+
+	t.Run("origin mismatch", func(t *testing.T) {
+		_, err := relyingParty.VerifyRegistration(challenge, authenticator.register(t, relyingParty.ID, "https://evil.example.com", challenge))
+		require.ErrorIs(t, err, auth.ErrInvalidWebAuthn)
+	})
+
+	t.Run("relying party mismatch", func(t *testing.T) {
+		_, err := relyingParty.VerifyRegistration(challenge, authenticator.register(t, "evil.example.com", relyingParty.Origin, challenge))
+		require.ErrorIs(t, err, auth.ErrInvalidWebAuthn)
+	})
+
+	t.Run("wrong ceremony", func(t *testing.T) {
+		response := authenticator.register(t, relyingParty.ID, relyingParty.Origin, challenge)
+		response.Response.ClientDataJSON = clientDataJSON(t, auth.WebAuthnCeremonyGet, challenge, relyingParty.Origin)
+
+		_, err := relyingParty.VerifyRegistration(challenge, response)
+		require.ErrorIs(t, err, auth.ErrInvalidWebAuthn)
+	})
+
+	t.Run("truncated attestation object", func(t *testing.T) {
+		response := authenticator.register(t, relyingParty.ID, relyingParty.Origin, challenge)
+		response.Response.AttestationObject = response.Response.AttestationObject[:40]
+
+		_, err := relyingParty.VerifyRegistration(challenge, response)
+		require.ErrorIs(t, err, auth.ErrInvalidWebAuthn)
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		unsupported := newES256TestAuthenticator(t)
+		unsupported.coseKey = cborMap(cborInt(1), cborInt(2), cborInt(3), cborInt(-35))
+
+		_, err := relyingParty.VerifyRegistration(challenge, unsupported.register(t, relyingParty.ID, relyingParty.Origin, challenge))
+		require.ErrorIs(t, err, auth.ErrWebAuthnUnsupportedKey)
+	})
+}
+
+func TestWebAuthnRelyingParty_VerifyAssertion_Invalid(t *testing.T) {
+	var (
+		relyingParty  = auth.WebAuthnRelyingParty{ID: "bloodhound.example.com", Origin: "https://bloodhound.example.com"}
+		authenticator = newES256TestAuthenticator(t)
+		challenge     = []byte("assertion-challenge")
+	)
+
+	t.Run("signature from another key", func(t *testing.T) {
+		other := newES256TestAuthenticator(t)
+
+		_, err := relyingParty.VerifyAssertion(challenge, other.assert(t, relyingParty.ID, relyingParty.Origin, challenge), authenticator.coseKey, 0)
+		require.ErrorIs(t, err, auth.ErrWebAuthnSignature)
+	})
+
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		response := authenticator.assert(t, relyingParty.ID, relyingParty.Origin, challenge)
+		response.Response.AuthenticatorData[33]++
+
+		_, err := relyingParty.VerifyAssertion(challenge, response, authenticator.coseKey, 0)
+		require.ErrorIs(t, err, auth.ErrWebAuthnSignature)
+	})
+
+	t.Run("signature counter regressed", func(t *testing.T) {
+		response := authenticator.assert(t, relyingParty.ID, relyingParty.Origin, challenge)
+
+		_, err := relyingParty.VerifyAssertion(challenge, response, authenticator.coseKey, authenticator.signCount)
+		require.ErrorIs(t, err, auth.ErrWebAuthnSignCountRegressed)
+	})
+
+	t.Run("challenge mismatch", func(t *testing.T) {
+		_, err := relyingParty.VerifyAssertion([]byte("other-challenge"), authenticator.assert(t, relyingParty.ID, relyingParty.Origin, challenge), authenticator.coseKey, 0)
+		require.ErrorIs(t, err, auth.ErrWebAuthnChallenge)
+	})
+
+	t.Run("signature counter fell back to zero", func(t *testing.T) {
+		counterless := newES256TestAuthenticator(t)
+		counterless.counterless = true
+
+		_, err := relyingParty.VerifyAssertion(challenge, counterless.assert(t, relyingParty.ID, relyingParty.Origin, challenge), counterless.coseKey, 5)
+		require.ErrorIs(t, err, auth.ErrWebAuthnSignCountRegressed)
+	})
+}
+
+func TestWebAuthnRelyingParty_VerifyAssertion_Counterless(t *testing.T) {
+	var (
+		relyingParty  = auth.WebAuthnRelyingParty{ID: "bloodhound.example.com", Origin: "https://bloodhound.example.com"}
+		authenticator = newES256TestAuthenticator(t)
+		challenge     = []byte("assertion-challenge")
+	)
+
+	authenticator.counterless = true
+
+	// Replays of counterless assertions are stopped by consuming the ceremony, not by the counter
+	for range 2 {
+		signCount, err := relyingParty.VerifyAssertion(challenge, authenticator.assert(t, relyingParty.ID, relyingParty.Origin, challenge), authenticator.coseKey, 0)
+		require.Nil(t, err)
+		require.Zero(t, signCount)
+	}
+}
+
+func TestWebAuthnCeremonyToken(t *testing.T) {
+	var (
+		signingKey = []byte("signing-key")
+		userID     = uuid.Must(uuid.NewV4())
+		challenge  = []byte("ceremony-challenge")
+	)
+
+	ceremonyToken, err := auth.NewWebAuthnCeremonyToken(signingKey, auth.WebAuthnCeremonyGet, userID, challenge, time.Now())
+	require.Nil(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		parsed, err := auth.ParseWebAuthnCeremonyToken(signingKey, ceremonyToken, auth.WebAuthnCeremonyGet, userID)
+		require.Nil(t, err)
+		require.Equal(t, challenge, []byte(parsed.Challenge))
+		require.NotEmpty(t, parsed.Id)
+	})
+
+	t.Run("unique ceremony IDs", func(t *testing.T) {
+		otherToken, err := auth.NewWebAuthnCeremonyToken(signingKey, auth.WebAuthnCeremonyGet, userID, challenge, time.Now())
+		require.Nil(t, err)
+
+		first, err := auth.ParseWebAuthnCeremonyToken(signingKey, ceremonyToken, auth.WebAuthnCeremonyGet, userID)
+		require.Nil(t, err)
+		second, err := auth.ParseWebAuthnCeremonyToken(signingKey, otherToken, auth.WebAuthnCeremonyGet, userID)
+		require.Nil(t, err)
+		require.NotEqual(t, first.Id, second.Id)
+	})
+
+	t.Run("other ceremony", func(t *testing.T) {
+		_, err := auth.ParseWebAuthnCeremonyToken(signingKey, ceremonyToken, auth.WebAuthnCeremonyCreate, userID)
+		require.ErrorIs(t, err, auth.ErrWebAuthnCeremonyExpired)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		_, err := auth.ParseWebAuthnCeremonyToken(signingKey, ceremonyToken, auth.WebAuthnCeremonyGet, uuid.Must(uuid.NewV4()))
+		require.ErrorIs(t, err, auth.ErrWebAuthnCeremonyExpired)
+	})
+
+	t.Run("other signing key", func(t *testing.T) {
+		_, err := auth.ParseWebAuthnCeremonyToken([]byte("other-key"), ceremonyToken, auth.WebAuthnCeremonyGet, userID)
+		require.ErrorIs(t, err, auth.ErrWebAuthnCeremonyExpired)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiredToken, err := auth.NewWebAuthnCeremonyToken(signingKey, auth.WebAuthnCeremonyGet, userID, challenge, time.Now().Add(-time.Hour))
+		require.Nil(t, err)
+
+		_, err = auth.ParseWebAuthnCeremonyToken(signingKey, expiredToken, auth.WebAuthnCeremonyGet, userID)
+		require.ErrorIs(t, err, auth.ErrWebAuthnCeremonyExpired)
+	})
+}
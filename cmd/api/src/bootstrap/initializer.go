@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/specterops/bloodhound/cmd/api/src/auth"
 	"github.com/specterops/bloodhound/cmd/api/src/config"
 	"github.com/specterops/bloodhound/cmd/api/src/daemons"
 	"github.com/specterops/bloodhound/cmd/api/src/database"
@@ -62,6 +63,12 @@ func (s Initializer[DBType, GraphType]) Launch(parentCtx context.Context, handle
 		return fmt.Errorf("failed to ensure server directories: %w", err)
 	}
 
+	if s.Configuration.BreachedPasswordsFile != "" {
+		if err := auth.ValidateBreachedPasswordFile(s.Configuration.BreachedPasswordsFile); err != nil {
+			return fmt.Errorf("invalid breached passwords file %s: %w", s.Configuration.BreachedPasswordsFile, err)
+		}
+	}
+
 	if databaseConnections, err = s.DBConnector(ctx, s.Configuration); err != nil {
 		return fmt.Errorf("failed to connect to databases: %w", err)
 	}
@@ -43,6 +43,7 @@ type Context struct {
 	RequestedURL model.AuditableURL
 	RequestIP    string
 	RemoteAddr   string
+	UserAgent    string
 }
 
 func (s *Context) ConstructGoContext() context.Context {
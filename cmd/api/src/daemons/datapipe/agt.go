@@ -22,12 +22,14 @@ import (
 	"log/slog"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
 	"github.com/specterops/bloodhound/packages/go/analysis"
+	azureanalysis "github.com/specterops/bloodhound/packages/go/analysis/azure"
 	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
 	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
 	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
@@ -65,8 +67,10 @@ func (s nodeWithSourceSet) AddIfNotExists(node *nodeWithSource) bool {
 	return true
 }
 
-// FetchNodesFromSeeds fetches all seed nodes along with any child or parent nodes via known expansion paths
-func FetchNodesFromSeeds(ctx context.Context, graphDb graph.Database, seeds []model.SelectorSeed, expansionMethod model.AssetGroupExpansionMethod, limit int) nodeWithSourceSet {
+// FetchNodesFromSeeds fetches all seed nodes along with any child or parent nodes via known expansion paths. When the
+// limit is reached, the stage that reached it is returned alongside the truncated result; otherwise the returned stage
+// is 0.
+func FetchNodesFromSeeds(ctx context.Context, graphDb graph.Database, seeds []model.SelectorSeed, expansionMethod model.AssetGroupExpansionMethod, limit int) (nodeWithSourceSet, model.AssetGroupSelectorNodeSource) {
 	var (
 		seedNodes = make(nodeWithSourceSet)
 		result    = make(nodeWithSourceSet)
@@ -102,6 +106,22 @@ func FetchNodesFromSeeds(ctx context.Context, graphDb graph.Database, seeds []mo
 						seedNodes.AddIfNotExists(nodeWithSrc)
 					}
 				}
+			case model.SelectorTypeGroupMembers, model.SelectorTypeOUContainment, model.SelectorTypePropertyMatch, model.SelectorTypeKind:
+				if cypherQuery, parameters, err := seed.StructuredQuery(); err != nil {
+					slog.WarnContext(ctx, "AGT: Invalid Structured Seed", "seedType", seed.Type, "seedValue", seed.Value, "err", err)
+				} else if nodes, err := fetchNodesByParameterizedQuery(tx, cypherQuery, parameters, limit); err != nil {
+					slog.WarnContext(ctx, "AGT: Fetch Structured Seed Err", "seedType", seed.Type, "cypherQuery", cypherQuery, "err", err)
+				} else {
+					for _, node := range nodes {
+						nodeWithSrc := &nodeWithSource{Source: model.AssetGroupSelectorNodeSourceSeed, Node: node}
+						if result.AddIfNotExists(nodeWithSrc) {
+							if result.LimitReached(limit) {
+								return nil
+							}
+						}
+						seedNodes.AddIfNotExists(nodeWithSrc)
+					}
+				}
 			default:
 				slog.WarnContext(ctx, fmt.Sprintf("AGT: Unsupported selector type: %d", seed.Type))
 			}
@@ -109,14 +129,16 @@ func FetchNodesFromSeeds(ctx context.Context, graphDb graph.Database, seeds []mo
 		return nil
 	})
 
-	if expansionMethod == model.AssetGroupExpansionMethodNone || result.LimitReached(limit) || len(result) == 0 {
-		return result
+	if result.LimitReached(limit) {
+		return result, model.AssetGroupSelectorNodeSourceSeed
+	} else if expansionMethod == model.AssetGroupExpansionMethodNone || len(result) == 0 {
+		return result, 0
 	}
 
 	if expansionMethod == model.AssetGroupExpansionMethodAll || expansionMethod == model.AssetGroupExpansionMethodChildren {
 		collected := fetchAllChildNodes(ctx, graphDb, seedNodes, result, limit)
 		if result.LimitReached(limit) {
-			return result
+			return result, model.AssetGroupSelectorNodeSourceChild
 		}
 
 		// Add any newly collected child nodes to seeds for optional parent expansion below
@@ -127,9 +149,35 @@ func FetchNodesFromSeeds(ctx context.Context, graphDb graph.Database, seeds []mo
 
 	if expansionMethod == model.AssetGroupExpansionMethodAll || expansionMethod == model.AssetGroupExpansionMethodParents {
 		fetchParentNodes(ctx, graphDb, seedNodes, result, limit)
+		if result.LimitReached(limit) {
+			return result, model.AssetGroupSelectorNodeSourceParent
+		}
 	}
 
-	return result
+	return result, 0
+}
+
+// fetchNodesByParameterizedQuery - behaves like ops.FetchNodesByQuery but binds the given parameters to the query
+func fetchNodesByParameterizedQuery(tx graph.Transaction, cypherQuery string, parameters map[string]any, limit int) (graph.NodeSet, error) {
+	nodes := graph.NodeSet{}
+
+	if result := tx.Query(cypherQuery, parameters); result.Error() != nil {
+		return nodes, result.Error()
+	} else {
+		defer result.Close()
+
+		for (limit <= 0 || nodes.Len() < limit) && result.Next() {
+			var node graph.Node
+
+			if err := result.Scan(&node); err != nil {
+				return nil, err
+			}
+
+			nodes.Add(&node)
+		}
+
+		return nodes, result.Error()
+	}
 }
 
 // fetchChildNodes - fetches all children for a single node and submits any found to supplied collector ch
@@ -160,9 +208,13 @@ func fetchChildNodes(ctx context.Context, tx traversal.Traversal, node *graph.No
 		// MATCH (n:AZResourceGroup)-[:AZContains*..]->(m:AZBase) RETURN m
 		// MATCH (n:AZManagementGroup)-[:AZContains*..]->(m:AZBase) RETURN m
 		// MATCH (n:AZSubscription)-[:AZContains*..]->(m:AZBase) RETURN m
+		// MATCH (n:AZSubscription)-[:AZContains*..]->(:AZBase)-[:AZManagedIdentity]->(m:AZServicePrincipal) RETURN m
 		pattern = traversal.NewPattern().OutboundWithDepth(0, 0, query.And(
 			query.KindIn(query.Relationship(), azure.Contains),
-			query.KindIn(query.End(), azure.Entity),
+			query.KindIn(query.End(), azureDescendentKinds(node)...),
+		)).OutboundWithDepth(0, 1, query.And(
+			query.KindIn(query.Relationship(), azure.ManagedIdentity),
+			query.KindIn(query.End(), azure.ServicePrincipal),
 		))
 	case node.Kinds.ContainsOneOf(azure.Role):
 		// MATCH (n:AZRole)<-[:AZHasRole|AZRoleEligible]-(m:AZBase) RETURN m
@@ -315,17 +367,35 @@ func fetchADParentNodes(ctx context.Context, tx traversal.Traversal, node *graph
 	return nil
 }
 
+// azureDescendentKinds - returns the kinds that may be contained by the given azure management group, subscription or resource group
+func azureDescendentKinds(node *graph.Node) []graph.Kind {
+	for _, kind := range []graph.Kind{azure.ManagementGroup, azure.Subscription, azure.ResourceGroup} {
+		if node.Kinds.ContainsOneOf(kind) {
+			return azureanalysis.GetDescendentKinds(kind)
+		}
+	}
+
+	return nil
+}
+
 // fetchAzureParentNodes -  fetches all parents for a single azure node and submits any found to supplied collector ch
 func fetchAzureParentNodes(ctx context.Context, tx traversal.Traversal, node *graph.Node, ch chan<- *nodeWithSource) error {
+	roleScopeKinds := azureanalysis.AzureRoleScopeKinds()
+
 	// MATCH (n:AZBase)-[:AZContains*..]->(m:AZBase) WHERE (n:Subscription) OR (n:ResourceGroup) OR (n:ManagementGroup) RETURN n
+	// MATCH (p:AZBase)-[:AZOwner|AZUserAccessAdministrator|AZContributor]->(n:AZBase)-[:AZContains*0..]->(m:AZBase) RETURN p
 	if err := tx.BreadthFirst(ctx, traversal.Plan{
 		Root: node,
 		Driver: traversal.NewPattern().InboundWithDepth(0, 0, query.And(
 			query.KindIn(query.Relationship(), azure.Contains),
 			query.KindIn(query.Start(), azure.Entity),
+		)).InboundWithDepth(0, 1, query.And(
+			query.KindIn(query.Relationship(), roleScopeKinds...),
+			query.KindIn(query.Start(), azure.Entity),
 		)).Do(func(path *graph.PathSegment) error {
 			path.WalkReverse(func(nextSegment *graph.PathSegment) bool {
-				if nextSegment.Node.Kinds.ContainsOneOf(azure.Subscription, azure.ResourceGroup, azure.ManagementGroup) {
+				if nextSegment.Node.Kinds.ContainsOneOf(azure.Subscription, azure.ResourceGroup, azure.ManagementGroup) ||
+					(nextSegment.Edge != nil && nextSegment.Edge.Kind.Is(roleScopeKinds...)) {
 					return channels.Submit(ctx, ch, &nodeWithSource{Source: model.AssetGroupSelectorNodeSourceParent, Node: nextSegment.Node})
 				}
 				return true
@@ -337,11 +407,18 @@ func fetchAzureParentNodes(ctx context.Context, tx traversal.Traversal, node *gr
 
 	if node.Kinds.ContainsOneOf(azure.ServicePrincipal) {
 		// MATCH (n:AZApp)-[:AZRunsAs]->(m:AZServicePrincipal) RETURN n
+		// MATCH (n:AZBase)-[:AZManagedIdentity]->(m:AZServicePrincipal) RETURN n
 		if err := tx.BreadthFirst(ctx, traversal.Plan{
 			Root: node,
-			Driver: traversal.NewPattern().InboundWithDepth(0, 1, query.And(
-				query.Kind(query.Relationship(), azure.RunsAs),
-				query.Kind(query.Start(), azure.App),
+			Driver: traversal.NewPattern().InboundWithDepth(0, 1, query.Or(
+				query.And(
+					query.Kind(query.Relationship(), azure.RunsAs),
+					query.Kind(query.Start(), azure.App),
+				),
+				query.And(
+					query.Kind(query.Relationship(), azure.ManagedIdentity),
+					query.Kind(query.Start(), azure.Entity),
+				),
 			)).Do(func(path *graph.PathSegment) error {
 				path.WalkReverse(func(nextSegment *graph.PathSegment) bool {
 					return channels.Submit(ctx, ch, &nodeWithSource{Source: model.AssetGroupSelectorNodeSourceParent, Node: nextSegment.Node})
@@ -412,12 +489,14 @@ func fetchOldSelectedNodes(ctx context.Context, db database.Database, selectorId
 // SelectNodes - selects all nodes for a given selector and diffs previous db state for minimal db updates
 func SelectNodes(ctx context.Context, db database.Database, graphDb graph.Database, selector model.AssetGroupTagSelector, expansionMethod model.AssetGroupExpansionMethod) error {
 	var (
-		countInserted int
-
 		certified   = model.AssetGroupCertificationNone
 		certifiedBy null.String
 
 		nodesToUpdate []model.AssetGroupSelectorNode
+		addedNodeIds  []graph.ID
+
+		startedAt      = time.Now()
+		expansionLimit = selector.GetExpansionLimit()
 	)
 
 	if selector.AutoCertify.ValueOrZero() {
@@ -426,7 +505,10 @@ func SelectNodes(ctx context.Context, db database.Database, graphDb graph.Databa
 	}
 
 	// 1. Grab the graph nodes
-	nodesWithSrcSet := FetchNodesFromSeeds(ctx, graphDb, selector.Seeds, expansionMethod, -1)
+	nodesWithSrcSet, limitReachedBy := FetchNodesFromSeeds(ctx, graphDb, selector.Seeds, expansionMethod, expansionLimit)
+	if limitReachedBy > 0 {
+		slog.WarnContext(ctx, "AGT: Selector reached its expansion limit, membership is incomplete", "selector", selector.Name, "limit", expansionLimit, "limitReachedBy", limitReachedBy)
+	}
 	// 2. Grab the already selected nodes
 	if oldSelectedNodesByNodeId, err := fetchOldSelectedNodes(ctx, db, selector.ID); err != nil {
 		return err
@@ -439,7 +521,7 @@ func SelectNodes(ctx context.Context, db database.Database, graphDb graph.Databa
 				if err = db.InsertSelectorNode(ctx, selector.AssetGroupTagId, selector.ID, id, certified, certifiedBy, node.Source, primaryKind, envId, objectId, displayName); err != nil {
 					return err
 				}
-				countInserted++
+				addedNodeIds = append(addedNodeIds, id)
 				// Auto certify is enabled but this node hasn't been certified, certify it. Further - update any out of sync node properties
 			} else if (selector.AutoCertify.ValueOrZero() && oldNode.Certified == model.AssetGroupCertificationNone) ||
 				oldNode.NodeName != displayName ||
@@ -474,15 +556,30 @@ func SelectNodes(ctx context.Context, db database.Database, graphDb graph.Databa
 		}
 
 		// Delete the selected nodes that need to be deleted
-		if len(oldSelectedNodesByNodeId) > 0 {
-			for nodeId := range oldSelectedNodesByNodeId {
-				if err = db.DeleteSelectorNodesByNodeId(ctx, selector.ID, nodeId); err != nil {
-					return err
-				}
+		removedNodeIds := make([]graph.ID, 0, len(oldSelectedNodesByNodeId))
+		for nodeId := range oldSelectedNodesByNodeId {
+			if err = db.DeleteSelectorNodesByNodeId(ctx, selector.ID, nodeId); err != nil {
+				return err
 			}
+			removedNodeIds = append(removedNodeIds, nodeId)
 		}
 
-		slog.Info("AGT: Completed selecting", "selector", selector.Name, "countTotal", len(nodesWithSrcSet), "countInserted", countInserted, "countUpdated", len(nodesToUpdate), "countDeleted", len(oldSelectedNodesByNodeId))
+		// 4. Record the run so membership changes can be tracked between evaluations
+		if _, err = db.CreateAssetGroupSelectorRun(ctx, model.AssetGroupSelectorRun{
+			SelectorId:      selector.ID,
+			AssetGroupTagId: selector.AssetGroupTagId,
+			StartedAt:       startedAt,
+			DurationMs:      time.Since(startedAt).Milliseconds(),
+			NodeCount:       len(nodesWithSrcSet),
+			AddedNodeIds:    addedNodeIds,
+			RemovedNodeIds:  removedNodeIds,
+			ExpansionLimit:  int(selector.ExpansionLimit.ValueOrZero()),
+			LimitReachedBy:  limitReachedBy,
+		}); err != nil {
+			return err
+		}
+
+		slog.Info("AGT: Completed selecting", "selector", selector.Name, "countTotal", len(nodesWithSrcSet), "countInserted", len(addedNodeIds), "countUpdated", len(nodesToUpdate), "countDeleted", len(removedNodeIds))
 	}
 	return nil
 }
@@ -543,9 +640,13 @@ func tagAssetGroupNodesForTag(ctx context.Context, db database.Database, graphDb
 
 			tagKind = tag.ToKind()
 
+			// Uncertified members are only withheld from the tag while certification enforcement is enabled
+			holdUncertified = tag.RequireCertify.Bool && appcfg.GetCertificationEnforcementParameter(ctx, db)
+
 			oldTaggedNodes         = cardinality.NewBitmap64()
 			newTaggedNodes         = cardinality.NewBitmap64()
 			missingSystemTagsNodes = cardinality.NewBitmap64()
+			previouslyTaggedNodes  graph.NodeSet
 		)
 
 		for _, selector := range selectors {
@@ -566,12 +667,13 @@ func tagAssetGroupNodesForTag(ctx context.Context, db database.Database, graphDb
 				return err
 			} else {
 				oldTaggedNodes = oldTaggedNodeSet.IDBitmap()
+				previouslyTaggedNodes = oldTaggedNodeSet
 
 				// 3. Diff the sets filling the respective sets for later db updates
 				for _, nodeDb := range selectedNodes {
 					if !nodesSeen.Contains(nodeDb.NodeId.Uint64()) {
 						// Skip any that are not certified when tag requires certification or are selected by disabled selectors
-						if tag.RequireCertify.Bool && nodeDb.Certified <= 0 {
+						if holdUncertified && nodeDb.Certified <= 0 {
 							continue
 						}
 
@@ -639,6 +741,13 @@ func tagAssetGroupNodesForTag(ctx context.Context, db database.Database, graphDb
 		}
 
 		slog.Info("AGT: Completed tagging", tag.ToType(), tag.Name, "total", countTotal, "tagged", newTaggedNodes.Cardinality(), "untagged", oldTaggedNodes.Cardinality())
+
+		// 6. Notify any webhooks subscribed to this tag of the membership change
+		if newTaggedNodes.Cardinality() > 0 || oldTaggedNodes.Cardinality() > 0 {
+			if err := queueAssetGroupTagMembershipChange(ctx, db, tag, selectedNodes, newTaggedNodes, previouslyTaggedNodes, oldTaggedNodes); err != nil {
+				slog.ErrorContext(ctx, "AGT: Error queueing membership change notifications", tag.ToType(), tag.Name, "err", err)
+			}
+		}
 	}
 	return nil
 }
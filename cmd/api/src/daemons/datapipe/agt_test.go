@@ -26,6 +26,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
 	schema "github.com/specterops/bloodhound/packages/go/graphschema"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
 	"github.com/specterops/bloodhound/packages/go/graphschema/common"
 	"github.com/specterops/dawgs/graph"
 	"github.com/stretchr/testify/require"
@@ -41,13 +42,13 @@ func TestAGT_FetchNodesFromSeeds_Expansions(t *testing.T) {
 	)
 
 	t.Run("FetchNodesFromSeeds with no expansion", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodNone, -1)
+		result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodNone, -1)
 		require.Len(t, result, 1)
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.OrganizationalUnitC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
 	})
 
 	t.Run("FetchNodesFromSeeds with only child expansion", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
+		result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
 		require.Len(t, result, 2)
 
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.OrganizationalUnitC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -55,7 +56,7 @@ func TestAGT_FetchNodesFromSeeds_Expansions(t *testing.T) {
 	})
 
 	t.Run("FetchNodesFromSeeds with only parent expansion", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodParents, -1)
+		result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodParents, -1)
 		require.Len(t, result, 2)
 
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.OrganizationalUnitC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -63,8 +64,9 @@ func TestAGT_FetchNodesFromSeeds_Expansions(t *testing.T) {
 	})
 
 	t.Run("FetchNodesFromSeeds with all expansions", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodAll, -1)
+		result, limitReachedBy := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodAll, -1)
 		require.Len(t, result, 3)
+		require.Zero(t, limitReachedBy)
 
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.OrganizationalUnitC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.UserC.ID].Source, model.AssetGroupSelectorNodeSourceChild)
@@ -72,11 +74,20 @@ func TestAGT_FetchNodesFromSeeds_Expansions(t *testing.T) {
 	})
 
 	t.Run("FetchNodesFromSeeds with all expansions with limit for seeds only", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodAll, 1)
+		result, limitReachedBy := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodAll, 1)
 		require.Len(t, result, 1)
+		require.Equal(t, model.AssetGroupSelectorNodeSourceSeed, limitReachedBy)
 
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.OrganizationalUnitC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
 	})
+
+	t.Run("FetchNodesFromSeeds with all expansions with limit reached by child expansion", func(t *testing.T) {
+		result, limitReachedBy := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodAll, 2)
+		require.Len(t, result, 2)
+		require.Equal(t, model.AssetGroupSelectorNodeSourceChild, limitReachedBy)
+
+		require.Equal(t, result[testContext.Harness.GPOEnforcement.UserC.ID].Source, model.AssetGroupSelectorNodeSourceChild)
+	})
 }
 
 func TestAGT_FetchNodesFromSeeds_ChildExpansion(t *testing.T) {
@@ -92,7 +103,7 @@ func TestAGT_FetchNodesFromSeeds_ChildExpansion(t *testing.T) {
 				seeds           = []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: seedObjectId}}
 			)
 
-			result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
 			require.Len(t, result, 3)
 
 			require.Equal(t, result[testContext.Harness.MembershipHarness.GroupB.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -110,7 +121,7 @@ func TestAGT_FetchNodesFromSeeds_ChildExpansion(t *testing.T) {
 				seedObjectId, _ = testContext.Harness.AZGroupMembership.Group.Properties.Get(common.ObjectID.String()).String()
 				seeds           = []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: seedObjectId}}
 			)
-			result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
 			require.Len(t, result, 4)
 
 			require.Equal(t, result[testContext.Harness.AZGroupMembership.Group.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -129,7 +140,7 @@ func TestAGT_FetchNodesFromSeeds_ChildExpansion(t *testing.T) {
 				seedObjectId, _ = testContext.Harness.OUHarness.OUA.Properties.Get(common.ObjectID.String()).String()
 				seeds           = []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: seedObjectId}}
 			)
-			result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, -1)
 			require.Len(t, result, 4)
 
 			require.Equal(t, result[testContext.Harness.OUHarness.OUA.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -148,7 +159,7 @@ func TestAGT_FetchNodesFromSeeds_ChildExpansion(t *testing.T) {
 				seedObjectId, _ = testContext.Harness.OUHarness.OUA.Properties.Get(common.ObjectID.String()).String()
 				seeds           = []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: seedObjectId}}
 			)
-			result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, 2)
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodChildren, 2)
 			require.Len(t, result, 2)
 
 			require.Equal(t, result[testContext.Harness.OUHarness.OUA.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -172,7 +183,7 @@ func TestAGT_FetchNodesFromSeeds_ParentExpansion(t *testing.T) {
 	)
 
 	t.Run("TestAGT_FetchNodesFromSeeds_ParentExpansion retrieves OUs from entities without limit", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodParents, -1)
+		result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodParents, -1)
 		require.Len(t, result, 3)
 
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.UserC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -181,7 +192,7 @@ func TestAGT_FetchNodesFromSeeds_ParentExpansion(t *testing.T) {
 	})
 
 	t.Run("TestAGT_FetchNodesFromSeeds_ParentExpansion with limit", func(t *testing.T) {
-		result := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodParents, 2)
+		result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, seeds, model.AssetGroupExpansionMethodParents, 2)
 		require.Len(t, result, 2)
 
 		require.Equal(t, result[testContext.Harness.GPOEnforcement.UserC.ID].Source, model.AssetGroupSelectorNodeSourceSeed)
@@ -193,3 +204,82 @@ func TestAGT_FetchNodesFromSeeds_ParentExpansion(t *testing.T) {
 		}
 	})
 }
+
+func TestAGT_FetchNodesFromSeeds_AzureExpansion(t *testing.T) {
+	testContext := integration.NewGraphTestContext(t, schema.DefaultGraphSchema())
+
+	var (
+		tenantID          = "tenant"
+		managementGroup   *graph.Node
+		subscription      *graph.Node
+		resourceGroup     *graph.Node
+		keyVault          *graph.Node
+		vm                *graph.Node
+		servicePrincipal  *graph.Node
+		subscriptionOwner *graph.Node
+		managementOwner   *graph.Node
+		keyVaultOwner     *graph.Node
+	)
+
+	setup := func(harness *integration.HarnessDetails) error {
+		managementGroup = testContext.NewAzureManagementGroup("MG", "mg", tenantID)
+		subscription = testContext.NewAzureSubscription("Sub", "sub", tenantID)
+		resourceGroup = testContext.NewAzureResourceGroup("RG", "rg", tenantID)
+		keyVault = testContext.NewAzureKeyVault("KV", "kv", tenantID)
+		vm = testContext.NewAzureVM("VM", "vm", tenantID)
+		servicePrincipal = testContext.NewAzureServicePrincipal("SP", "sp", tenantID)
+		subscriptionOwner = testContext.NewAzureUser("SubOwner", "subowner", "", "subowner", "", tenantID, false)
+		managementOwner = testContext.NewAzureUser("MGOwner", "mgowner", "", "mgowner", "", tenantID, false)
+		keyVaultOwner = testContext.NewAzureUser("KVOwner", "kvowner", "", "kvowner", "", tenantID, false)
+
+		testContext.NewRelationship(managementGroup, subscription, azure.Contains)
+		testContext.NewRelationship(subscription, resourceGroup, azure.Contains)
+		testContext.NewRelationship(resourceGroup, keyVault, azure.Contains)
+		testContext.NewRelationship(resourceGroup, vm, azure.Contains)
+		testContext.NewRelationship(vm, servicePrincipal, azure.ManagedIdentity)
+		testContext.NewRelationship(subscriptionOwner, subscription, azure.Owner)
+		testContext.NewRelationship(managementOwner, managementGroup, azure.UserAccessAdministrator)
+		testContext.NewRelationship(keyVaultOwner, keyVault, azure.Contributor)
+		return nil
+	}
+
+	objectIdSeeds := func(node *graph.Node) []model.SelectorSeed {
+		objectId, _ := node.Properties.Get(common.ObjectID.String()).String()
+		return []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: objectId}}
+	}
+
+	t.Run("FetchNodesFromSeeds_ChildExpansion retrieves subscription descendents and managed identities", func(t *testing.T) {
+		testContext.DatabaseTestWithSetup(setup, func(harness integration.HarnessDetails, db graph.Database) {
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, objectIdSeeds(subscription), model.AssetGroupExpansionMethodChildren, -1)
+			require.Len(t, result, 5)
+
+			require.Equal(t, model.AssetGroupSelectorNodeSourceSeed, result[subscription.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceChild, result[resourceGroup.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceChild, result[keyVault.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceChild, result[vm.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceChild, result[servicePrincipal.ID].Source)
+		})
+	})
+
+	t.Run("FetchNodesFromSeeds_ParentExpansion retrieves management group ancestors and scoped role holders", func(t *testing.T) {
+		testContext.DatabaseTestWithSetup(setup, func(harness integration.HarnessDetails, db graph.Database) {
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, objectIdSeeds(subscription), model.AssetGroupExpansionMethodParents, -1)
+			require.Len(t, result, 4)
+
+			require.Equal(t, model.AssetGroupSelectorNodeSourceSeed, result[subscription.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceParent, result[managementGroup.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceParent, result[subscriptionOwner.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceParent, result[managementOwner.ID].Source)
+		})
+	})
+
+	t.Run("FetchNodesFromSeeds_ParentExpansion retrieves resources running as a service principal", func(t *testing.T) {
+		testContext.DatabaseTestWithSetup(setup, func(harness integration.HarnessDetails, db graph.Database) {
+			result, _ := FetchNodesFromSeeds(context.Background(), testContext.Graph.Database, objectIdSeeds(servicePrincipal), model.AssetGroupExpansionMethodParents, -1)
+			require.Len(t, result, 2)
+
+			require.Equal(t, model.AssetGroupSelectorNodeSourceSeed, result[servicePrincipal.ID].Source)
+			require.Equal(t, model.AssetGroupSelectorNodeSourceParent, result[vm.ID].Source)
+		})
+	})
+}
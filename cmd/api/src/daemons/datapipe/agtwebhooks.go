@@ -0,0 +1,91 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+)
+
+// queueAssetGroupTagMembershipChange queues a membership change delivery for every webhook subscribed to the tag. Added
+// nodes are described from their selector node records while removed nodes are described from the graph.
+func queueAssetGroupTagMembershipChange(ctx context.Context, db database.Database, tag model.AssetGroupTag, selectedNodes []model.AssetGroupSelectorNode, added cardinality.Duplex[uint64], previouslyTagged graph.NodeSet, removed cardinality.Duplex[uint64]) error {
+	change := model.AssetGroupTagMembershipChange{
+		Event:      model.AssetGroupTagWebhookEventMembershipChanged,
+		OccurredAt: time.Now().UTC(),
+		Tag: model.AssetGroupTagWebhookTag{
+			ID:       tag.ID,
+			Name:     tag.Name,
+			Type:     tag.Type,
+			Position: tag.Position,
+		},
+		AddedCount:   int(added.Cardinality()),
+		RemovedCount: int(removed.Cardinality()),
+		Added:        []model.AssetGroupTagWebhookNode{},
+		Removed:      []model.AssetGroupTagWebhookNode{},
+	}
+
+	// The same node may be selected by several selectors so each is only described once
+	described := cardinality.NewBitmap64()
+	for _, selectedNode := range selectedNodes {
+		if len(change.Added) >= model.AssetGroupTagWebhookMaxNodes {
+			break
+		} else if added.Contains(selectedNode.NodeId.Uint64()) && described.CheckedAdd(selectedNode.NodeId.Uint64()) {
+			change.Added = append(change.Added, model.AssetGroupTagWebhookNode{
+				NodeId:      selectedNode.NodeId,
+				ObjectId:    selectedNode.NodeObjectId,
+				Name:        selectedNode.NodeName,
+				PrimaryKind: selectedNode.NodePrimaryKind,
+			})
+		}
+	}
+
+	removed.Each(func(nodeId uint64) bool {
+		if node := previouslyTagged.Get(graph.ID(nodeId)); node != nil {
+			objectId, _ := node.Properties.GetOrDefault(common.ObjectID.String(), "").String()
+			name, _ := node.Properties.GetOrDefault(common.Name.String(), "").String()
+
+			change.Removed = append(change.Removed, model.AssetGroupTagWebhookNode{
+				NodeId:      node.ID,
+				ObjectId:    objectId,
+				Name:        name,
+				PrimaryKind: analysis.GetNodeKindDisplayLabel(node),
+			})
+		}
+
+		return len(change.Removed) < model.AssetGroupTagWebhookMaxNodes
+	})
+
+	if payload, err := types.NewJSONBObject(change); err != nil {
+		return err
+	} else if queued, err := db.CreateAssetGroupTagWebhookDeliveries(ctx, tag.ID, change.Event, payload); err != nil {
+		return err
+	} else if queued > 0 {
+		slog.InfoContext(ctx, "AGT: Queued membership change notifications", tag.ToType(), tag.Name, "deliveries", queued, "added", change.AddedCount, "removed", change.RemovedCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueueAssetGroupTagMembershipChange(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		tag      = model.AssetGroupTag{ID: 1, Name: "Tier Zero", Type: model.AssetGroupTagTypeTier}
+
+		// Node 1 is selected by two selectors and node 2 was already tagged
+		selectedNodes = []model.AssetGroupSelectorNode{
+			{SelectorId: 1, NodeId: 1, NodeObjectId: "S-1-5-21-1", NodeName: "ADMIN", NodePrimaryKind: "User"},
+			{SelectorId: 2, NodeId: 1, NodeObjectId: "S-1-5-21-1", NodeName: "ADMIN", NodePrimaryKind: "User"},
+			{SelectorId: 2, NodeId: 2, NodeObjectId: "S-1-5-21-2", NodeName: "DA", NodePrimaryKind: "Group"},
+		}
+		removedNode = graph.NewNode(3, graph.AsProperties(map[string]any{
+			common.ObjectID.String(): "S-1-5-21-3",
+			common.Name.String():     "OLD",
+		}), ad.Entity, ad.Computer)
+		previouslyTagged = graph.NewNodeSet(removedNode, graph.NewNode(2, graph.NewProperties(), ad.Entity, ad.Group))
+		added            = cardinality.NewBitmap64With(1)
+		removed          = cardinality.NewBitmap64With(3)
+	)
+
+	mockDB.EXPECT().CreateAssetGroupTagWebhookDeliveries(gomock.Any(), tag.ID, model.AssetGroupTagWebhookEventMembershipChanged, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, _ model.AssetGroupTagWebhookEvent, payload types.JSONBObject) (int, error) {
+			var change model.AssetGroupTagMembershipChange
+			require.NoError(t, payload.Map(&change))
+
+			require.Equal(t, "Tier Zero", change.Tag.Name)
+			require.Equal(t, 1, change.AddedCount)
+			require.Equal(t, 1, change.RemovedCount)
+			require.Equal(t, []model.AssetGroupTagWebhookNode{{NodeId: 1, ObjectId: "S-1-5-21-1", Name: "ADMIN", PrimaryKind: "User"}}, change.Added)
+			require.Equal(t, []model.AssetGroupTagWebhookNode{{NodeId: 3, ObjectId: "S-1-5-21-3", Name: "OLD", PrimaryKind: ad.Computer.String()}}, change.Removed)
+			return 1, nil
+		})
+
+	require.NoError(t, queueAssetGroupTagMembershipChange(context.Background(), mockDB, tag, selectedNodes, added, previouslyTagged, removed))
+}
@@ -26,9 +26,11 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/analysis/azure"
 	"github.com/specterops/bloodhound/cmd/api/src/config"
 	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
 	"github.com/specterops/bloodhound/cmd/api/src/services/agi"
 	"github.com/specterops/bloodhound/cmd/api/src/services/dataquality"
+	"github.com/specterops/bloodhound/cmd/api/src/services/graphsnapshot"
 	"github.com/specterops/bloodhound/packages/go/analysis"
 	adAnalysis "github.com/specterops/bloodhound/packages/go/analysis/ad"
 	"github.com/specterops/dawgs/graph"
@@ -91,6 +93,17 @@ func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB gr
 		stats.LogStats()
 	}
 
+	if err := postExposureMetrics(ctx, db, graphDB); err != nil {
+		collectedErrors = append(collectedErrors, fmt.Errorf("error computing exposure metrics: %w", err))
+	}
+
+	// Tier violations depend on both the tagged tier members and the post-processed edges between them
+	if tieringEnabled {
+		if err := computeAssetGroupTagViolations(ctx, db, graphDB); err != nil {
+			collectedErrors = append(collectedErrors, fmt.Errorf("error computing tier violations: %w", err))
+		}
+	}
+
 	if !tieringEnabled {
 		if err := agi.RunAssetGroupIsolationCollections(ctx, db, graphDB); err != nil {
 			collectedErrors = append(collectedErrors, fmt.Errorf("asset group isolation collection failed: %w", err))
@@ -103,6 +116,10 @@ func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB gr
 		dataQualityFailed = true
 	}
 
+	if _, err := graphsnapshot.SaveGraphSnapshot(ctx, db, graphDB, appcfg.GetGraphSnapshotsParameter(ctx, db).Retention); err != nil {
+		collectedErrors = append(collectedErrors, fmt.Errorf("error saving graph snapshot: %w", err))
+	}
+
 	if len(collectedErrors) > 0 {
 		for _, err := range collectedErrors {
 			slog.ErrorContext(ctx, fmt.Sprintf("Analysis error encountered: %v", err))
@@ -117,3 +134,22 @@ func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB gr
 
 	return nil
 }
+
+// postExposureMetrics computes exposure metrics for the members of every asset group tag when enabled, otherwise the
+// metrics left over from earlier runs are cleared
+func postExposureMetrics(ctx context.Context, db database.Database, graphDB graph.Database) error {
+	if !appcfg.GetExposureMetricsParameter(ctx, db) {
+		return analysis.ClearExposureMetrics(ctx, graphDB)
+	} else if tags, err := db.GetAssetGroupTags(ctx, model.SQLFilter{}); err != nil {
+		return err
+	} else {
+		targetKinds := make(graph.Kinds, 0, len(tags))
+
+		for _, tag := range tags {
+			targetKinds = append(targetKinds, tag.ToKind())
+		}
+
+		_, err := analysis.PostExposureMetrics(ctx, graphDB, targetKinds)
+		return err
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/ops"
+	"github.com/specterops/dawgs/query"
+)
+
+var ErrNoOwnedTag = errors.New("no owned tag exists")
+
+// GenerateBlastRadiusReports generates every queued blast radius report that is ready to run
+func GenerateBlastRadiusReports(ctx context.Context, db database.Database, graphDb graph.Database) error {
+	if reports, err := db.GetQueuedBlastRadiusReports(ctx); err != nil {
+		return fmt.Errorf("fetching queued blast radius reports: %w", err)
+	} else if len(reports) == 0 {
+		return nil
+	} else if datapipeStatus, err := db.GetDatapipeStatus(ctx); err != nil {
+		return fmt.Errorf("fetching datapipe status: %w", err)
+	} else {
+		for _, report := range reports {
+			// Hold the report until owned principals marked before the request have been tagged by analysis
+			if report.WaitForAnalysis && !datapipeStatus.LastCompleteAnalysisAt.After(report.CreatedAt) {
+				continue
+			}
+
+			report.Status = model.BlastRadiusReportStatusRunning
+			report.StartedAt = null.TimeFrom(time.Now().UTC())
+			if err := db.UpdateBlastRadiusReport(ctx, report); err != nil {
+				return fmt.Errorf("starting blast radius report %d: %w", report.ID, err)
+			}
+
+			if result, err := computeBlastRadiusResult(ctx, db, graphDb); err != nil {
+				slog.ErrorContext(ctx, "Blast radius report failed", slog.Int64("report_id", report.ID), slog.String("err", err.Error()))
+				report.Status = model.BlastRadiusReportStatusFailed
+				report.StatusMessage = err.Error()
+			} else if resultObject, err := types.NewJSONBObject(result); err != nil {
+				report.Status = model.BlastRadiusReportStatusFailed
+				report.StatusMessage = err.Error()
+			} else {
+				report.Status = model.BlastRadiusReportStatusComplete
+				report.OwnedCount = result.OwnedCount
+				report.ReachableCount = result.ReachableCount
+				report.TierZeroReachableCount = len(result.TierZeroPaths)
+				report.Result = resultObject
+			}
+
+			report.CompletedAt = null.TimeFrom(time.Now().UTC())
+			if err := db.UpdateBlastRadiusReport(ctx, report); err != nil {
+				return fmt.Errorf("finishing blast radius report %d: %w", report.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// computeBlastRadiusResult resolves everything the members of the owned tag can reach, grouped by tier
+func computeBlastRadiusResult(ctx context.Context, db database.Database, graphDb graph.Database) (model.BlastRadiusResult, error) {
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "Finished computing blast radius report")()
+
+	var (
+		result      = model.BlastRadiusResult{Tiers: []model.BlastRadiusTier{}, Owned: []model.BlastRadiusPrincipal{}, TierZeroPaths: []model.BlastRadiusPath{}}
+		ownedTag    model.AssetGroupTag
+		ownedIDs    []graph.ID
+		tierMembers []cardinality.Duplex[uint64]
+		nodes       = map[graph.ID]*graph.Node{}
+		tierZero    = cardinality.NewBitmap64()
+		tagged      = cardinality.NewBitmap64()
+	)
+
+	tags, err := db.GetAssetGroupTags(ctx, model.SQLFilter{})
+	if err != nil {
+		return result, err
+	}
+
+	for _, tag := range tags {
+		if tag.Type == model.AssetGroupTagTypeOwned {
+			ownedTag = tag
+		}
+	}
+
+	if ownedTag.ID == 0 {
+		return result, ErrNoOwnedTag
+	}
+
+	tiers, err := db.GetOrderedAssetGroupTagTiers(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	if err := graphDb.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if ownedNodes, err := ops.FetchNodeSet(tx.Nodes().Filter(query.Kind(query.Node(), ownedTag.ToKind()))); err != nil {
+			return err
+		} else {
+			for _, node := range ownedNodes {
+				ownedIDs = append(ownedIDs, node.ID)
+				nodes[node.ID] = node
+			}
+		}
+
+		for _, tier := range tiers {
+			members := cardinality.NewBitmap64()
+
+			if tierNodes, err := ops.FetchNodeSet(tx.Nodes().Filter(query.Kind(query.Node(), tier.ToKind()))); err != nil {
+				return err
+			} else {
+				// A node only belongs to the highest tier it is tagged with
+				for _, node := range tierNodes {
+					if tagged.CheckedAdd(node.ID.Uint64()) {
+						members.Add(node.ID.Uint64())
+						nodes[node.ID] = node
+					}
+				}
+			}
+
+			tierMembers = append(tierMembers, members)
+		}
+
+		return nil
+	}); err != nil {
+		return result, fmt.Errorf("fetching owned and tier members: %w", err)
+	}
+
+	if len(tierMembers) > 0 {
+		tierZero = tierMembers[0]
+	}
+
+	blastRadius, err := analysis.ComputeBlastRadius(ctx, analysis.NewGraphOutboundAdjacency(graphDb, analysis.DefaultMaxOutboundAdjacencyEdges), ownedIDs, tierZero)
+	if err != nil {
+		return result, fmt.Errorf("computing blast radius: %w", err)
+	}
+
+	// Intermediate nodes along the Tier Zero paths are not necessarily tagged so their details are fetched separately
+	var pathNodeIDs []graph.ID
+	for _, path := range blastRadius.ShortestPaths {
+		for _, nodeID := range path.NodeIDs {
+			if _, found := nodes[nodeID]; !found {
+				pathNodeIDs = append(pathNodeIDs, nodeID)
+			}
+		}
+	}
+
+	if len(pathNodeIDs) > 0 {
+		if err := graphDb.ReadTransaction(ctx, func(tx graph.Transaction) error {
+			if pathNodes, err := ops.FetchNodeSet(tx.Nodes().Filter(query.InIDs(query.NodeID(), pathNodeIDs...))); err != nil {
+				return err
+			} else {
+				for _, node := range pathNodes {
+					nodes[node.ID] = node
+				}
+				return nil
+			}
+		}); err != nil {
+			return result, fmt.Errorf("fetching path nodes: %w", err)
+		}
+	}
+
+	result.OwnedCount = len(ownedIDs)
+	result.ReachableCount = int(blastRadius.Reachable.Cardinality())
+	result.UntieredReachableCount = result.ReachableCount
+
+	for idx, tier := range tiers {
+		reachable := tierMembers[idx].Clone()
+		reachable.And(blastRadius.Reachable)
+
+		reportTier := model.BlastRadiusTier{
+			TagId:          tier.ID,
+			Name:           tier.Name,
+			Position:       tier.Position.ValueOrZero(),
+			ReachableCount: int(reachable.Cardinality()),
+			Nodes:          []model.BlastRadiusNode{},
+		}
+
+		reachable.Each(func(nodeID uint64) bool {
+			reportTier.Nodes = append(reportTier.Nodes, newBlastRadiusNode(graph.ID(nodeID), nodes))
+			return true
+		})
+
+		sortBlastRadiusNodes(reportTier.Nodes)
+		result.UntieredReachableCount -= reportTier.ReachableCount
+		result.Tiers = append(result.Tiers, reportTier)
+	}
+
+	for _, ownedID := range ownedIDs {
+		var (
+			reachable         = blastRadius.ReachableByOwned[ownedID]
+			tierZeroReachable = reachable.Clone()
+		)
+
+		tierZeroReachable.And(tierZero)
+		result.Owned = append(result.Owned, model.BlastRadiusPrincipal{
+			BlastRadiusNode:        newBlastRadiusNode(ownedID, nodes),
+			ReachableCount:         int(reachable.Cardinality()),
+			TierZeroReachableCount: int(tierZeroReachable.Cardinality()),
+		})
+	}
+
+	slices.SortFunc(result.Owned, func(a, b model.BlastRadiusPrincipal) int {
+		return cmp.Or(cmp.Compare(b.ReachableCount, a.ReachableCount), cmp.Compare(a.Name, b.Name))
+	})
+
+	for targetID, path := range blastRadius.ShortestPaths {
+		reportPath := model.BlastRadiusPath{
+			Target:    newBlastRadiusNode(targetID, nodes),
+			Hops:      len(path.Kinds),
+			Nodes:     make([]model.BlastRadiusNode, 0, len(path.NodeIDs)),
+			EdgeKinds: path.Kinds.Strings(),
+		}
+
+		for _, nodeID := range path.NodeIDs {
+			reportPath.Nodes = append(reportPath.Nodes, newBlastRadiusNode(nodeID, nodes))
+		}
+
+		result.TierZeroPaths = append(result.TierZeroPaths, reportPath)
+	}
+
+	slices.SortFunc(result.TierZeroPaths, func(a, b model.BlastRadiusPath) int {
+		return cmp.Or(cmp.Compare(a.Hops, b.Hops), cmp.Compare(a.Target.Name, b.Target.Name))
+	})
+
+	return result, nil
+}
+
+func newBlastRadiusNode(nodeID graph.ID, nodes map[graph.ID]*graph.Node) model.BlastRadiusNode {
+	reportNode := model.BlastRadiusNode{ID: nodeID}
+
+	if node, found := nodes[nodeID]; found {
+		reportNode.ObjectId, _ = node.Properties.GetOrDefault(common.ObjectID.String(), "").String()
+		reportNode.Name, _ = node.Properties.GetWithFallback(common.Name.String(), "", common.DisplayName.String()).String()
+		reportNode.Kind = analysis.GetNodeKindDisplayLabel(node)
+	}
+
+	return reportNode
+}
+
+func sortBlastRadiusNodes(nodes []model.BlastRadiusNode) {
+	slices.SortFunc(nodes, func(a, b model.BlastRadiusNode) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+}
@@ -46,6 +46,8 @@ type Pipeline interface {
 	IngestTasks(context.Context) error
 	// Analyze provides a way to analyze and enhance graph data, including post processing
 	Analyze(context.Context) error
+	// GenerateReports provides a way to generate queued reports against the analyzed graph
+	GenerateReports(context.Context) error
 }
 
 type Daemon struct {
@@ -92,6 +94,8 @@ func (s *Daemon) Start(ctx context.Context) {
 
 			s.WithDatapipeStatus(ctx, model.DatapipeStatusAnalyzing, s.pipeline.Analyze)
 
+			s.WithDatapipeStatus(ctx, model.DatapipeStatusReporting, s.pipeline.GenerateReports)
+
 			datapipeLoopTimer.Reset(s.tickInterval)
 
 		case <-ctx.Done():
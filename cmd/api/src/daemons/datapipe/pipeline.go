@@ -239,3 +239,8 @@ func (s *BHCEPipeline) Analyze(ctx context.Context) error {
 		return nil
 	}
 }
+
+// GenerateReports generates any queued reports once ingest and analysis for this tick are done
+func (s *BHCEPipeline) GenerateReports(ctx context.Context) error {
+	return GenerateBlastRadiusReports(ctx, s.db, s.graphdb)
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/ops"
+	"github.com/specterops/dawgs/query"
+)
+
+// maxTierViolationVisits bounds the number of nodes visited while searching for multi-hop tier violations
+const maxTierViolationVisits = 1_000_000
+
+// tierViolationPath is the shortest path found from a tiered node into a node of a higher tier
+type tierViolationPath struct {
+	SourceID graph.ID
+	TargetID graph.ID
+	Kinds    []string
+}
+
+// findTierViolations walks the outbound adjacency breadth first from every tiered node for up to maxDepth hops and
+// returns the shortest path to each node of a higher tier that is reached. Tier ranks follow tier order so a lower rank
+// is a higher tier. A path is not followed past the first higher tier node it reaches since anything beyond that node
+// is reported as a violation of its own tier. Only the relationships of each frontier are loaded. The returned flag is
+// false when the visit budget or the adjacency budget ran out.
+func findTierViolations(ctx context.Context, outbound analysis.OutboundAdjacency, tierRanks map[graph.ID]int, maxDepth int) ([]tierViolationPath, bool, error) {
+	var (
+		violations []tierViolationPath
+		sourceIDs  = make([]graph.ID, 0, len(tierRanks))
+		visits     = 0
+	)
+
+	for nodeID, rank := range tierRanks {
+		// Nothing ranks above the first tier
+		if rank > 0 {
+			sourceIDs = append(sourceIDs, nodeID)
+		}
+	}
+
+	// Keep findings stable between runs when the visit budget is exhausted
+	slices.Sort(sourceIDs)
+
+	for _, sourceID := range sourceIDs {
+		var (
+			sourceRank = tierRanks[sourceID]
+			parents    = map[graph.ID]analysis.OutboundEdge{sourceID: {}}
+			frontier   = []graph.ID{sourceID}
+		)
+
+		for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+			var next []graph.ID
+
+			if err := outbound.Load(ctx, frontier); errors.Is(err, analysis.ErrAdjacencyTooLarge) {
+				return violations, false, nil
+			} else if err != nil {
+				return nil, false, err
+			}
+
+			for _, nodeID := range frontier {
+				for _, edge := range outbound.Outbound(nodeID) {
+					if _, visited := parents[edge.EndID]; visited {
+						continue
+					} else if visits++; visits > maxTierViolationVisits {
+						return violations, false, nil
+					}
+
+					// The parent map stores the previous node in EndID and the kind of the relationship taken
+					parents[edge.EndID] = analysis.OutboundEdge{EndID: nodeID, Kind: edge.Kind}
+
+					if targetRank, tiered := tierRanks[edge.EndID]; tiered && targetRank < sourceRank {
+						violations = append(violations, tierViolationPath{
+							SourceID: sourceID,
+							TargetID: edge.EndID,
+							Kinds:    tierViolationPathKinds(parents, sourceID, edge.EndID, depth),
+						})
+					} else {
+						next = append(next, edge.EndID)
+					}
+				}
+			}
+
+			frontier = next
+		}
+	}
+
+	return violations, true, nil
+}
+
+func tierViolationPathKinds(parents map[graph.ID]analysis.OutboundEdge, sourceID, targetID graph.ID, hops int) []string {
+	kinds := make([]string, hops)
+
+	for cursor := targetID; cursor != sourceID; cursor = parents[cursor].EndID {
+		hops--
+		kinds[hops] = parents[cursor].Kind.String()
+	}
+
+	return kinds
+}
+
+// computeAssetGroupTagViolations finds attack paths from members of lower tiers into members of higher tiers and
+// replaces the findings of the previous run with them
+func computeAssetGroupTagViolations(ctx context.Context, db database.Database, graphDb graph.Database) error {
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "Finished computing tier violations")()
+
+	var (
+		maxDepth   = appcfg.GetTierViolationsParameter(ctx, db).MaxDepth
+		tierRanks  = map[graph.ID]int{}
+		nodes      = map[graph.ID]*graph.Node{}
+		violations = model.AssetGroupTagViolations{}
+	)
+
+	tiers, err := db.GetOrderedAssetGroupTagTiers(ctx)
+	if err != nil {
+		return err
+	} else if len(tiers) < 2 {
+		// A single tier cannot be violated
+		return db.ReplaceAssetGroupTagViolations(ctx, violations)
+	}
+
+	if err := graphDb.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		for rank, tier := range tiers {
+			if tierNodes, err := ops.FetchNodeSet(tx.Nodes().Filter(query.Kind(query.Node(), tier.ToKind()))); err != nil {
+				return err
+			} else {
+				for _, node := range tierNodes {
+					if _, seen := tierRanks[node.ID]; !seen {
+						tierRanks[node.ID] = rank
+						nodes[node.ID] = node
+					}
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed fetching tier violation inputs: %w", err)
+	}
+
+	paths, complete, err := findTierViolations(ctx, analysis.NewGraphOutboundAdjacency(graphDb, analysis.DefaultMaxOutboundAdjacencyEdges), tierRanks, maxDepth)
+	if err != nil {
+		return fmt.Errorf("failed searching for tier violations: %w", err)
+	} else if !complete {
+		slog.WarnContext(ctx, "AGT: Tier violation search stopped early; results are incomplete", "maxVisits", maxTierViolationVisits, "maxEdges", analysis.DefaultMaxOutboundAdjacencyEdges, "maxDepth", maxDepth)
+	}
+
+	for _, path := range paths {
+		var (
+			source = nodes[path.SourceID]
+			target = nodes[path.TargetID]
+		)
+
+		violations = append(violations, model.AssetGroupTagViolation{
+			SourceTagId:    tiers[tierRanks[path.SourceID]].ID,
+			TargetTagId:    tiers[tierRanks[path.TargetID]].ID,
+			SourceNodeId:   path.SourceID,
+			SourceObjectId: tierViolationNodeProperty(source, common.ObjectID.String()),
+			SourceName:     tierViolationNodeProperty(source, common.Name.String(), common.DisplayName.String()),
+			TargetNodeId:   path.TargetID,
+			TargetObjectId: tierViolationNodeProperty(target, common.ObjectID.String()),
+			TargetName:     tierViolationNodeProperty(target, common.Name.String(), common.DisplayName.String()),
+			Hops:           len(path.Kinds),
+			EdgeKinds:      path.Kinds,
+		})
+	}
+
+	slog.InfoContext(ctx, "AGT: Computed tier violations", "count", len(violations), "maxDepth", maxDepth)
+
+	return db.ReplaceAssetGroupTagViolations(ctx, violations)
+}
+
+func tierViolationNodeProperty(node *graph.Node, key string, fallbackKeys ...string) string {
+	value, _ := node.Properties.GetWithFallback(key, "", fallbackKeys...).String()
+	return value
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package datapipe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTierViolations(t *testing.T) {
+	var (
+		// 1 and 2 are tier zero, 3 and 4 are tier one, 5 is tier two and 6 and 7 are untiered
+		tierRanks = map[graph.ID]int{1: 0, 2: 0, 3: 1, 4: 1, 5: 2}
+		outbound  = analysis.StaticOutboundAdjacency{
+			3: {{EndID: 1, Kind: ad.GenericAll}, {EndID: 6, Kind: ad.MemberOf}},
+			6: {{EndID: 7, Kind: ad.AdminTo}},
+			7: {{EndID: 2, Kind: ad.HasSession}},
+			5: {{EndID: 4, Kind: ad.GenericWrite}, {EndID: 3, Kind: ad.WriteDACL}},
+			4: {{EndID: 2, Kind: ad.Owns}},
+			1: {{EndID: 2, Kind: ad.DCSync}},
+		}
+	)
+
+	t.Run("direct edges only", func(t *testing.T) {
+		violations, complete, err := findTierViolations(context.Background(), outbound, tierRanks, 1)
+		require.NoError(t, err)
+		require.True(t, complete)
+		assert.ElementsMatch(t, []tierViolationPath{
+			{SourceID: 3, TargetID: 1, Kinds: []string{"GenericAll"}},
+			{SourceID: 4, TargetID: 2, Kinds: []string{"Owns"}},
+			{SourceID: 5, TargetID: 3, Kinds: []string{"WriteDacl"}},
+			{SourceID: 5, TargetID: 4, Kinds: []string{"GenericWrite"}},
+		}, violations)
+	})
+
+	t.Run("multi-hop paths stop at the first higher tier node", func(t *testing.T) {
+		violations, complete, err := findTierViolations(context.Background(), outbound, tierRanks, 3)
+		require.NoError(t, err)
+		require.True(t, complete)
+		assert.ElementsMatch(t, []tierViolationPath{
+			{SourceID: 3, TargetID: 1, Kinds: []string{"GenericAll"}},
+			{SourceID: 3, TargetID: 2, Kinds: []string{"MemberOf", "AdminTo", "HasSession"}},
+			{SourceID: 4, TargetID: 2, Kinds: []string{"Owns"}},
+			{SourceID: 5, TargetID: 3, Kinds: []string{"WriteDacl"}},
+			{SourceID: 5, TargetID: 4, Kinds: []string{"GenericWrite"}},
+		}, violations)
+	})
+
+	t.Run("exhausted adjacency budget returns incomplete results", func(t *testing.T) {
+		violations, complete, err := findTierViolations(context.Background(), exhaustedOutboundAdjacency{outbound}, tierRanks, 3)
+		require.NoError(t, err)
+		require.False(t, complete)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("adjacency errors are returned", func(t *testing.T) {
+		_, _, err := findTierViolations(context.Background(), failingOutboundAdjacency{outbound}, tierRanks, 3)
+		require.ErrorContains(t, err, "graph error")
+	})
+
+	t.Run("paths longer than the max depth are ignored", func(t *testing.T) {
+		violations, _, err := findTierViolations(context.Background(), outbound, tierRanks, 2)
+		require.NoError(t, err)
+		for _, violation := range violations {
+			assert.NotEqual(t, []string{"MemberOf", "AdminTo", "HasSession"}, violation.Kinds)
+		}
+	})
+}
+
+// exhaustedOutboundAdjacency fails every load as if the adjacency budget had run out
+type exhaustedOutboundAdjacency struct {
+	analysis.StaticOutboundAdjacency
+}
+
+func (s exhaustedOutboundAdjacency) Load(ctx context.Context, nodeIDs []graph.ID) error {
+	return analysis.ErrAdjacencyTooLarge
+}
+
+type failingOutboundAdjacency struct {
+	analysis.StaticOutboundAdjacency
+}
+
+func (s failingOutboundAdjacency) Load(ctx context.Context, nodeIDs []graph.ID) error {
+	return errors.New("graph error")
+}
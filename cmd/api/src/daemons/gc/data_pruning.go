@@ -49,16 +49,23 @@ func (s *Daemon) Start(ctx context.Context) {
 	defer close(s.exitC)
 	defer ticker.Stop()
 
-	// prune sessions and collections once when the daemon starts up
+	// prune sessions, expired auth tokens, consumed webauthn ceremonies, collections and selector runs once when the
+	// daemon starts up
 	s.db.SweepSessions(ctx)
+	s.db.SweepAuthTokens(ctx)
+	s.db.SweepWebAuthnCeremonies(ctx)
 	s.db.SweepAssetGroupCollections(ctx)
+	s.db.SweepAssetGroupSelectorRuns(ctx)
 
 	// thereafter, prune conditionally once a day
 	for {
 		select {
 		case <-ticker.C:
 			s.db.SweepSessions(ctx)
+			s.db.SweepAuthTokens(ctx)
+			s.db.SweepWebAuthnCeremonies(ctx)
 			s.db.SweepAssetGroupCollections(ctx)
+			s.db.SweepAssetGroupSelectorRuns(ctx)
 
 		case <-s.exitC:
 			return
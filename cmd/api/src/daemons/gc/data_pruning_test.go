@@ -55,9 +55,18 @@ func TestGC_Start(t *testing.T) {
 		// simulate some work being done
 		time.Sleep(1 * time.Millisecond)
 	})
+	mockDB.EXPECT().SweepAuthTokens(gomock.Any()).Do(func(ctx context.Context) {
+		time.Sleep(1 * time.Millisecond)
+	})
+	mockDB.EXPECT().SweepWebAuthnCeremonies(gomock.Any()).Do(func(ctx context.Context) {
+		time.Sleep(1 * time.Millisecond)
+	})
 	mockDB.EXPECT().SweepAssetGroupCollections(gomock.Any()).Do(func(ctx context.Context) {
 		time.Sleep(1 * time.Millisecond)
 	})
+	mockDB.EXPECT().SweepAssetGroupSelectorRuns(gomock.Any()).Do(func(ctx context.Context) {
+		time.Sleep(1 * time.Millisecond)
+	})
 
 	daemon := NewDataPruningDaemon(mockDB)
 	require.NotNil(t, daemon)
@@ -0,0 +1,293 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook delivers queued asset group tag webhook notifications. Each request body is signed with the
+// webhook's secret and failed deliveries are retried with exponential backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/headers"
+	"github.com/specterops/bloodhound/packages/go/mediatypes"
+)
+
+const (
+	// HeaderEvent names the event that triggered the delivery
+	HeaderEvent = "X-BloodHound-Event"
+	// HeaderDelivery carries the delivery id, which stays the same across retries
+	HeaderDelivery = "X-BloodHound-Delivery"
+	// HeaderTimestamp carries the unix time the request was signed at
+	HeaderTimestamp = "X-BloodHound-Timestamp"
+	// HeaderSignature carries the HMAC-SHA256 signature of the request, see Sign
+	HeaderSignature = "X-BloodHound-Signature"
+
+	// MaxAttempts is the number of times a delivery is attempted before it is marked as failed
+	MaxAttempts = 8
+
+	pollInterval   = 5 * time.Second
+	requestTimeout = 10 * time.Second
+	baseBackoff    = 30 * time.Second
+	maxBackoff     = time.Hour
+	batchSize      = 100
+)
+
+// ErrDisallowedAddress is returned when a webhook endpoint resolves to an address that BloodHound will not send
+// notifications to
+var ErrDisallowedAddress = errors.New("webhook endpoint address is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, which some cloud providers also serve instance metadata from
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsDisallowedAddress returns true for loopback, private, link-local, shared, multicast and unspecified addresses. Cloud instance
+// metadata services listen on link-local or shared addresses so they are covered as well.
+func IsDisallowedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// dialControl refuses connections to disallowed addresses. It runs after name resolution so that a public host name
+// that resolves to an internal address is refused as well.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	if addrPort, err := netip.ParseAddrPort(address); err != nil {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, address)
+	} else if IsDisallowedAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// newClient returns the HTTP client deliveries are sent with. Requests are never proxied, and redirects are not
+// followed so that an endpoint cannot bounce a delivery to an address the dialer would otherwise refuse.
+func newClient(control func(network, address string, conn syscall.RawConn) error) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: control,
+	}
+
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: requestTimeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Sign returns the value of the signature header for a request body sent at the given unix timestamp. Receivers verify
+// a delivery by computing the HMAC-SHA256 of "<timestamp>.<body>" with the shared secret and comparing it against the
+// header in constant time.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns how long to wait before retrying a delivery that has failed the given number of times
+func Backoff(attempts int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+// Daemon holds data relevant to the webhook delivery daemon
+type Daemon struct {
+	exitC  chan struct{}
+	db     database.Database
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewDaemon creates a new webhook delivery daemon
+func NewDaemon(db database.Database) *Daemon {
+	return &Daemon{
+		exitC:  make(chan struct{}),
+		db:     db,
+		client: newClient(dialControl),
+		now:    time.Now,
+	}
+}
+
+// Name returns the name of the daemon
+func (s *Daemon) Name() string {
+	return "Webhook Delivery Daemon"
+}
+
+// Start begins the daemon and waits for a stop signal in the exit channel
+func (s *Daemon) Start(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+
+	defer close(s.exitC)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.DeliverDue(ctx); err != nil {
+				slog.ErrorContext(ctx, fmt.Sprintf("Error delivering webhooks: %v", err))
+			}
+
+		case <-s.exitC:
+			return
+		}
+	}
+}
+
+// Stop passes in a stop signal to the exit channel, thereby killing the daemon
+func (s *Daemon) Stop(ctx context.Context) error {
+	s.exitC <- struct{}{}
+
+	select {
+	case <-s.exitC:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// DeliverDue attempts every pending delivery whose next attempt is due
+func (s *Daemon) DeliverDue(ctx context.Context) error {
+	if deliveries, err := s.db.GetDueAssetGroupTagWebhookDeliveries(ctx, s.now().UTC(), batchSize); err != nil {
+		return fmt.Errorf("fetching due webhook deliveries: %w", err)
+	} else {
+		webhooks := map[int]model.AssetGroupTagWebhook{}
+
+		for _, delivery := range deliveries {
+			webhook, found := webhooks[delivery.WebhookId]
+			if !found {
+				if webhook, err = s.db.GetAssetGroupTagWebhook(ctx, delivery.WebhookId); err != nil {
+					return fmt.Errorf("fetching webhook %d: %w", delivery.WebhookId, err)
+				}
+				webhooks[webhook.ID] = webhook
+			}
+
+			if err := s.deliver(ctx, webhook, delivery); err != nil {
+				return fmt.Errorf("recording webhook delivery %d: %w", delivery.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deliver makes a single attempt at sending a delivery and records the outcome
+func (s *Daemon) deliver(ctx context.Context, webhook model.AssetGroupTagWebhook, delivery model.AssetGroupTagWebhookDelivery) error {
+	var (
+		startedAt = s.now().UTC()
+		attempt   = model.AssetGroupTagWebhookDeliveryAttempt{
+			DeliveryId:  delivery.ID,
+			Attempt:     delivery.Attempts + 1,
+			AttemptedAt: startedAt,
+		}
+	)
+
+	if !webhook.Enabled {
+		attempt.Error = "webhook is disabled"
+	} else {
+		statusCode, err := s.send(ctx, webhook, delivery, startedAt.Unix())
+		if statusCode > 0 {
+			attempt.StatusCode = null.Int32From(int32(statusCode))
+		}
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+	}
+
+	attempt.DurationMs = s.now().UTC().Sub(startedAt).Milliseconds()
+
+	delivery.Attempts = attempt.Attempt
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	if attempt.Error == "" {
+		delivery.Status = model.AssetGroupTagWebhookDeliveryStatusSucceeded
+		delivery.NextAttemptAt = null.Time{}
+		delivery.CompletedAt = null.TimeFrom(s.now().UTC())
+	} else if !webhook.Enabled || delivery.Attempts >= MaxAttempts {
+		delivery.Status = model.AssetGroupTagWebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = null.Time{}
+		delivery.CompletedAt = null.TimeFrom(s.now().UTC())
+		slog.WarnContext(ctx, "Webhook delivery failed", slog.Int("webhook_id", webhook.ID), slog.Int64("delivery_id", delivery.ID), slog.Int("attempts", delivery.Attempts), slog.String("err", attempt.Error))
+	} else {
+		delivery.NextAttemptAt = null.TimeFrom(s.now().UTC().Add(Backoff(delivery.Attempts)))
+	}
+
+	return s.db.RecordAssetGroupTagWebhookDeliveryAttempt(ctx, delivery, attempt)
+}
+
+// send posts the signed delivery payload and returns the response status code. Any response outside of the 2xx range
+// is returned as an error. Only the status is recorded since the response body is controlled by the endpoint.
+func (s *Daemon) send(ctx context.Context, webhook model.AssetGroupTagWebhook, delivery model.AssetGroupTagWebhookDelivery, timestamp int64) (int, error) {
+	// Payloads read back from the database only hold their raw bytes which the pointer marshaller returns as-is
+	if bodyBytes, err := json.Marshal(&delivery.Payload); err != nil {
+		return 0, fmt.Errorf("encoding payload: %w", err)
+	} else if request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(bodyBytes)); err != nil {
+		return 0, fmt.Errorf("building request: %w", err)
+	} else {
+		request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		request.Header.Set(HeaderEvent, string(delivery.Event))
+		request.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+		request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		request.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, bodyBytes))
+
+		if response, err := s.client.Do(request); err != nil {
+			return 0, err
+		} else {
+			defer response.Body.Close()
+
+			if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+				return response.StatusCode, fmt.Errorf("endpoint responded with status %d", response.StatusCode)
+			}
+
+			return response.StatusCode, nil
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/mocks"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDelivery(t *testing.T, attempts int) model.AssetGroupTagWebhookDelivery {
+	var payload types.JSONBObject
+
+	// Deliveries are read back from the database with only the raw payload bytes populated
+	change, err := json.Marshal(model.AssetGroupTagMembershipChange{
+		Event:      model.AssetGroupTagWebhookEventMembershipChanged,
+		Tag:        model.AssetGroupTagWebhookTag{ID: 1, Name: "Tier Zero"},
+		AddedCount: 1,
+		Added:      []model.AssetGroupTagWebhookNode{{NodeId: 10, ObjectId: "S-1-5-21-1", Name: "ADMIN", PrimaryKind: "User"}},
+		Removed:    []model.AssetGroupTagWebhookNode{},
+	})
+	require.NoError(t, err)
+	require.NoError(t, payload.Scan(change))
+
+	return model.AssetGroupTagWebhookDelivery{
+		ID:              7,
+		WebhookId:       3,
+		AssetGroupTagId: 1,
+		Event:           model.AssetGroupTagWebhookEventMembershipChanged,
+		Payload:         payload,
+		Status:          model.AssetGroupTagWebhookDeliveryStatusPending,
+		Attempts:        attempts,
+	}
+}
+
+func TestWebhook_Name(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	daemon := NewDaemon(mocks.NewMockDatabase(mockCtrl))
+	require.Equal(t, "Webhook Delivery Daemon", daemon.Name())
+}
+
+func TestWebhook_Sign(t *testing.T) {
+	// Reference value computed with: printf '1700000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686", Sign("secret", 1700000000, []byte(`{"a":1}`)))
+	require.NotEqual(t, Sign("secret", 1700000000, []byte(`{"a":1}`)), Sign("other", 1700000000, []byte(`{"a":1}`)))
+	require.NotEqual(t, Sign("secret", 1700000000, []byte(`{"a":1}`)), Sign("secret", 1700000001, []byte(`{"a":1}`)))
+}
+
+func TestWebhook_Backoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, Backoff(1))
+	require.Equal(t, time.Minute, Backoff(2))
+	require.Equal(t, 2*time.Minute, Backoff(3))
+	require.Equal(t, time.Hour, Backoff(20))
+}
+
+func TestWebhook_DeliverDue(t *testing.T) {
+	var (
+		now     = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		webhook = model.AssetGroupTagWebhook{ID: 3, Name: "soc", Secret: "secret", Enabled: true}
+	)
+
+	t.Run("success signs the payload and completes the delivery", func(t *testing.T) {
+		var (
+			mockCtrl = gomock.NewController(t)
+			mockDB   = mocks.NewMockDatabase(mockCtrl)
+			delivery = newTestDelivery(t, 0)
+			received = make(chan *http.Request, 1)
+			bodies   = make(chan []byte, 1)
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			body, _ := io.ReadAll(request.Body)
+			received <- request
+			bodies <- body
+			response.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		mockDB.EXPECT().GetDueAssetGroupTagWebhookDeliveries(gomock.Any(), now, batchSize).Return(model.AssetGroupTagWebhookDeliveries{delivery}, nil)
+		mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockDB.EXPECT().RecordAssetGroupTagWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+				require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusSucceeded, delivery.Status)
+				require.Equal(t, 1, delivery.Attempts)
+				require.True(t, delivery.CompletedAt.Valid)
+				require.False(t, delivery.NextAttemptAt.Valid)
+				require.Equal(t, 1, attempt.Attempt)
+				require.Equal(t, int32(http.StatusNoContent), attempt.StatusCode.Int32)
+				require.Empty(t, attempt.Error)
+				return nil
+			})
+
+		daemon := NewDaemon(mockDB)
+		daemon.now = func() time.Time { return now }
+		// Test servers listen on loopback, which the production dialer refuses
+		daemon.client = newClient(nil)
+		require.NoError(t, daemon.DeliverDue(context.Background()))
+
+		request, body := <-received, <-bodies
+		require.Equal(t, http.MethodPost, request.Method)
+		require.Equal(t, string(model.AssetGroupTagWebhookEventMembershipChanged), request.Header.Get(HeaderEvent))
+		require.Equal(t, "7", request.Header.Get(HeaderDelivery))
+		require.Equal(t, strconv.FormatInt(now.Unix(), 10), request.Header.Get(HeaderTimestamp))
+		require.Equal(t, Sign("secret", now.Unix(), body), request.Header.Get(HeaderSignature))
+
+		var change model.AssetGroupTagMembershipChange
+		require.NoError(t, json.Unmarshal(body, &change))
+		require.Equal(t, "Tier Zero", change.Tag.Name)
+		require.Equal(t, "S-1-5-21-1", change.Added[0].ObjectId)
+	})
+
+	t.Run("failure schedules a retry with backoff", func(t *testing.T) {
+		var (
+			mockCtrl = gomock.NewController(t)
+			mockDB   = mocks.NewMockDatabase(mockCtrl)
+			delivery = newTestDelivery(t, 1)
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			http.Error(response, "internal upstream detail", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		mockDB.EXPECT().GetDueAssetGroupTagWebhookDeliveries(gomock.Any(), now, batchSize).Return(model.AssetGroupTagWebhookDeliveries{delivery}, nil)
+		mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockDB.EXPECT().RecordAssetGroupTagWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+				require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusPending, delivery.Status)
+				require.Equal(t, 2, delivery.Attempts)
+				require.Equal(t, now.Add(Backoff(2)), delivery.NextAttemptAt.Time)
+				require.Equal(t, int32(http.StatusServiceUnavailable), delivery.LastStatusCode.Int32)
+				require.Equal(t, "endpoint responded with status 503", attempt.Error)
+				return nil
+			})
+
+		daemon := NewDaemon(mockDB)
+		daemon.now = func() time.Time { return now }
+		// Test servers listen on loopback, which the production dialer refuses
+		daemon.client = newClient(nil)
+		require.NoError(t, daemon.DeliverDue(context.Background()))
+	})
+
+	t.Run("the final failed attempt fails the delivery", func(t *testing.T) {
+		var (
+			mockCtrl = gomock.NewController(t)
+			mockDB   = mocks.NewMockDatabase(mockCtrl)
+			delivery = newTestDelivery(t, MaxAttempts-1)
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			response.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		mockDB.EXPECT().GetDueAssetGroupTagWebhookDeliveries(gomock.Any(), now, batchSize).Return(model.AssetGroupTagWebhookDeliveries{delivery}, nil)
+		mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockDB.EXPECT().RecordAssetGroupTagWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+				require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusFailed, delivery.Status)
+				require.Equal(t, MaxAttempts, delivery.Attempts)
+				require.False(t, delivery.NextAttemptAt.Valid)
+				require.True(t, delivery.CompletedAt.Valid)
+				return nil
+			})
+
+		daemon := NewDaemon(mockDB)
+		daemon.now = func() time.Time { return now }
+		// Test servers listen on loopback, which the production dialer refuses
+		daemon.client = newClient(nil)
+		require.NoError(t, daemon.DeliverDue(context.Background()))
+	})
+
+	t.Run("deliveries for a disabled webhook fail without being sent", func(t *testing.T) {
+		var (
+			mockCtrl = gomock.NewController(t)
+			mockDB   = mocks.NewMockDatabase(mockCtrl)
+			delivery = newTestDelivery(t, 0)
+			disabled = webhook
+		)
+
+		disabled.Enabled = false
+		disabled.URL = "http://127.0.0.1:0"
+
+		mockDB.EXPECT().GetDueAssetGroupTagWebhookDeliveries(gomock.Any(), now, batchSize).Return(model.AssetGroupTagWebhookDeliveries{delivery}, nil)
+		mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), webhook.ID).Return(disabled, nil)
+		mockDB.EXPECT().RecordAssetGroupTagWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+				require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusFailed, delivery.Status)
+				require.Equal(t, "webhook is disabled", attempt.Error)
+				require.False(t, attempt.StatusCode.Valid)
+				return nil
+			})
+
+		daemon := NewDaemon(mockDB)
+		daemon.now = func() time.Time { return now }
+		require.NoError(t, daemon.DeliverDue(context.Background()))
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		var (
+			mockCtrl   = gomock.NewController(t)
+			mockDB     = mocks.NewMockDatabase(mockCtrl)
+			delivery   = newTestDelivery(t, 0)
+			redirected = make(chan struct{}, 1)
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if request.URL.Path == "/internal" {
+				redirected <- struct{}{}
+				return
+			}
+
+			http.Redirect(response, request, "/internal", http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		mockDB.EXPECT().GetDueAssetGroupTagWebhookDeliveries(gomock.Any(), now, batchSize).Return(model.AssetGroupTagWebhookDeliveries{delivery}, nil)
+		mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockDB.EXPECT().RecordAssetGroupTagWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+				require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusPending, delivery.Status)
+				require.Equal(t, int32(http.StatusTemporaryRedirect), attempt.StatusCode.Int32)
+				require.Equal(t, "endpoint responded with status 307", attempt.Error)
+				return nil
+			})
+
+		daemon := NewDaemon(mockDB)
+		daemon.now = func() time.Time { return now }
+		daemon.client = newClient(nil)
+		require.NoError(t, daemon.DeliverDue(context.Background()))
+		require.Empty(t, redirected)
+	})
+
+	t.Run("internal endpoints are refused when dialing", func(t *testing.T) {
+		var (
+			mockCtrl = gomock.NewController(t)
+			mockDB   = mocks.NewMockDatabase(mockCtrl)
+			delivery = newTestDelivery(t, 0)
+			received = make(chan struct{}, 1)
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			received <- struct{}{}
+		}))
+		defer server.Close()
+
+		webhook.URL = server.URL
+
+		mockDB.EXPECT().GetDueAssetGroupTagWebhookDeliveries(gomock.Any(), now, batchSize).Return(model.AssetGroupTagWebhookDeliveries{delivery}, nil)
+		mockDB.EXPECT().GetAssetGroupTagWebhook(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockDB.EXPECT().RecordAssetGroupTagWebhookDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+				require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusPending, delivery.Status)
+				require.False(t, attempt.StatusCode.Valid)
+				require.Contains(t, attempt.Error, ErrDisallowedAddress.Error())
+				return nil
+			})
+
+		daemon := NewDaemon(mockDB)
+		daemon.now = func() time.Time { return now }
+		require.NoError(t, daemon.DeliverDue(context.Background()))
+		require.Empty(t, received)
+	})
+}
+
+func TestWebhook_IsDisallowedAddress(t *testing.T) {
+	for address, disallowed := range map[string]bool{
+		"127.0.0.1":          true,
+		"::1":                true,
+		"10.1.2.3":           true,
+		"172.16.0.1":         true,
+		"192.168.1.1":        true,
+		"169.254.169.254":    true,
+		"100.100.100.200":    true,
+		"fd00:ec2::254":      true,
+		"fe80::1":            true,
+		"0.0.0.0":            true,
+		"::ffff:127.0.0.1":   true,
+		"224.0.0.1":          true,
+		"93.184.216.34":      false,
+		"2606:4700::6810:84": false,
+	} {
+		require.Equal(t, disallowed, IsDisallowedAddress(netip.MustParseAddr(address)), address)
+	}
+}
@@ -30,6 +30,7 @@ const (
 type EnvironmentAccessControlData interface {
 	GetEnvironmentAccessListForUser(ctx context.Context, user model.User) ([]EnvironmentAccess, error)
 	UpdateEnvironmentListForUser(ctx context.Context, user model.User, environments ...string) error
+	GrantAllEnvironmentsForUser(ctx context.Context, user model.User) error
 }
 
 // EnvironmentAccess defines the model for a row in the environment_access_control table
@@ -84,20 +85,39 @@ func (s *BloodhoundDB) UpdateEnvironmentListForUser(ctx context.Context, user mo
 			availableEnvironments = append(availableEnvironments, newAccessControl)
 		}
 
-		result := tx.WithContext(ctx).Table(EnvironmentAccessControlTable).Create(&availableEnvironments)
+		// A user may be restricted to no environments at all, in which case there is nothing to insert
+		if len(availableEnvironments) > 0 {
+			result := tx.WithContext(ctx).Table(EnvironmentAccessControlTable).Create(&availableEnvironments)
 
-		if err := CheckError(result); err != nil {
-			return err
+			if err := CheckError(result); err != nil {
+				return err
+			}
 		}
 
-		user.AllEnvironments = false
+		// Only the flag is updated so that the user's roles and auth secret are left untouched
+		return CheckError(tx.WithContext(ctx).Model(&model.User{}).Where("id = ?", user.ID).Update("all_environments", false))
+	})
+}
 
-		err = s.UpdateUser(ctx, user)
+// GrantAllEnvironmentsForUser removes all entries in the access control list for a user and grants them access to every environment
+func (s *BloodhoundDB) GrantAllEnvironmentsForUser(ctx context.Context, user model.User) error {
+	var (
+		auditData = model.AuditData{
+			"userUuid":         user.ID.String(),
+			"all_environments": true,
+		}
+		auditEntry, err = model.NewAuditEntry(model.AuditLogActionUpdateEnvironmentAccessList, model.AuditLogStatusIntent, auditData)
+	)
 
-		if err != nil {
+	if err != nil {
+		return err
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		if err := CheckError(tx.WithContext(ctx).Table(EnvironmentAccessControlTable).Where("user_id = ?", user.ID.String()).Delete(&EnvironmentAccess{})); err != nil {
 			return err
 		}
 
-		return nil
+		return CheckError(tx.WithContext(ctx).Model(&model.User{}).Where("id = ?", user.ID).Update("all_environments", true))
 	})
 }
@@ -0,0 +1,203 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/dawgs/graph"
+	"gorm.io/gorm"
+)
+
+const (
+	// AssetGroupSelectorRunRetention is the number of evaluation runs kept per selector
+	AssetGroupSelectorRunRetention = 100
+
+	// AssetGroupSelectorRunMaxAge is how long evaluation runs are kept before being swept. The latest run of each
+	// selector is always kept.
+	AssetGroupSelectorRunMaxAge = 90 * 24 * time.Hour
+)
+
+// AssetGroupSelectorRunData defines the methods required to interact with the asset_group_tag_selector_runs and asset_group_tag_selector_run_nodes tables
+type AssetGroupSelectorRunData interface {
+	CreateAssetGroupSelectorRun(ctx context.Context, run model.AssetGroupSelectorRun) (model.AssetGroupSelectorRun, error)
+	GetAssetGroupSelectorRuns(ctx context.Context, selectorId int, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.AssetGroupSelectorRuns, int, error)
+	GetAssetGroupSelectorRun(ctx context.Context, selectorId int, runId int64) (model.AssetGroupSelectorRun, error)
+	SweepAssetGroupSelectorRuns(ctx context.Context)
+}
+
+// CreateAssetGroupSelectorRun records an evaluation run along with its membership deltas, updates whether the selector
+// reached its expansion limit and removes the oldest runs of the selector beyond AssetGroupSelectorRunRetention
+func (s *BloodhoundDB) CreateAssetGroupSelectorRun(ctx context.Context, run model.AssetGroupSelectorRun) (model.AssetGroupSelectorRun, error) {
+	run.AddedCount = len(run.AddedNodeIds)
+	run.RemovedCount = len(run.RemovedNodeIds)
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Raw(fmt.Sprintf(`
+			INSERT INTO %s (selector_id, asset_group_tag_id, started_at, duration_ms, node_count, added_count, removed_count, expansion_limit, limit_reached_by)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id`,
+			run.TableName()),
+			run.SelectorId, run.AssetGroupTagId, run.StartedAt, run.DurationMs, run.NodeCount, run.AddedCount, run.RemovedCount, run.ExpansionLimit, run.LimitReachedBy).Scan(&run.ID); result.Error != nil {
+			return CheckError(result)
+		}
+
+		if result := tx.Exec(fmt.Sprintf(`
+			UPDATE %s SET limit_reached_by = ?, limit_reached_at = CASE WHEN ? > 0 THEN ?::timestamptz ELSE NULL END
+			WHERE id = ?`,
+			model.AssetGroupTagSelector{}.TableName()),
+			run.LimitReachedBy, run.LimitReachedBy, run.StartedAt, run.SelectorId); result.Error != nil {
+			return CheckError(result)
+		}
+
+		if len(run.AddedNodeIds)+len(run.RemovedNodeIds) > 0 {
+			var (
+				nodeIds = make([]int64, 0, len(run.AddedNodeIds)+len(run.RemovedNodeIds))
+				added   = make([]bool, 0, len(run.AddedNodeIds)+len(run.RemovedNodeIds))
+			)
+
+			for _, nodeId := range run.AddedNodeIds {
+				nodeIds = append(nodeIds, nodeId.Int64())
+				added = append(added, true)
+			}
+			for _, nodeId := range run.RemovedNodeIds {
+				nodeIds = append(nodeIds, nodeId.Int64())
+				added = append(added, false)
+			}
+
+			if result := tx.Exec(`
+				INSERT INTO asset_group_tag_selector_run_nodes (run_id, node_id, added)
+				SELECT ?, * FROM unnest(?::bigint[], ?::boolean[])
+				ON CONFLICT DO NOTHING`,
+				run.ID, pq.Array(nodeIds), pq.Array(added)); result.Error != nil {
+				return CheckError(result)
+			}
+		}
+
+		return CheckError(tx.Exec(fmt.Sprintf(`
+			DELETE FROM %[1]s
+			WHERE selector_id = ? AND id NOT IN (SELECT id FROM %[1]s WHERE selector_id = ? ORDER BY started_at DESC, id DESC LIMIT ?)`,
+			run.TableName()),
+			run.SelectorId, run.SelectorId, AssetGroupSelectorRunRetention))
+	}); err != nil {
+		return model.AssetGroupSelectorRun{}, err
+	}
+
+	return run, nil
+}
+
+// GetAssetGroupSelectorRuns returns the evaluation runs of a selector without their membership deltas
+func (s *BloodhoundDB) GetAssetGroupSelectorRuns(ctx context.Context, selectorId int, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.AssetGroupSelectorRuns, int, error) {
+	var (
+		runs            = model.AssetGroupSelectorRuns{}
+		skipLimitString string
+		sortString      = "ORDER BY started_at DESC, id DESC"
+		count           int
+	)
+
+	if sqlFilter.SQLString != "" {
+		sqlFilter.SQLString = " AND " + sqlFilter.SQLString
+	}
+
+	if len(sortItems) > 0 {
+		var sortColumns []string
+		for _, item := range sortItems {
+			dirString := "ASC"
+			if item.Direction == model.DescendingSortDirection {
+				dirString = "DESC"
+			}
+			sortColumns = append(sortColumns, fmt.Sprintf("%s %s", item.Column, dirString))
+		}
+		sortString = "ORDER BY " + strings.Join(sortColumns, ", ")
+	}
+
+	if limit > 0 {
+		skipLimitString += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		skipLimitString += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	params := append([]any{selectorId}, sqlFilter.Params...)
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT id, selector_id, asset_group_tag_id, started_at, duration_ms, node_count, added_count, removed_count, expansion_limit, limit_reached_by FROM %s WHERE selector_id = ?%s %s%s",
+		model.AssetGroupSelectorRun{}.TableName(), sqlFilter.SQLString, sortString, skipLimitString),
+		params...).Find(&runs); result.Error != nil {
+		return model.AssetGroupSelectorRuns{}, 0, CheckError(result)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s WHERE selector_id = ?%s",
+		model.AssetGroupSelectorRun{}.TableName(), sqlFilter.SQLString),
+		params...).Scan(&count); result.Error != nil {
+		return model.AssetGroupSelectorRuns{}, 0, CheckError(result)
+	}
+
+	return runs, count, nil
+}
+
+// GetAssetGroupSelectorRun returns a single evaluation run of a selector including the node ids it added and removed
+func (s *BloodhoundDB) GetAssetGroupSelectorRun(ctx context.Context, selectorId int, runId int64) (model.AssetGroupSelectorRun, error) {
+	var (
+		run   model.AssetGroupSelectorRun
+		nodes []struct {
+			NodeId graph.ID
+			Added  bool
+		}
+	)
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT id, selector_id, asset_group_tag_id, started_at, duration_ms, node_count, added_count, removed_count, expansion_limit, limit_reached_by FROM %s WHERE selector_id = ? AND id = ?",
+		run.TableName()),
+		selectorId, runId).First(&run); result.Error != nil {
+		return model.AssetGroupSelectorRun{}, CheckError(result)
+	} else if result := s.db.WithContext(ctx).Raw("SELECT node_id, added FROM asset_group_tag_selector_run_nodes WHERE run_id = ? ORDER BY node_id", run.ID).Scan(&nodes); result.Error != nil {
+		return model.AssetGroupSelectorRun{}, CheckError(result)
+	}
+
+	run.AddedNodeIds, run.RemovedNodeIds = []graph.ID{}, []graph.ID{}
+	for _, node := range nodes {
+		if node.Added {
+			run.AddedNodeIds = append(run.AddedNodeIds, node.NodeId)
+		} else {
+			run.RemovedNodeIds = append(run.RemovedNodeIds, node.NodeId)
+		}
+	}
+
+	return run, nil
+}
+
+// SweepAssetGroupSelectorRuns removes evaluation runs older than AssetGroupSelectorRunMaxAge other than the latest run
+// of each selector. The membership deltas of removed runs are removed by cascade.
+func (s *BloodhoundDB) SweepAssetGroupSelectorRuns(ctx context.Context) {
+	if result := s.db.WithContext(ctx).Exec(fmt.Sprintf(`
+		DELETE FROM %[1]s r
+		WHERE r.started_at < ?
+		AND r.id <> (SELECT l.id FROM %[1]s l WHERE l.selector_id = r.selector_id ORDER BY l.started_at DESC, l.id DESC LIMIT 1)`,
+		model.AssetGroupSelectorRun{}.TableName()),
+		time.Now().UTC().Add(-AssetGroupSelectorRunMaxAge)); result.Error != nil {
+		slog.ErrorContext(ctx, fmt.Sprintf("Error sweeping asset group selector runs: %v", result.Error))
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_AssetGroupSelectorRuns(t *testing.T) {
+	var (
+		testCtx   = context.Background()
+		testActor = model.User{Unique: model.Unique{ID: uuid.FromStringOrNil("01234567-9012-4567-9012-456789012345")}}
+		dbInst    = integration.SetupDB(t)
+	)
+
+	selector, err := dbInst.CreateAssetGroupTagSelector(testCtx, 1, testActor, "runs", "", false, true, null.BoolFrom(false), []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "S-1-1"}})
+	require.NoError(t, err)
+
+	first, err := dbInst.CreateAssetGroupSelectorRun(testCtx, model.AssetGroupSelectorRun{
+		SelectorId:      selector.ID,
+		AssetGroupTagId: selector.AssetGroupTagId,
+		StartedAt:       time.Now().Add(-time.Hour),
+		DurationMs:      20,
+		NodeCount:       2,
+		AddedNodeIds:    []graph.ID{1, 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, first.AddedCount)
+
+	second, err := dbInst.CreateAssetGroupSelectorRun(testCtx, model.AssetGroupSelectorRun{
+		SelectorId:      selector.ID,
+		AssetGroupTagId: selector.AssetGroupTagId,
+		StartedAt:       time.Now(),
+		DurationMs:      10,
+		NodeCount:       2,
+		AddedNodeIds:    []graph.ID{3},
+		RemovedNodeIds:  []graph.ID{1},
+	})
+	require.NoError(t, err)
+
+	t.Run("lists runs newest first", func(t *testing.T) {
+		runs, count, err := dbInst.GetAssetGroupSelectorRuns(testCtx, selector.ID, model.SQLFilter{}, model.Sort{}, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		require.Equal(t, second.ID, runs[0].ID)
+		require.Equal(t, 1, runs[0].RemovedCount)
+		require.Empty(t, runs[0].AddedNodeIds)
+	})
+
+	t.Run("filters runs", func(t *testing.T) {
+		runs, count, err := dbInst.GetAssetGroupSelectorRuns(testCtx, selector.ID, model.SQLFilter{SQLString: "added_count >= ?", Params: []any{2}}, model.Sort{}, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, first.ID, runs[0].ID)
+	})
+
+	t.Run("returns run deltas", func(t *testing.T) {
+		run, err := dbInst.GetAssetGroupSelectorRun(testCtx, selector.ID, second.ID)
+		require.NoError(t, err)
+		require.Equal(t, []graph.ID{3}, run.AddedNodeIds)
+		require.Equal(t, []graph.ID{1}, run.RemovedNodeIds)
+
+		_, err = dbInst.GetAssetGroupSelectorRun(testCtx, selector.ID+1, second.ID)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("records when the expansion limit is reached", func(t *testing.T) {
+		truncated, err := dbInst.CreateAssetGroupSelectorRun(testCtx, model.AssetGroupSelectorRun{
+			SelectorId:      selector.ID,
+			AssetGroupTagId: selector.AssetGroupTagId,
+			StartedAt:       time.Now(),
+			NodeCount:       5,
+			ExpansionLimit:  5,
+			LimitReachedBy:  model.AssetGroupSelectorNodeSourceChild,
+		})
+		require.NoError(t, err)
+
+		run, err := dbInst.GetAssetGroupSelectorRun(testCtx, selector.ID, truncated.ID)
+		require.NoError(t, err)
+		require.Equal(t, 5, run.ExpansionLimit)
+		require.Equal(t, model.AssetGroupSelectorNodeSourceChild, run.LimitReachedBy)
+
+		updated, err := dbInst.GetAssetGroupTagSelectorBySelectorId(testCtx, selector.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.AssetGroupSelectorNodeSourceChild, updated.LimitReachedBy)
+		require.True(t, updated.LimitReachedAt.Valid)
+
+		_, err = dbInst.CreateAssetGroupSelectorRun(testCtx, model.AssetGroupSelectorRun{SelectorId: selector.ID, AssetGroupTagId: selector.AssetGroupTagId, StartedAt: time.Now(), ExpansionLimit: 10})
+		require.NoError(t, err)
+
+		updated, err = dbInst.GetAssetGroupTagSelectorBySelectorId(testCtx, selector.ID)
+		require.NoError(t, err)
+		require.Zero(t, updated.LimitReachedBy)
+		require.False(t, updated.LimitReachedAt.Valid)
+	})
+
+	t.Run("keeps only the latest runs", func(t *testing.T) {
+		for i := 0; i < database.AssetGroupSelectorRunRetention; i++ {
+			_, err := dbInst.CreateAssetGroupSelectorRun(testCtx, model.AssetGroupSelectorRun{SelectorId: selector.ID, AssetGroupTagId: selector.AssetGroupTagId, StartedAt: time.Now()})
+			require.NoError(t, err)
+		}
+
+		_, count, err := dbInst.GetAssetGroupSelectorRuns(testCtx, selector.ID, model.SQLFilter{}, model.Sort{}, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, database.AssetGroupSelectorRunRetention, count)
+
+		_, err = dbInst.GetAssetGroupSelectorRun(testCtx, selector.ID, first.ID)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("sweeps runs past their max age", func(t *testing.T) {
+		var (
+			startedAt = time.Now().Add(-database.AssetGroupSelectorRunMaxAge - time.Hour)
+			latest    model.AssetGroupSelectorRun
+		)
+
+		other, err := dbInst.CreateAssetGroupTagSelector(testCtx, 1, testActor, "swept runs", "", false, true, null.BoolFrom(false), []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "S-1-2"}})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			latest, err = dbInst.CreateAssetGroupSelectorRun(testCtx, model.AssetGroupSelectorRun{SelectorId: other.ID, AssetGroupTagId: other.AssetGroupTagId, StartedAt: startedAt.Add(time.Duration(i) * time.Minute), AddedNodeIds: []graph.ID{graph.ID(i)}})
+			require.NoError(t, err)
+		}
+
+		dbInst.SweepAssetGroupSelectorRuns(testCtx)
+
+		// The latest run of a selector is kept regardless of age
+		runs, count, err := dbInst.GetAssetGroupSelectorRuns(testCtx, other.ID, model.SQLFilter{}, model.Sort{}, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, latest.ID, runs[0].ID)
+
+		// Recent runs are kept
+		_, count, err = dbInst.GetAssetGroupSelectorRuns(testCtx, selector.ID, model.SQLFilter{}, model.Sort{}, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, database.AssetGroupSelectorRunRetention, count)
+	})
+}
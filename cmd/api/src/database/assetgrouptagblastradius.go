@@ -0,0 +1,171 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"gorm.io/gorm"
+)
+
+// BlastRadiusReportRetention is the number of finished blast radius reports that are kept
+const BlastRadiusReportRetention = 20
+
+const blastRadiusReportColumns = "id, status, status_message, wait_for_analysis, requested_by, owned_count, reachable_count, tier_zero_reachable_count, created_at, started_at, completed_at"
+
+// BlastRadiusReportData defines the methods required to interact with the asset_group_tag_blast_radius_reports table
+type BlastRadiusReportData interface {
+	CreateBlastRadiusReport(ctx context.Context, requestedBy string, waitForAnalysis bool) (model.BlastRadiusReport, error)
+	GetBlastRadiusReports(ctx context.Context, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.BlastRadiusReports, int, error)
+	GetBlastRadiusReport(ctx context.Context, id int64) (model.BlastRadiusReport, error)
+	GetQueuedBlastRadiusReports(ctx context.Context) (model.BlastRadiusReports, error)
+	UpdateBlastRadiusReport(ctx context.Context, report model.BlastRadiusReport) error
+}
+
+// CreateBlastRadiusReport queues a new report and removes the oldest finished reports beyond BlastRadiusReportRetention
+func (s *BloodhoundDB) CreateBlastRadiusReport(ctx context.Context, requestedBy string, waitForAnalysis bool) (model.BlastRadiusReport, error) {
+	var report = model.BlastRadiusReport{
+		Status:          model.BlastRadiusReportStatusPending,
+		WaitForAnalysis: waitForAnalysis,
+		RequestedBy:     requestedBy,
+	}
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Raw(fmt.Sprintf(`
+			INSERT INTO %s (status, wait_for_analysis, requested_by)
+			VALUES (?, ?, ?)
+			RETURNING %s`,
+			report.TableName(), blastRadiusReportColumns),
+			report.Status, report.WaitForAnalysis, report.RequestedBy).Scan(&report); result.Error != nil {
+			return CheckError(result)
+		}
+
+		return CheckError(tx.Exec(fmt.Sprintf(`
+			DELETE FROM %[1]s
+			WHERE status IN (?, ?) AND id NOT IN (SELECT id FROM %[1]s WHERE status IN (?, ?) ORDER BY created_at DESC, id DESC LIMIT ?)`,
+			report.TableName()),
+			model.BlastRadiusReportStatusComplete, model.BlastRadiusReportStatusFailed,
+			model.BlastRadiusReportStatusComplete, model.BlastRadiusReportStatusFailed,
+			BlastRadiusReportRetention))
+	}); err != nil {
+		return model.BlastRadiusReport{}, err
+	}
+
+	return report, nil
+}
+
+// GetBlastRadiusReports returns reports without their results
+func (s *BloodhoundDB) GetBlastRadiusReports(ctx context.Context, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.BlastRadiusReports, int, error) {
+	var (
+		reports         = model.BlastRadiusReports{}
+		skipLimitString string
+		whereString     string
+		sortString      = "ORDER BY created_at DESC, id DESC"
+		count           int
+	)
+
+	if sqlFilter.SQLString != "" {
+		whereString = " WHERE " + sqlFilter.SQLString
+	}
+
+	if len(sortItems) > 0 {
+		var sortColumns []string
+		for _, item := range sortItems {
+			dirString := "ASC"
+			if item.Direction == model.DescendingSortDirection {
+				dirString = "DESC"
+			}
+			sortColumns = append(sortColumns, fmt.Sprintf("%s %s", item.Column, dirString))
+		}
+		sortString = "ORDER BY " + strings.Join(sortColumns, ", ")
+	}
+
+	if limit > 0 {
+		skipLimitString += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		skipLimitString += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s%s %s%s",
+		blastRadiusReportColumns, model.BlastRadiusReport{}.TableName(), whereString, sortString, skipLimitString),
+		sqlFilter.Params...).Find(&reports); result.Error != nil {
+		return model.BlastRadiusReports{}, 0, CheckError(result)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s%s",
+		model.BlastRadiusReport{}.TableName(), whereString),
+		sqlFilter.Params...).Scan(&count); result.Error != nil {
+		return model.BlastRadiusReports{}, 0, CheckError(result)
+	}
+
+	return reports, count, nil
+}
+
+// GetBlastRadiusReport returns a single report including its result
+func (s *BloodhoundDB) GetBlastRadiusReport(ctx context.Context, id int64) (model.BlastRadiusReport, error) {
+	var report model.BlastRadiusReport
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s, result FROM %s WHERE id = ?",
+		blastRadiusReportColumns, report.TableName()),
+		id).First(&report); result.Error != nil {
+		return model.BlastRadiusReport{}, CheckError(result)
+	}
+
+	return report, nil
+}
+
+// GetQueuedBlastRadiusReports returns reports that have not finished, oldest first. Reports left running are included
+// since the datapipe generates reports one at a time and a running report can only be left over from an interrupted run.
+func (s *BloodhoundDB) GetQueuedBlastRadiusReports(ctx context.Context) (model.BlastRadiusReports, error) {
+	var reports = model.BlastRadiusReports{}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s WHERE status IN (?, ?) ORDER BY created_at ASC, id ASC",
+		blastRadiusReportColumns, model.BlastRadiusReport{}.TableName()),
+		model.BlastRadiusReportStatusPending, model.BlastRadiusReportStatusRunning).Find(&reports); result.Error != nil {
+		return model.BlastRadiusReports{}, CheckError(result)
+	}
+
+	return reports, nil
+}
+
+// UpdateBlastRadiusReport saves the status, counts and result of a report. The result is left untouched when it is unset.
+func (s *BloodhoundDB) UpdateBlastRadiusReport(ctx context.Context, report model.BlastRadiusReport) error {
+	columns := map[string]any{
+		"status":                    report.Status,
+		"status_message":            report.StatusMessage,
+		"owned_count":               report.OwnedCount,
+		"reachable_count":           report.ReachableCount,
+		"tier_zero_reachable_count": report.TierZeroReachableCount,
+		"started_at":                report.StartedAt,
+		"completed_at":              report.CompletedAt,
+	}
+
+	if report.Result.Object != nil {
+		columns["result"] = report.Result
+	}
+
+	return CheckError(s.db.WithContext(ctx).Table(report.TableName()).Where("id = ?", report.ID).Updates(columns))
+}
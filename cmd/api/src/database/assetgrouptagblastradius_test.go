@@ -0,0 +1,107 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_BlastRadiusReports(t *testing.T) {
+	var (
+		testCtx = context.Background()
+		dbInst  = integration.SetupDB(t)
+	)
+
+	report, err := dbInst.CreateBlastRadiusReport(testCtx, "user", true)
+	require.NoError(t, err)
+	require.Equal(t, model.BlastRadiusReportStatusPending, report.Status)
+	require.True(t, report.WaitForAnalysis)
+
+	t.Run("queued reports include pending reports", func(t *testing.T) {
+		queued, err := dbInst.GetQueuedBlastRadiusReports(testCtx)
+		require.NoError(t, err)
+		require.Len(t, queued, 1)
+		require.Equal(t, report.ID, queued[0].ID)
+	})
+
+	t.Run("completing a report stores its result", func(t *testing.T) {
+		result, err := types.NewJSONBObject(model.BlastRadiusResult{OwnedCount: 1, ReachableCount: 4})
+		require.NoError(t, err)
+
+		report.Status = model.BlastRadiusReportStatusComplete
+		report.OwnedCount = 1
+		report.ReachableCount = 4
+		report.CompletedAt = null.TimeFrom(time.Now().UTC())
+		report.Result = result
+		require.NoError(t, dbInst.UpdateBlastRadiusReport(testCtx, report))
+
+		fetched, err := dbInst.GetBlastRadiusReport(testCtx, report.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.BlastRadiusReportStatusComplete, fetched.Status)
+
+		var fetchedResult model.BlastRadiusResult
+		require.NoError(t, fetched.Result.Map(&fetchedResult))
+		require.Equal(t, 4, fetchedResult.ReachableCount)
+
+		queued, err := dbInst.GetQueuedBlastRadiusReports(testCtx)
+		require.NoError(t, err)
+		require.Empty(t, queued)
+	})
+
+	t.Run("lists and filters reports", func(t *testing.T) {
+		_, err := dbInst.CreateBlastRadiusReport(testCtx, "user", false)
+		require.NoError(t, err)
+
+		reports, count, err := dbInst.GetBlastRadiusReports(testCtx, model.SQLFilter{SQLString: "status = 'complete'"}, model.Sort{}, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, report.ID, reports[0].ID)
+	})
+
+	t.Run("finished reports beyond retention are pruned", func(t *testing.T) {
+		for range database.BlastRadiusReportRetention {
+			created, err := dbInst.CreateBlastRadiusReport(testCtx, "user", false)
+			require.NoError(t, err)
+
+			created.Status = model.BlastRadiusReportStatusFailed
+			require.NoError(t, dbInst.UpdateBlastRadiusReport(testCtx, created))
+		}
+
+		_, err := dbInst.CreateBlastRadiusReport(testCtx, "user", false)
+		require.NoError(t, err)
+
+		_, err = dbInst.GetBlastRadiusReport(testCtx, report.ID)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("missing report", func(t *testing.T) {
+		_, err := dbInst.GetBlastRadiusReport(testCtx, 0)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
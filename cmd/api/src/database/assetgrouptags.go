@@ -45,6 +45,9 @@ type AssetGroupTagData interface {
 	GetAssetGroupTags(ctx context.Context, sqlFilter model.SQLFilter) (model.AssetGroupTags, error)
 	GetOrderedAssetGroupTagTiers(ctx context.Context) ([]model.AssetGroupTag, error)
 	GetAssetGroupTagForSelection(ctx context.Context) ([]model.AssetGroupTag, error)
+	ReorderAssetGroupTagTiers(ctx context.Context, user model.User, orderedTierIds []int) (model.AssetGroupTags, error)
+	GetAssetGroupMemberCertifications(ctx context.Context, assetGroupTagId int, certified model.AssetGroupCertification, skip, limit int) (model.AssetGroupMemberCertifications, int, error)
+	UpdateAssetGroupMemberCertifications(ctx context.Context, user model.User, update model.AssetGroupCertificationUpdate) (int, error)
 }
 
 // AssetGroupTagSelectorData defines the methods required to interact with the asset_group_tag_selectors and asset_group_tag_selector_seeds tables
@@ -107,7 +110,7 @@ func (s *BloodhoundDB) CreateAssetGroupTagSelector(ctx context.Context, assetGro
 		if result := tx.Raw(fmt.Sprintf(`
 			INSERT INTO %s (asset_group_tag_id, created_at, created_by, updated_at, updated_by, name, description, is_default, allow_disable, auto_certify)
 			VALUES (?, NOW(), ?, NOW(), ?, ?, ?, ?, ?, ?)
-			RETURNING id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify`,
+			RETURNING id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify, expansion_limit, limit_reached_by, limit_reached_at`,
 			selector.TableName()),
 			assetGroupTagId, userIdStr, userIdStr, name, description, isDefault, allowDisable, autoCertify).Scan(&selector); result.Error != nil {
 			return CheckError(result)
@@ -136,7 +139,7 @@ func (s *BloodhoundDB) GetAssetGroupTagSelectorBySelectorId(ctx context.Context,
 
 	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if result := tx.Raw(fmt.Sprintf(`
-			SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify
+			SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify, expansion_limit, limit_reached_by, limit_reached_at
 			FROM %s WHERE id = ?`,
 			selector.TableName()),
 			assetGroupTagSelectorId).First(&selector); result.Error != nil {
@@ -163,10 +166,10 @@ func (s *BloodhoundDB) UpdateAssetGroupTagSelector(ctx context.Context, actorId,
 	if err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
 		bhdb := NewBloodhoundDB(tx, s.idResolver)
 		if result := tx.Exec(fmt.Sprintf(`
-			UPDATE %s SET updated_at = NOW(), updated_by = ?, name = ?, description = ?, disabled_at = ?, disabled_by = ?, auto_certify = ?
+			UPDATE %s SET updated_at = NOW(), updated_by = ?, name = ?, description = ?, disabled_at = ?, disabled_by = ?, auto_certify = ?, expansion_limit = ?
 			WHERE id = ?`,
 			selector.TableName()),
-			actorId, selector.Name, selector.Description, selector.DisabledAt, selector.DisabledBy, selector.AutoCertify, selector.ID); result.Error != nil {
+			actorId, selector.Name, selector.Description, selector.DisabledAt, selector.DisabledBy, selector.AutoCertify, selector.ExpansionLimit, selector.ID); result.Error != nil {
 			return CheckError(result)
 		} else {
 			if selector.Seeds != nil {
@@ -564,7 +567,7 @@ func (s *BloodhoundDB) GetAssetGroupTagSelectorsByTagId(ctx context.Context, ass
 
 	baseSqlStr := fmt.Sprintf(`
 		WITH selectors AS (
-			SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify FROM %s WHERE asset_group_tag_id = ?%s
+			SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify, expansion_limit, limit_reached_by, limit_reached_at FROM %s WHERE asset_group_tag_id = ?%s
 		), seeds AS (
 			SELECT selector_id, type, value FROM %s %s
 		)`,
@@ -618,7 +621,7 @@ func (s *BloodhoundDB) GetCustomAssetGroupTagSelectorsToMigrate(ctx context.Cont
 
 	sqlStr := fmt.Sprintf(`
 		WITH selectors AS (
-			SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify FROM %s WHERE created_at = updated_at AND created_at < '2025-05-28' AND is_default = false
+			SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify, expansion_limit, limit_reached_by, limit_reached_at FROM %s WHERE created_at = updated_at AND created_at < '2025-05-28' AND is_default = false
 		), seeds AS (
 			SELECT selector_id, type, value FROM %s WHERE type = 1
 		)
@@ -765,6 +768,51 @@ func (s *BloodhoundDB) UpdateTierPositions(ctx context.Context, user model.User,
 	return nil
 }
 
+// ReorderAssetGroupTagTiers assigns tier positions in the order of the given tier IDs. The list must contain every
+// existing tier exactly once and the tier in the first position may not be moved.
+func (s *BloodhoundDB) ReorderAssetGroupTagTiers(ctx context.Context, user model.User, orderedTierIds []int) (model.AssetGroupTags, error) {
+	var reorderedTiers model.AssetGroupTags
+
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bhdb := NewBloodhoundDB(tx, s.idResolver)
+
+		currentTiers, err := bhdb.GetOrderedAssetGroupTagTiers(ctx)
+		if err != nil {
+			return err
+		} else if len(orderedTierIds) != len(currentTiers) {
+			return fmt.Errorf("%w: expected %d tier ids but received %d", ErrInvalidTierOrder, len(currentTiers), len(orderedTierIds))
+		}
+
+		tiersById := make(map[int]model.AssetGroupTag, len(currentTiers))
+		for _, tier := range currentTiers {
+			tiersById[tier.ID] = tier
+		}
+
+		orderedTiers := make(model.AssetGroupTags, 0, len(orderedTierIds))
+		for _, tierId := range orderedTierIds {
+			if tier, ok := tiersById[tierId]; !ok {
+				return fmt.Errorf("%w: tier id %d is unknown or repeated", ErrInvalidTierOrder, tierId)
+			} else {
+				orderedTiers = append(orderedTiers, tier)
+				delete(tiersById, tierId)
+			}
+		}
+
+		if len(currentTiers) > 0 && orderedTiers[0].ID != currentTiers[0].ID {
+			return fmt.Errorf("%w: the tier in position %d cannot be moved", ErrInvalidTierOrder, model.AssetGroupTierZeroPosition)
+		} else if err := bhdb.UpdateTierPositions(ctx, user, orderedTiers); err != nil {
+			return err
+		} else {
+			reorderedTiers, err = bhdb.GetOrderedAssetGroupTagTiers(ctx)
+			return err
+		}
+	}); err != nil {
+		return model.AssetGroupTags{}, err
+	}
+
+	return reorderedTiers, nil
+}
+
 func (s *BloodhoundDB) GetAssetGroupTagSelectors(ctx context.Context, sqlFilter model.SQLFilter, limit int) (model.AssetGroupTagSelectors, error) {
 	var selectors = model.AssetGroupTagSelectors{}
 
@@ -779,7 +827,7 @@ func (s *BloodhoundDB) GetAssetGroupTagSelectors(ctx context.Context, sqlFilter
 
 	if result := s.db.WithContext(ctx).Raw(
 		fmt.Sprintf(
-			"SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify FROM %s %s ORDER BY name ASC, asset_group_tag_id ASC, id ASC %s",
+			"SELECT id, asset_group_tag_id, created_at, created_by, updated_at, updated_by, disabled_at, disabled_by, name, description, is_default, allow_disable, auto_certify, expansion_limit, limit_reached_by, limit_reached_at FROM %s %s ORDER BY name ASC, asset_group_tag_id ASC, id ASC %s",
 			model.AssetGroupTagSelector{}.TableName(),
 			sqlFilter.SQLString,
 			limitStr,
@@ -791,3 +839,113 @@ func (s *BloodhoundDB) GetAssetGroupTagSelectors(ctx context.Context, sqlFilter
 
 	return selectors, nil
 }
+
+// GetAssetGroupMemberCertifications returns the members of tags requiring certification that are in the given
+// certification state. A member selected by more than one selector is certified when any of its selections are
+// certified. An assetGroupTagId of 0 returns members of every tag.
+func (s *BloodhoundDB) GetAssetGroupMemberCertifications(ctx context.Context, assetGroupTagId int, certified model.AssetGroupCertification, skip, limit int) (model.AssetGroupMemberCertifications, int, error) {
+	var (
+		members         = model.AssetGroupMemberCertifications{}
+		tagFilterStr    string
+		skipLimitString string
+		totalRowCount   int
+		params          []any
+	)
+
+	if assetGroupTagId > 0 {
+		tagFilterStr = " AND s.asset_group_tag_id = ?"
+		params = append(params, assetGroupTagId)
+	}
+	params = append(params, certified)
+
+	if limit > 0 {
+		skipLimitString += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		skipLimitString += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	baseSqlStr := fmt.Sprintf(`
+		WITH members AS (
+			SELECT s.asset_group_tag_id, n.node_id, MAX(n.node_object_id) AS object_id, MAX(n.node_environment_id) AS environment_id,
+				MAX(n.node_primary_kind) AS primary_kind, MAX(n.node_name) AS name, MIN(n.created_at) AS created_at,
+				CASE WHEN MAX(n.certified) > 0 THEN MAX(n.certified) ELSE MIN(n.certified) END AS certified,
+				MAX(n.certified_by) AS certified_by
+			FROM %s n
+			JOIN %s s ON s.id = n.selector_id
+			JOIN %s t ON t.id = s.asset_group_tag_id
+			WHERE t.require_certify AND t.deleted_at IS NULL AND s.disabled_at IS NULL%s
+			GROUP BY s.asset_group_tag_id, n.node_id
+		)`,
+		model.AssetGroupSelectorNode{}.TableName(), model.AssetGroupTagSelector{}.TableName(), model.AssetGroupTag{}.TableName(), tagFilterStr)
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf("%s SELECT COUNT(*) FROM members WHERE certified = ?", baseSqlStr), params...).Scan(&totalRowCount); result.Error != nil {
+		return members, 0, CheckError(result)
+	} else if result := s.db.WithContext(ctx).Raw(fmt.Sprintf("%s SELECT node_id, asset_group_tag_id, object_id, environment_id, primary_kind, name, certified, certified_by, created_at FROM members WHERE certified = ? ORDER BY created_at, node_id%s", baseSqlStr, skipLimitString), params...).Scan(&members); result.Error != nil {
+		return members, 0, CheckError(result)
+	}
+
+	return members, totalRowCount, nil
+}
+
+// UpdateAssetGroupMemberCertifications sets the certification of every selection of the given members and records a
+// history entry per member and tag whose certification changed. It returns the number of history entries recorded.
+func (s *BloodhoundDB) UpdateAssetGroupMemberCertifications(ctx context.Context, user model.User, update model.AssetGroupCertificationUpdate) (int, error) {
+	var (
+		updatedCount int
+
+		auditEntry = model.AuditEntry{
+			Action: model.AuditLogActionCertifyAssetGroupTagMembers,
+			Model:  &update, // Pointer is required to ensure success log contains updated fields after transaction
+		}
+	)
+
+	if len(update.MemberIds) == 0 {
+		return 0, nil
+	}
+
+	if err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		var (
+			bhdb         = NewBloodhoundDB(tx, s.idResolver)
+			tagFilterStr string
+			params       = []any{update.Certification, user.ID.String(), update.MemberIds, update.Certification}
+			updated      []struct {
+				AssetGroupTagId int
+				NodeId          graph.ID
+				NodeName        string
+				EnvironmentId   string
+			}
+		)
+
+		if update.AssetGroupTagId > 0 {
+			tagFilterStr = " AND s.asset_group_tag_id = ?"
+			params = append(params, update.AssetGroupTagId)
+		}
+
+		if result := tx.WithContext(ctx).Raw(fmt.Sprintf(`
+			WITH updated AS (
+				UPDATE %s n SET certified = ?, certified_by = ?, updated_at = current_timestamp
+				FROM %s s
+				WHERE s.id = n.selector_id AND n.node_id IN ? AND n.certified <> ?%s
+				RETURNING s.asset_group_tag_id, n.node_id, n.node_name, n.node_environment_id
+			)
+			SELECT DISTINCT asset_group_tag_id, node_id, node_name, node_environment_id AS environment_id FROM updated ORDER BY asset_group_tag_id, node_id`,
+			model.AssetGroupSelectorNode{}.TableName(), model.AssetGroupTagSelector{}.TableName(), tagFilterStr), params...).Scan(&updated); result.Error != nil {
+			return CheckError(result)
+		}
+
+		for _, member := range updated {
+			if err := bhdb.CreateAssetGroupHistoryRecord(ctx, user.ID.String(), user.EmailAddress.ValueOrZero(), member.NodeName, model.ToAssetGroupHistoryActionFromAssetGroupCertification(update.Certification), member.AssetGroupTagId, null.StringFrom(member.EnvironmentId), update.Note); err != nil {
+				return err
+			}
+		}
+
+		updatedCount = len(updated)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return updatedCount, nil
+}
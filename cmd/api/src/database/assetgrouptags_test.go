@@ -150,6 +150,7 @@ func TestDatabase_UpdateAssetGroupTagSelector(t *testing.T) {
 	selector.DisabledAt = disabledTime
 	selector.DisabledBy = null.StringFrom(updateActor.ID.String())
 	selector.AutoCertify = updateAutoCert
+	selector.ExpansionLimit = null.Int32From(500)
 	selector.Seeds = updateSeeds
 
 	// call the update function
@@ -168,6 +169,7 @@ func TestDatabase_UpdateAssetGroupTagSelector(t *testing.T) {
 	require.Equal(t, updateName, readBackSelector.Name)
 	require.Equal(t, updateDescription, readBackSelector.Description)
 	require.Equal(t, updateAutoCert, readBackSelector.AutoCertify)
+	require.Equal(t, null.Int32From(500), readBackSelector.ExpansionLimit)
 	require.Equal(t, isDefault, readBackSelector.IsDefault)
 	for idx, seed := range updateSeeds {
 		require.Equal(t, seed.Type, readBackSelector.Seeds[idx].Type)
@@ -599,6 +601,64 @@ func TestDatabase_UpdateAssetGroupTag_shifting(t *testing.T) {
 	})
 }
 
+func TestDatabase_ReorderAssetGroupTagTiers(t *testing.T) {
+	var (
+		testCtx   = context.Background()
+		testActor = model.User{Unique: model.Unique{ID: uuid.FromStringOrNil("01234567-9012-4567-9012-456789012345")}}
+	)
+
+	t.Run("reorders tiers successfully", func(t *testing.T) {
+		dbInst := integration.SetupDB(t)
+
+		tiers, err := dbInst.GetOrderedAssetGroupTagTiers(testCtx)
+		require.NoError(t, err)
+		require.Len(t, tiers, 1)
+
+		tag1, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "reorder1", "", null.Int32From(2), null.Bool{})
+		require.NoError(t, err)
+		tag2, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "reorder2", "", null.Int32From(3), null.Bool{})
+		require.NoError(t, err)
+		tag3, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "reorder3", "", null.Int32From(4), null.Bool{})
+		require.NoError(t, err)
+
+		reordered, err := dbInst.ReorderAssetGroupTagTiers(testCtx, testActor, []int{tiers[0].ID, tag3.ID, tag1.ID, tag2.ID})
+		require.NoError(t, err)
+		require.Len(t, reordered, 4)
+		require.Equal(t, []int{tiers[0].ID, tag3.ID, tag1.ID, tag2.ID}, []int{reordered[0].ID, reordered[1].ID, reordered[2].ID, reordered[3].ID})
+		require.Equal(t, int32(2), reordered[1].Position.ValueOrZero())
+		require.Equal(t, int32(4), reordered[3].Position.ValueOrZero())
+	})
+
+	t.Run("fails when the first tier is moved", func(t *testing.T) {
+		dbInst := integration.SetupDB(t)
+
+		tiers, err := dbInst.GetOrderedAssetGroupTagTiers(testCtx)
+		require.NoError(t, err)
+
+		tag1, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "reorder1", "", null.Int32{}, null.Bool{})
+		require.NoError(t, err)
+
+		_, err = dbInst.ReorderAssetGroupTagTiers(testCtx, testActor, []int{tag1.ID, tiers[0].ID})
+		require.ErrorIs(t, err, database.ErrInvalidTierOrder)
+	})
+
+	t.Run("fails when a tier is missing or repeated", func(t *testing.T) {
+		dbInst := integration.SetupDB(t)
+
+		tiers, err := dbInst.GetOrderedAssetGroupTagTiers(testCtx)
+		require.NoError(t, err)
+
+		_, err = dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "reorder1", "", null.Int32{}, null.Bool{})
+		require.NoError(t, err)
+
+		_, err = dbInst.ReorderAssetGroupTagTiers(testCtx, testActor, []int{tiers[0].ID})
+		require.ErrorIs(t, err, database.ErrInvalidTierOrder)
+
+		_, err = dbInst.ReorderAssetGroupTagTiers(testCtx, testActor, []int{tiers[0].ID, tiers[0].ID})
+		require.ErrorIs(t, err, database.ErrInvalidTierOrder)
+	})
+}
+
 func TestDatabase_DeleteAssetGroupTag(t *testing.T) {
 	var (
 		testCtx                = context.Background()
@@ -1090,3 +1150,64 @@ func TestDatabase_GetAssetGroupTagSelectors(t *testing.T) {
 		require.GreaterOrEqual(t, len(items), 2)
 	})
 }
+
+func TestDatabase_AssetGroupMemberCertifications(t *testing.T) {
+	var (
+		dbInst    = integration.SetupDB(t)
+		testCtx   = context.Background()
+		testActor = model.User{Unique: model.Unique{ID: uuid.FromStringOrNil("01234567-9012-4567-9012-456789012345")}}
+		seeds     = []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "ObjectID1234"}}
+	)
+
+	tier, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "certify tier", "", null.Int32{}, null.BoolFrom(true))
+	require.NoError(t, err)
+	selector1, err := dbInst.CreateAssetGroupTagSelector(testCtx, tier.ID, testActor, "selector 1", "", false, true, null.BoolFrom(false), seeds)
+	require.NoError(t, err)
+	selector2, err := dbInst.CreateAssetGroupTagSelector(testCtx, tier.ID, testActor, "selector 2", "", false, true, null.BoolFrom(false), seeds)
+	require.NoError(t, err)
+
+	// node 1 is selected twice, node 2 once
+	require.NoError(t, dbInst.InsertSelectorNode(testCtx, tier.ID, selector1.ID, 1, model.AssetGroupCertificationNone, null.String{}, model.AssetGroupSelectorNodeSourceSeed, "User", "env", "OBJ-1", "user 1"))
+	require.NoError(t, dbInst.InsertSelectorNode(testCtx, tier.ID, selector2.ID, 1, model.AssetGroupCertificationNone, null.String{}, model.AssetGroupSelectorNodeSourceSeed, "User", "env", "OBJ-1", "user 1"))
+	require.NoError(t, dbInst.InsertSelectorNode(testCtx, tier.ID, selector1.ID, 2, model.AssetGroupCertificationNone, null.String{}, model.AssetGroupSelectorNodeSourceSeed, "User", "env", "OBJ-2", "user 2"))
+
+	pending, count, err := dbInst.GetAssetGroupMemberCertifications(testCtx, tier.ID, model.AssetGroupCertificationNone, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Len(t, pending, 2)
+	require.Equal(t, "OBJ-1", pending[0].ObjectId)
+
+	updated, err := dbInst.UpdateAssetGroupMemberCertifications(testCtx, testActor, model.AssetGroupCertificationUpdate{
+		AssetGroupTagId: tier.ID,
+		MemberIds:       []graph.ID{1},
+		Certification:   model.AssetGroupCertificationManual,
+		Note:            null.StringFrom("reviewed"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, updated)
+
+	// certifying again is a no-op
+	updated, err = dbInst.UpdateAssetGroupMemberCertifications(testCtx, testActor, model.AssetGroupCertificationUpdate{
+		AssetGroupTagId: tier.ID,
+		MemberIds:       []graph.ID{1},
+		Certification:   model.AssetGroupCertificationManual,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, updated)
+
+	pending, count, err = dbInst.GetAssetGroupMemberCertifications(testCtx, tier.ID, model.AssetGroupCertificationNone, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, graph.ID(2), pending[0].NodeId)
+
+	certified, _, err := dbInst.GetAssetGroupMemberCertifications(testCtx, tier.ID, model.AssetGroupCertificationManual, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, certified, 1)
+	require.Equal(t, testActor.ID.String(), certified[0].CertifiedBy.ValueOrZero())
+
+	history, _, err := dbInst.GetAssetGroupHistoryRecords(testCtx, model.SQLFilter{SQLString: "action = ?", Params: []any{model.AssetGroupHistoryActionCertifyNodeManual}}, model.Sort{}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "user 1", history[0].Target)
+	require.Equal(t, "reviewed", history[0].Note.ValueOrZero())
+}
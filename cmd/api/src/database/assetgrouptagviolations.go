@@ -0,0 +1,113 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"gorm.io/gorm"
+)
+
+// AssetGroupTagViolationData defines the methods required to interact with the asset_group_tag_violations table
+type AssetGroupTagViolationData interface {
+	ReplaceAssetGroupTagViolations(ctx context.Context, violations model.AssetGroupTagViolations) error
+	GetAssetGroupTagViolationCounts(ctx context.Context) (model.AssetGroupTagViolationCounts, error)
+	GetAssetGroupTagViolations(ctx context.Context, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.AssetGroupTagViolations, int, error)
+}
+
+// ReplaceAssetGroupTagViolations swaps out the findings of the previous analysis run for the given violations
+func (s *BloodhoundDB) ReplaceAssetGroupTagViolations(ctx context.Context, violations model.AssetGroupTagViolations) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Exec(fmt.Sprintf("DELETE FROM %s", model.AssetGroupTagViolation{}.TableName())); result.Error != nil {
+			return CheckError(result)
+		} else if len(violations) == 0 {
+			return nil
+		}
+
+		return CheckError(tx.CreateInBatches(&violations, 500))
+	})
+}
+
+// GetAssetGroupTagViolationCounts returns the number of violations for every pair of tiers that has any
+func (s *BloodhoundDB) GetAssetGroupTagViolationCounts(ctx context.Context) (model.AssetGroupTagViolationCounts, error) {
+	var counts = model.AssetGroupTagViolationCounts{}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		SELECT source_tag_id, target_tag_id, COUNT(*) AS count, COUNT(*) FILTER (WHERE hops = 1) AS direct_count
+		FROM %s
+		GROUP BY source_tag_id, target_tag_id
+		ORDER BY source_tag_id, target_tag_id`,
+		model.AssetGroupTagViolation{}.TableName())).Scan(&counts); result.Error != nil {
+		return model.AssetGroupTagViolationCounts{}, CheckError(result)
+	}
+
+	return counts, nil
+}
+
+// GetAssetGroupTagViolations returns the individual violation findings
+func (s *BloodhoundDB) GetAssetGroupTagViolations(ctx context.Context, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.AssetGroupTagViolations, int, error) {
+	var (
+		violations      = model.AssetGroupTagViolations{}
+		skipLimitString string
+		whereString     string
+		sortString      = "ORDER BY hops ASC, id ASC"
+		count           int
+	)
+
+	if sqlFilter.SQLString != "" {
+		whereString = " WHERE " + sqlFilter.SQLString
+	}
+
+	if len(sortItems) > 0 {
+		var sortColumns []string
+		for _, item := range sortItems {
+			dirString := "ASC"
+			if item.Direction == model.DescendingSortDirection {
+				dirString = "DESC"
+			}
+			sortColumns = append(sortColumns, fmt.Sprintf("%s %s", item.Column, dirString))
+		}
+		sortString = "ORDER BY " + strings.Join(sortColumns, ", ")
+	}
+
+	if limit > 0 {
+		skipLimitString += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		skipLimitString += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT * FROM %s%s %s%s",
+		model.AssetGroupTagViolation{}.TableName(), whereString, sortString, skipLimitString),
+		sqlFilter.Params...).Find(&violations); result.Error != nil {
+		return model.AssetGroupTagViolations{}, 0, CheckError(result)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s%s",
+		model.AssetGroupTagViolation{}.TableName(), whereString),
+		sqlFilter.Params...).Scan(&count); result.Error != nil {
+		return model.AssetGroupTagViolations{}, 0, CheckError(result)
+	}
+
+	return violations, count, nil
+}
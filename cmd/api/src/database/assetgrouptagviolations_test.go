@@ -0,0 +1,69 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_AssetGroupTagViolations(t *testing.T) {
+	var (
+		testCtx   = context.Background()
+		testActor = model.User{Unique: model.Unique{ID: uuid.FromStringOrNil("01234567-9012-4567-9012-456789012345")}}
+		dbInst    = integration.SetupDB(t)
+	)
+
+	tierOne, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "Tier One", "", null.Int32From(2), null.BoolFrom(false))
+	require.NoError(t, err)
+
+	require.NoError(t, dbInst.ReplaceAssetGroupTagViolations(testCtx, model.AssetGroupTagViolations{
+		{SourceTagId: tierOne.ID, TargetTagId: 1, SourceNodeId: 10, SourceName: "SVC@CORP", TargetNodeId: 1, TargetName: "DOMAIN ADMINS@CORP", Hops: 1, EdgeKinds: []string{"GenericAll"}},
+		{SourceTagId: tierOne.ID, TargetTagId: 1, SourceNodeId: 11, SourceName: "HELPDESK@CORP", TargetNodeId: 1, TargetName: "DOMAIN ADMINS@CORP", Hops: 2, EdgeKinds: []string{"MemberOf", "AddMember"}},
+	}))
+
+	t.Run("counts violations per tier pair", func(t *testing.T) {
+		counts, err := dbInst.GetAssetGroupTagViolationCounts(testCtx)
+		require.NoError(t, err)
+		require.Equal(t, model.AssetGroupTagViolationCounts{{SourceTagId: tierOne.ID, TargetTagId: 1, Count: 2, DirectCount: 1}}, counts)
+	})
+
+	t.Run("lists and filters violations", func(t *testing.T) {
+		violations, count, err := dbInst.GetAssetGroupTagViolations(testCtx, model.SQLFilter{SQLString: "hops > 1"}, model.Sort{}, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, "HELPDESK@CORP", violations[0].SourceName)
+		require.Equal(t, []string{"MemberOf", "AddMember"}, []string(violations[0].EdgeKinds))
+	})
+
+	t.Run("replacing clears previous findings", func(t *testing.T) {
+		require.NoError(t, dbInst.ReplaceAssetGroupTagViolations(testCtx, nil))
+
+		counts, err := dbInst.GetAssetGroupTagViolationCounts(testCtx)
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"gorm.io/gorm"
+)
+
+const (
+	assetGroupTagWebhookColumns         = "id, name, url, secret, asset_group_tag_id, enabled, created_at, created_by, updated_at, updated_by"
+	assetGroupTagWebhookDeliveryColumns = "id, webhook_id, asset_group_tag_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, completed_at"
+)
+
+// AssetGroupTagWebhookData defines the methods required to interact with the asset group tag webhook tables
+type AssetGroupTagWebhookData interface {
+	CreateAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error)
+	GetAssetGroupTagWebhooks(ctx context.Context, sqlFilter model.SQLFilter) (model.AssetGroupTagWebhooks, error)
+	GetAssetGroupTagWebhook(ctx context.Context, id int) (model.AssetGroupTagWebhook, error)
+	UpdateAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error)
+	DeleteAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) error
+	CreateAssetGroupTagWebhookDeliveries(ctx context.Context, assetGroupTagId int, event model.AssetGroupTagWebhookEvent, payload types.JSONBObject) (int, error)
+	GetAssetGroupTagWebhookDeliveries(ctx context.Context, webhookId int, sqlFilter model.SQLFilter, skip, limit int) (model.AssetGroupTagWebhookDeliveries, int, error)
+	GetAssetGroupTagWebhookDelivery(ctx context.Context, webhookId int, deliveryId int64) (model.AssetGroupTagWebhookDelivery, error)
+	GetAssetGroupTagWebhookDeliveryAttempts(ctx context.Context, deliveryId int64) (model.AssetGroupTagWebhookDeliveryAttempts, error)
+	GetDueAssetGroupTagWebhookDeliveries(ctx context.Context, now time.Time, limit int) (model.AssetGroupTagWebhookDeliveries, error)
+	RecordAssetGroupTagWebhookDeliveryAttempt(ctx context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error
+}
+
+func (s *BloodhoundDB) CreateAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionCreateAssetGroupTagWebhook,
+		Model:  &webhook, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	if err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Raw(fmt.Sprintf(`
+			INSERT INTO %s (name, url, secret, asset_group_tag_id, enabled, created_at, created_by, updated_at, updated_by)
+			VALUES (?, ?, ?, ?, ?, NOW(), ?, NOW(), ?)
+			RETURNING %s`,
+			webhook.TableName(), assetGroupTagWebhookColumns),
+			webhook.Name, webhook.URL, webhook.Secret, webhook.AssetGroupTagId, webhook.Enabled, webhook.CreatedBy, webhook.CreatedBy).Scan(&webhook))
+	}); err != nil {
+		return model.AssetGroupTagWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+func (s *BloodhoundDB) GetAssetGroupTagWebhooks(ctx context.Context, sqlFilter model.SQLFilter) (model.AssetGroupTagWebhooks, error) {
+	var (
+		webhooks    = model.AssetGroupTagWebhooks{}
+		whereString string
+	)
+
+	if sqlFilter.SQLString != "" {
+		whereString = " WHERE " + sqlFilter.SQLString
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s%s ORDER BY name ASC, id ASC",
+		assetGroupTagWebhookColumns, model.AssetGroupTagWebhook{}.TableName(), whereString),
+		sqlFilter.Params...).Find(&webhooks); result.Error != nil {
+		return model.AssetGroupTagWebhooks{}, CheckError(result)
+	}
+
+	return webhooks, nil
+}
+
+func (s *BloodhoundDB) GetAssetGroupTagWebhook(ctx context.Context, id int) (model.AssetGroupTagWebhook, error) {
+	var webhook model.AssetGroupTagWebhook
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s WHERE id = ?",
+		assetGroupTagWebhookColumns, webhook.TableName()),
+		id).First(&webhook); result.Error != nil {
+		return model.AssetGroupTagWebhook{}, CheckError(result)
+	}
+
+	return webhook, nil
+}
+
+func (s *BloodhoundDB) UpdateAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionUpdateAssetGroupTagWebhook,
+		Model:  &webhook, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	if err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Raw(fmt.Sprintf(`
+			UPDATE %s SET name = ?, url = ?, secret = ?, asset_group_tag_id = ?, enabled = ?, updated_at = NOW(), updated_by = ?
+			WHERE id = ?
+			RETURNING %s`,
+			webhook.TableName(), assetGroupTagWebhookColumns),
+			webhook.Name, webhook.URL, webhook.Secret, webhook.AssetGroupTagId, webhook.Enabled, webhook.UpdatedBy, webhook.ID).Scan(&webhook))
+	}); err != nil {
+		return model.AssetGroupTagWebhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteAssetGroupTagWebhook removes a webhook along with its deliveries and their attempts
+func (s *BloodhoundDB) DeleteAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionDeleteAssetGroupTagWebhook,
+		Model:  &webhook, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", webhook.TableName()), webhook.ID))
+	})
+}
+
+// CreateAssetGroupTagWebhookDeliveries queues a delivery of the payload to every enabled webhook that is subscribed to
+// the given tag and returns the number of deliveries queued
+func (s *BloodhoundDB) CreateAssetGroupTagWebhookDeliveries(ctx context.Context, assetGroupTagId int, event model.AssetGroupTagWebhookEvent, payload types.JSONBObject) (int, error) {
+	result := s.db.WithContext(ctx).Exec(fmt.Sprintf(`
+		INSERT INTO %s (webhook_id, asset_group_tag_id, event, payload, status, next_attempt_at)
+		SELECT id, ?, ?, ?, ?, NOW() FROM %s
+		WHERE enabled AND (asset_group_tag_id IS NULL OR asset_group_tag_id = ?)`,
+		model.AssetGroupTagWebhookDelivery{}.TableName(), model.AssetGroupTagWebhook{}.TableName()),
+		assetGroupTagId, event, payload, model.AssetGroupTagWebhookDeliveryStatusPending, assetGroupTagId)
+
+	if result.Error != nil {
+		return 0, CheckError(result)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (s *BloodhoundDB) GetAssetGroupTagWebhookDeliveries(ctx context.Context, webhookId int, sqlFilter model.SQLFilter, skip, limit int) (model.AssetGroupTagWebhookDeliveries, int, error) {
+	var (
+		deliveries      = model.AssetGroupTagWebhookDeliveries{}
+		whereString     = "WHERE webhook_id = ?"
+		params          = []any{webhookId}
+		skipLimitString string
+		count           int
+	)
+
+	if sqlFilter.SQLString != "" {
+		whereString += " AND " + sqlFilter.SQLString
+		params = append(params, sqlFilter.Params...)
+	}
+
+	if limit > 0 {
+		skipLimitString += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		skipLimitString += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s %s ORDER BY created_at DESC, id DESC%s",
+		assetGroupTagWebhookDeliveryColumns, model.AssetGroupTagWebhookDelivery{}.TableName(), whereString, skipLimitString),
+		params...).Find(&deliveries); result.Error != nil {
+		return model.AssetGroupTagWebhookDeliveries{}, 0, CheckError(result)
+	}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s %s",
+		model.AssetGroupTagWebhookDelivery{}.TableName(), whereString),
+		params...).Scan(&count); result.Error != nil {
+		return model.AssetGroupTagWebhookDeliveries{}, 0, CheckError(result)
+	}
+
+	return deliveries, count, nil
+}
+
+func (s *BloodhoundDB) GetAssetGroupTagWebhookDelivery(ctx context.Context, webhookId int, deliveryId int64) (model.AssetGroupTagWebhookDelivery, error) {
+	var delivery model.AssetGroupTagWebhookDelivery
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s WHERE webhook_id = ? AND id = ?",
+		assetGroupTagWebhookDeliveryColumns, delivery.TableName()),
+		webhookId, deliveryId).First(&delivery); result.Error != nil {
+		return model.AssetGroupTagWebhookDelivery{}, CheckError(result)
+	}
+
+	return delivery, nil
+}
+
+func (s *BloodhoundDB) GetAssetGroupTagWebhookDeliveryAttempts(ctx context.Context, deliveryId int64) (model.AssetGroupTagWebhookDeliveryAttempts, error) {
+	var attempts = model.AssetGroupTagWebhookDeliveryAttempts{}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT id, delivery_id, attempt, status_code, error, duration_ms, attempted_at FROM %s WHERE delivery_id = ? ORDER BY attempt ASC",
+		model.AssetGroupTagWebhookDeliveryAttempt{}.TableName()),
+		deliveryId).Find(&attempts); result.Error != nil {
+		return model.AssetGroupTagWebhookDeliveryAttempts{}, CheckError(result)
+	}
+
+	return attempts, nil
+}
+
+// GetDueAssetGroupTagWebhookDeliveries returns pending deliveries whose next attempt is due, oldest first
+func (s *BloodhoundDB) GetDueAssetGroupTagWebhookDeliveries(ctx context.Context, now time.Time, limit int) (model.AssetGroupTagWebhookDeliveries, error) {
+	var deliveries = model.AssetGroupTagWebhookDeliveries{}
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf(
+		"SELECT %s FROM %s WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at ASC, id ASC LIMIT ?",
+		assetGroupTagWebhookDeliveryColumns, model.AssetGroupTagWebhookDelivery{}.TableName()),
+		model.AssetGroupTagWebhookDeliveryStatusPending, now, limit).Find(&deliveries); result.Error != nil {
+		return model.AssetGroupTagWebhookDeliveries{}, CheckError(result)
+	}
+
+	return deliveries, nil
+}
+
+// RecordAssetGroupTagWebhookDeliveryAttempt stores an attempt and saves the resulting status of its delivery
+func (s *BloodhoundDB) RecordAssetGroupTagWebhookDeliveryAttempt(ctx context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Exec(fmt.Sprintf(`
+			INSERT INTO %s (delivery_id, attempt, status_code, error, duration_ms, attempted_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			attempt.TableName()),
+			delivery.ID, attempt.Attempt, attempt.StatusCode, attempt.Error, attempt.DurationMs, attempt.AttemptedAt); result.Error != nil {
+			return CheckError(result)
+		}
+
+		return CheckError(tx.Exec(fmt.Sprintf(`
+			UPDATE %s SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, completed_at = ?
+			WHERE id = ?`,
+			delivery.TableName()),
+			delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode, delivery.LastError, delivery.CompletedAt, delivery.ID))
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_AssetGroupTagWebhooks(t *testing.T) {
+	var (
+		testCtx = context.Background()
+		dbInst  = integration.SetupDB(t)
+	)
+
+	allTags, err := dbInst.CreateAssetGroupTagWebhook(testCtx, model.AssetGroupTagWebhook{Name: "all tags", URL: "https://example.com/all", Secret: "secret", Enabled: true, CreatedBy: "user"})
+	require.NoError(t, err)
+	require.Equal(t, "secret", allTags.Secret)
+	require.False(t, allTags.AssetGroupTagId.Valid)
+
+	tierZero, err := dbInst.CreateAssetGroupTagWebhook(testCtx, model.AssetGroupTagWebhook{Name: "tier zero", URL: "https://example.com/t0", Secret: "secret", AssetGroupTagId: null.Int32From(1), Enabled: true, CreatedBy: "user"})
+	require.NoError(t, err)
+
+	disabled, err := dbInst.CreateAssetGroupTagWebhook(testCtx, model.AssetGroupTagWebhook{Name: "disabled", URL: "https://example.com/off", Secret: "secret", Enabled: false, CreatedBy: "user"})
+	require.NoError(t, err)
+
+	t.Run("lists and filters webhooks", func(t *testing.T) {
+		webhooks, err := dbInst.GetAssetGroupTagWebhooks(testCtx, model.SQLFilter{})
+		require.NoError(t, err)
+		require.Len(t, webhooks, 3)
+
+		webhooks, err = dbInst.GetAssetGroupTagWebhooks(testCtx, model.SQLFilter{SQLString: "enabled = false"})
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, disabled.ID, webhooks[0].ID)
+	})
+
+	t.Run("updates a webhook", func(t *testing.T) {
+		tierZero.URL = "https://example.com/tier-zero"
+		tierZero.UpdatedBy = "other"
+
+		updated, err := dbInst.UpdateAssetGroupTagWebhook(testCtx, tierZero)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/tier-zero", updated.URL)
+		require.Equal(t, "other", updated.UpdatedBy)
+	})
+
+	t.Run("queues deliveries for subscribed and enabled webhooks", func(t *testing.T) {
+		payload, err := types.NewJSONBObject(model.AssetGroupTagMembershipChange{AddedCount: 1})
+		require.NoError(t, err)
+
+		queued, err := dbInst.CreateAssetGroupTagWebhookDeliveries(testCtx, 2, model.AssetGroupTagWebhookEventMembershipChanged, payload)
+		require.NoError(t, err)
+		require.Equal(t, 1, queued)
+
+		queued, err = dbInst.CreateAssetGroupTagWebhookDeliveries(testCtx, 1, model.AssetGroupTagWebhookEventMembershipChanged, payload)
+		require.NoError(t, err)
+		require.Equal(t, 2, queued)
+
+		deliveries, count, err := dbInst.GetAssetGroupTagWebhookDeliveries(testCtx, allTags.ID, model.SQLFilter{}, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		require.Len(t, deliveries, 2)
+
+		deliveries, count, err = dbInst.GetAssetGroupTagWebhookDeliveries(testCtx, disabled.ID, model.SQLFilter{}, 0, 0)
+		require.NoError(t, err)
+		require.Zero(t, count)
+		require.Empty(t, deliveries)
+	})
+
+	t.Run("records delivery attempts", func(t *testing.T) {
+		due, err := dbInst.GetDueAssetGroupTagWebhookDeliveries(testCtx, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 3)
+
+		delivery := due[0]
+		delivery.Attempts = 1
+		delivery.LastStatusCode = null.Int32From(500)
+		delivery.LastError = "endpoint responded with 500"
+		delivery.NextAttemptAt = null.TimeFrom(time.Now().Add(time.Hour))
+		require.NoError(t, dbInst.RecordAssetGroupTagWebhookDeliveryAttempt(testCtx, delivery, model.AssetGroupTagWebhookDeliveryAttempt{
+			Attempt:     1,
+			StatusCode:  null.Int32From(500),
+			Error:       delivery.LastError,
+			AttemptedAt: time.Now(),
+		}))
+
+		due, err = dbInst.GetDueAssetGroupTagWebhookDeliveries(testCtx, time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+
+		fetched, err := dbInst.GetAssetGroupTagWebhookDelivery(testCtx, delivery.WebhookId, delivery.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, fetched.Attempts)
+		require.Equal(t, model.AssetGroupTagWebhookDeliveryStatusPending, fetched.Status)
+
+		attempts, err := dbInst.GetAssetGroupTagWebhookDeliveryAttempts(testCtx, delivery.ID)
+		require.NoError(t, err)
+		require.Len(t, attempts, 1)
+		require.Equal(t, int32(500), attempts[0].StatusCode.Int32)
+	})
+
+	t.Run("deleting a webhook removes its deliveries", func(t *testing.T) {
+		require.NoError(t, dbInst.DeleteAssetGroupTagWebhook(testCtx, allTags))
+
+		_, err := dbInst.GetAssetGroupTagWebhook(testCtx, allTags.ID)
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		deliveries, _, err := dbInst.GetAssetGroupTagWebhookDeliveries(testCtx, allTags.ID, model.SQLFilter{}, 0, 0)
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+	})
+}
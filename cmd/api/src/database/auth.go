@@ -24,6 +24,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
@@ -132,6 +134,110 @@ func (s *BloodhoundDB) GetRole(ctx context.Context, id int32) (model.Role, error
 	return role, CheckError(result)
 }
 
+// CreateRole creates a new custom role with the permissions assigned to it
+// INSERT INTO roles (...) VALUES (...); INSERT INTO roles_permissions (...) VALUES (...)
+func (s *BloodhoundDB) CreateRole(ctx context.Context, role model.Role) (model.Role, error) {
+	newRole := role
+	newRole.ID = 0
+	newRole.BuiltIn = false
+
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionCreateRole,
+		Model:  &newRole,
+	}
+
+	return newRole, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		result := tx.WithContext(ctx).Omit("Permissions.*").Create(&newRole)
+
+		if result.Error != nil && strings.Contains(result.Error.Error(), "duplicate key value violates unique constraint \"roles_name_key\"") {
+			return fmt.Errorf("%w: %v", ErrDuplicateRoleName, result.Error)
+		}
+
+		return CheckError(result)
+	})
+}
+
+// UpdateRole updates the name, description and permissions of a custom role. Built-in roles are immutable.
+// UPDATE roles SET ... WHERE id = ... AND built_in = false
+func (s *BloodhoundDB) UpdateRole(ctx context.Context, role model.Role) (model.Role, error) {
+	updatedRole := role
+
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionUpdateRole,
+		Model:  &updatedRole,
+	}
+
+	return updatedRole, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		if err := isMutableRole(ctx, tx, updatedRole.ID); err != nil {
+			return err
+		} else if result := tx.WithContext(ctx).Model(&updatedRole).Select("name", "description").Updates(&updatedRole); result.Error != nil {
+			if strings.Contains(result.Error.Error(), "duplicate key value violates unique constraint \"roles_name_key\"") {
+				return fmt.Errorf("%w: %v", ErrDuplicateRoleName, result.Error)
+			}
+
+			return CheckError(result)
+		} else if err := tx.WithContext(ctx).Model(&updatedRole).Association("Permissions").Replace(updatedRole.Permissions); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// DeleteRole removes a custom role and its permission assignments. Roles that are still assigned to users, roles that
+// an SSO provider provisions as its default or through a mapping rule, and built-in roles cannot be deleted.
+// DELETE FROM roles WHERE id = ... AND built_in = false
+func (s *BloodhoundDB) DeleteRole(ctx context.Context, role model.Role) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionDeleteRole,
+		Model:  &role,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		var assignedUsers, referencingProviders int64
+
+		if err := isMutableRole(ctx, tx, role.ID); err != nil {
+			return err
+		} else if err := tx.WithContext(ctx).Raw("SELECT count(*) FROM users_roles WHERE role_id = ?", role.ID).Scan(&assignedUsers).Error; err != nil {
+			return err
+		} else if assignedUsers > 0 {
+			return ErrRoleInUse
+		} else if err := tx.WithContext(ctx).Raw(ssoProvidersReferencingRoleSQL, role.ID, role.ID).Scan(&referencingProviders).Error; err != nil {
+			return err
+		} else if referencingProviders > 0 {
+			return ErrRoleReferencedBySSOProvider
+		} else if err := tx.WithContext(ctx).Model(&role).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+
+		return CheckError(tx.WithContext(ctx).Delete(&role))
+	})
+}
+
+// ssoProvidersReferencingRoleSQL counts the SSO providers that provision a role as their default role or through one of
+// their mapping rules
+const ssoProvidersReferencingRoleSQL = `
+SELECT count(*) FROM sso_providers
+WHERE (config -> 'auto_provision' ->> 'default_role_id')::int = ?
+   OR EXISTS (
+       SELECT 1 FROM jsonb_array_elements(COALESCE(config -> 'auto_provision' -> 'mapping_rules', '[]'::jsonb)) AS rule
+       WHERE (rule ->> 'role_id')::int = ?
+   )`
+
+func isMutableRole(ctx context.Context, tx *gorm.DB, roleID int32) error {
+	var builtIn []bool
+
+	if err := tx.WithContext(ctx).Raw("SELECT built_in FROM roles WHERE id = ?", roleID).Scan(&builtIn).Error; err != nil {
+		return err
+	} else if len(builtIn) == 0 {
+		return ErrNotFound
+	} else if builtIn[0] {
+		return ErrBuiltInRole
+	}
+
+	return nil
+}
+
 // GetAllPermissions retrieves all rows from the Permissions table
 // SELECT * FROM permissions
 func (s *BloodhoundDB) GetAllPermissions(ctx context.Context, order string, filter model.SQLFilter) (model.Permissions, error) {
@@ -280,7 +386,8 @@ func (s *BloodhoundDB) CreateUser(ctx context.Context, user model.User) (model.U
 	})
 }
 
-// UpdateUser updates the roles associated with the user according to the input struct
+// UpdateUser updates the roles associated with the user according to the input struct. If the user's roles change, every
+// active session of the user is revoked.
 // UPDATE users SET roles = ....
 func (s *BloodhoundDB) UpdateUser(ctx context.Context, user model.User) error {
 	// Ensure lowercase emails
@@ -292,6 +399,12 @@ func (s *BloodhoundDB) UpdateUser(ctx context.Context, user model.User) error {
 	}
 
 	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		var previousRoleIDs []int32
+
+		if err := tx.WithContext(ctx).Raw("SELECT role_id FROM users_roles WHERE user_id = ? ORDER BY role_id", user.ID).Scan(&previousRoleIDs).Error; err != nil {
+			return err
+		}
+
 		// Update roles first
 		if err := tx.Model(&user).WithContext(ctx).Association("Roles").Replace(&user.Roles); err != nil {
 			return err
@@ -320,7 +433,35 @@ func (s *BloodhoundDB) UpdateUser(ctx context.Context, user model.User) error {
 			}
 		}
 
-		return CheckError(result)
+		if err := CheckError(result); err != nil {
+			return err
+		}
+
+		// Sessions carry the permissions of the roles the user logged in with, so whichever path changes the roles,
+		// whether the management API, SCIM or SSO role mapping, logs the user out everywhere
+		roleIDs := user.Roles.IDs()
+		slices.Sort(roleIDs)
+
+		if !slices.Equal(previousRoleIDs, roleIDs) {
+			return NewBloodhoundDB(tx, s.idResolver).RevokeUserSessions(ctx, user)
+		}
+
+		return nil
+	})
+}
+
+// UpdateUsers updates every given user in a single transaction; if any update fails none are applied
+func (s *BloodhoundDB) UpdateUsers(ctx context.Context, users model.Users) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bhdb := NewBloodhoundDB(tx, s.idResolver)
+
+		for _, user := range users {
+			if err := bhdb.UpdateUser(ctx, user); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
@@ -373,6 +514,20 @@ func (s *BloodhoundDB) DeleteUser(ctx context.Context, user model.User) error {
 	})
 }
 
+// DeleteUserAndTransferSavedQueries hands the shared saved queries of the given user to toUser and deletes the user in a
+// single transaction so that neither change is applied without the other
+func (s *BloodhoundDB) DeleteUserAndTransferSavedQueries(ctx context.Context, user model.User, toUser model.User) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bhdb := NewBloodhoundDB(tx, s.idResolver)
+
+		if err := bhdb.TransferSavedQueries(ctx, user, toUser); err != nil {
+			return err
+		}
+
+		return bhdb.DeleteUser(ctx, user)
+	})
+}
+
 // LookupUser retrieves the User row associated with the provided name. The name is matched against both the
 // principal_name and email address fields of a user.
 //
@@ -396,15 +551,16 @@ func (s *BloodhoundDB) CreateAuthToken(ctx context.Context, authToken model.Auth
 	}
 
 	return authToken, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
-		return CheckError(tx.WithContext(ctx).Create(&authToken))
+		return CheckError(tx.WithContext(ctx).Omit("Permissions.*").Create(&authToken))
 	})
 }
 
-// UpdateAuthToken updates all fields in the AuthToken row as specified in the provided struct
+// UpdateAuthToken updates all fields in the AuthToken row as specified in the provided struct. The token's permission
+// scope is fixed at creation and is not modified.
 // UPDATE auth_tokens SET key = ..., hmac_method = ..., last_access = ...
 // WHERE user_id = ... AND client_id = ...
 func (s *BloodhoundDB) UpdateAuthToken(ctx context.Context, authToken model.AuthToken) error {
-	result := s.db.WithContext(ctx).Save(&authToken)
+	result := s.db.WithContext(ctx).Omit("Permissions").Save(&authToken)
 	return CheckError(result)
 }
 
@@ -413,7 +569,7 @@ func (s *BloodhoundDB) UpdateAuthToken(ctx context.Context, authToken model.Auth
 func (s *BloodhoundDB) GetAuthToken(ctx context.Context, id uuid.UUID) (model.AuthToken, error) {
 	var (
 		authToken model.AuthToken
-		result    = s.db.WithContext(ctx).First(&authToken, id)
+		result    = s.preload(model.AuthTokenAssociations()).WithContext(ctx).First(&authToken, id)
 	)
 
 	return authToken, CheckError(result)
@@ -422,7 +578,7 @@ func (s *BloodhoundDB) GetAuthToken(ctx context.Context, id uuid.UUID) (model.Au
 func (s *BloodhoundDB) GetAllAuthTokens(ctx context.Context, order string, filter model.SQLFilter) (model.AuthTokens, error) {
 	var (
 		tokens model.AuthTokens
-		cursor = s.db.WithContext(ctx)
+		cursor = s.preload(model.AuthTokenAssociations()).WithContext(ctx)
 	)
 
 	if order != "" {
@@ -439,7 +595,7 @@ func (s *BloodhoundDB) GetAllAuthTokens(ctx context.Context, order string, filte
 func (s *BloodhoundDB) GetUserToken(ctx context.Context, userId, tokenId uuid.UUID) (model.AuthToken, error) {
 	var (
 		authToken model.AuthToken
-		result    = s.db.WithContext(ctx).First(&authToken, "id = ? AND user_id = ?", tokenId, userId)
+		result    = s.preload(model.AuthTokenAssociations()).WithContext(ctx).First(&authToken, "id = ? AND user_id = ?", tokenId, userId)
 	)
 	return authToken, CheckError(result)
 }
@@ -450,6 +606,31 @@ func (s *BloodhoundDB) DeleteAuthToken(ctx context.Context, authToken model.Auth
 	return CheckError(s.db.WithContext(ctx).Where("id = ?", authToken.ID).Delete(&authToken))
 }
 
+// SweepAuthTokens disables all auth tokens that have expired and records each change in the audit log
+func (s *BloodhoundDB) SweepAuthTokens(ctx context.Context) {
+	var expiredTokens model.AuthTokens
+
+	if err := s.db.WithContext(ctx).Where("is_disabled = false AND expires_at IS NOT NULL AND expires_at <= NOW()").Find(&expiredTokens).Error; err != nil {
+		slog.ErrorContext(ctx, fmt.Sprintf("Error fetching expired auth tokens: %v", err))
+		return
+	}
+
+	for _, expiredToken := range expiredTokens {
+		expiredToken.IsDisabled = true
+
+		auditEntry := model.AuditEntry{
+			Action: model.AuditLogActionDisableAuthToken,
+			Model:  &expiredToken,
+		}
+
+		if err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+			return CheckError(tx.WithContext(ctx).Model(&model.AuthToken{}).Where("id = ?", expiredToken.ID).Update("is_disabled", true))
+		}); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("Error disabling expired auth token %s: %v", expiredToken.ID, err))
+		}
+	}
+}
+
 // CreateAuthSecret creates a new AuthSecret row
 // INSERT INTO auth_secrets (...) VALUES (....)
 func (s *BloodhoundDB) CreateAuthSecret(ctx context.Context, authSecret model.AuthSecret) (model.AuthSecret, error) {
@@ -474,7 +655,8 @@ func (s *BloodhoundDB) GetAuthSecret(ctx context.Context, id int32) (model.AuthS
 	return authSecret, CheckError(result)
 }
 
-// UpdateAuthSecret updates the auth secret with the input struct specified
+// UpdateAuthSecret updates the auth secret with the input struct specified. When the digest changes, the replaced
+// digest is kept in auth_secret_history. The lockout columns are left untouched.
 // UPDATE auth_secrets SET digest = .., hmac_method = ..., expires_at = ...
 // WHERE user_id = ....
 func (s *BloodhoundDB) UpdateAuthSecret(ctx context.Context, authSecret model.AuthSecret) error {
@@ -484,7 +666,79 @@ func (s *BloodhoundDB) UpdateAuthSecret(ctx context.Context, authSecret model.Au
 	}
 
 	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
-		return CheckError(tx.WithContext(ctx).Save(&authSecret))
+		var existing model.AuthSecret
+
+		if err := CheckError(tx.WithContext(ctx).First(&existing, authSecret.ID)); err != nil {
+			return err
+		} else if existing.Digest != authSecret.Digest {
+			history := model.AuthSecretHistory{
+				UserID:       existing.UserID,
+				Digest:       existing.Digest,
+				DigestMethod: existing.DigestMethod,
+			}
+
+			if err := CheckError(tx.WithContext(ctx).Create(&history)); err != nil {
+				return err
+			}
+		}
+
+		return CheckError(tx.WithContext(ctx).Omit("failed_login_count", "locked_until").Save(&authSecret))
+	})
+}
+
+// GetAuthSecretHistory returns up to limit of the digests a user's secret previously held, most recent first
+// SELECT * FROM auth_secret_history WHERE user_id = ... ORDER BY id DESC LIMIT ...
+func (s *BloodhoundDB) GetAuthSecretHistory(ctx context.Context, userID uuid.UUID, limit int) ([]model.AuthSecretHistory, error) {
+	var (
+		history []model.AuthSecretHistory
+		result  = s.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Limit(limit).Find(&history)
+	)
+
+	return history, CheckError(result)
+}
+
+// RecordFailedLogin increments the consecutive failed login counter of an auth secret and returns the new count
+// UPDATE auth_secrets SET failed_login_count = failed_login_count + 1 WHERE id = ... RETURNING failed_login_count
+func (s *BloodhoundDB) RecordFailedLogin(ctx context.Context, authSecret model.AuthSecret) (int, error) {
+	var failedLoginCount int
+
+	result := s.db.WithContext(ctx).Raw(
+		`UPDATE auth_secrets SET failed_login_count = failed_login_count + 1 WHERE id = ? RETURNING failed_login_count`,
+		authSecret.ID,
+	).Scan(&failedLoginCount)
+
+	return failedLoginCount, CheckError(result)
+}
+
+// LockAuthSecret locks an auth secret until the given time and resets its failed login counter. The lockout is audited
+// by the login flow rather than here since the request is not authenticated.
+// UPDATE auth_secrets SET failed_login_count = 0, locked_until = ... WHERE id = ...
+func (s *BloodhoundDB) LockAuthSecret(ctx context.Context, authSecret model.AuthSecret, lockedUntil time.Time) error {
+	return CheckError(s.db.WithContext(ctx).Model(&model.AuthSecret{}).Where("id = ?", authSecret.ID).Updates(map[string]any{
+		"failed_login_count": 0,
+		"locked_until":       lockedUntil.UTC(),
+	}))
+}
+
+// ResetFailedLogins clears the failed login counter of an auth secret after a successful login
+// UPDATE auth_secrets SET failed_login_count = 0 WHERE id = ...
+func (s *BloodhoundDB) ResetFailedLogins(ctx context.Context, authSecret model.AuthSecret) error {
+	return CheckError(s.db.WithContext(ctx).Model(&model.AuthSecret{}).Where("id = ?", authSecret.ID).Update("failed_login_count", 0))
+}
+
+// UnlockAuthSecret clears the lockout and failed login counter of an auth secret
+// UPDATE auth_secrets SET failed_login_count = 0, locked_until = NULL WHERE id = ...
+func (s *BloodhoundDB) UnlockAuthSecret(ctx context.Context, authSecret model.AuthSecret) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionUnlockUserAccount,
+		Model:  &authSecret,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Model(&model.AuthSecret{}).Where("id = ?", authSecret.ID).Updates(map[string]any{
+			"failed_login_count": 0,
+			"locked_until":       nil,
+		}))
 	})
 }
 
@@ -523,6 +777,33 @@ func (s *BloodhoundDB) EndUserSession(ctx context.Context, userSession model.Use
 	s.db.Model(&userSession).WithContext(ctx).Update("expires_at", gorm.Expr("NOW()"))
 }
 
+// RevokeUserSession ends the provided session. Sessions are validated against the database on every request, so the
+// revocation takes effect immediately.
+// UPDATE user_sessions SET expires_at = NOW() WHERE id = ...
+func (s *BloodhoundDB) RevokeUserSession(ctx context.Context, userSession model.UserSession) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionRevokeUserSession,
+		Model:  &userSession,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Model(&model.UserSession{}).Where("id = ?", userSession.ID).Update("expires_at", gorm.Expr("NOW()")))
+	})
+}
+
+// RevokeUserSessions ends every active session of the provided user
+// UPDATE user_sessions SET expires_at = NOW() WHERE user_id = ... AND expires_at >= NOW()
+func (s *BloodhoundDB) RevokeUserSessions(ctx context.Context, user model.User) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionRevokeUserSessions,
+		Model:  &user,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Model(&model.UserSession{}).Where("user_id = ? AND expires_at >= NOW()", user.ID).Update("expires_at", gorm.Expr("NOW()")))
+	})
+}
+
 // corresponding retrival function is model.UserSession.GetFlag()
 func (s *BloodhoundDB) SetUserSessionFlag(ctx context.Context, userSession *model.UserSession, key model.SessionFlagKey, state bool) error {
 	if userSession.ID == 0 {
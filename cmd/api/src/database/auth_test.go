@@ -21,6 +21,9 @@ package database_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -29,6 +32,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/specterops/bloodhound/cmd/api/src/test/must"
 	"github.com/specterops/bloodhound/cmd/api/src/utils/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -155,6 +159,65 @@ func TestDatabase_InitializeRoles(t *testing.T) {
 	}
 }
 
+func TestDatabase_CreateUpdateDeleteCustomRole(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		dbInst, roles = initAndGetRoles(t)
+	)
+
+	for _, role := range roles {
+		assert.True(t, role.BuiltIn, "seeded role %s should be built-in", role.Name)
+	}
+
+	permissions, err := dbInst.GetAllPermissions(ctx, "", model.SQLFilter{})
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, len(permissions), 2)
+
+	customRole, err := dbInst.CreateRole(ctx, model.Role{
+		Name:        "AGT Reviewer",
+		Description: "Read-only graph plus certify",
+		Permissions: permissions[:1],
+	})
+	require.Nil(t, err)
+	require.False(t, customRole.BuiltIn)
+
+	_, err = dbInst.CreateRole(ctx, model.Role{Name: "AGT Reviewer"})
+	require.ErrorIs(t, err, database.ErrDuplicateRoleName)
+
+	customRole.Permissions = permissions[:2]
+	_, err = dbInst.UpdateRole(ctx, customRole)
+	require.Nil(t, err)
+
+	fetched, err := dbInst.GetRole(ctx, customRole.ID)
+	require.Nil(t, err)
+	require.Len(t, fetched.Permissions, 2)
+
+	_, err = dbInst.UpdateRole(ctx, roles[0])
+	require.ErrorIs(t, err, database.ErrBuiltInRole)
+	require.ErrorIs(t, dbInst.DeleteRole(ctx, roles[0]), database.ErrBuiltInRole)
+
+	user, err := dbInst.CreateUser(ctx, model.User{Roles: model.Roles{fetched}, PrincipalName: userPrincipal})
+	require.Nil(t, err)
+	require.ErrorIs(t, dbInst.DeleteRole(ctx, fetched), database.ErrRoleInUse)
+
+	require.Nil(t, dbInst.DeleteUser(ctx, user))
+
+	for idx, config := range []model.SSOProviderConfig{
+		{AutoProvision: model.SSOProviderAutoProvisionConfig{DefaultRoleId: fetched.ID}},
+		{AutoProvision: model.SSOProviderAutoProvisionConfig{DefaultRoleId: roles[0].ID, MappingRules: []model.SSOProviderMappingRule{{Claim: "groups", Regex: "^reviewers$", RoleId: fetched.ID}}}},
+	} {
+		provider, err := dbInst.CreateOIDCProvider(ctx, fmt.Sprintf("provider-%d", idx), "https://idp.example.com", "client", config)
+		require.Nil(t, err)
+		require.ErrorIs(t, dbInst.DeleteRole(ctx, fetched), database.ErrRoleReferencedBySSOProvider)
+		require.Nil(t, dbInst.DeleteSSOProvider(ctx, int(provider.SSOProviderID)))
+	}
+
+	require.Nil(t, dbInst.DeleteRole(ctx, fetched))
+
+	_, err = dbInst.GetRole(ctx, fetched.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
 func TestDatabase_CreateGetDeleteUser(t *testing.T) {
 	var (
 		ctx           = context.Background()
@@ -234,6 +297,61 @@ func TestDatabase_CreateGetDeleteUser(t *testing.T) {
 	}
 }
 
+func TestDatabase_CreateUserAllEnvironments(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		dbInst, roles = initAndGetRoles(t)
+	)
+
+	for _, allEnvironments := range []bool{false, true} {
+		principalName := fmt.Sprintf("all-environments-%t", allEnvironments)
+
+		if _, err := dbInst.CreateUser(ctx, model.User{
+			Roles:           roles,
+			EmailAddress:    null.StringFrom(principalName),
+			PrincipalName:   principalName,
+			AllEnvironments: allEnvironments,
+		}); err != nil {
+			t.Fatalf("Error creating user: %v", err)
+		} else if newUser, err := dbInst.LookupUser(ctx, principalName); err != nil {
+			t.Fatalf("Failed looking up user by principal %s: %v", principalName, err)
+		} else if newUser.AllEnvironments != allEnvironments {
+			t.Fatalf("Expected all_environments to be %t but got %t", allEnvironments, newUser.AllEnvironments)
+		}
+	}
+}
+
+func TestDatabase_UpdateUsersRollsBack(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		dbInst, roles = initAndGetRoles(t)
+		users         model.Users
+	)
+
+	for _, principalName := range []string{"update-users-a", "update-users-b"} {
+		if user, err := dbInst.CreateUser(ctx, model.User{
+			EmailAddress:    null.StringFrom(principalName),
+			PrincipalName:   principalName,
+			AllEnvironments: true,
+		}); err != nil {
+			t.Fatalf("Error creating user: %v", err)
+		} else {
+			users = append(users, user)
+		}
+	}
+
+	users[0].Roles = roles
+	users[1].Roles = model.Roles{{Serial: model.Serial{ID: 9999}}}
+
+	if err := dbInst.UpdateUsers(ctx, users); err == nil {
+		t.Fatal("Expected updating a user with an unknown role to fail")
+	} else if updatedUser, err := dbInst.GetUser(ctx, users[0].ID); err != nil {
+		t.Fatalf("Error fetching user: %v", err)
+	} else if len(updatedUser.Roles) != 0 {
+		t.Fatalf("Expected no roles to be assigned after a failed update but got %d", len(updatedUser.Roles))
+	}
+}
+
 func TestDatabase_UpdateUserAuth(t *testing.T) {
 	var (
 		ctx          = context.Background()
@@ -335,6 +453,80 @@ func TestDatabase_CreateGetDeleteAuthToken(t *testing.T) {
 	}
 }
 
+func TestDatabase_ScopedAuthTokenAndSweep(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		dbInst, user = initAndCreateUser(t)
+		scope        = user.Roles.Permissions()[:1]
+		expiredToken = model.AuthToken{
+			UserID:     database.NullUUID(user.ID),
+			Key:        "expired",
+			HmacMethod: "fake",
+			ExpiresAt:  null.TimeFrom(time.Now().Add(-time.Minute)),
+			Unique:     model.Unique{ID: must.NewUUIDv4()},
+		}
+		scopedToken = model.AuthToken{
+			UserID:      database.NullUUID(user.ID),
+			Key:         "scoped",
+			HmacMethod:  "fake",
+			ExpiresAt:   null.TimeFrom(time.Now().Add(time.Hour)),
+			Permissions: scope,
+			Unique:      model.Unique{ID: must.NewUUIDv4()},
+		}
+	)
+
+	_, err := dbInst.CreateAuthToken(ctx, expiredToken)
+	require.Nil(t, err)
+	_, err = dbInst.CreateAuthToken(ctx, scopedToken)
+	require.Nil(t, err)
+
+	fetched, err := dbInst.GetAuthToken(ctx, scopedToken.ID)
+	require.Nil(t, err)
+	require.True(t, fetched.IsScoped())
+	require.True(t, fetched.Permissions.Equals(scope))
+
+	// Updating last access must not touch the token's scope
+	fetched.LastAccess = time.Now().UTC()
+	require.Nil(t, dbInst.UpdateAuthToken(ctx, fetched))
+
+	dbInst.SweepAuthTokens(ctx)
+
+	swept, err := dbInst.GetAuthToken(ctx, expiredToken.ID)
+	require.Nil(t, err)
+	require.True(t, swept.IsDisabled)
+	require.Nil(t, test.VerifyAuditLogs(dbInst, model.AuditLogActionDisableAuthToken, "id", expiredToken.ID.String()))
+
+	unswept, err := dbInst.GetAuthToken(ctx, scopedToken.ID)
+	require.Nil(t, err)
+	require.False(t, unswept.IsDisabled)
+	require.Len(t, unswept.Permissions, len(scope))
+}
+
+func TestDatabase_ConsumeWebAuthnCeremony(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		dbInst    = integration.SetupDB(t)
+		expiresAt = time.Now().Add(auth.WebAuthnCeremonyTimeout)
+	)
+
+	if err := dbInst.ConsumeWebAuthnCeremony(ctx, "ceremony", expiresAt); err != nil {
+		t.Fatalf("Error consuming ceremony: %v", err)
+	} else if err := dbInst.ConsumeWebAuthnCeremony(ctx, "ceremony", expiresAt); !errors.Is(err, database.ErrWebAuthnCeremonyConsumed) {
+		t.Fatalf("Expected a second use of the ceremony to fail but got: %v", err)
+	} else if err := dbInst.ConsumeWebAuthnCeremony(ctx, "expired", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Error consuming ceremony: %v", err)
+	}
+
+	// Sweeping only forgets ceremonies whose tokens can no longer be presented
+	dbInst.SweepWebAuthnCeremonies(ctx)
+
+	if err := dbInst.ConsumeWebAuthnCeremony(ctx, "ceremony", expiresAt); !errors.Is(err, database.ErrWebAuthnCeremonyConsumed) {
+		t.Fatalf("Expected the unexpired ceremony to survive the sweep but got: %v", err)
+	} else if err := dbInst.ConsumeWebAuthnCeremony(ctx, "expired", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Expected the expired ceremony to be swept but got: %v", err)
+	}
+}
+
 func TestDatabase_CreateGetDeleteAuthSecret(t *testing.T) {
 	const updatedDigest = "updated"
 
@@ -489,7 +681,7 @@ func TestDatabase_CreateUpdateDeleteSSOProvider(t *testing.T) {
 				t.Fatalf("Updated user has SSOProvider ID %d when %v was expected", updatedUser.SSOProvider.ID, newOIDCProvider.ID)
 			} else if updatedUser.SSOProvider.OIDCProvider.Issuer != newOIDCProvider.Issuer {
 				t.Fatalf("Updated user has OIDCProvider Issuer %s when %s was expected", updatedUser.SSOProvider.OIDCProvider.Issuer, newOIDCProvider.Issuer)
-			} else if updatedUser.SSOProvider.Config != emptyConfig {
+			} else if !reflect.DeepEqual(updatedUser.SSOProvider.Config, emptyConfig) {
 				t.Fatalf("Updated user has Config %v when %v was expected", updatedUser.SSOProvider.Config, emptyConfig)
 			} else {
 				updatedSSOProvider := model.SSOProvider{
@@ -559,6 +751,42 @@ func TestDatabase_CreateUserSession(t *testing.T) {
 	assert.False(t, user.LastLogin.IsZero(), "User last login date was not set")
 }
 
+func TestDatabase_UpdateUserRoleChangeRevokesSessions(t *testing.T) {
+	var (
+		testCtx      = context.Background()
+		dbInst, user = initAndCreateUser(t)
+	)
+
+	if _, err := dbInst.CreateUserSession(testCtx, model.UserSession{
+		User:      user,
+		UserID:    user.ID,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Failed to create new user session: %v", err)
+	}
+
+	// Updates that leave the roles alone keep the user logged in
+	user.FirstName = null.StringFrom("Renamed")
+
+	if err := dbInst.UpdateUser(testCtx, user); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	} else if sessions, err := dbInst.LookupActiveSessionsByUser(testCtx, user); err != nil {
+		t.Fatalf("Failed to look up sessions: %v", err)
+	} else if len(sessions) != 1 {
+		t.Fatalf("Expected the session to remain active but found %d active sessions", len(sessions))
+	}
+
+	user.Roles = user.Roles[:1]
+
+	if err := dbInst.UpdateUser(testCtx, user); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	} else if sessions, err := dbInst.LookupActiveSessionsByUser(testCtx, user); err != nil {
+		t.Fatalf("Failed to look up sessions: %v", err)
+	} else if len(sessions) != 0 {
+		t.Fatalf("Expected a role change to revoke every session but found %d active sessions", len(sessions))
+	}
+}
+
 func TestDatabase_SetUserSessionFlag(t *testing.T) {
 	var (
 		testCtx      = context.Background()
@@ -50,6 +50,11 @@ var (
 	ErrDuplicateCustomNodeKindName = errors.New("duplicate custom node kind name")
 	ErrDuplicateKindName           = errors.New("duplicate kind name")
 	ErrPositionOutOfRange          = errors.New("position out of range")
+	ErrInvalidTierOrder            = errors.New("invalid tier order")
+	ErrDuplicateRoleName           = errors.New("duplicate role name")
+	ErrBuiltInRole                 = errors.New("built-in roles cannot be modified")
+	ErrRoleInUse                   = errors.New("role is assigned to one or more users")
+	ErrRoleReferencedBySSOProvider = errors.New("role is referenced by one or more sso providers")
 )
 
 func IsUnexpectedDatabaseError(err error) bool {
@@ -102,6 +107,9 @@ type Database interface {
 	// Roles
 	GetAllRoles(ctx context.Context, order string, filter model.SQLFilter) (model.Roles, error)
 	GetRoles(ctx context.Context, ids []int32) (model.Roles, error)
+	CreateRole(ctx context.Context, role model.Role) (model.Role, error)
+	UpdateRole(ctx context.Context, role model.Role) (model.Role, error)
+	DeleteRole(ctx context.Context, role model.Role) error
 	GetRole(ctx context.Context, id int32) (model.Role, error)
 
 	// Permissions
@@ -111,9 +119,11 @@ type Database interface {
 	// Users
 	CreateUser(ctx context.Context, user model.User) (model.User, error)
 	UpdateUser(ctx context.Context, user model.User) error
+	UpdateUsers(ctx context.Context, users model.Users) error
 	GetAllUsers(ctx context.Context, order string, filter model.SQLFilter) (model.Users, error)
 	GetUser(ctx context.Context, id uuid.UUID) (model.User, error)
 	DeleteUser(ctx context.Context, user model.User) error
+	DeleteUserAndTransferSavedQueries(ctx context.Context, user model.User, toUser model.User) error
 	LookupUser(ctx context.Context, principalName string) (model.User, error)
 
 	// Auth
@@ -123,11 +133,18 @@ type Database interface {
 	GetAuthToken(ctx context.Context, id uuid.UUID) (model.AuthToken, error)
 	GetUserToken(ctx context.Context, userId, tokenId uuid.UUID) (model.AuthToken, error)
 	DeleteAuthToken(ctx context.Context, authToken model.AuthToken) error
+	SweepAuthTokens(ctx context.Context)
 	CreateAuthSecret(ctx context.Context, authSecret model.AuthSecret) (model.AuthSecret, error)
 	GetAuthSecret(ctx context.Context, id int32) (model.AuthSecret, error)
 	UpdateAuthSecret(ctx context.Context, authSecret model.AuthSecret) error
 	DeleteAuthSecret(ctx context.Context, authSecret model.AuthSecret) error
+	GetAuthSecretHistory(ctx context.Context, userID uuid.UUID, limit int) ([]model.AuthSecretHistory, error)
+	RecordFailedLogin(ctx context.Context, authSecret model.AuthSecret) (int, error)
+	LockAuthSecret(ctx context.Context, authSecret model.AuthSecret, lockedUntil time.Time) error
+	ResetFailedLogins(ctx context.Context, authSecret model.AuthSecret) error
+	UnlockAuthSecret(ctx context.Context, authSecret model.AuthSecret) error
 	InitializeSecretAuth(ctx context.Context, adminUser model.User, authSecret model.AuthSecret) (model.Installation, error)
+	WebAuthnCredentialData
 
 	// SSO
 	SSOProviderData
@@ -139,6 +156,8 @@ type Database interface {
 	SetUserSessionFlag(ctx context.Context, userSession *model.UserSession, key model.SessionFlagKey, state bool) error
 	LookupActiveSessionsByUser(ctx context.Context, user model.User) ([]model.UserSession, error)
 	EndUserSession(ctx context.Context, userSession model.UserSession)
+	RevokeUserSession(ctx context.Context, userSession model.UserSession) error
+	RevokeUserSessions(ctx context.Context, user model.User) error
 	GetUserSession(ctx context.Context, id int64) (model.UserSession, error)
 	SweepSessions(ctx context.Context)
 
@@ -168,6 +187,11 @@ type Database interface {
 	AssetGroupTagData
 	AssetGroupTagSelectorData
 	AssetGroupTagSelectorNodeData
+	TieringConfigurationData
+	AssetGroupSelectorRunData
+	AssetGroupTagViolationData
+	BlastRadiusReportData
+	AssetGroupTagWebhookData
 
 	// Custom Node Kinds
 	CustomNodeKindData
@@ -175,6 +199,9 @@ type Database interface {
 	// Source Kinds
 	SourceKindsData
 
+	// Graph Snapshots
+	GraphSnapshotData
+
 	// Access Control List
 	EnvironmentAccessControlData
 }
@@ -0,0 +1,303 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"gorm.io/gorm"
+)
+
+type GraphSnapshotData interface {
+	CreateGraphSnapshot(ctx context.Context, runID string) (model.GraphSnapshot, error)
+	AppendGraphSnapshotNodes(ctx context.Context, snapshotID int64, nodes model.GraphSnapshotNodes) error
+	AppendGraphSnapshotEdges(ctx context.Context, snapshotID int64, edges model.GraphSnapshotEdges) error
+	CompleteGraphSnapshot(ctx context.Context, snapshotID int64) (model.GraphSnapshot, error)
+	GetGraphSnapshots(ctx context.Context) (model.GraphSnapshots, error)
+	GetGraphSnapshotByRunID(ctx context.Context, runID string) (model.GraphSnapshot, error)
+	DeleteGraphSnapshotsExceptLatest(ctx context.Context, keep int) error
+	GetGraphSnapshotDiff(ctx context.Context, from, to model.GraphSnapshot, filter model.GraphDiffFilter, skip, limit int) (model.GraphDiff, error)
+}
+
+func (s *BloodhoundDB) CreateGraphSnapshot(ctx context.Context, runID string) (model.GraphSnapshot, error) {
+	snapshot := model.GraphSnapshot{
+		RunID:     runID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	return snapshot, CheckError(s.db.WithContext(ctx).Create(&snapshot))
+}
+
+// AppendGraphSnapshotNodes records the fingerprints of the given nodes against a snapshot. Node details are only
+// written the first time a fingerprint is seen by any snapshot.
+func (s *BloodhoundDB) AppendGraphSnapshotNodes(ctx context.Context, snapshotID int64, nodes model.GraphSnapshotNodes) error {
+	const (
+		detailsQuery = `
+			INSERT INTO graph_snapshot_node_details (fingerprint, object_id, kind, name, tags)
+			SELECT * FROM unnest(?::bigint[], ?::text[], ?::text[], ?::text[], ?::text[])
+			ON CONFLICT DO NOTHING;`
+		snapshotQuery = `
+			INSERT INTO graph_snapshot_nodes (snapshot_id, object_hash, fingerprint)
+			SELECT ?, * FROM unnest(?::bigint[], ?::bigint[])
+			ON CONFLICT DO NOTHING;`
+	)
+
+	// GORM will fail on an attempt to insert a nil slice, so we have to guard against empty node arrays here
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var (
+		objectHashes = make([]int64, len(nodes))
+		fingerprints = make([]int64, len(nodes))
+		objectIDs    = make([]string, len(nodes))
+		kinds        = make([]string, len(nodes))
+		names        = make([]string, len(nodes))
+		tags         = make([]string, len(nodes))
+	)
+
+	for idx, node := range nodes {
+		objectHashes[idx] = node.ObjectHash()
+		fingerprints[idx] = node.Fingerprint()
+		objectIDs[idx] = node.ObjectID
+		kinds[idx] = node.Kind
+		names[idx] = node.Name
+		tags[idx] = node.Tags
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := CheckError(tx.Exec(detailsQuery, pq.Array(fingerprints), pq.Array(objectIDs), pq.Array(kinds), pq.Array(names), pq.Array(tags))); err != nil {
+			return err
+		}
+
+		return CheckError(tx.Exec(snapshotQuery, snapshotID, pq.Array(objectHashes), pq.Array(fingerprints)))
+	})
+}
+
+// AppendGraphSnapshotEdges records the fingerprints of the given edges against a snapshot. Edge details are only
+// written the first time a fingerprint is seen by any snapshot.
+func (s *BloodhoundDB) AppendGraphSnapshotEdges(ctx context.Context, snapshotID int64, edges model.GraphSnapshotEdges) error {
+	const (
+		detailsQuery = `
+			INSERT INTO graph_snapshot_edge_details (fingerprint, start_object_hash, start_object_id, kind, end_object_hash, end_object_id)
+			SELECT * FROM unnest(?::bigint[], ?::bigint[], ?::text[], ?::text[], ?::bigint[], ?::text[])
+			ON CONFLICT DO NOTHING;`
+		snapshotQuery = `
+			INSERT INTO graph_snapshot_edges (snapshot_id, fingerprint)
+			SELECT ?, * FROM unnest(?::bigint[])
+			ON CONFLICT DO NOTHING;`
+	)
+
+	if len(edges) == 0 {
+		return nil
+	}
+
+	var (
+		fingerprints      = make([]int64, len(edges))
+		startObjectHashes = make([]int64, len(edges))
+		startObjectIDs    = make([]string, len(edges))
+		kinds             = make([]string, len(edges))
+		endObjectHashes   = make([]int64, len(edges))
+		endObjectIDs      = make([]string, len(edges))
+	)
+
+	for idx, edge := range edges {
+		fingerprints[idx] = edge.Fingerprint()
+		startObjectHashes[idx] = model.GraphSnapshotNode{ObjectID: edge.StartObjectID}.ObjectHash()
+		startObjectIDs[idx] = edge.StartObjectID
+		kinds[idx] = edge.Kind
+		endObjectHashes[idx] = model.GraphSnapshotNode{ObjectID: edge.EndObjectID}.ObjectHash()
+		endObjectIDs[idx] = edge.EndObjectID
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := CheckError(tx.Exec(detailsQuery, pq.Array(fingerprints), pq.Array(startObjectHashes), pq.Array(startObjectIDs), pq.Array(kinds), pq.Array(endObjectHashes), pq.Array(endObjectIDs))); err != nil {
+			return err
+		}
+
+		return CheckError(tx.Exec(snapshotQuery, snapshotID, pq.Array(fingerprints)))
+	})
+}
+
+// CompleteGraphSnapshot records the final node and edge counts of a snapshot and marks it as usable for diffing
+func (s *BloodhoundDB) CompleteGraphSnapshot(ctx context.Context, snapshotID int64) (model.GraphSnapshot, error) {
+	const query = `
+		UPDATE graph_snapshots SET
+			node_count = (SELECT count(*) FROM graph_snapshot_nodes WHERE snapshot_id = @id),
+			edge_count = (SELECT count(*) FROM graph_snapshot_edges WHERE snapshot_id = @id),
+			completed_at = @now
+		WHERE id = @id
+		RETURNING *;`
+
+	var snapshot model.GraphSnapshot
+
+	if result := s.db.WithContext(ctx).Raw(query, map[string]any{"id": snapshotID, "now": time.Now().UTC()}).Scan(&snapshot); result.Error != nil {
+		return snapshot, CheckError(result)
+	} else if result.RowsAffected == 0 {
+		return snapshot, ErrNotFound
+	}
+
+	return snapshot, nil
+}
+
+// GetGraphSnapshots returns all completed snapshots, newest first
+func (s *BloodhoundDB) GetGraphSnapshots(ctx context.Context) (model.GraphSnapshots, error) {
+	var snapshots model.GraphSnapshots
+
+	return snapshots, CheckError(s.db.WithContext(ctx).Where("completed_at IS NOT NULL").Order("id DESC").Find(&snapshots))
+}
+
+// GetGraphSnapshotByRunID returns the completed snapshot for the given analysis run
+func (s *BloodhoundDB) GetGraphSnapshotByRunID(ctx context.Context, runID string) (model.GraphSnapshot, error) {
+	var snapshot model.GraphSnapshot
+
+	return snapshot, CheckError(s.db.WithContext(ctx).Where("run_id = ? AND completed_at IS NOT NULL", runID).First(&snapshot))
+}
+
+// DeleteGraphSnapshotsExceptLatest removes all but the latest keep completed snapshots along with any snapshot left
+// incomplete by an earlier run. Snapshot fingerprints are removed by cascade, after which node and edge details no
+// longer referenced by any snapshot are removed.
+func (s *BloodhoundDB) DeleteGraphSnapshotsExceptLatest(ctx context.Context, keep int) error {
+	const (
+		snapshotsQuery = `
+			DELETE FROM graph_snapshots
+			WHERE id NOT IN (SELECT id FROM graph_snapshots WHERE completed_at IS NOT NULL ORDER BY id DESC LIMIT ?)
+			AND (completed_at IS NOT NULL OR id < (SELECT coalesce(max(id), 0) FROM graph_snapshots WHERE completed_at IS NOT NULL));`
+		nodeDetailsQuery = `
+			DELETE FROM graph_snapshot_node_details d
+			WHERE NOT EXISTS (SELECT 1 FROM graph_snapshot_nodes n WHERE n.fingerprint = d.fingerprint);`
+		edgeDetailsQuery = `
+			DELETE FROM graph_snapshot_edge_details d
+			WHERE NOT EXISTS (SELECT 1 FROM graph_snapshot_edges e WHERE e.fingerprint = d.fingerprint);`
+	)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := CheckError(tx.Exec(snapshotsQuery, keep)); err != nil {
+			return err
+		} else if err := CheckError(tx.Exec(nodeDetailsQuery)); err != nil {
+			return err
+		}
+
+		return CheckError(tx.Exec(edgeDetailsQuery))
+	})
+}
+
+// GetGraphSnapshotDiff returns the nodes and edges present in the to snapshot but not the from snapshot (added) and
+// vice versa (removed). Each list is paginated independently by skip and limit; the counts reflect the full diff.
+func (s *BloodhoundDB) GetGraphSnapshotDiff(ctx context.Context, from, to model.GraphSnapshot, filter model.GraphDiffFilter, skip, limit int) (model.GraphDiff, error) {
+	var (
+		diff = model.GraphDiff{
+			From: from,
+			To:   to,
+		}
+		skipLimitString string
+		err             error
+	)
+
+	if limit > 0 {
+		skipLimitString += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		skipLimitString += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	if diff.Nodes.Added, diff.Nodes.AddedCount, err = s.diffGraphSnapshotNodes(ctx, to.ID, from.ID, filter, skipLimitString); err != nil {
+		return diff, err
+	} else if diff.Nodes.Removed, diff.Nodes.RemovedCount, err = s.diffGraphSnapshotNodes(ctx, from.ID, to.ID, filter, skipLimitString); err != nil {
+		return diff, err
+	} else if diff.Edges.Added, diff.Edges.AddedCount, err = s.diffGraphSnapshotEdges(ctx, to.ID, from.ID, filter, skipLimitString); err != nil {
+		return diff, err
+	} else if diff.Edges.Removed, diff.Edges.RemovedCount, err = s.diffGraphSnapshotEdges(ctx, from.ID, to.ID, filter, skipLimitString); err != nil {
+		return diff, err
+	}
+
+	return diff, nil
+}
+
+// diffGraphSnapshotNodes returns the nodes in snapshot a that are not in snapshot b
+func (s *BloodhoundDB) diffGraphSnapshotNodes(ctx context.Context, a, b int64, filter model.GraphDiffFilter, skipLimitString string) (model.GraphSnapshotNodes, int, error) {
+	var (
+		nodes      = model.GraphSnapshotNodes{}
+		count      int64
+		conditions = []string{
+			"n.snapshot_id = @a",
+			"NOT EXISTS (SELECT 1 FROM graph_snapshot_nodes o WHERE o.snapshot_id = @b AND o.object_hash = n.object_hash)",
+		}
+		params = map[string]any{"a": a, "b": b}
+	)
+
+	if len(filter.NodeKinds) > 0 {
+		conditions = append(conditions, "d.kind = ANY(@node_kinds)")
+		params["node_kinds"] = pq.Array(filter.NodeKinds)
+	}
+
+	if filter.TagKind != "" {
+		conditions = append(conditions, "@tag_kind = ANY(string_to_array(d.tags, ' '))")
+		params["tag_kind"] = filter.TagKind
+	}
+
+	const from = "graph_snapshot_nodes n JOIN graph_snapshot_node_details d ON d.fingerprint = n.fingerprint"
+	where := strings.Join(conditions, " AND ")
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf("SELECT count(*) FROM %s WHERE %s;", from, where), params).Scan(&count); result.Error != nil {
+		return nodes, 0, CheckError(result)
+	} else if result := s.db.WithContext(ctx).Raw(fmt.Sprintf("SELECT d.object_id, d.kind, d.name, d.tags FROM %s WHERE %s ORDER BY d.object_id%s;", from, where, skipLimitString), params).Scan(&nodes); result.Error != nil {
+		return nodes, 0, CheckError(result)
+	}
+
+	return nodes, int(count), nil
+}
+
+// diffGraphSnapshotEdges returns the edges in snapshot a that are not in snapshot b
+func (s *BloodhoundDB) diffGraphSnapshotEdges(ctx context.Context, a, b int64, filter model.GraphDiffFilter, skipLimitString string) (model.GraphSnapshotEdges, int, error) {
+	var (
+		edges      = model.GraphSnapshotEdges{}
+		count      int64
+		conditions = []string{
+			"e.snapshot_id = @a",
+			"NOT EXISTS (SELECT 1 FROM graph_snapshot_edges o WHERE o.snapshot_id = @b AND o.fingerprint = e.fingerprint)",
+		}
+		params = map[string]any{"a": a, "b": b}
+	)
+
+	if len(filter.EdgeKinds) > 0 {
+		conditions = append(conditions, "d.kind = ANY(@edge_kinds)")
+		params["edge_kinds"] = pq.Array(filter.EdgeKinds)
+	}
+
+	if filter.TagKind != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM graph_snapshot_nodes n JOIN graph_snapshot_node_details nd ON nd.fingerprint = n.fingerprint WHERE n.snapshot_id = @a AND n.object_hash IN (d.start_object_hash, d.end_object_hash) AND @tag_kind = ANY(string_to_array(nd.tags, ' ')))")
+		params["tag_kind"] = filter.TagKind
+	}
+
+	const from = "graph_snapshot_edges e JOIN graph_snapshot_edge_details d ON d.fingerprint = e.fingerprint"
+	where := strings.Join(conditions, " AND ")
+
+	if result := s.db.WithContext(ctx).Raw(fmt.Sprintf("SELECT count(*) FROM %s WHERE %s;", from, where), params).Scan(&count); result.Error != nil {
+		return edges, 0, CheckError(result)
+	} else if result := s.db.WithContext(ctx).Raw(fmt.Sprintf("SELECT d.start_object_id, d.kind, d.end_object_id FROM %s WHERE %s ORDER BY d.start_object_id, d.kind, d.end_object_id%s;", from, where, skipLimitString), params).Scan(&edges); result.Error != nil {
+		return edges, 0, CheckError(result)
+	}
+
+	return edges, int(count), nil
+}
@@ -0,0 +1,118 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphSnapshotDiff(t *testing.T) {
+	var (
+		testCtx = context.Background()
+		db      = integration.SetupDB(t)
+	)
+
+	from, err := db.CreateGraphSnapshot(testCtx, "run-1")
+	require.Nil(t, err)
+	require.Nil(t, db.AppendGraphSnapshotNodes(testCtx, from.ID, model.GraphSnapshotNodes{
+		{ObjectID: "1", Kind: "User", Name: "ALICE"},
+		{ObjectID: "2", Kind: "Group", Name: "ADMINS", Tags: "Tag_Tier_Zero"},
+		{ObjectID: "3", Kind: "Computer", Name: "WS01"},
+	}))
+	require.Nil(t, db.AppendGraphSnapshotEdges(testCtx, from.ID, model.GraphSnapshotEdges{
+		{StartObjectID: "1", Kind: "MemberOf", EndObjectID: "2"},
+		{StartObjectID: "3", Kind: "HasSession", EndObjectID: "1"},
+	}))
+	from, err = db.CompleteGraphSnapshot(testCtx, from.ID)
+	require.Nil(t, err)
+	require.EqualValues(t, 3, from.NodeCount)
+	require.EqualValues(t, 2, from.EdgeCount)
+
+	// Incomplete snapshots may not be diffed
+	to, err := db.CreateGraphSnapshot(testCtx, "run-2")
+	require.Nil(t, err)
+	_, err = db.GetGraphSnapshotByRunID(testCtx, "run-2")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	require.Nil(t, db.AppendGraphSnapshotNodes(testCtx, to.ID, model.GraphSnapshotNodes{
+		{ObjectID: "1", Kind: "User", Name: "ALICE"},
+		{ObjectID: "2", Kind: "Group", Name: "ADMINS", Tags: "Tag_Tier_Zero"},
+		{ObjectID: "4", Kind: "User", Name: "BOB", Tags: "Tag_Owned Tag_Tier_Zero"},
+	}))
+	require.Nil(t, db.AppendGraphSnapshotEdges(testCtx, to.ID, model.GraphSnapshotEdges{
+		{StartObjectID: "1", Kind: "MemberOf", EndObjectID: "2"},
+		{StartObjectID: "4", Kind: "MemberOf", EndObjectID: "2"},
+	}))
+	_, err = db.CompleteGraphSnapshot(testCtx, to.ID)
+	require.Nil(t, err)
+
+	to, err = db.GetGraphSnapshotByRunID(testCtx, "run-2")
+	require.Nil(t, err)
+
+	t.Run("Unfiltered", func(t *testing.T) {
+		diff, err := db.GetGraphSnapshotDiff(testCtx, from, to, model.GraphDiffFilter{}, 0, 0)
+		require.Nil(t, err)
+
+		require.Equal(t, 1, diff.Nodes.AddedCount)
+		require.Equal(t, "4", diff.Nodes.Added[0].ObjectID)
+		require.Equal(t, 1, diff.Nodes.RemovedCount)
+		require.Equal(t, "3", diff.Nodes.Removed[0].ObjectID)
+		require.Equal(t, 1, diff.Edges.AddedCount)
+		require.Equal(t, "4", diff.Edges.Added[0].StartObjectID)
+		require.Equal(t, 1, diff.Edges.RemovedCount)
+		require.Equal(t, "HasSession", diff.Edges.Removed[0].Kind)
+	})
+
+	t.Run("FilteredByKind", func(t *testing.T) {
+		diff, err := db.GetGraphSnapshotDiff(testCtx, from, to, model.GraphDiffFilter{NodeKinds: []string{"Computer"}, EdgeKinds: []string{"MemberOf"}}, 0, 0)
+		require.Nil(t, err)
+
+		require.Equal(t, 0, diff.Nodes.AddedCount)
+		require.Equal(t, 1, diff.Nodes.RemovedCount)
+		require.Equal(t, 1, diff.Edges.AddedCount)
+		require.Equal(t, 0, diff.Edges.RemovedCount)
+	})
+
+	t.Run("FilteredByTier", func(t *testing.T) {
+		diff, err := db.GetGraphSnapshotDiff(testCtx, from, to, model.GraphDiffFilter{TagKind: "Tag_Tier_Zero"}, 0, 0)
+		require.Nil(t, err)
+
+		require.Equal(t, 1, diff.Nodes.AddedCount)
+		require.Equal(t, 0, diff.Nodes.RemovedCount)
+		require.Equal(t, 1, diff.Edges.AddedCount)
+		require.Equal(t, 0, diff.Edges.RemovedCount)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		require.Nil(t, db.DeleteGraphSnapshotsExceptLatest(testCtx, 1))
+
+		snapshots, err := db.GetGraphSnapshots(testCtx)
+		require.Nil(t, err)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, "run-2", snapshots[0].RunID)
+
+		// Details of nodes only referenced by the pruned snapshot are written again when a later snapshot sees them
+		latest, err := db.CreateGraphSnapshot(testCtx, "run-3")
+		require.Nil(t, err)
+		require.Nil(t, db.AppendGraphSnapshotNodes(testCtx, latest.ID, model.GraphSnapshotNodes{
+			{ObjectID: "1", Kind: "User", Name: "ALICE"},
+			{ObjectID: "3", Kind: "Computer", Name: "WS01"},
+		}))
+		latest, err = db.CompleteGraphSnapshot(testCtx, latest.ID)
+		require.Nil(t, err)
+
+		diff, err := db.GetGraphSnapshotDiff(testCtx, to, latest, model.GraphDiffFilter{}, 0, 0)
+		require.Nil(t, err)
+		require.Equal(t, 1, diff.Nodes.AddedCount)
+		require.Equal(t, model.GraphSnapshotNode{ObjectID: "3", Kind: "Computer", Name: "WS01"}, diff.Nodes.Added[0])
+		require.Equal(t, 2, diff.Nodes.RemovedCount)
+		require.Equal(t, 2, diff.Edges.RemovedCount)
+	})
+}
@@ -0,0 +1,299 @@
+-- Copyright 2026 Specter Ops, Inc.
+--
+-- Licensed under the Apache License, Version 2.0
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+--
+-- SPDX-License-Identifier: Apache-2.0
+
+-- Graph snapshots taken at the end of each analysis run, used to diff the graph between runs
+CREATE TABLE IF NOT EXISTS graph_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    run_id TEXT NOT NULL,
+    node_count BIGINT NOT NULL DEFAULT 0,
+    edge_count BIGINT NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT current_timestamp,
+    completed_at timestamp with time zone,
+    CONSTRAINT graph_snapshots_run_id_key UNIQUE (run_id)
+);
+
+-- Snapshots reference nodes and edges by 64-bit fingerprint. The details behind a fingerprint are stored once and shared
+-- by every snapshot referencing it.
+CREATE TABLE IF NOT EXISTS graph_snapshot_node_details (
+    fingerprint BIGINT PRIMARY KEY,
+    object_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS graph_snapshot_nodes (
+    snapshot_id BIGINT NOT NULL REFERENCES graph_snapshots(id) ON DELETE CASCADE,
+    object_hash BIGINT NOT NULL,
+    fingerprint BIGINT NOT NULL,
+    PRIMARY KEY (snapshot_id, object_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_graph_snapshot_nodes_fingerprint ON graph_snapshot_nodes (fingerprint);
+
+CREATE TABLE IF NOT EXISTS graph_snapshot_edge_details (
+    fingerprint BIGINT PRIMARY KEY,
+    start_object_hash BIGINT NOT NULL,
+    start_object_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    end_object_hash BIGINT NOT NULL,
+    end_object_id TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS graph_snapshot_edges (
+    snapshot_id BIGINT NOT NULL REFERENCES graph_snapshots(id) ON DELETE CASCADE,
+    fingerprint BIGINT NOT NULL,
+    PRIMARY KEY (snapshot_id, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_graph_snapshot_edges_fingerprint ON graph_snapshot_edges (fingerprint);
+
+-- Number of graph snapshots kept for diffing after each analysis run
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('analysis.graph_snapshots',
+        'Graph Snapshots',
+        'This configuration parameter determines how many graph snapshots are kept for diffing after each analysis run',
+        '{"retention": 7}',
+        current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Exposure metrics walk every traversable edge during analysis so they are only computed once enabled
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('analysis.exposure_metrics',
+        'Exposure Metrics',
+        'This configuration parameter determines whether analysis computes the inbound exposure of tagged nodes and the outbound impact of principals',
+        '{"enabled": false}',
+        current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Withhold uncertified members of tags that require certification from the tag until they are certified
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('analysis.certification_enforcement',
+        'Certification Enforcement',
+        'This configuration parameter determines whether members of tags that require certification are withheld from the tag until they are certified',
+        '{"enabled": true}',
+        current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Per-selector evaluation runs along with the members each run added and removed
+CREATE TABLE IF NOT EXISTS asset_group_tag_selector_runs (
+    id BIGSERIAL PRIMARY KEY,
+    selector_id INT NOT NULL REFERENCES asset_group_tag_selectors(id) ON DELETE CASCADE,
+    asset_group_tag_id INT NOT NULL REFERENCES asset_group_tags(id) ON DELETE CASCADE,
+    started_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    node_count INT NOT NULL DEFAULT 0,
+    added_count INT NOT NULL DEFAULT 0,
+    removed_count INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_agt_selector_runs_selector_id_started_at ON asset_group_tag_selector_runs USING btree (selector_id, started_at);
+
+CREATE TABLE IF NOT EXISTS asset_group_tag_selector_run_nodes (
+    run_id BIGINT NOT NULL REFERENCES asset_group_tag_selector_runs(id) ON DELETE CASCADE,
+    node_id BIGINT NOT NULL,
+    added BOOLEAN NOT NULL,
+    PRIMARY KEY (run_id, node_id)
+);
+
+-- Attack paths from members of lower tiers into members of higher tiers, recomputed on every analysis run
+CREATE TABLE IF NOT EXISTS asset_group_tag_violations (
+    id BIGSERIAL PRIMARY KEY,
+    source_tag_id INT NOT NULL REFERENCES asset_group_tags(id) ON DELETE CASCADE,
+    target_tag_id INT NOT NULL REFERENCES asset_group_tags(id) ON DELETE CASCADE,
+    source_node_id BIGINT NOT NULL,
+    source_object_id TEXT NOT NULL DEFAULT '',
+    source_name TEXT NOT NULL DEFAULT '',
+    target_node_id BIGINT NOT NULL,
+    target_object_id TEXT NOT NULL DEFAULT '',
+    target_name TEXT NOT NULL DEFAULT '',
+    hops INT NOT NULL DEFAULT 1,
+    edge_kinds TEXT[] NOT NULL DEFAULT '{}',
+    created_at timestamp with time zone DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_agt_violations_source_tag_id_target_tag_id ON asset_group_tag_violations USING btree (source_tag_id, target_tag_id);
+
+-- Maximum path length followed when looking for tier violations; a depth of 1 only considers direct edges
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('analysis.tier_violations',
+        'Tier Violations',
+        'This configuration parameter determines how many hops are followed when looking for attack paths from lower tiers into higher tiers',
+        '{"max_depth": 1}',
+        current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Reports of everything reachable from members of the owned tag, generated asynchronously by the datapipe
+CREATE TABLE IF NOT EXISTS asset_group_tag_blast_radius_reports (
+    id BIGSERIAL PRIMARY KEY,
+    status TEXT NOT NULL DEFAULT 'pending',
+    status_message TEXT NOT NULL DEFAULT '',
+    wait_for_analysis BOOLEAN NOT NULL DEFAULT false,
+    requested_by TEXT NOT NULL DEFAULT '',
+    owned_count INT NOT NULL DEFAULT 0,
+    reachable_count INT NOT NULL DEFAULT 0,
+    tier_zero_reachable_count INT NOT NULL DEFAULT 0,
+    result JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamp with time zone DEFAULT current_timestamp,
+    started_at timestamp with time zone,
+    completed_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_agt_blast_radius_reports_status ON asset_group_tag_blast_radius_reports USING btree (status);
+
+-- Per selector expansion limits and the stage that hit the limit during the latest selection
+ALTER TABLE IF EXISTS asset_group_tag_selectors
+    ADD COLUMN IF NOT EXISTS expansion_limit INT,
+    ADD COLUMN IF NOT EXISTS limit_reached_by SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_reached_at timestamp with time zone;
+
+ALTER TABLE IF EXISTS asset_group_tag_selector_runs
+    ADD COLUMN IF NOT EXISTS expansion_limit INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS limit_reached_by SMALLINT NOT NULL DEFAULT 0;
+
+-- Webhooks notified when the members of a tag change, along with their queued deliveries and delivery attempts
+CREATE TABLE IF NOT EXISTS asset_group_tag_webhooks (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    asset_group_tag_id INT REFERENCES asset_group_tags (id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    updated_by TEXT NOT NULL DEFAULT '',
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS asset_group_tag_webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INT NOT NULL REFERENCES asset_group_tag_webhooks (id) ON DELETE CASCADE,
+    asset_group_tag_id INT NOT NULL,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone,
+    last_status_code INT,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    completed_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_agt_webhook_deliveries_webhook_id ON asset_group_tag_webhook_deliveries USING btree (webhook_id);
+CREATE INDEX IF NOT EXISTS idx_agt_webhook_deliveries_status_next_attempt_at ON asset_group_tag_webhook_deliveries USING btree (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS asset_group_tag_webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES asset_group_tag_webhook_deliveries (id) ON DELETE CASCADE,
+    attempt INT NOT NULL,
+    status_code INT,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    attempted_at timestamp with time zone NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_agt_webhook_delivery_attempts_delivery_id ON asset_group_tag_webhook_delivery_attempts USING btree (delivery_id);
+
+-- Custom roles: flag the seeded role templates as built-in so they cannot be modified through the API
+ALTER TABLE IF EXISTS roles ADD COLUMN IF NOT EXISTS built_in BOOLEAN NOT NULL DEFAULT false;
+UPDATE roles SET built_in = true WHERE name IN ('Administrator', 'User', 'Read-Only', 'Upload-Only', 'Power User');
+ALTER TABLE ONLY roles ALTER COLUMN created_at SET DEFAULT current_timestamp;
+ALTER TABLE ONLY roles ALTER COLUMN updated_at SET DEFAULT current_timestamp;
+
+-- Scoped and expiring API tokens
+ALTER TABLE IF EXISTS auth_tokens ADD COLUMN IF NOT EXISTS expires_at timestamp with time zone;
+ALTER TABLE IF EXISTS auth_tokens ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS auth_tokens_permissions (
+    auth_token_id TEXT NOT NULL REFERENCES auth_tokens (id) ON DELETE CASCADE,
+    permission_id INT NOT NULL REFERENCES permissions (id) ON DELETE CASCADE,
+    PRIMARY KEY (auth_token_id, permission_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_tokens_expires_at ON auth_tokens USING btree (expires_at) WHERE is_disabled = false;
+
+-- WebAuthn credentials registered by local users as a second authentication factor
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id SERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    credential_id TEXT NOT NULL,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    last_used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT webauthn_credentials_credential_id_key UNIQUE (credential_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials USING btree (user_id);
+
+-- WebAuthn ceremonies whose challenge has been answered, so that each challenge can only be used once
+CREATE TABLE IF NOT EXISTS webauthn_consumed_ceremonies (
+    id TEXT PRIMARY KEY,
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthn_consumed_ceremonies_expires_at ON webauthn_consumed_ceremonies USING btree (expires_at);
+
+-- Roles whose members must use a WebAuthn credential as their second factor
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('auth.webauthn',
+        'WebAuthn',
+        'This configuration parameter lists the roles whose members must use a WebAuthn credential as their second factor when logging in',
+        '{"required_role_ids": []}',
+        current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Password policy and account lockout for local users
+ALTER TABLE IF EXISTS auth_secrets ADD COLUMN IF NOT EXISTS failed_login_count INT NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS auth_secrets ADD COLUMN IF NOT EXISTS locked_until timestamp with time zone;
+
+CREATE TABLE IF NOT EXISTS auth_secret_history (
+    id SERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    digest TEXT NOT NULL,
+    digest_method TEXT NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+    updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_secret_history_user_id ON auth_secret_history USING btree (user_id, id DESC);
+
+INSERT INTO parameters (key, name, description, value, created_at, updated_at)
+VALUES ('auth.password_policy',
+        'Password Policy',
+        'This configuration parameter sets the minimum length of local user passwords, how many previous passwords may not be reused and whether passwords are checked against the breached password file, which must be a Have I Been Pwned SHA-1 download ordered by hash',
+        '{"minimum_length": 12, "history_count": 0, "breached_password_check": false}',
+        current_timestamp, current_timestamp),
+       ('auth.account_lockout',
+        'Account Lockout',
+        'This configuration parameter sets how many consecutive failed logins lock a local account and for how long. A threshold of 0 disables lockout',
+        '{"threshold": 5, "duration": "PT15M"}',
+        current_timestamp, current_timestamp)
+ON CONFLICT DO NOTHING;
+
+-- Active session management: record where each session was created from
+ALTER TABLE IF EXISTS user_sessions ADD COLUMN IF NOT EXISTS ip_address TEXT NOT NULL DEFAULT '';
+ALTER TABLE IF EXISTS user_sessions ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
+
+-- Saved query sharing: share to roles and grant edit rights
+ALTER TABLE IF EXISTS saved_queries_permissions ADD COLUMN IF NOT EXISTS shared_to_role_id INTEGER REFERENCES roles (id) ON DELETE CASCADE;
+ALTER TABLE IF EXISTS saved_queries_permissions ADD COLUMN IF NOT EXISTS can_edit BOOLEAN NOT NULL DEFAULT false;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_queries_permissions_role_query ON saved_queries_permissions USING btree (shared_to_role_id, query_id);
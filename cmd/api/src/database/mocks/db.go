@@ -32,6 +32,7 @@ import (
 
 	uuid "github.com/gofrs/uuid"
 	database "github.com/specterops/bloodhound/cmd/api/src/database"
+	types "github.com/specterops/bloodhound/cmd/api/src/database/types"
 	null "github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	model "github.com/specterops/bloodhound/cmd/api/src/model"
 	appcfg "github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
@@ -77,6 +78,34 @@ func (mr *MockDatabaseMockRecorder) AppendAuditLog(ctx, entry any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuditLog", reflect.TypeOf((*MockDatabase)(nil).AppendAuditLog), ctx, entry)
 }
 
+// AppendGraphSnapshotEdges mocks base method.
+func (m *MockDatabase) AppendGraphSnapshotEdges(ctx context.Context, snapshotID int64, edges model.GraphSnapshotEdges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendGraphSnapshotEdges", ctx, snapshotID, edges)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendGraphSnapshotEdges indicates an expected call of AppendGraphSnapshotEdges.
+func (mr *MockDatabaseMockRecorder) AppendGraphSnapshotEdges(ctx, snapshotID, edges any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendGraphSnapshotEdges", reflect.TypeOf((*MockDatabase)(nil).AppendGraphSnapshotEdges), ctx, snapshotID, edges)
+}
+
+// AppendGraphSnapshotNodes mocks base method.
+func (m *MockDatabase) AppendGraphSnapshotNodes(ctx context.Context, snapshotID int64, nodes model.GraphSnapshotNodes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendGraphSnapshotNodes", ctx, snapshotID, nodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendGraphSnapshotNodes indicates an expected call of AppendGraphSnapshotNodes.
+func (mr *MockDatabaseMockRecorder) AppendGraphSnapshotNodes(ctx, snapshotID, nodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendGraphSnapshotNodes", reflect.TypeOf((*MockDatabase)(nil).AppendGraphSnapshotNodes), ctx, snapshotID, nodes)
+}
+
 // CancelAllIngestJobs mocks base method.
 func (m *MockDatabase) CancelAllIngestJobs(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -103,6 +132,35 @@ func (mr *MockDatabaseMockRecorder) Close(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabase)(nil).Close), ctx)
 }
 
+// CompleteGraphSnapshot mocks base method.
+func (m *MockDatabase) CompleteGraphSnapshot(ctx context.Context, snapshotID int64) (model.GraphSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteGraphSnapshot", ctx, snapshotID)
+	ret0, _ := ret[0].(model.GraphSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteGraphSnapshot indicates an expected call of CompleteGraphSnapshot.
+func (mr *MockDatabaseMockRecorder) CompleteGraphSnapshot(ctx, snapshotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteGraphSnapshot", reflect.TypeOf((*MockDatabase)(nil).CompleteGraphSnapshot), ctx, snapshotID)
+}
+
+// ConsumeWebAuthnCeremony mocks base method.
+func (m *MockDatabase) ConsumeWebAuthnCeremony(ctx context.Context, ceremonyID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeWebAuthnCeremony", ctx, ceremonyID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeWebAuthnCeremony indicates an expected call of ConsumeWebAuthnCeremony.
+func (mr *MockDatabaseMockRecorder) ConsumeWebAuthnCeremony(ctx, ceremonyID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeWebAuthnCeremony", reflect.TypeOf((*MockDatabase)(nil).ConsumeWebAuthnCeremony), ctx, ceremonyID, expiresAt)
+}
+
 // CountAllIngestTasks mocks base method.
 func (m *MockDatabase) CountAllIngestTasks(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +249,21 @@ func (mr *MockDatabaseMockRecorder) CreateAssetGroupHistoryRecord(ctx, actorId,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssetGroupHistoryRecord", reflect.TypeOf((*MockDatabase)(nil).CreateAssetGroupHistoryRecord), ctx, actorId, email, target, action, assetGroupTagId, environmentId, note)
 }
 
+// CreateAssetGroupSelectorRun mocks base method.
+func (m *MockDatabase) CreateAssetGroupSelectorRun(ctx context.Context, run model.AssetGroupSelectorRun) (model.AssetGroupSelectorRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssetGroupSelectorRun", ctx, run)
+	ret0, _ := ret[0].(model.AssetGroupSelectorRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssetGroupSelectorRun indicates an expected call of CreateAssetGroupSelectorRun.
+func (mr *MockDatabaseMockRecorder) CreateAssetGroupSelectorRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssetGroupSelectorRun", reflect.TypeOf((*MockDatabase)(nil).CreateAssetGroupSelectorRun), ctx, run)
+}
+
 // CreateAssetGroupTag mocks base method.
 func (m *MockDatabase) CreateAssetGroupTag(ctx context.Context, tagType model.AssetGroupTagType, user model.User, name, description string, position null.Int32, requireCertify null.Bool) (model.AssetGroupTag, error) {
 	m.ctrl.T.Helper()
@@ -221,6 +294,36 @@ func (mr *MockDatabaseMockRecorder) CreateAssetGroupTagSelector(ctx, assetGroupT
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssetGroupTagSelector", reflect.TypeOf((*MockDatabase)(nil).CreateAssetGroupTagSelector), ctx, assetGroupTagId, user, name, description, isDefault, allowDisable, autoCertify, seeds)
 }
 
+// CreateAssetGroupTagWebhook mocks base method.
+func (m *MockDatabase) CreateAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssetGroupTagWebhook", ctx, webhook)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssetGroupTagWebhook indicates an expected call of CreateAssetGroupTagWebhook.
+func (mr *MockDatabaseMockRecorder) CreateAssetGroupTagWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssetGroupTagWebhook", reflect.TypeOf((*MockDatabase)(nil).CreateAssetGroupTagWebhook), ctx, webhook)
+}
+
+// CreateAssetGroupTagWebhookDeliveries mocks base method.
+func (m *MockDatabase) CreateAssetGroupTagWebhookDeliveries(ctx context.Context, assetGroupTagId int, event model.AssetGroupTagWebhookEvent, payload types.JSONBObject) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssetGroupTagWebhookDeliveries", ctx, assetGroupTagId, event, payload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssetGroupTagWebhookDeliveries indicates an expected call of CreateAssetGroupTagWebhookDeliveries.
+func (mr *MockDatabaseMockRecorder) CreateAssetGroupTagWebhookDeliveries(ctx, assetGroupTagId, event, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssetGroupTagWebhookDeliveries", reflect.TypeOf((*MockDatabase)(nil).CreateAssetGroupTagWebhookDeliveries), ctx, assetGroupTagId, event, payload)
+}
+
 // CreateAuditLog mocks base method.
 func (m *MockDatabase) CreateAuditLog(ctx context.Context, auditLog model.AuditLog) error {
 	m.ctrl.T.Helper()
@@ -295,6 +398,21 @@ func (mr *MockDatabaseMockRecorder) CreateAzureDataQualityStats(ctx, stats any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAzureDataQualityStats", reflect.TypeOf((*MockDatabase)(nil).CreateAzureDataQualityStats), ctx, stats)
 }
 
+// CreateBlastRadiusReport mocks base method.
+func (m *MockDatabase) CreateBlastRadiusReport(ctx context.Context, requestedBy string, waitForAnalysis bool) (model.BlastRadiusReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlastRadiusReport", ctx, requestedBy, waitForAnalysis)
+	ret0, _ := ret[0].(model.BlastRadiusReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlastRadiusReport indicates an expected call of CreateBlastRadiusReport.
+func (mr *MockDatabaseMockRecorder) CreateBlastRadiusReport(ctx, requestedBy, waitForAnalysis any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlastRadiusReport", reflect.TypeOf((*MockDatabase)(nil).CreateBlastRadiusReport), ctx, requestedBy, waitForAnalysis)
+}
+
 // CreateCompositionInfo mocks base method.
 func (m *MockDatabase) CreateCompositionInfo(ctx context.Context, nodes model.EdgeCompositionNodes, edges model.EdgeCompositionEdges) (model.EdgeCompositionNodes, model.EdgeCompositionEdges, error) {
 	m.ctrl.T.Helper()
@@ -326,6 +444,21 @@ func (mr *MockDatabaseMockRecorder) CreateCustomNodeKinds(ctx, customNodeKind an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomNodeKinds", reflect.TypeOf((*MockDatabase)(nil).CreateCustomNodeKinds), ctx, customNodeKind)
 }
 
+// CreateGraphSnapshot mocks base method.
+func (m *MockDatabase) CreateGraphSnapshot(ctx context.Context, runID string) (model.GraphSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGraphSnapshot", ctx, runID)
+	ret0, _ := ret[0].(model.GraphSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGraphSnapshot indicates an expected call of CreateGraphSnapshot.
+func (mr *MockDatabaseMockRecorder) CreateGraphSnapshot(ctx, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGraphSnapshot", reflect.TypeOf((*MockDatabase)(nil).CreateGraphSnapshot), ctx, runID)
+}
+
 // CreateIngestJob mocks base method.
 func (m *MockDatabase) CreateIngestJob(ctx context.Context, job model.IngestJob) (model.IngestJob, error) {
 	m.ctrl.T.Helper()
@@ -386,6 +519,21 @@ func (mr *MockDatabaseMockRecorder) CreateOIDCProvider(ctx, name, issuer, client
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCProvider", reflect.TypeOf((*MockDatabase)(nil).CreateOIDCProvider), ctx, name, issuer, clientID, config)
 }
 
+// CreateRole mocks base method.
+func (m *MockDatabase) CreateRole(ctx context.Context, role model.Role) (model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, role)
+	ret0, _ := ret[0].(model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockDatabaseMockRecorder) CreateRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockDatabase)(nil).CreateRole), ctx, role)
+}
+
 // CreateSAMLIdentityProvider mocks base method.
 func (m *MockDatabase) CreateSAMLIdentityProvider(ctx context.Context, samlProvider model.SAMLProvider, config model.SSOProviderConfig) (model.SAMLProvider, error) {
 	m.ctrl.T.Helper()
@@ -460,10 +608,30 @@ func (mr *MockDatabaseMockRecorder) CreateSavedQueryPermissionToPublic(ctx, quer
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQueryPermissionToPublic", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQueryPermissionToPublic), ctx, queryID)
 }
 
+// CreateSavedQueryPermissionsToRoles mocks base method.
+func (m *MockDatabase) CreateSavedQueryPermissionsToRoles(ctx context.Context, queryID int64, canEdit bool, roleIDs ...int32) ([]model.SavedQueriesPermissions, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, queryID, canEdit}
+	for _, a := range roleIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSavedQueryPermissionsToRoles", varargs...)
+	ret0, _ := ret[0].([]model.SavedQueriesPermissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedQueryPermissionsToRoles indicates an expected call of CreateSavedQueryPermissionsToRoles.
+func (mr *MockDatabaseMockRecorder) CreateSavedQueryPermissionsToRoles(ctx, queryID, canEdit any, roleIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, queryID, canEdit}, roleIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQueryPermissionsToRoles", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQueryPermissionsToRoles), varargs...)
+}
+
 // CreateSavedQueryPermissionsToUsers mocks base method.
-func (m *MockDatabase) CreateSavedQueryPermissionsToUsers(ctx context.Context, queryID int64, userIDs ...uuid.UUID) ([]model.SavedQueriesPermissions, error) {
+func (m *MockDatabase) CreateSavedQueryPermissionsToUsers(ctx context.Context, queryID int64, canEdit bool, userIDs ...uuid.UUID) ([]model.SavedQueriesPermissions, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx, queryID}
+	varargs := []any{ctx, queryID, canEdit}
 	for _, a := range userIDs {
 		varargs = append(varargs, a)
 	}
@@ -474,9 +642,9 @@ func (m *MockDatabase) CreateSavedQueryPermissionsToUsers(ctx context.Context, q
 }
 
 // CreateSavedQueryPermissionsToUsers indicates an expected call of CreateSavedQueryPermissionsToUsers.
-func (mr *MockDatabaseMockRecorder) CreateSavedQueryPermissionsToUsers(ctx, queryID any, userIDs ...any) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) CreateSavedQueryPermissionsToUsers(ctx, queryID, canEdit any, userIDs ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, queryID}, userIDs...)
+	varargs := append([]any{ctx, queryID, canEdit}, userIDs...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQueryPermissionsToUsers", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQueryPermissionsToUsers), varargs...)
 }
 
@@ -510,6 +678,21 @@ func (mr *MockDatabaseMockRecorder) CreateUserSession(ctx, userSession any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSession", reflect.TypeOf((*MockDatabase)(nil).CreateUserSession), ctx, userSession)
 }
 
+// CreateWebAuthnCredential mocks base method.
+func (m *MockDatabase) CreateWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) (model.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebAuthnCredential", ctx, credential)
+	ret0, _ := ret[0].(model.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebAuthnCredential indicates an expected call of CreateWebAuthnCredential.
+func (mr *MockDatabaseMockRecorder) CreateWebAuthnCredential(ctx, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebAuthnCredential", reflect.TypeOf((*MockDatabase)(nil).CreateWebAuthnCredential), ctx, credential)
+}
+
 // DeleteAllDataQuality mocks base method.
 func (m *MockDatabase) DeleteAllDataQuality(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -636,6 +819,20 @@ func (mr *MockDatabaseMockRecorder) DeleteAssetGroupTagSelector(ctx, user, selec
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssetGroupTagSelector", reflect.TypeOf((*MockDatabase)(nil).DeleteAssetGroupTagSelector), ctx, user, selector)
 }
 
+// DeleteAssetGroupTagWebhook mocks base method.
+func (m *MockDatabase) DeleteAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssetGroupTagWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssetGroupTagWebhook indicates an expected call of DeleteAssetGroupTagWebhook.
+func (mr *MockDatabaseMockRecorder) DeleteAssetGroupTagWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssetGroupTagWebhook", reflect.TypeOf((*MockDatabase)(nil).DeleteAssetGroupTagWebhook), ctx, webhook)
+}
+
 // DeleteAuthSecret mocks base method.
 func (m *MockDatabase) DeleteAuthSecret(ctx context.Context, authSecret model.AuthSecret) error {
 	m.ctrl.T.Helper()
@@ -678,6 +875,20 @@ func (mr *MockDatabaseMockRecorder) DeleteCustomNodeKind(ctx, kindName any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomNodeKind", reflect.TypeOf((*MockDatabase)(nil).DeleteCustomNodeKind), ctx, kindName)
 }
 
+// DeleteGraphSnapshotsExceptLatest mocks base method.
+func (m *MockDatabase) DeleteGraphSnapshotsExceptLatest(ctx context.Context, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGraphSnapshotsExceptLatest", ctx, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGraphSnapshotsExceptLatest indicates an expected call of DeleteGraphSnapshotsExceptLatest.
+func (mr *MockDatabaseMockRecorder) DeleteGraphSnapshotsExceptLatest(ctx, keep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGraphSnapshotsExceptLatest", reflect.TypeOf((*MockDatabase)(nil).DeleteGraphSnapshotsExceptLatest), ctx, keep)
+}
+
 // DeleteIngestTask mocks base method.
 func (m *MockDatabase) DeleteIngestTask(ctx context.Context, ingestTask model.IngestTask) error {
 	m.ctrl.T.Helper()
@@ -692,6 +903,20 @@ func (mr *MockDatabaseMockRecorder) DeleteIngestTask(ctx, ingestTask any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngestTask", reflect.TypeOf((*MockDatabase)(nil).DeleteIngestTask), ctx, ingestTask)
 }
 
+// DeleteRole mocks base method.
+func (m *MockDatabase) DeleteRole(ctx context.Context, role model.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockDatabaseMockRecorder) DeleteRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockDatabase)(nil).DeleteRole), ctx, role)
+}
+
 // DeleteSSOProvider mocks base method.
 func (m *MockDatabase) DeleteSSOProvider(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
@@ -720,6 +945,25 @@ func (mr *MockDatabaseMockRecorder) DeleteSavedQuery(ctx, savedQueryID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedQuery", reflect.TypeOf((*MockDatabase)(nil).DeleteSavedQuery), ctx, savedQueryID)
 }
 
+// DeleteSavedQueryPermissionsForRoles mocks base method.
+func (m *MockDatabase) DeleteSavedQueryPermissionsForRoles(ctx context.Context, queryID int64, roleIDs ...int32) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, queryID}
+	for _, a := range roleIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteSavedQueryPermissionsForRoles", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedQueryPermissionsForRoles indicates an expected call of DeleteSavedQueryPermissionsForRoles.
+func (mr *MockDatabaseMockRecorder) DeleteSavedQueryPermissionsForRoles(ctx, queryID any, roleIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, queryID}, roleIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedQueryPermissionsForRoles", reflect.TypeOf((*MockDatabase)(nil).DeleteSavedQueryPermissionsForRoles), varargs...)
+}
+
 // DeleteSavedQueryPermissionsForUsers mocks base method.
 func (m *MockDatabase) DeleteSavedQueryPermissionsForUsers(ctx context.Context, queryID int64, userIDs ...uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -800,6 +1044,34 @@ func (mr *MockDatabaseMockRecorder) DeleteUser(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDatabase)(nil).DeleteUser), ctx, user)
 }
 
+// DeleteUserAndTransferSavedQueries mocks base method.
+func (m *MockDatabase) DeleteUserAndTransferSavedQueries(ctx context.Context, user model.User, toUser model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserAndTransferSavedQueries", ctx, user, toUser)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserAndTransferSavedQueries indicates an expected call of DeleteUserAndTransferSavedQueries.
+func (mr *MockDatabaseMockRecorder) DeleteUserAndTransferSavedQueries(ctx, user, toUser any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAndTransferSavedQueries", reflect.TypeOf((*MockDatabase)(nil).DeleteUserAndTransferSavedQueries), ctx, user, toUser)
+}
+
+// DeleteWebAuthnCredential mocks base method.
+func (m *MockDatabase) DeleteWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebAuthnCredential", ctx, credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebAuthnCredential indicates an expected call of DeleteWebAuthnCredential.
+func (mr *MockDatabaseMockRecorder) DeleteWebAuthnCredential(ctx, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebAuthnCredential", reflect.TypeOf((*MockDatabase)(nil).DeleteWebAuthnCredential), ctx, credential)
+}
+
 // EndUserSession mocks base method.
 func (m *MockDatabase) EndUserSession(ctx context.Context, userSession model.UserSession) {
 	m.ctrl.T.Helper()
@@ -1101,6 +1373,22 @@ func (mr *MockDatabaseMockRecorder) GetAssetGroupHistoryRecords(ctx, sqlFilter,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupHistoryRecords", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupHistoryRecords), ctx, sqlFilter, sortItems, skip, limit)
 }
 
+// GetAssetGroupMemberCertifications mocks base method.
+func (m *MockDatabase) GetAssetGroupMemberCertifications(ctx context.Context, assetGroupTagId int, certified model.AssetGroupCertification, skip, limit int) (model.AssetGroupMemberCertifications, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupMemberCertifications", ctx, assetGroupTagId, certified, skip, limit)
+	ret0, _ := ret[0].(model.AssetGroupMemberCertifications)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssetGroupMemberCertifications indicates an expected call of GetAssetGroupMemberCertifications.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupMemberCertifications(ctx, assetGroupTagId, certified, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupMemberCertifications", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupMemberCertifications), ctx, assetGroupTagId, certified, skip, limit)
+}
+
 // GetAssetGroupSelector mocks base method.
 func (m *MockDatabase) GetAssetGroupSelector(ctx context.Context, id int32) (model.AssetGroupSelector, error) {
 	m.ctrl.T.Helper()
@@ -1116,6 +1404,37 @@ func (mr *MockDatabaseMockRecorder) GetAssetGroupSelector(ctx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupSelector", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupSelector), ctx, id)
 }
 
+// GetAssetGroupSelectorRun mocks base method.
+func (m *MockDatabase) GetAssetGroupSelectorRun(ctx context.Context, selectorId int, runId int64) (model.AssetGroupSelectorRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupSelectorRun", ctx, selectorId, runId)
+	ret0, _ := ret[0].(model.AssetGroupSelectorRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupSelectorRun indicates an expected call of GetAssetGroupSelectorRun.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupSelectorRun(ctx, selectorId, runId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupSelectorRun", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupSelectorRun), ctx, selectorId, runId)
+}
+
+// GetAssetGroupSelectorRuns mocks base method.
+func (m *MockDatabase) GetAssetGroupSelectorRuns(ctx context.Context, selectorId int, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.AssetGroupSelectorRuns, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupSelectorRuns", ctx, selectorId, sqlFilter, sortItems, skip, limit)
+	ret0, _ := ret[0].(model.AssetGroupSelectorRuns)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssetGroupSelectorRuns indicates an expected call of GetAssetGroupSelectorRuns.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupSelectorRuns(ctx, selectorId, sqlFilter, sortItems, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupSelectorRuns", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupSelectorRuns), ctx, selectorId, sqlFilter, sortItems, skip, limit)
+}
+
 // GetAssetGroupTag mocks base method.
 func (m *MockDatabase) GetAssetGroupTag(ctx context.Context, assetGroupTagId int) (model.AssetGroupTag, error) {
 	m.ctrl.T.Helper()
@@ -1207,6 +1526,113 @@ func (mr *MockDatabaseMockRecorder) GetAssetGroupTagSelectorsByTagId(ctx, assetG
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagSelectorsByTagId", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagSelectorsByTagId), ctx, assetGroupTagId, selectorSqlFilter, selectorSeedSqlFilter, skip, limit)
 }
 
+// GetAssetGroupTagViolationCounts mocks base method.
+func (m *MockDatabase) GetAssetGroupTagViolationCounts(ctx context.Context) (model.AssetGroupTagViolationCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagViolationCounts", ctx)
+	ret0, _ := ret[0].(model.AssetGroupTagViolationCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupTagViolationCounts indicates an expected call of GetAssetGroupTagViolationCounts.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagViolationCounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagViolationCounts", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagViolationCounts), ctx)
+}
+
+// GetAssetGroupTagViolations mocks base method.
+func (m *MockDatabase) GetAssetGroupTagViolations(ctx context.Context, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.AssetGroupTagViolations, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagViolations", ctx, sqlFilter, sortItems, skip, limit)
+	ret0, _ := ret[0].(model.AssetGroupTagViolations)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssetGroupTagViolations indicates an expected call of GetAssetGroupTagViolations.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagViolations(ctx, sqlFilter, sortItems, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagViolations", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagViolations), ctx, sqlFilter, sortItems, skip, limit)
+}
+
+// GetAssetGroupTagWebhook mocks base method.
+func (m *MockDatabase) GetAssetGroupTagWebhook(ctx context.Context, id int) (model.AssetGroupTagWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagWebhook", ctx, id)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupTagWebhook indicates an expected call of GetAssetGroupTagWebhook.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagWebhook", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagWebhook), ctx, id)
+}
+
+// GetAssetGroupTagWebhookDeliveries mocks base method.
+func (m *MockDatabase) GetAssetGroupTagWebhookDeliveries(ctx context.Context, webhookId int, sqlFilter model.SQLFilter, skip, limit int) (model.AssetGroupTagWebhookDeliveries, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagWebhookDeliveries", ctx, webhookId, sqlFilter, skip, limit)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhookDeliveries)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssetGroupTagWebhookDeliveries indicates an expected call of GetAssetGroupTagWebhookDeliveries.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagWebhookDeliveries(ctx, webhookId, sqlFilter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagWebhookDeliveries", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagWebhookDeliveries), ctx, webhookId, sqlFilter, skip, limit)
+}
+
+// GetAssetGroupTagWebhookDelivery mocks base method.
+func (m *MockDatabase) GetAssetGroupTagWebhookDelivery(ctx context.Context, webhookId int, deliveryId int64) (model.AssetGroupTagWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagWebhookDelivery", ctx, webhookId, deliveryId)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupTagWebhookDelivery indicates an expected call of GetAssetGroupTagWebhookDelivery.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagWebhookDelivery(ctx, webhookId, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagWebhookDelivery", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagWebhookDelivery), ctx, webhookId, deliveryId)
+}
+
+// GetAssetGroupTagWebhookDeliveryAttempts mocks base method.
+func (m *MockDatabase) GetAssetGroupTagWebhookDeliveryAttempts(ctx context.Context, deliveryId int64) (model.AssetGroupTagWebhookDeliveryAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagWebhookDeliveryAttempts", ctx, deliveryId)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhookDeliveryAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupTagWebhookDeliveryAttempts indicates an expected call of GetAssetGroupTagWebhookDeliveryAttempts.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagWebhookDeliveryAttempts(ctx, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagWebhookDeliveryAttempts", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagWebhookDeliveryAttempts), ctx, deliveryId)
+}
+
+// GetAssetGroupTagWebhooks mocks base method.
+func (m *MockDatabase) GetAssetGroupTagWebhooks(ctx context.Context, sqlFilter model.SQLFilter) (model.AssetGroupTagWebhooks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetGroupTagWebhooks", ctx, sqlFilter)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhooks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetGroupTagWebhooks indicates an expected call of GetAssetGroupTagWebhooks.
+func (mr *MockDatabaseMockRecorder) GetAssetGroupTagWebhooks(ctx, sqlFilter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupTagWebhooks", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupTagWebhooks), ctx, sqlFilter)
+}
+
 // GetAssetGroupTags mocks base method.
 func (m *MockDatabase) GetAssetGroupTags(ctx context.Context, sqlFilter model.SQLFilter) (model.AssetGroupTags, error) {
 	m.ctrl.T.Helper()
@@ -1237,6 +1663,21 @@ func (mr *MockDatabaseMockRecorder) GetAuthSecret(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthSecret", reflect.TypeOf((*MockDatabase)(nil).GetAuthSecret), ctx, id)
 }
 
+// GetAuthSecretHistory mocks base method.
+func (m *MockDatabase) GetAuthSecretHistory(ctx context.Context, userID uuid.UUID, limit int) ([]model.AuthSecretHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthSecretHistory", ctx, userID, limit)
+	ret0, _ := ret[0].([]model.AuthSecretHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthSecretHistory indicates an expected call of GetAuthSecretHistory.
+func (mr *MockDatabaseMockRecorder) GetAuthSecretHistory(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthSecretHistory", reflect.TypeOf((*MockDatabase)(nil).GetAuthSecretHistory), ctx, userID, limit)
+}
+
 // GetAuthToken mocks base method.
 func (m *MockDatabase) GetAuthToken(ctx context.Context, id uuid.UUID) (model.AuthToken, error) {
 	m.ctrl.T.Helper()
@@ -1284,6 +1725,37 @@ func (mr *MockDatabaseMockRecorder) GetAzureDataQualityStats(ctx, tenantId, star
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAzureDataQualityStats", reflect.TypeOf((*MockDatabase)(nil).GetAzureDataQualityStats), ctx, tenantId, start, end, sort_by, limit, skip)
 }
 
+// GetBlastRadiusReport mocks base method.
+func (m *MockDatabase) GetBlastRadiusReport(ctx context.Context, id int64) (model.BlastRadiusReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlastRadiusReport", ctx, id)
+	ret0, _ := ret[0].(model.BlastRadiusReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlastRadiusReport indicates an expected call of GetBlastRadiusReport.
+func (mr *MockDatabaseMockRecorder) GetBlastRadiusReport(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlastRadiusReport", reflect.TypeOf((*MockDatabase)(nil).GetBlastRadiusReport), ctx, id)
+}
+
+// GetBlastRadiusReports mocks base method.
+func (m *MockDatabase) GetBlastRadiusReports(ctx context.Context, sqlFilter model.SQLFilter, sortItems model.Sort, skip, limit int) (model.BlastRadiusReports, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlastRadiusReports", ctx, sqlFilter, sortItems, skip, limit)
+	ret0, _ := ret[0].(model.BlastRadiusReports)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlastRadiusReports indicates an expected call of GetBlastRadiusReports.
+func (mr *MockDatabaseMockRecorder) GetBlastRadiusReports(ctx, sqlFilter, sortItems, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlastRadiusReports", reflect.TypeOf((*MockDatabase)(nil).GetBlastRadiusReports), ctx, sqlFilter, sortItems, skip, limit)
+}
+
 // GetConfigurationParameter mocks base method.
 func (m *MockDatabase) GetConfigurationParameter(ctx context.Context, parameterKey appcfg.ParameterKey) (appcfg.Parameter, error) {
 	m.ctrl.T.Helper()
@@ -1359,6 +1831,21 @@ func (mr *MockDatabaseMockRecorder) GetDatapipeStatus(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatapipeStatus", reflect.TypeOf((*MockDatabase)(nil).GetDatapipeStatus), ctx)
 }
 
+// GetDueAssetGroupTagWebhookDeliveries mocks base method.
+func (m *MockDatabase) GetDueAssetGroupTagWebhookDeliveries(ctx context.Context, now time.Time, limit int) (model.AssetGroupTagWebhookDeliveries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueAssetGroupTagWebhookDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhookDeliveries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueAssetGroupTagWebhookDeliveries indicates an expected call of GetDueAssetGroupTagWebhookDeliveries.
+func (mr *MockDatabaseMockRecorder) GetDueAssetGroupTagWebhookDeliveries(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueAssetGroupTagWebhookDeliveries", reflect.TypeOf((*MockDatabase)(nil).GetDueAssetGroupTagWebhookDeliveries), ctx, now, limit)
+}
+
 // GetEnvironmentAccessListForUser mocks base method.
 func (m *MockDatabase) GetEnvironmentAccessListForUser(ctx context.Context, user model.User) ([]database.EnvironmentAccess, error) {
 	m.ctrl.T.Helper()
@@ -1404,6 +1891,51 @@ func (mr *MockDatabaseMockRecorder) GetFlagByKey(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlagByKey", reflect.TypeOf((*MockDatabase)(nil).GetFlagByKey), arg0, arg1)
 }
 
+// GetGraphSnapshotByRunID mocks base method.
+func (m *MockDatabase) GetGraphSnapshotByRunID(ctx context.Context, runID string) (model.GraphSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphSnapshotByRunID", ctx, runID)
+	ret0, _ := ret[0].(model.GraphSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphSnapshotByRunID indicates an expected call of GetGraphSnapshotByRunID.
+func (mr *MockDatabaseMockRecorder) GetGraphSnapshotByRunID(ctx, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphSnapshotByRunID", reflect.TypeOf((*MockDatabase)(nil).GetGraphSnapshotByRunID), ctx, runID)
+}
+
+// GetGraphSnapshotDiff mocks base method.
+func (m *MockDatabase) GetGraphSnapshotDiff(ctx context.Context, from, to model.GraphSnapshot, filter model.GraphDiffFilter, skip, limit int) (model.GraphDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphSnapshotDiff", ctx, from, to, filter, skip, limit)
+	ret0, _ := ret[0].(model.GraphDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphSnapshotDiff indicates an expected call of GetGraphSnapshotDiff.
+func (mr *MockDatabaseMockRecorder) GetGraphSnapshotDiff(ctx, from, to, filter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphSnapshotDiff", reflect.TypeOf((*MockDatabase)(nil).GetGraphSnapshotDiff), ctx, from, to, filter, skip, limit)
+}
+
+// GetGraphSnapshots mocks base method.
+func (m *MockDatabase) GetGraphSnapshots(ctx context.Context) (model.GraphSnapshots, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphSnapshots", ctx)
+	ret0, _ := ret[0].(model.GraphSnapshots)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphSnapshots indicates an expected call of GetGraphSnapshots.
+func (mr *MockDatabaseMockRecorder) GetGraphSnapshots(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphSnapshots", reflect.TypeOf((*MockDatabase)(nil).GetGraphSnapshots), ctx)
+}
+
 // GetIngestJob mocks base method.
 func (m *MockDatabase) GetIngestJob(ctx context.Context, id int64) (model.IngestJob, error) {
 	m.ctrl.T.Helper()
@@ -1524,6 +2056,21 @@ func (mr *MockDatabaseMockRecorder) GetPublicSavedQueries(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicSavedQueries", reflect.TypeOf((*MockDatabase)(nil).GetPublicSavedQueries), ctx)
 }
 
+// GetQueuedBlastRadiusReports mocks base method.
+func (m *MockDatabase) GetQueuedBlastRadiusReports(ctx context.Context) (model.BlastRadiusReports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueuedBlastRadiusReports", ctx)
+	ret0, _ := ret[0].(model.BlastRadiusReports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueuedBlastRadiusReports indicates an expected call of GetQueuedBlastRadiusReports.
+func (mr *MockDatabaseMockRecorder) GetQueuedBlastRadiusReports(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueuedBlastRadiusReports", reflect.TypeOf((*MockDatabase)(nil).GetQueuedBlastRadiusReports), ctx)
+}
+
 // GetRole mocks base method.
 func (m *MockDatabase) GetRole(ctx context.Context, id int32) (model.Role, error) {
 	m.ctrl.T.Helper()
@@ -1754,6 +2301,21 @@ func (mr *MockDatabaseMockRecorder) GetSourceKinds(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceKinds", reflect.TypeOf((*MockDatabase)(nil).GetSourceKinds), ctx)
 }
 
+// GetTieringConfiguration mocks base method.
+func (m *MockDatabase) GetTieringConfiguration(ctx context.Context) (model.TieringConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTieringConfiguration", ctx)
+	ret0, _ := ret[0].(model.TieringConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTieringConfiguration indicates an expected call of GetTieringConfiguration.
+func (mr *MockDatabaseMockRecorder) GetTieringConfiguration(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTieringConfiguration", reflect.TypeOf((*MockDatabase)(nil).GetTieringConfiguration), ctx)
+}
+
 // GetTimeRangedAssetGroupCollections mocks base method.
 func (m *MockDatabase) GetTimeRangedAssetGroupCollections(ctx context.Context, assetGroupID int32, from, to int64, order string) (model.AssetGroupCollections, error) {
 	m.ctrl.T.Helper()
@@ -1814,6 +2376,50 @@ func (mr *MockDatabaseMockRecorder) GetUserToken(ctx, userId, tokenId any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserToken", reflect.TypeOf((*MockDatabase)(nil).GetUserToken), ctx, userId, tokenId)
 }
 
+// GetWebAuthnCredential mocks base method.
+func (m *MockDatabase) GetWebAuthnCredential(ctx context.Context, userID uuid.UUID, id int32) (model.WebAuthnCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebAuthnCredential", ctx, userID, id)
+	ret0, _ := ret[0].(model.WebAuthnCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebAuthnCredential indicates an expected call of GetWebAuthnCredential.
+func (mr *MockDatabaseMockRecorder) GetWebAuthnCredential(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebAuthnCredential", reflect.TypeOf((*MockDatabase)(nil).GetWebAuthnCredential), ctx, userID, id)
+}
+
+// GetWebAuthnCredentials mocks base method.
+func (m *MockDatabase) GetWebAuthnCredentials(ctx context.Context, userID uuid.UUID) (model.WebAuthnCredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebAuthnCredentials", ctx, userID)
+	ret0, _ := ret[0].(model.WebAuthnCredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebAuthnCredentials indicates an expected call of GetWebAuthnCredentials.
+func (mr *MockDatabaseMockRecorder) GetWebAuthnCredentials(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebAuthnCredentials", reflect.TypeOf((*MockDatabase)(nil).GetWebAuthnCredentials), ctx, userID)
+}
+
+// GrantAllEnvironmentsForUser mocks base method.
+func (m *MockDatabase) GrantAllEnvironmentsForUser(ctx context.Context, user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantAllEnvironmentsForUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantAllEnvironmentsForUser indicates an expected call of GrantAllEnvironmentsForUser.
+func (mr *MockDatabaseMockRecorder) GrantAllEnvironmentsForUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantAllEnvironmentsForUser", reflect.TypeOf((*MockDatabase)(nil).GrantAllEnvironmentsForUser), ctx, user)
+}
+
 // HasAnalysisRequest mocks base method.
 func (m *MockDatabase) HasAnalysisRequest(ctx context.Context) bool {
 	m.ctrl.T.Helper()
@@ -1858,6 +2464,21 @@ func (mr *MockDatabaseMockRecorder) HasInstallation(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasInstallation", reflect.TypeOf((*MockDatabase)(nil).HasInstallation), ctx)
 }
 
+// ImportTieringConfiguration mocks base method.
+func (m *MockDatabase) ImportTieringConfiguration(ctx context.Context, user model.User, desired model.TieringConfiguration, prune bool) (model.TieringConfigurationPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTieringConfiguration", ctx, user, desired, prune)
+	ret0, _ := ret[0].(model.TieringConfigurationPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTieringConfiguration indicates an expected call of ImportTieringConfiguration.
+func (mr *MockDatabaseMockRecorder) ImportTieringConfiguration(ctx, user, desired, prune any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTieringConfiguration", reflect.TypeOf((*MockDatabase)(nil).ImportTieringConfiguration), ctx, user, desired, prune)
+}
+
 // InitializeSecretAuth mocks base method.
 func (m *MockDatabase) InitializeSecretAuth(ctx context.Context, adminUser model.User, authSecret model.AuthSecret) (model.Installation, error) {
 	m.ctrl.T.Helper()
@@ -1887,6 +2508,21 @@ func (mr *MockDatabaseMockRecorder) InsertSelectorNode(ctx, assetGroupTagId, sel
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSelectorNode", reflect.TypeOf((*MockDatabase)(nil).InsertSelectorNode), ctx, assetGroupTagId, selectorId, nodeId, certified, certifiedBy, source, primaryKind, environmentId, objectId, name)
 }
 
+// IsSavedQueryEditableByUser mocks base method.
+func (m *MockDatabase) IsSavedQueryEditableByUser(ctx context.Context, queryID int64, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSavedQueryEditableByUser", ctx, queryID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSavedQueryEditableByUser indicates an expected call of IsSavedQueryEditableByUser.
+func (mr *MockDatabaseMockRecorder) IsSavedQueryEditableByUser(ctx, queryID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSavedQueryEditableByUser", reflect.TypeOf((*MockDatabase)(nil).IsSavedQueryEditableByUser), ctx, queryID, userID)
+}
+
 // IsSavedQueryPublic mocks base method.
 func (m *MockDatabase) IsSavedQueryPublic(ctx context.Context, savedQueryID int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1964,6 +2600,20 @@ func (mr *MockDatabaseMockRecorder) ListSavedQueries(ctx, scope, userID, order,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedQueries", reflect.TypeOf((*MockDatabase)(nil).ListSavedQueries), ctx, scope, userID, order, filter, skip, limit)
 }
 
+// LockAuthSecret mocks base method.
+func (m *MockDatabase) LockAuthSecret(ctx context.Context, authSecret model.AuthSecret, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAuthSecret", ctx, authSecret, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAuthSecret indicates an expected call of LockAuthSecret.
+func (mr *MockDatabaseMockRecorder) LockAuthSecret(ctx, authSecret, lockedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAuthSecret", reflect.TypeOf((*MockDatabase)(nil).LockAuthSecret), ctx, authSecret, lockedUntil)
+}
+
 // LookupActiveSessionsByUser mocks base method.
 func (m *MockDatabase) LookupActiveSessionsByUser(ctx context.Context, user model.User) ([]model.UserSession, error) {
 	m.ctrl.T.Helper()
@@ -2008,6 +2658,35 @@ func (mr *MockDatabaseMockRecorder) Migrate(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockDatabase)(nil).Migrate), ctx)
 }
 
+// RecordAssetGroupTagWebhookDeliveryAttempt mocks base method.
+func (m *MockDatabase) RecordAssetGroupTagWebhookDeliveryAttempt(ctx context.Context, delivery model.AssetGroupTagWebhookDelivery, attempt model.AssetGroupTagWebhookDeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAssetGroupTagWebhookDeliveryAttempt", ctx, delivery, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAssetGroupTagWebhookDeliveryAttempt indicates an expected call of RecordAssetGroupTagWebhookDeliveryAttempt.
+func (mr *MockDatabaseMockRecorder) RecordAssetGroupTagWebhookDeliveryAttempt(ctx, delivery, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAssetGroupTagWebhookDeliveryAttempt", reflect.TypeOf((*MockDatabase)(nil).RecordAssetGroupTagWebhookDeliveryAttempt), ctx, delivery, attempt)
+}
+
+// RecordFailedLogin mocks base method.
+func (m *MockDatabase) RecordFailedLogin(ctx context.Context, authSecret model.AuthSecret) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, authSecret)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockDatabaseMockRecorder) RecordFailedLogin(ctx, authSecret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockDatabase)(nil).RecordFailedLogin), ctx, authSecret)
+}
+
 // RegisterSourceKind mocks base method.
 func (m *MockDatabase) RegisterSourceKind(ctx context.Context) func(graph.Kind) error {
 	m.ctrl.T.Helper()
@@ -2022,6 +2701,35 @@ func (mr *MockDatabaseMockRecorder) RegisterSourceKind(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSourceKind", reflect.TypeOf((*MockDatabase)(nil).RegisterSourceKind), ctx)
 }
 
+// ReorderAssetGroupTagTiers mocks base method.
+func (m *MockDatabase) ReorderAssetGroupTagTiers(ctx context.Context, user model.User, orderedTierIds []int) (model.AssetGroupTags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderAssetGroupTagTiers", ctx, user, orderedTierIds)
+	ret0, _ := ret[0].(model.AssetGroupTags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderAssetGroupTagTiers indicates an expected call of ReorderAssetGroupTagTiers.
+func (mr *MockDatabaseMockRecorder) ReorderAssetGroupTagTiers(ctx, user, orderedTierIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderAssetGroupTagTiers", reflect.TypeOf((*MockDatabase)(nil).ReorderAssetGroupTagTiers), ctx, user, orderedTierIds)
+}
+
+// ReplaceAssetGroupTagViolations mocks base method.
+func (m *MockDatabase) ReplaceAssetGroupTagViolations(ctx context.Context, violations model.AssetGroupTagViolations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAssetGroupTagViolations", ctx, violations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAssetGroupTagViolations indicates an expected call of ReplaceAssetGroupTagViolations.
+func (mr *MockDatabaseMockRecorder) ReplaceAssetGroupTagViolations(ctx, violations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAssetGroupTagViolations", reflect.TypeOf((*MockDatabase)(nil).ReplaceAssetGroupTagViolations), ctx, violations)
+}
+
 // RequestAnalysis mocks base method.
 func (m *MockDatabase) RequestAnalysis(ctx context.Context, requester string) error {
 	m.ctrl.T.Helper()
@@ -2050,6 +2758,48 @@ func (mr *MockDatabaseMockRecorder) RequestCollectedGraphDataDeletion(ctx, reque
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCollectedGraphDataDeletion", reflect.TypeOf((*MockDatabase)(nil).RequestCollectedGraphDataDeletion), ctx, request)
 }
 
+// ResetFailedLogins mocks base method.
+func (m *MockDatabase) ResetFailedLogins(ctx context.Context, authSecret model.AuthSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedLogins", ctx, authSecret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedLogins indicates an expected call of ResetFailedLogins.
+func (mr *MockDatabaseMockRecorder) ResetFailedLogins(ctx, authSecret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedLogins", reflect.TypeOf((*MockDatabase)(nil).ResetFailedLogins), ctx, authSecret)
+}
+
+// RevokeUserSession mocks base method.
+func (m *MockDatabase) RevokeUserSession(ctx context.Context, userSession model.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSession", ctx, userSession)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSession indicates an expected call of RevokeUserSession.
+func (mr *MockDatabaseMockRecorder) RevokeUserSession(ctx, userSession any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSession", reflect.TypeOf((*MockDatabase)(nil).RevokeUserSession), ctx, userSession)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockDatabase) RevokeUserSessions(ctx context.Context, user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockDatabaseMockRecorder) RevokeUserSessions(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockDatabase)(nil).RevokeUserSessions), ctx, user)
+}
+
 // SavedQueryBelongsToUser mocks base method.
 func (m *MockDatabase) SavedQueryBelongsToUser(ctx context.Context, userID uuid.UUID, savedQueryID int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -2147,6 +2897,30 @@ func (mr *MockDatabaseMockRecorder) SweepAssetGroupCollections(ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepAssetGroupCollections", reflect.TypeOf((*MockDatabase)(nil).SweepAssetGroupCollections), ctx)
 }
 
+// SweepAssetGroupSelectorRuns mocks base method.
+func (m *MockDatabase) SweepAssetGroupSelectorRuns(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SweepAssetGroupSelectorRuns", ctx)
+}
+
+// SweepAssetGroupSelectorRuns indicates an expected call of SweepAssetGroupSelectorRuns.
+func (mr *MockDatabaseMockRecorder) SweepAssetGroupSelectorRuns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepAssetGroupSelectorRuns", reflect.TypeOf((*MockDatabase)(nil).SweepAssetGroupSelectorRuns), ctx)
+}
+
+// SweepAuthTokens mocks base method.
+func (m *MockDatabase) SweepAuthTokens(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SweepAuthTokens", ctx)
+}
+
+// SweepAuthTokens indicates an expected call of SweepAuthTokens.
+func (mr *MockDatabaseMockRecorder) SweepAuthTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepAuthTokens", reflect.TypeOf((*MockDatabase)(nil).SweepAuthTokens), ctx)
+}
+
 // SweepSessions mocks base method.
 func (m *MockDatabase) SweepSessions(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -2159,6 +2933,18 @@ func (mr *MockDatabaseMockRecorder) SweepSessions(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepSessions", reflect.TypeOf((*MockDatabase)(nil).SweepSessions), ctx)
 }
 
+// SweepWebAuthnCeremonies mocks base method.
+func (m *MockDatabase) SweepWebAuthnCeremonies(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SweepWebAuthnCeremonies", ctx)
+}
+
+// SweepWebAuthnCeremonies indicates an expected call of SweepWebAuthnCeremonies.
+func (mr *MockDatabaseMockRecorder) SweepWebAuthnCeremonies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepWebAuthnCeremonies", reflect.TypeOf((*MockDatabase)(nil).SweepWebAuthnCeremonies), ctx)
+}
+
 // TerminateUserSessionsBySSOProvider mocks base method.
 func (m *MockDatabase) TerminateUserSessionsBySSOProvider(ctx context.Context, ssoProvider model.SSOProvider) error {
 	m.ctrl.T.Helper()
@@ -2173,6 +2959,34 @@ func (mr *MockDatabaseMockRecorder) TerminateUserSessionsBySSOProvider(ctx, ssoP
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateUserSessionsBySSOProvider", reflect.TypeOf((*MockDatabase)(nil).TerminateUserSessionsBySSOProvider), ctx, ssoProvider)
 }
 
+// TransferSavedQueries mocks base method.
+func (m *MockDatabase) TransferSavedQueries(ctx context.Context, fromUser model.User, toUser model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferSavedQueries", ctx, fromUser, toUser)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferSavedQueries indicates an expected call of TransferSavedQueries.
+func (mr *MockDatabaseMockRecorder) TransferSavedQueries(ctx, fromUser, toUser any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferSavedQueries", reflect.TypeOf((*MockDatabase)(nil).TransferSavedQueries), ctx, fromUser, toUser)
+}
+
+// UnlockAuthSecret mocks base method.
+func (m *MockDatabase) UnlockAuthSecret(ctx context.Context, authSecret model.AuthSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAuthSecret", ctx, authSecret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAuthSecret indicates an expected call of UnlockAuthSecret.
+func (mr *MockDatabaseMockRecorder) UnlockAuthSecret(ctx, authSecret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAuthSecret", reflect.TypeOf((*MockDatabase)(nil).UnlockAuthSecret), ctx, authSecret)
+}
+
 // UpdateAssetGroup mocks base method.
 func (m *MockDatabase) UpdateAssetGroup(ctx context.Context, assetGroup model.AssetGroup) error {
 	m.ctrl.T.Helper()
@@ -2187,6 +3001,21 @@ func (mr *MockDatabaseMockRecorder) UpdateAssetGroup(ctx, assetGroup any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroup", reflect.TypeOf((*MockDatabase)(nil).UpdateAssetGroup), ctx, assetGroup)
 }
 
+// UpdateAssetGroupMemberCertifications mocks base method.
+func (m *MockDatabase) UpdateAssetGroupMemberCertifications(ctx context.Context, user model.User, update model.AssetGroupCertificationUpdate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssetGroupMemberCertifications", ctx, user, update)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAssetGroupMemberCertifications indicates an expected call of UpdateAssetGroupMemberCertifications.
+func (mr *MockDatabaseMockRecorder) UpdateAssetGroupMemberCertifications(ctx, user, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroupMemberCertifications", reflect.TypeOf((*MockDatabase)(nil).UpdateAssetGroupMemberCertifications), ctx, user, update)
+}
+
 // UpdateAssetGroupSelectors mocks base method.
 func (m *MockDatabase) UpdateAssetGroupSelectors(ctx context.Context, assetGroup model.AssetGroup, selectorSpecs []model.AssetGroupSelectorSpec, systemSelector bool) (model.UpdatedAssetGroupSelectors, error) {
 	m.ctrl.T.Helper()
@@ -2232,6 +3061,21 @@ func (mr *MockDatabaseMockRecorder) UpdateAssetGroupTagSelector(ctx, actorId, em
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroupTagSelector", reflect.TypeOf((*MockDatabase)(nil).UpdateAssetGroupTagSelector), ctx, actorId, email, selector)
 }
 
+// UpdateAssetGroupTagWebhook mocks base method.
+func (m *MockDatabase) UpdateAssetGroupTagWebhook(ctx context.Context, webhook model.AssetGroupTagWebhook) (model.AssetGroupTagWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssetGroupTagWebhook", ctx, webhook)
+	ret0, _ := ret[0].(model.AssetGroupTagWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAssetGroupTagWebhook indicates an expected call of UpdateAssetGroupTagWebhook.
+func (mr *MockDatabaseMockRecorder) UpdateAssetGroupTagWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssetGroupTagWebhook", reflect.TypeOf((*MockDatabase)(nil).UpdateAssetGroupTagWebhook), ctx, webhook)
+}
+
 // UpdateAuthSecret mocks base method.
 func (m *MockDatabase) UpdateAuthSecret(ctx context.Context, authSecret model.AuthSecret) error {
 	m.ctrl.T.Helper()
@@ -2260,6 +3104,20 @@ func (mr *MockDatabaseMockRecorder) UpdateAuthToken(ctx, authToken any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthToken", reflect.TypeOf((*MockDatabase)(nil).UpdateAuthToken), ctx, authToken)
 }
 
+// UpdateBlastRadiusReport mocks base method.
+func (m *MockDatabase) UpdateBlastRadiusReport(ctx context.Context, report model.BlastRadiusReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBlastRadiusReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBlastRadiusReport indicates an expected call of UpdateBlastRadiusReport.
+func (mr *MockDatabaseMockRecorder) UpdateBlastRadiusReport(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlastRadiusReport", reflect.TypeOf((*MockDatabase)(nil).UpdateBlastRadiusReport), ctx, report)
+}
+
 // UpdateCustomNodeKind mocks base method.
 func (m *MockDatabase) UpdateCustomNodeKind(ctx context.Context, customNodeKind model.CustomNodeKind) (model.CustomNodeKind, error) {
 	m.ctrl.T.Helper()
@@ -2337,6 +3195,21 @@ func (mr *MockDatabaseMockRecorder) UpdateOIDCProvider(ctx, ssoProvider any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOIDCProvider", reflect.TypeOf((*MockDatabase)(nil).UpdateOIDCProvider), ctx, ssoProvider)
 }
 
+// UpdateRole mocks base method.
+func (m *MockDatabase) UpdateRole(ctx context.Context, role model.Role) (model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, role)
+	ret0, _ := ret[0].(model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockDatabaseMockRecorder) UpdateRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockDatabase)(nil).UpdateRole), ctx, role)
+}
+
 // UpdateSAMLIdentityProvider mocks base method.
 func (m *MockDatabase) UpdateSAMLIdentityProvider(ctx context.Context, ssoProvider model.SSOProvider) (model.SAMLProvider, error) {
 	m.ctrl.T.Helper()
@@ -2410,6 +3283,34 @@ func (mr *MockDatabaseMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockDatabase)(nil).UpdateUser), ctx, user)
 }
 
+// UpdateUsers mocks base method.
+func (m *MockDatabase) UpdateUsers(ctx context.Context, users model.Users) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsers", ctx, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsers indicates an expected call of UpdateUsers.
+func (mr *MockDatabaseMockRecorder) UpdateUsers(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsers", reflect.TypeOf((*MockDatabase)(nil).UpdateUsers), ctx, users)
+}
+
+// UpdateWebAuthnCredentialUsage mocks base method.
+func (m *MockDatabase) UpdateWebAuthnCredentialUsage(ctx context.Context, credential model.WebAuthnCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebAuthnCredentialUsage", ctx, credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebAuthnCredentialUsage indicates an expected call of UpdateWebAuthnCredentialUsage.
+func (mr *MockDatabaseMockRecorder) UpdateWebAuthnCredentialUsage(ctx, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebAuthnCredentialUsage", reflect.TypeOf((*MockDatabase)(nil).UpdateWebAuthnCredentialUsage), ctx, credential)
+}
+
 // Wipe mocks base method.
 func (m *MockDatabase) Wipe(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -19,6 +19,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gofrs/uuid"
@@ -39,6 +40,7 @@ type SavedQueriesData interface {
 	CreateSavedQueries(ctx context.Context, savedQueries model.SavedQueries) error
 	GetAllSavedQueriesByUser(ctx context.Context, userID uuid.UUID) (model.SavedQueries, error)
 	GetSavedQueriesOwnedBy(ctx context.Context, userID uuid.UUID) (model.SavedQueries, error)
+	TransferSavedQueries(ctx context.Context, fromUser model.User, toUser model.User) error
 }
 
 func (s *BloodhoundDB) GetSavedQuery(ctx context.Context, savedQueryID int64) (model.SavedQuery, error) {
@@ -51,8 +53,8 @@ func (s *BloodhoundDB) ListSavedQueries(ctx context.Context, scope string, userI
 	var (
 		queries []model.ScopedSavedQuery
 		// cant chain scope + cursor after declaration so must declare twice
-		countCursor    = s.db.WithContext(ctx).Select("DISTINCT sq.*, CASE WHEN (sqp.public = TRUE AND sq.user_id <> ?) THEN 'public' WHEN sqp.shared_to_user_id = ? THEN 'shared' ELSE 'owned' END AS scope", userID, userID).Table("saved_queries sq").Joins("LEFT JOIN public.saved_queries_permissions sqp ON sq.id = sqp.query_id")
-		cursor         = s.Scope(Paginate(skip, limit)).WithContext(ctx).Select("DISTINCT sq.*, CASE WHEN (sqp.public = TRUE AND sq.user_id <> ?) THEN 'public' WHEN sqp.shared_to_user_id = ? THEN 'shared' ELSE 'owned' END AS scope", userID, userID).Table("saved_queries sq").Joins("LEFT JOIN public.saved_queries_permissions sqp ON sq.id = sqp.query_id")
+		countCursor    = s.db.WithContext(ctx).Select("DISTINCT sq.*, CASE WHEN (sqp.public = TRUE AND sq.user_id <> ?) THEN 'public' WHEN (sq.user_id <> ? AND "+savedQuerySharedToUserSQL+") THEN 'shared' ELSE 'owned' END AS scope", userID, userID, userID, userID).Table("saved_queries sq").Joins("LEFT JOIN public.saved_queries_permissions sqp ON sq.id = sqp.query_id")
+		cursor         = s.Scope(Paginate(skip, limit)).WithContext(ctx).Select("DISTINCT sq.*, CASE WHEN (sqp.public = TRUE AND sq.user_id <> ?) THEN 'public' WHEN (sq.user_id <> ? AND "+savedQuerySharedToUserSQL+") THEN 'shared' ELSE 'owned' END AS scope", userID, userID, userID, userID).Table("saved_queries sq").Joins("LEFT JOIN public.saved_queries_permissions sqp ON sq.id = sqp.query_id")
 		orderReplacer  = strings.NewReplacer("id", "sq.id", "created_at", "sq.created_at", "updated_at", "sq.updated_at")
 		filterReplacer = strings.NewReplacer("id", "sq.id")
 		count          int64
@@ -67,14 +69,14 @@ func (s *BloodhoundDB) ListSavedQueries(ctx context.Context, scope string, userI
 		cursor = cursor.Where("sq.user_id = ?", userID)
 		countCursor = countCursor.Where("sq.user_id = ?", userID)
 	case string(model.SavedQueryScopeShared):
-		cursor = cursor.Where("sqp.shared_to_user_id = ?", userID)
-		countCursor = countCursor.Where("sqp.shared_to_user_id = ?", userID)
+		cursor = cursor.Where("sq.user_id <> ? AND "+savedQuerySharedToUserSQL, userID, userID, userID)
+		countCursor = countCursor.Where("sq.user_id <> ? AND "+savedQuerySharedToUserSQL, userID, userID, userID)
 	case string(model.SavedQueryScopePublic):
 		cursor = cursor.Where("sqp.public = TRUE")
 		countCursor = countCursor.Where("sqp.public = TRUE")
 	case string(model.SavedQueryScopeAll):
-		cursor = cursor.Where("sqp.public = TRUE OR sq.user_id = ? OR sqp.shared_to_user_id = ?", userID, userID)
-		countCursor = countCursor.Where("sqp.public = TRUE OR sq.user_id = ? OR sqp.shared_to_user_id = ?", userID, userID)
+		cursor = cursor.Where("sqp.public = TRUE OR sq.user_id = ? OR "+savedQuerySharedToUserSQL, userID, userID, userID)
+		countCursor = countCursor.Where("sqp.public = TRUE OR sq.user_id = ? OR "+savedQuerySharedToUserSQL, userID, userID, userID)
 	default:
 		return nil, 0, fmt.Errorf("invalid scope parameter: %s", scope)
 	}
@@ -128,7 +130,7 @@ func (s *BloodhoundDB) SavedQueryBelongsToUser(ctx context.Context, userID uuid.
 func (s *BloodhoundDB) GetSharedSavedQueries(ctx context.Context, userID uuid.UUID) (model.SavedQueries, error) {
 	savedQueries := model.SavedQueries{}
 
-	result := s.db.WithContext(ctx).Select("saved_queries.*").Joins("JOIN saved_queries_permissions sqp ON sqp.query_id = saved_queries.id").Where("sqp.shared_to_user_id = ? ", userID).Find(&savedQueries)
+	result := s.db.WithContext(ctx).Select("DISTINCT saved_queries.*").Joins("JOIN saved_queries_permissions sqp ON sqp.query_id = saved_queries.id").Where("saved_queries.user_id <> ? AND "+savedQuerySharedToUserSQL, userID, userID, userID).Find(&savedQueries)
 
 	return savedQueries, CheckError(result)
 }
@@ -145,7 +147,7 @@ func (s *BloodhoundDB) GetPublicSavedQueries(ctx context.Context) (model.SavedQu
 // GetAllSavedQueriesByUser - Returns queries that are public, owned by, or shared to the user.
 func (s *BloodhoundDB) GetAllSavedQueriesByUser(ctx context.Context, userID uuid.UUID) (model.SavedQueries, error) {
 	savedQueries := model.SavedQueries{}
-	results := s.db.WithContext(ctx).Select("DISTINCT saved_queries.*").Joins("LEFT JOIN saved_queries_permissions sqp ON sqp.query_id = saved_queries.id").Where("sqp.public = true OR saved_queries.user_id = ? OR sqp.shared_to_user_id = ?", userID, userID).Find(&savedQueries)
+	results := s.db.WithContext(ctx).Select("DISTINCT saved_queries.*").Joins("LEFT JOIN saved_queries_permissions sqp ON sqp.query_id = saved_queries.id").Where("sqp.public = true OR saved_queries.user_id = ? OR "+savedQuerySharedToUserSQL, userID, userID, userID).Find(&savedQueries)
 	return savedQueries, CheckError(results)
 }
 
@@ -155,6 +157,56 @@ func (s *BloodhoundDB) GetSavedQueriesOwnedBy(ctx context.Context, userID uuid.U
 	return savedQueries, CheckError(result)
 }
 
+// TransferSavedQueries hands the saved queries of fromUser that are shared with other users or publicly to toUser.
+// Private queries stay with fromUser so they are never exposed to toUser. Queries whose name is already taken by one of
+// toUser's queries get the principal name of fromUser appended, followed by a counter when that name is taken as well.
+// Shares of the transferred queries to toUser are dropped as they would otherwise share the queries to their own owner.
+func (s *BloodhoundDB) TransferSavedQueries(ctx context.Context, fromUser model.User, toUser model.User) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionTransferSavedQueries,
+		Model:  model.SavedQueryOwnershipTransfer{FromUserID: fromUser.ID, ToUserID: toUser.ID},
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		var (
+			sharedQueries model.SavedQueries
+			takenNames    []string
+		)
+
+		if result := tx.WithContext(ctx).Where("user_id = ? AND id IN (SELECT query_id FROM saved_queries_permissions)", fromUser.ID).Order("id").Find(&sharedQueries); result.Error != nil {
+			return CheckError(result)
+		} else if len(sharedQueries) == 0 {
+			return nil
+		} else if result := tx.WithContext(ctx).Model(&model.SavedQuery{}).Where("user_id = ?", toUser.ID).Pluck("name", &takenNames); result.Error != nil {
+			return CheckError(result)
+		} else if result := tx.WithContext(ctx).Exec(
+			"DELETE FROM saved_queries_permissions WHERE shared_to_user_id = ? AND query_id IN (SELECT id FROM saved_queries WHERE user_id = ?)",
+			toUser.ID, fromUser.ID,
+		); result.Error != nil {
+			return CheckError(result)
+		}
+
+		for _, savedQuery := range sharedQueries {
+			name := savedQuery.Name
+			for attempt := 1; slices.Contains(takenNames, name); attempt++ {
+				if attempt == 1 {
+					name = fmt.Sprintf("%s (%s)", savedQuery.Name, fromUser.PrincipalName)
+				} else {
+					name = fmt.Sprintf("%s (%s %d)", savedQuery.Name, fromUser.PrincipalName, attempt)
+				}
+			}
+
+			takenNames = append(takenNames, name)
+
+			if result := tx.WithContext(ctx).Exec("UPDATE saved_queries SET user_id = ?, name = ?, updated_at = NOW() WHERE id = ?", toUser.ID, name, savedQuery.ID); result.Error != nil {
+				return CheckError(result)
+			}
+		}
+
+		return nil
+	})
+}
+
 // CreateSavedQueries - inserts saved queries records in batches
 func (s *BloodhoundDB) CreateSavedQueries(ctx context.Context, savedQueries model.SavedQueries) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
@@ -26,6 +26,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
 )
@@ -67,3 +68,80 @@ func TestSavedQueries_ListSavedQueries(t *testing.T) {
 		t.Fatalf("Expected 3 saved queries to be returned, received %d", count)
 	}
 }
+
+func TestSavedQueries_TransferSavedQueries(t *testing.T) {
+	var (
+		testCtx = context.Background()
+		dbInst  = integration.SetupDB(t)
+		user1   = createUser(t, dbInst, userPrincipal)
+		user2   = createUser(t, dbInst, user2Principal)
+	)
+
+	privateQuery, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Private", "MATCH (n) RETURN n", "")
+	require.NoError(t, err)
+	duplicateQuery, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Duplicate", "MATCH (n) RETURN n", "")
+	require.NoError(t, err)
+	sharedQuery, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Shared", "MATCH (n) RETURN n", "")
+	require.NoError(t, err)
+	_, err = dbInst.CreateSavedQuery(testCtx, user2.ID, "Duplicate", "MATCH (n) RETURN n", "")
+	require.NoError(t, err)
+	_, err = dbInst.CreateSavedQuery(testCtx, user2.ID, fmt.Sprintf("Duplicate (%s)", user1.PrincipalName), "MATCH (n) RETURN n", "")
+	require.NoError(t, err)
+
+	_, err = dbInst.CreateSavedQueryPermissionToPublic(testCtx, duplicateQuery.ID)
+	require.NoError(t, err)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, sharedQuery.ID, false, user2.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, dbInst.TransferSavedQueries(testCtx, user1, user2))
+
+	// Private queries are never handed to the new owner
+	remaining, err := dbInst.GetSavedQueriesOwnedBy(testCtx, user1.ID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	require.Equal(t, privateQuery.ID, remaining[0].ID)
+
+	transferred, err := dbInst.GetSavedQueriesOwnedBy(testCtx, user2.ID)
+	require.NoError(t, err)
+	require.Len(t, transferred, 4)
+
+	var names []string
+	for _, query := range transferred {
+		names = append(names, query.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"Duplicate",
+		fmt.Sprintf("Duplicate (%s)", user1.PrincipalName),
+		fmt.Sprintf("Duplicate (%s 2)", user1.PrincipalName),
+		"Shared",
+	}, names)
+
+	// The new owner no longer needs the query shared to them
+	sharedToUser, err := dbInst.IsSavedQuerySharedToUser(testCtx, sharedQuery.ID, user2.ID)
+	require.NoError(t, err)
+	require.False(t, sharedToUser)
+}
+
+func TestSavedQueries_DeleteUserAndTransferSavedQueries(t *testing.T) {
+	var (
+		testCtx = context.Background()
+		dbInst  = integration.SetupDB(t)
+		user1   = createUser(t, dbInst, userPrincipal)
+		user2   = createUser(t, dbInst, user2Principal)
+	)
+
+	sharedQuery, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Shared", "MATCH (n) RETURN n", "")
+	require.NoError(t, err)
+	_, err = dbInst.CreateSavedQueryPermissionToPublic(testCtx, sharedQuery.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, dbInst.DeleteUserAndTransferSavedQueries(testCtx, user1, user2))
+
+	_, err = dbInst.GetUser(testCtx, user1.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	transferred, err := dbInst.GetSavedQueriesOwnedBy(testCtx, user2.ID)
+	require.NoError(t, err)
+	require.Len(t, transferred, 1)
+	require.Equal(t, sharedQuery.ID, transferred[0].ID)
+}
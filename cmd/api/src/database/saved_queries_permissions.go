@@ -23,6 +23,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 	"github.com/specterops/bloodhound/cmd/api/src/model"
 )
 
@@ -30,14 +31,21 @@ import (
 type SavedQueriesPermissionsData interface {
 	GetSavedQueryPermissions(ctx context.Context, queryID int64) ([]model.SavedQueriesPermissions, error)
 	CreateSavedQueryPermissionToPublic(ctx context.Context, queryID int64) (model.SavedQueriesPermissions, error)
-	CreateSavedQueryPermissionsToUsers(ctx context.Context, queryID int64, userIDs ...uuid.UUID) ([]model.SavedQueriesPermissions, error)
+	CreateSavedQueryPermissionsToUsers(ctx context.Context, queryID int64, canEdit bool, userIDs ...uuid.UUID) ([]model.SavedQueriesPermissions, error)
+	CreateSavedQueryPermissionsToRoles(ctx context.Context, queryID int64, canEdit bool, roleIDs ...int32) ([]model.SavedQueriesPermissions, error)
 	DeleteSavedQueryPermissionsForUsers(ctx context.Context, queryID int64, userIDs ...uuid.UUID) error
+	DeleteSavedQueryPermissionsForRoles(ctx context.Context, queryID int64, roleIDs ...int32) error
 	GetScopeForSavedQuery(ctx context.Context, queryID int64, userID uuid.UUID) (SavedQueryScopeMap, error)
 	IsSavedQueryPublic(ctx context.Context, savedQueryID int64) (bool, error)
 	IsSavedQuerySharedToUser(ctx context.Context, queryID int64, userID uuid.UUID) (bool, error)
 	IsSavedQuerySharedToUserOrPublic(ctx context.Context, queryID int64, userID uuid.UUID) (bool, error)
+	IsSavedQueryEditableByUser(ctx context.Context, queryID int64, userID uuid.UUID) (bool, error)
 }
 
+// savedQuerySharedToUserSQL matches saved query permissions granted to a user directly or through one of their roles.
+// The user ID must be bound twice.
+const savedQuerySharedToUserSQL = "(sqp.shared_to_user_id = ? OR sqp.shared_to_role_id IN (SELECT role_id FROM users_roles WHERE user_id = ?))"
+
 // SavedQueryScopeMap holds the information of a saved query's scope [IE: owned, shared, public]
 type SavedQueryScopeMap map[model.SavedQueryScope]bool
 
@@ -50,15 +58,21 @@ func (s *BloodhoundDB) GetSavedQueryPermissions(ctx context.Context, queryID int
 
 // CreateSavedQueryPermissionToPublic creates a new entry to the SavedQueriesPermissions table granting public read permissions to all users
 func (s *BloodhoundDB) CreateSavedQueryPermissionToPublic(ctx context.Context, queryID int64) (model.SavedQueriesPermissions, error) {
-	permission := model.SavedQueriesPermissions{
-		QueryID: queryID,
-		Public:  true,
-	}
+	var (
+		permission = model.SavedQueriesPermissions{
+			QueryID: queryID,
+			Public:  true,
+		}
+		auditEntry = model.AuditEntry{
+			Action: model.AuditLogActionShareSavedQuery,
+			Model:  model.SavedQueryPermissionChange{QueryID: queryID, Public: true},
+		}
+	)
 
-	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := CheckError(tx.Create(&permission)); err != nil {
+	err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		if err := CheckError(tx.WithContext(ctx).Create(&permission)); err != nil {
 			return err
-		} else if err := CheckError(tx.Table("saved_queries_permissions").Where("query_id = ? AND public = false", queryID).Delete(&model.SavedQueriesPermissions{})); err != nil {
+		} else if err := CheckError(tx.WithContext(ctx).Table("saved_queries_permissions").Where("query_id = ? AND public = false", queryID).Delete(&model.SavedQueriesPermissions{})); err != nil {
 			return err
 		}
 
@@ -68,33 +82,90 @@ func (s *BloodhoundDB) CreateSavedQueryPermissionToPublic(ctx context.Context, q
 	return permission, err
 }
 
-// CreateSavedQueryPermissionsToUsers - attempts to save the given saved query permissions in batches of 100 in a transaction
-func (s *BloodhoundDB) CreateSavedQueryPermissionsToUsers(ctx context.Context, queryID int64, userIDs ...uuid.UUID) ([]model.SavedQueriesPermissions, error) {
-	var newPermissions []model.SavedQueriesPermissions
+// CreateSavedQueryPermissionsToUsers - attempts to save the given saved query permissions in batches of 100 in a transaction.
+// Existing shares to the given users are updated to the given edit rights.
+func (s *BloodhoundDB) CreateSavedQueryPermissionsToUsers(ctx context.Context, queryID int64, canEdit bool, userIDs ...uuid.UUID) ([]model.SavedQueriesPermissions, error) {
+	var (
+		newPermissions []model.SavedQueriesPermissions
+		auditEntry     = model.AuditEntry{
+			Action: model.AuditLogActionShareSavedQuery,
+			Model:  model.SavedQueryPermissionChange{QueryID: queryID, CanEdit: canEdit, UserIDs: userIDs},
+		}
+	)
+
 	for _, sharedUserID := range userIDs {
 		newPermissions = append(newPermissions, model.SavedQueriesPermissions{
 			QueryID:        queryID,
 			SharedToUserID: NullUUID(sharedUserID),
 			Public:         false,
+			CanEdit:        canEdit,
 		})
 	}
 
-	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
-		DoNothing: true,
-	}).CreateInBatches(&newPermissions, 100)
+	return newPermissions, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "shared_to_user_id"}, {Name: "query_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"can_edit", "updated_at"}),
+		}).CreateInBatches(&newPermissions, 100))
+	})
+}
 
-	return newPermissions, CheckError(result)
+// CreateSavedQueryPermissionsToRoles shares a saved query to every user holding one of the given roles. Existing shares
+// to the given roles are updated to the given edit rights.
+func (s *BloodhoundDB) CreateSavedQueryPermissionsToRoles(ctx context.Context, queryID int64, canEdit bool, roleIDs ...int32) ([]model.SavedQueriesPermissions, error) {
+	var (
+		newPermissions []model.SavedQueriesPermissions
+		auditEntry     = model.AuditEntry{
+			Action: model.AuditLogActionShareSavedQuery,
+			Model:  model.SavedQueryPermissionChange{QueryID: queryID, CanEdit: canEdit, RoleIDs: roleIDs},
+		}
+	)
+
+	for _, sharedRoleID := range roleIDs {
+		newPermissions = append(newPermissions, model.SavedQueriesPermissions{
+			QueryID:        queryID,
+			SharedToRoleID: null.Int32From(sharedRoleID),
+			Public:         false,
+			CanEdit:        canEdit,
+		})
+	}
+
+	return newPermissions, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "shared_to_role_id"}, {Name: "query_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"can_edit", "updated_at"}),
+		}).CreateInBatches(&newPermissions, 100))
+	})
 }
 
 // DeleteSavedQueryPermissionsForUsers batch deletes permissions associated with a query id and a list of users
 // If no user ids are supplied, all records for query id are deleted
 func (s *BloodhoundDB) DeleteSavedQueryPermissionsForUsers(ctx context.Context, queryID int64, userIDs ...uuid.UUID) error {
-	result := s.db.WithContext(ctx).Table("saved_queries_permissions").Where("query_id = ?", queryID)
-	if len(userIDs) > 0 {
-		result = result.Where("shared_to_user_id IN ?", userIDs)
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionUnshareSavedQuery,
+		Model:  model.SavedQueryPermissionChange{QueryID: queryID, UserIDs: userIDs},
 	}
 
-	return CheckError(result.Delete(&model.SavedQueriesPermissions{}))
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		result := tx.WithContext(ctx).Table("saved_queries_permissions").Where("query_id = ?", queryID)
+		if len(userIDs) > 0 {
+			result = result.Where("shared_to_user_id IN ?", userIDs)
+		}
+
+		return CheckError(result.Delete(&model.SavedQueriesPermissions{}))
+	})
+}
+
+// DeleteSavedQueryPermissionsForRoles batch deletes the permissions of a query that were granted to the given roles
+func (s *BloodhoundDB) DeleteSavedQueryPermissionsForRoles(ctx context.Context, queryID int64, roleIDs ...int32) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionUnshareSavedQuery,
+		Model:  model.SavedQueryPermissionChange{QueryID: queryID, RoleIDs: roleIDs},
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Table("saved_queries_permissions").Where("query_id = ? AND shared_to_role_id IN ?", queryID, roleIDs).Delete(&model.SavedQueriesPermissions{}))
+	})
 }
 
 // GetScopeForSavedQuery will return a map of the possible scopes given a query id and a user id
@@ -142,8 +213,18 @@ func (s *BloodhoundDB) IsSavedQuerySharedToUser(ctx context.Context, queryID int
 	return rows > 0, CheckError(result)
 }
 
+// IsSavedQuerySharedToUserOrPublic returns true if a saved query is public or shared to the user directly or through one
+// of their roles
 func (s *BloodhoundDB) IsSavedQuerySharedToUserOrPublic(ctx context.Context, queryID int64, userID uuid.UUID) (bool, error) {
 	rows := int64(0)
-	result := s.db.WithContext(ctx).Table("saved_queries_permissions").Where("query_id = ? AND (shared_to_user_id = ? or public = true)", queryID, userID).Count(&rows)
+	result := s.db.WithContext(ctx).Table("saved_queries_permissions sqp").Where("sqp.query_id = ? AND ("+savedQuerySharedToUserSQL+" OR sqp.public = true)", queryID, userID, userID).Count(&rows)
+	return rows > 0, CheckError(result)
+}
+
+// IsSavedQueryEditableByUser returns true if a saved query was shared with edit rights to the user directly or through
+// one of their roles
+func (s *BloodhoundDB) IsSavedQueryEditableByUser(ctx context.Context, queryID int64, userID uuid.UUID) (bool, error) {
+	rows := int64(0)
+	result := s.db.WithContext(ctx).Table("saved_queries_permissions sqp").Where("sqp.query_id = ? AND sqp.can_edit = true AND "+savedQuerySharedToUserSQL, queryID, userID, userID).Count(&rows)
 	return rows > 0, CheckError(result)
 }
@@ -60,7 +60,7 @@ func TestSavedQueriesPermissions_CreateSavedQueryPermissionToPublic(t *testing.T
 		query, err := dbInst.CreateSavedQuery(testCtx, user.ID, "Test Query2", "TESTING2", "Example2")
 		require.NoError(t, err)
 
-		_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID)
+		_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID)
 		require.NoError(t, err)
 
 		scope, err := dbInst.GetScopeForSavedQuery(testCtx, query.ID, user2.ID)
@@ -97,7 +97,7 @@ func TestSavedQueriesPermissions_CreateSavedQueryPermissionsToUsers(t *testing.T
 	query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Example")
 	require.NoError(t, err)
 
-	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID, user3.ID, user4.ID)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID, user3.ID, user4.ID)
 	require.NoError(t, err)
 
 	scope, err := dbInst.GetScopeForSavedQuery(testCtx, query.ID, user2.ID)
@@ -138,7 +138,7 @@ func TestSavedQueriesPermissions_CreateSavedQueryPermissionsBatchBadDataError(t
 	query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Example")
 	require.NoError(t, err)
 
-	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID, unknownUUID)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID, unknownUUID)
 	require.Error(t, err)
 
 	// verify partial share doesn't happen
@@ -194,7 +194,7 @@ func TestSavedQueriesPermissions_GetScopeForSavedQueryShared(t *testing.T) {
 	query, err := dbInst.CreateSavedQuery(testCtx, user2.ID, "Test Query", "TESTING", "Example")
 	require.NoError(t, err)
 
-	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user1.ID)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user1.ID)
 	require.NoError(t, err)
 
 	scope, err := dbInst.GetScopeForSavedQuery(testCtx, query.ID, user1.ID)
@@ -218,7 +218,7 @@ func TestSavedQueriesPermissions_GetScopeForSavedQueryOwned(t *testing.T) {
 	query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Example")
 	require.NoError(t, err)
 
-	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID)
 	require.NoError(t, err)
 
 	scope, err := dbInst.GetScopeForSavedQuery(testCtx, query.ID, user1.ID)
@@ -244,7 +244,7 @@ func TestSavedQueriesPermissions_DeleteSavedQueryPermissionsForUsers(t *testing.
 		query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Example")
 		require.NoError(t, err)
 
-		_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID, user3.ID)
+		_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID, user3.ID)
 		require.NoError(t, err)
 
 		scope, err := dbInst.GetScopeForSavedQuery(testCtx, query.ID, user2.ID)
@@ -287,7 +287,7 @@ func TestSavedQueriesPermissions_DeleteSavedQueryPermissionsForUsers(t *testing.
 		query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query2", "TESTING2", "Example2")
 		require.NoError(t, err)
 
-		_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID)
+		_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID)
 		require.NoError(t, err)
 
 		scope, err := dbInst.GetScopeForSavedQuery(testCtx, query.ID, user2.ID)
@@ -337,7 +337,7 @@ func TestSavedQueriesPermissions_IsSavedQuerySharedToUser(t *testing.T) {
 	query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Example")
 	require.NoError(t, err)
 
-	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user1.ID)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user1.ID)
 	require.NoError(t, err)
 
 	isShared, err := dbInst.IsSavedQuerySharedToUser(testCtx, query.ID, user1.ID)
@@ -365,7 +365,7 @@ func TestSavedQueriesPermissions_GetSavedQueryPermissions(t *testing.T) {
 
 	query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Test Description")
 	require.NoError(t, err)
-	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, user2.ID)
+	_, err = dbInst.CreateSavedQueryPermissionsToUsers(testCtx, query.ID, false, user2.ID)
 	require.NoError(t, err)
 	actualSavedQueryPermissions, err := dbInst.GetSavedQueryPermissions(testCtx, query.ID)
 	require.NoError(t, err)
@@ -376,3 +376,47 @@ func TestSavedQueriesPermissions_GetSavedQueryPermissions(t *testing.T) {
 		assert.Equal(t, expectedSavedQueryPermissions[idx], actualSavedQueryPermissions[idx])
 	}
 }
+
+func TestSavedQueriesPermissions_CreateSavedQueryPermissionsToRoles(t *testing.T) {
+	var (
+		testCtx = context.Background()
+		dbInst  = integration.SetupDB(t)
+		user1   = createUser(t, dbInst, userPrincipal)
+		user2   = createUser(t, dbInst, user2Principal)
+		roleID  = user2.Roles[0].ID
+	)
+
+	query, err := dbInst.CreateSavedQuery(testCtx, user1.ID, "Test Query", "TESTING", "Example")
+	require.NoError(t, err)
+
+	_, err = dbInst.CreateSavedQueryPermissionsToRoles(testCtx, query.ID, false, roleID)
+	require.NoError(t, err)
+
+	shared, err := dbInst.IsSavedQuerySharedToUserOrPublic(testCtx, query.ID, user2.ID)
+	require.NoError(t, err)
+	assert.True(t, shared)
+
+	editable, err := dbInst.IsSavedQueryEditableByUser(testCtx, query.ID, user2.ID)
+	require.NoError(t, err)
+	assert.False(t, editable)
+
+	// Sharing to the role again updates its edit rights
+	_, err = dbInst.CreateSavedQueryPermissionsToRoles(testCtx, query.ID, true, roleID)
+	require.NoError(t, err)
+
+	editable, err = dbInst.IsSavedQueryEditableByUser(testCtx, query.ID, user2.ID)
+	require.NoError(t, err)
+	assert.True(t, editable)
+
+	permissions, err := dbInst.GetSavedQueryPermissions(testCtx, query.ID)
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	assert.Equal(t, roleID, permissions[0].SharedToRoleID.Int32)
+
+	err = dbInst.DeleteSavedQueryPermissionsForRoles(testCtx, query.ID, roleID)
+	require.NoError(t, err)
+
+	shared, err = dbInst.IsSavedQuerySharedToUserOrPublic(testCtx, query.ID, user2.ID)
+	require.NoError(t, err)
+	assert.False(t, shared)
+}
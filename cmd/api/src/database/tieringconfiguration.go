@@ -0,0 +1,274 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"gorm.io/gorm"
+)
+
+// TieringConfigurationData defines the methods required to export and import the asset group tag configuration
+type TieringConfigurationData interface {
+	GetTieringConfiguration(ctx context.Context) (model.TieringConfiguration, error)
+	ImportTieringConfiguration(ctx context.Context, user model.User, desired model.TieringConfiguration, prune bool) (model.TieringConfigurationPlan, error)
+}
+
+// getTieringState returns every asset group tag, tiers first in position order, along with their selectors keyed by tag id
+func (s *BloodhoundDB) getTieringState(ctx context.Context) (model.AssetGroupTags, map[int]model.AssetGroupTagSelectors, error) {
+	var (
+		tags             model.AssetGroupTags
+		selectorsByTagId = map[int]model.AssetGroupTagSelectors{}
+	)
+
+	if tiers, err := s.GetOrderedAssetGroupTagTiers(ctx); err != nil {
+		return nil, nil, err
+	} else if others, err := s.GetAssetGroupTags(ctx, model.SQLFilter{SQLString: "type <> ?", Params: []any{model.AssetGroupTagTypeTier}}); err != nil {
+		return nil, nil, err
+	} else {
+		tags = append(append(tags, tiers...), others...)
+	}
+
+	for _, tag := range tags {
+		if selectors, _, err := s.GetAssetGroupTagSelectorsByTagId(ctx, tag.ID, model.SQLFilter{}, model.SQLFilter{}, 0, 0); err != nil {
+			return nil, nil, err
+		} else {
+			selectorsByTagId[tag.ID] = selectors
+		}
+	}
+
+	return tags, selectorsByTagId, nil
+}
+
+func (s *BloodhoundDB) GetTieringConfiguration(ctx context.Context) (model.TieringConfiguration, error) {
+	if tags, selectorsByTagId, err := s.getTieringState(ctx); err != nil {
+		return model.TieringConfiguration{}, err
+	} else {
+		return model.NewTieringConfiguration(tags, selectorsByTagId), nil
+	}
+}
+
+// ImportTieringConfiguration plans the changes required to reach the desired configuration and applies them in a single
+// transaction. Every created, changed or deleted tag and selector writes its own audit entry in addition to the entry
+// for the import as a whole.
+func (s *BloodhoundDB) ImportTieringConfiguration(ctx context.Context, user model.User, desired model.TieringConfiguration, prune bool) (model.TieringConfigurationPlan, error) {
+	var (
+		plan       model.TieringConfigurationPlan
+		auditEntry = model.AuditEntry{
+			Action: model.AuditLogActionImportTieringConfiguration,
+			Model:  &plan, // Pointer is required to ensure success log contains updated fields after transaction
+		}
+	)
+
+	if err := s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		bhdb := NewBloodhoundDB(tx, s.idResolver)
+
+		tags, selectorsByTagId, err := bhdb.getTieringState(ctx)
+		if err != nil {
+			return err
+		} else if plan, err = model.PlanTieringConfiguration(model.NewTieringConfiguration(tags, selectorsByTagId), desired, prune); err != nil {
+			return err
+		}
+
+		var (
+			tagsByName    = make(map[string]model.AssetGroupTag, len(tags))
+			desiredByName = make(map[string]model.TieringConfigurationTag, len(desired.Tags))
+		)
+
+		for _, tag := range tags {
+			tagsByName[tag.Name] = tag
+		}
+		for _, tag := range desired.Tags {
+			desiredByName[tag.Name] = tag
+		}
+
+		findSelector := func(tag model.AssetGroupTag, name string) (model.AssetGroupTagSelector, error) {
+			for _, selector := range selectorsByTagId[tag.ID] {
+				if selector.Name == name {
+					return selector, nil
+				}
+			}
+			return model.AssetGroupTagSelector{}, fmt.Errorf("selector %s not found in tag %s", name, tag.Name)
+		}
+
+		findDesiredSelector := func(tagName, name string) model.TieringConfigurationSelector {
+			for _, selector := range desiredByName[tagName].Selectors {
+				if selector.Name == name {
+					return selector
+				}
+			}
+			return model.TieringConfigurationSelector{}
+		}
+
+		for _, change := range plan.Changes {
+			desiredTag := desiredByName[change.Tag]
+
+			switch {
+			case change.Object == model.TieringConfigurationObjectTag && change.Action == model.TieringConfigurationActionCreate:
+				tagType, _ := model.ParseAssetGroupTagType(desiredTag.Type)
+
+				var requireCertify null.Bool
+				if tagType == model.AssetGroupTagTypeTier {
+					requireCertify = null.BoolFrom(desiredTag.RequireCertify != nil && *desiredTag.RequireCertify)
+				}
+
+				if tag, err := bhdb.CreateAssetGroupTag(ctx, tagType, user, desiredTag.Name, desiredTag.Description, null.Int32{}, requireCertify); err != nil {
+					return err
+				} else if desiredTag.AnalysisEnabled != nil && *desiredTag.AnalysisEnabled {
+					// New tiers are always created with analysis disabled
+					tag.AnalysisEnabled = null.BoolFrom(true)
+					if tagsByName[tag.Name], err = bhdb.UpdateAssetGroupTag(ctx, user, tag); err != nil {
+						return err
+					}
+				} else {
+					tagsByName[tag.Name] = tag
+				}
+
+			case change.Object == model.TieringConfigurationObjectTag && change.Action == model.TieringConfigurationActionUpdate:
+				// Refetch the tag since positions may have shifted while applying earlier changes. Position changes
+				// are applied once all other changes are done.
+				if tag, err := bhdb.GetAssetGroupTag(ctx, tagsByName[change.Tag].ID); err != nil {
+					return err
+				} else {
+					tag.Description = desiredTag.Description
+					if tag.Type == model.AssetGroupTagTypeTier {
+						if desiredTag.RequireCertify != nil {
+							tag.RequireCertify = null.BoolFrom(*desiredTag.RequireCertify)
+						}
+						if desiredTag.AnalysisEnabled != nil {
+							tag.AnalysisEnabled = null.BoolFrom(*desiredTag.AnalysisEnabled)
+						}
+					}
+
+					if tagsByName[tag.Name], err = bhdb.UpdateAssetGroupTag(ctx, user, tag); err != nil {
+						return err
+					}
+				}
+
+			case change.Object == model.TieringConfigurationObjectTag && change.Action == model.TieringConfigurationActionDelete:
+				if tag, err := bhdb.GetAssetGroupTag(ctx, tagsByName[change.Tag].ID); err != nil {
+					return err
+				} else if err := bhdb.DeleteAssetGroupTag(ctx, user, tag); err != nil {
+					return err
+				}
+
+			case change.Object == model.TieringConfigurationObjectSelector && change.Action == model.TieringConfigurationActionCreate:
+				desiredSelector := findDesiredSelector(change.Tag, change.Selector)
+
+				if selector, err := bhdb.CreateAssetGroupTagSelector(ctx, tagsByName[change.Tag].ID, user, desiredSelector.Name, desiredSelector.Description, false, true, null.BoolFrom(desiredSelector.AutoCertify), desiredSelector.ToSelectorSeeds()); err != nil {
+					return err
+				} else if desiredSelector.Disabled {
+					selector.DisabledAt = null.TimeFrom(time.Now())
+					selector.DisabledBy = null.StringFrom(user.ID.String())
+					selector.Seeds = nil
+					if _, err := bhdb.UpdateAssetGroupTagSelector(ctx, user.ID.String(), user.EmailAddress.ValueOrZero(), selector); err != nil {
+						return err
+					}
+				}
+
+			case change.Object == model.TieringConfigurationObjectSelector && change.Action == model.TieringConfigurationActionUpdate:
+				desiredSelector := findDesiredSelector(change.Tag, change.Selector)
+
+				if selector, err := findSelector(tagsByName[change.Tag], change.Selector); err != nil {
+					return err
+				} else {
+					// Seeds are only rewritten when they changed
+					seeds := selector.Seeds
+					selector.Seeds = nil
+					if !selector.IsDefault {
+						selector.Description = desiredSelector.Description
+						if !seedsMatch(seeds, desiredSelector.ToSelectorSeeds()) {
+							selector.Seeds = desiredSelector.ToSelectorSeeds()
+						}
+					}
+
+					selector.AutoCertify = null.BoolFrom(desiredSelector.AutoCertify)
+					if desiredSelector.Disabled && !selector.DisabledAt.Valid {
+						selector.DisabledAt = null.TimeFrom(time.Now())
+						selector.DisabledBy = null.StringFrom(user.ID.String())
+					} else if !desiredSelector.Disabled {
+						selector.DisabledAt = null.Time{}
+						selector.DisabledBy = null.String{}
+					}
+
+					if _, err := bhdb.UpdateAssetGroupTagSelector(ctx, user.ID.String(), user.EmailAddress.ValueOrZero(), selector); err != nil {
+						return err
+					}
+				}
+
+			case change.Object == model.TieringConfigurationObjectSelector && change.Action == model.TieringConfigurationActionDelete:
+				if selector, err := findSelector(tagsByName[change.Tag], change.Selector); err != nil {
+					return err
+				} else if err := bhdb.DeleteAssetGroupTagSelector(ctx, user, selector); err != nil {
+					return err
+				}
+			}
+		}
+
+		if currentTiers, err := bhdb.GetOrderedAssetGroupTagTiers(ctx); err != nil {
+			return err
+		} else {
+			tiersByName := make(map[string]model.AssetGroupTag, len(currentTiers))
+			for _, tier := range currentTiers {
+				tiersByName[tier.Name] = tier
+			}
+
+			orderedTiers := make(model.AssetGroupTags, 0, len(plan.TierOrder))
+			for _, tierName := range plan.TierOrder {
+				if tier, ok := tiersByName[tierName]; !ok {
+					return fmt.Errorf("%w: tier %s not found", ErrInvalidTierOrder, tierName)
+				} else {
+					orderedTiers = append(orderedTiers, tier)
+				}
+			}
+
+			if len(orderedTiers) != len(currentTiers) {
+				return fmt.Errorf("%w: expected %d tiers but the configuration orders %d", ErrInvalidTierOrder, len(currentTiers), len(orderedTiers))
+			}
+
+			return bhdb.UpdateTierPositions(ctx, user, orderedTiers)
+		}
+	}); err != nil {
+		return model.TieringConfigurationPlan{}, err
+	}
+
+	return plan, nil
+}
+
+func seedsMatch(a, b []model.SelectorSeed) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[model.SelectorSeed]int, len(a))
+	for _, seed := range a {
+		counts[model.SelectorSeed{Type: seed.Type, Value: seed.Value}]++
+	}
+	for _, seed := range b {
+		key := model.SelectorSeed{Type: seed.Type, Value: seed.Value}
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/cmd/api/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_ImportTieringConfiguration(t *testing.T) {
+	var (
+		testCtx   = context.Background()
+		testActor = model.User{Unique: model.Unique{ID: uuid.FromStringOrNil("01234567-9012-4567-9012-456789012345")}}
+	)
+
+	t.Run("exported configuration imports without changes", func(t *testing.T) {
+		dbInst := integration.SetupDB(t)
+
+		config, err := dbInst.GetTieringConfiguration(testCtx)
+		require.NoError(t, err)
+		require.NotEmpty(t, config.Tags)
+
+		plan, err := dbInst.ImportTieringConfiguration(testCtx, testActor, config, true)
+		require.NoError(t, err)
+		require.Empty(t, plan.Changes)
+	})
+
+	t.Run("applies creates, updates, deletes and tier order", func(t *testing.T) {
+		dbInst := integration.SetupDB(t)
+
+		existing, err := dbInst.CreateAssetGroupTag(testCtx, model.AssetGroupTagTypeTier, testActor, "existing tier", "", null.Int32{}, null.BoolFrom(false))
+		require.NoError(t, err)
+		_, err = dbInst.CreateAssetGroupTagSelector(testCtx, existing.ID, testActor, "stale", "", false, true, null.BoolFrom(false), []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "S-1-1"}})
+		require.NoError(t, err)
+
+		config, err := dbInst.GetTieringConfiguration(testCtx)
+		require.NoError(t, err)
+
+		// Tiers are ordered by document order when no positions are given, so the new tier goes before the existing one
+		var desired = model.TieringConfiguration{Version: model.TieringConfigurationVersion}
+		for _, tag := range config.Tags {
+			tag.Position = nil
+			if tag.Name == existing.Name {
+				desired.Tags = append(desired.Tags, model.TieringConfigurationTag{
+					Name:      "new tier",
+					Type:      "tier",
+					Selectors: []model.TieringConfigurationSelector{{Name: "admins", AutoCertify: true, Seeds: []model.TieringConfigurationSeed{{Type: "object_id", Value: "S-1-3"}}}},
+				})
+
+				tag.Description = "updated"
+				tag.Selectors = []model.TieringConfigurationSelector{{Name: "fresh", Disabled: true, Seeds: []model.TieringConfigurationSeed{{Type: "object_id", Value: "S-1-2"}}}}
+			}
+			desired.Tags = append(desired.Tags, tag)
+		}
+
+		plan, err := dbInst.ImportTieringConfiguration(testCtx, testActor, desired, true)
+		require.NoError(t, err)
+		require.NotEmpty(t, plan.Changes)
+
+		tiers, err := dbInst.GetOrderedAssetGroupTagTiers(testCtx)
+		require.NoError(t, err)
+		require.Len(t, tiers, 3)
+		require.Equal(t, "new tier", tiers[1].Name)
+		require.Equal(t, existing.Name, tiers[2].Name)
+		require.Equal(t, "updated", tiers[2].Description)
+
+		selectors, _, err := dbInst.GetAssetGroupTagSelectorsByTagId(testCtx, existing.ID, model.SQLFilter{}, model.SQLFilter{}, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, selectors, 1)
+		require.Equal(t, "fresh", selectors[0].Name)
+		require.True(t, selectors[0].DisabledAt.Valid)
+
+		auditLogs, _, err := dbInst.ListAuditLogs(testCtx, time.Now().Add(time.Hour), time.Now().Add(-time.Hour), 0, 100, "", model.SQLFilter{SQLString: "action = ? AND status = ?", Params: []any{model.AuditLogActionCreateAssetGroupTagSelector, model.AuditLogStatusSuccess}})
+		require.NoError(t, err)
+		require.Len(t, auditLogs, 3)
+	})
+}
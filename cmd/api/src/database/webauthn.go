@@ -0,0 +1,120 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"gorm.io/gorm"
+)
+
+// ErrWebAuthnCeremonyConsumed is returned when the challenge of a WebAuthn ceremony is answered more than once
+var ErrWebAuthnCeremonyConsumed = errors.New("webauthn ceremony has already been used")
+
+// WebAuthnCredentialData defines the methods required to interact with the webauthn_credentials and
+// webauthn_consumed_ceremonies tables
+type WebAuthnCredentialData interface {
+	CreateWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) (model.WebAuthnCredential, error)
+	GetWebAuthnCredentials(ctx context.Context, userID uuid.UUID) (model.WebAuthnCredentials, error)
+	GetWebAuthnCredential(ctx context.Context, userID uuid.UUID, id int32) (model.WebAuthnCredential, error)
+	UpdateWebAuthnCredentialUsage(ctx context.Context, credential model.WebAuthnCredential) error
+	DeleteWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) error
+	ConsumeWebAuthnCeremony(ctx context.Context, ceremonyID string, expiresAt time.Time) error
+	SweepWebAuthnCeremonies(ctx context.Context)
+}
+
+// CreateWebAuthnCredential registers a new WebAuthn credential for a user
+// INSERT INTO webauthn_credentials (...) VALUES (...)
+func (s *BloodhoundDB) CreateWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) (model.WebAuthnCredential, error) {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionCreateWebAuthnCredential,
+		Model:  &credential, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	return credential, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Create(&credential))
+	})
+}
+
+// GetWebAuthnCredentials returns all WebAuthn credentials registered by a user
+// SELECT * FROM webauthn_credentials WHERE user_id = ... ORDER BY id
+func (s *BloodhoundDB) GetWebAuthnCredentials(ctx context.Context, userID uuid.UUID) (model.WebAuthnCredentials, error) {
+	var (
+		credentials = model.WebAuthnCredentials{}
+		result      = s.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&credentials)
+	)
+
+	return credentials, CheckError(result)
+}
+
+// GetWebAuthnCredential retrieves a WebAuthn credential registered by the given user
+// SELECT * FROM webauthn_credentials WHERE user_id = ... AND id = ...
+func (s *BloodhoundDB) GetWebAuthnCredential(ctx context.Context, userID uuid.UUID, id int32) (model.WebAuthnCredential, error) {
+	var (
+		credential model.WebAuthnCredential
+		result     = s.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).First(&credential)
+	)
+
+	return credential, CheckError(result)
+}
+
+// UpdateWebAuthnCredentialUsage records the signature counter and last use of a credential after a successful assertion.
+// Credential use is audited by the login flow rather than here.
+// UPDATE webauthn_credentials SET sign_count = ..., last_used_at = ... WHERE id = ...
+func (s *BloodhoundDB) UpdateWebAuthnCredentialUsage(ctx context.Context, credential model.WebAuthnCredential) error {
+	return CheckError(s.db.WithContext(ctx).Model(&credential).Select("sign_count", "last_used_at", "updated_at").Updates(&credential))
+}
+
+// DeleteWebAuthnCredential removes a WebAuthn credential
+// DELETE FROM webauthn_credentials WHERE id = ...
+func (s *BloodhoundDB) DeleteWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionDeleteWebAuthnCredential,
+		Model:  &credential,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.WithContext(ctx).Where("id = ?", credential.ID).Delete(&model.WebAuthnCredential{}))
+	})
+}
+
+// ConsumeWebAuthnCeremony marks a WebAuthn ceremony as answered. Ceremony tokens are stateless, so this is what keeps a
+// challenge from being answered twice; ErrWebAuthnCeremonyConsumed is returned if the ceremony was already consumed.
+// INSERT INTO webauthn_consumed_ceremonies (id, expires_at) VALUES (...) ON CONFLICT DO NOTHING
+func (s *BloodhoundDB) ConsumeWebAuthnCeremony(ctx context.Context, ceremonyID string, expiresAt time.Time) error {
+	result := s.db.WithContext(ctx).Exec(
+		`INSERT INTO webauthn_consumed_ceremonies (id, expires_at) VALUES (?, ?) ON CONFLICT DO NOTHING`,
+		ceremonyID, expiresAt.UTC(),
+	)
+
+	if result.Error != nil {
+		return CheckError(result)
+	} else if result.RowsAffected == 0 {
+		return ErrWebAuthnCeremonyConsumed
+	}
+
+	return nil
+}
+
+// SweepWebAuthnCeremonies deletes consumed ceremonies whose tokens have expired and can no longer be presented
+func (s *BloodhoundDB) SweepWebAuthnCeremonies(ctx context.Context) {
+	s.db.WithContext(ctx).Exec(`DELETE FROM webauthn_consumed_ceremonies WHERE expires_at < NOW()`)
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"slices"
 	"time"
 
 	iso8601 "github.com/channelmeter/iso8601duration"
@@ -42,6 +43,13 @@ const (
 	CitrixRDPSupportKey      ParameterKey = "analysis.citrix_rdp_support"
 	PruneTTL                 ParameterKey = "prune.ttl"
 	ReconciliationKey        ParameterKey = "analysis.reconciliation"
+	CertificationEnforcement ParameterKey = "analysis.certification_enforcement"
+	TierViolationsKey        ParameterKey = "analysis.tier_violations"
+	GraphSnapshotsKey        ParameterKey = "analysis.graph_snapshots"
+	ExposureMetricsKey       ParameterKey = "analysis.exposure_metrics"
+	WebAuthnKey              ParameterKey = "auth.webauthn"
+	PasswordPolicyKey        ParameterKey = "auth.password_policy"
+	AccountLockoutKey        ParameterKey = "auth.account_lockout"
 
 	// The below keys are not intended to be user updateable, so should not be added to IsValidKey
 	ScheduledAnalysis          ParameterKey = "analysis.scheduled"
@@ -53,6 +61,11 @@ const (
 const (
 	DefaultPasswordExpirationWindow = time.Hour * 24 * 90
 
+	DefaultPasswordMinimumLength   = 12
+	MaxPasswordHistoryCount        = 24
+	DefaultAccountLockoutThreshold = 5
+	DefaultAccountLockoutDuration  = time.Minute * 15
+
 	DefaultSessionTTLHours = 8
 
 	DefaultPruneBaseTTL           = time.Hour * 24 * 7
@@ -60,6 +73,12 @@ const (
 
 	DefaultTierLimit  = 1
 	DefaultLabelLimit = 0
+
+	DefaultTierViolationsMaxDepth = 1
+	MaxTierViolationsMaxDepth     = 5
+
+	DefaultGraphSnapshotRetention = 7
+	MaxGraphSnapshotRetention     = 90
 )
 
 // Parameter is a runtime configuration parameter that can be fetched from the appcfg.ParameterService interface. The
@@ -82,7 +101,7 @@ func (s *Parameter) Map(value any) error {
 
 func (s *Parameter) IsValidKey(parameterKey ParameterKey) bool {
 	switch parameterKey {
-	case PasswordExpirationWindow, Neo4jConfigs, PruneTTL, CitrixRDPSupportKey, ReconciliationKey:
+	case PasswordExpirationWindow, Neo4jConfigs, PruneTTL, CitrixRDPSupportKey, ReconciliationKey, CertificationEnforcement, TierViolationsKey, GraphSnapshotsKey, ExposureMetricsKey, WebAuthnKey, PasswordPolicyKey, AccountLockoutKey:
 		return true
 	default:
 		return false
@@ -123,6 +142,20 @@ func (s *Parameter) Validate() utils.Errors {
 		v = &CitrixRDPSupport{}
 	case ReconciliationKey:
 		v = &ReconciliationParameter{}
+	case CertificationEnforcement:
+		v = &CertificationEnforcementParameter{}
+	case TierViolationsKey:
+		v = &TierViolationsParameter{}
+	case GraphSnapshotsKey:
+		v = &GraphSnapshotsParameter{}
+	case ExposureMetricsKey:
+		v = &ExposureMetricsParameter{}
+	case WebAuthnKey:
+		v = &WebAuthnParameter{}
+	case PasswordPolicyKey:
+		v = &PasswordPolicyParameter{}
+	case AccountLockoutKey:
+		v = &AccountLockoutParameter{}
 	case TierManagementParameterKey:
 		v = &TieringParameters{}
 	case ScheduledAnalysis:
@@ -334,6 +367,228 @@ func GetReconciliationParameter(ctx context.Context, service ParameterService) b
 	return result.Enabled
 }
 
+// Certification Enforcement
+
+// CertificationEnforcementParameter controls whether members of tags that require certification are withheld from
+// the tag until they have been certified.
+type CertificationEnforcementParameter struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func GetCertificationEnforcementParameter(ctx context.Context, service ParameterService) bool {
+	result := CertificationEnforcementParameter{Enabled: true}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, CertificationEnforcement); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch certification enforcement configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid certification enforcement configuration supplied, %v. returning default values.", err))
+	}
+
+	return result.Enabled
+}
+
+// Tier Violations
+
+// TierViolationsParameter controls how far analysis follows attack paths from members of lower tiers when looking for
+// paths into higher tiers. A max depth of 1 only reports direct edges.
+type TierViolationsParameter struct {
+	MaxDepth int `json:"max_depth,omitempty"`
+}
+
+func GetTierViolationsParameter(ctx context.Context, service ParameterService) TierViolationsParameter {
+	result := TierViolationsParameter{MaxDepth: DefaultTierViolationsMaxDepth}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, TierViolationsKey); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch tier violations configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid tier violations configuration supplied, %v. returning default values.", err))
+		result.MaxDepth = DefaultTierViolationsMaxDepth
+	}
+
+	if result.MaxDepth < 1 {
+		result.MaxDepth = DefaultTierViolationsMaxDepth
+	} else if result.MaxDepth > MaxTierViolationsMaxDepth {
+		result.MaxDepth = MaxTierViolationsMaxDepth
+	}
+
+	return result
+}
+
+// Graph Snapshots
+
+// GraphSnapshotsParameter controls how many graph snapshots are kept for diffing once an analysis run completes
+type GraphSnapshotsParameter struct {
+	Retention int `json:"retention,omitempty"`
+}
+
+func GetGraphSnapshotsParameter(ctx context.Context, service ParameterService) GraphSnapshotsParameter {
+	result := GraphSnapshotsParameter{Retention: DefaultGraphSnapshotRetention}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, GraphSnapshotsKey); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch graph snapshots configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid graph snapshots configuration supplied, %v. returning default values.", err))
+		result.Retention = DefaultGraphSnapshotRetention
+	}
+
+	if result.Retention < 1 {
+		result.Retention = DefaultGraphSnapshotRetention
+	} else if result.Retention > MaxGraphSnapshotRetention {
+		result.Retention = MaxGraphSnapshotRetention
+	}
+
+	return result
+}
+
+// Exposure Metrics
+
+// ExposureMetricsParameter controls whether analysis computes the inbound exposure of tagged nodes and the outbound
+// impact of principals. The computation walks every traversable edge so it is disabled by default.
+type ExposureMetricsParameter struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func GetExposureMetricsParameter(ctx context.Context, service ParameterService) bool {
+	result := ExposureMetricsParameter{Enabled: false}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, ExposureMetricsKey); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch exposure metrics configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid exposure metrics configuration supplied, %v. returning default values.", err))
+	}
+
+	return result.Enabled
+}
+
+// WebAuthn
+
+// WebAuthnParameter lists the roles whose members must use a WebAuthn credential as their second factor when logging in
+// with a secret. Members of these roles who have not registered a credential yet are limited to managing their own
+// account until they do.
+type WebAuthnParameter struct {
+	RequiredRoleIDs []int32 `json:"required_role_ids"`
+}
+
+// RequiredFor returns true if any of the given roles requires WebAuthn
+func (s WebAuthnParameter) RequiredFor(roles model.Roles) bool {
+	for _, role := range roles {
+		if slices.Contains(s.RequiredRoleIDs, role.ID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func GetWebAuthnParameter(ctx context.Context, service ParameterService) WebAuthnParameter {
+	result := WebAuthnParameter{}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, WebAuthnKey); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch webauthn configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid webauthn configuration supplied, %v. returning default values.", err))
+		result = WebAuthnParameter{}
+	}
+
+	return result
+}
+
+// PasswordPolicy
+
+// PasswordPolicyParameter controls the secrets local users may set. The minimum length can only raise the built-in
+// floor of 12 characters. The history count is the number of most recent secrets, including the current one, that may
+// not be reused. The breached password check rejects secrets found in the breached password file named by the
+// breached_passwords_file configuration value. That file must be a Have I Been Pwned SHA-1 download ordered by hash,
+// with one hex encoded digest and an optional ":<count>" suffix per line, rather than a plain text password list. The
+// server refuses to start when the file does not match this format.
+type PasswordPolicyParameter struct {
+	MinimumLength         int  `json:"minimum_length"`
+	HistoryCount          int  `json:"history_count"`
+	BreachedPasswordCheck bool `json:"breached_password_check"`
+}
+
+// UnmarshalJSON rejects negative lengths and history counts so that they are caught when the parameter is updated
+func (s *PasswordPolicyParameter) UnmarshalJSON(data []byte) error {
+	type passwordPolicy PasswordPolicyParameter
+
+	var pDb passwordPolicy
+
+	if err := json.Unmarshal(data, &pDb); err != nil {
+		return fmt.Errorf("error unmarshaling data for PasswordPolicyParameter: %w", err)
+	} else if pDb.MinimumLength < 0 || pDb.HistoryCount < 0 {
+		return fmt.Errorf("password policy minimum length and history count must not be negative")
+	} else {
+		*s = PasswordPolicyParameter(pDb)
+		return nil
+	}
+}
+
+func GetPasswordPolicyParameter(ctx context.Context, service ParameterService) PasswordPolicyParameter {
+	result := PasswordPolicyParameter{MinimumLength: DefaultPasswordMinimumLength}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, PasswordPolicyKey); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch password policy configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid password policy configuration supplied, %v. returning default values.", err))
+		result = PasswordPolicyParameter{MinimumLength: DefaultPasswordMinimumLength}
+	}
+
+	if result.MinimumLength < DefaultPasswordMinimumLength {
+		result.MinimumLength = DefaultPasswordMinimumLength
+	}
+
+	if result.HistoryCount > MaxPasswordHistoryCount {
+		result.HistoryCount = MaxPasswordHistoryCount
+	}
+
+	return result
+}
+
+// AccountLockout
+
+// AccountLockoutParameter controls how many consecutive failed logins lock a local account and for how long. A
+// threshold of 0 disables lockout.
+type AccountLockoutParameter struct {
+	Threshold int           `json:"threshold"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Because the lockout duration is stored as an ISO string, but we want to use it as a duration, we override
+// UnmarshalJSON to handle the conversion
+func (s *AccountLockoutParameter) UnmarshalJSON(data []byte) error {
+	pDb := struct {
+		Threshold int    `json:"threshold"`
+		Duration  string `json:"duration,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(data, &pDb); err != nil {
+		return fmt.Errorf("error unmarshaling data for AccountLockoutParameter: %w", err)
+	} else if pDb.Threshold < 0 {
+		return fmt.Errorf("account lockout threshold must not be negative")
+	} else if duration, err := iso8601.FromString(pDb.Duration); err != nil {
+		return err
+	} else if duration.ToDuration() <= 0 {
+		return fmt.Errorf("account lockout duration must be positive")
+	} else {
+		s.Threshold = pDb.Threshold
+		s.Duration = duration.ToDuration()
+		return nil
+	}
+}
+
+func GetAccountLockoutParameter(ctx context.Context, service ParameterService) AccountLockoutParameter {
+	result := AccountLockoutParameter{Threshold: DefaultAccountLockoutThreshold, Duration: DefaultAccountLockoutDuration}
+
+	if cfg, err := service.GetConfigurationParameter(ctx, AccountLockoutKey); err != nil {
+		slog.WarnContext(ctx, "Failed to fetch account lockout configuration; returning default values")
+	} else if err := cfg.Map(&result); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("Invalid account lockout configuration supplied, %v. returning default values.", err))
+		result = AccountLockoutParameter{Threshold: DefaultAccountLockoutThreshold, Duration: DefaultAccountLockoutDuration}
+	}
+
+	return result
+}
+
 type ScheduledAnalysisParameter struct {
 	Enabled bool   `json:"enabled,omitempty"`
 	RRule   string `json:"rrule,omitempty" validate:"rrule"`
@@ -131,6 +131,24 @@ func TestParameters_GetReconciliationParameter(t *testing.T) {
 	require.True(t, appcfg.GetReconciliationParameter(context.Background(), integration.SetupDB(t)))
 }
 
+func TestParameters_GetCertificationEnforcementParameter(t *testing.T) {
+	require.True(t, appcfg.GetCertificationEnforcementParameter(context.Background(), integration.SetupDB(t)))
+}
+
+func TestParameters_GetTierViolationsParameter(t *testing.T) {
+	result := appcfg.TierViolationsParameter{MaxDepth: appcfg.DefaultTierViolationsMaxDepth}
+	require.Equal(t, result, appcfg.GetTierViolationsParameter(context.Background(), integration.SetupDB(t)))
+}
+
+func TestParameters_GetGraphSnapshotsParameter(t *testing.T) {
+	result := appcfg.GraphSnapshotsParameter{Retention: appcfg.DefaultGraphSnapshotRetention}
+	require.Equal(t, result, appcfg.GetGraphSnapshotsParameter(context.Background(), integration.SetupDB(t)))
+}
+
+func TestParameters_GetExposureMetricsParameter(t *testing.T) {
+	require.False(t, appcfg.GetExposureMetricsParameter(context.Background(), integration.SetupDB(t)))
+}
+
 func TestParameters_GetTieringParameters(t *testing.T) {
 	result := appcfg.TieringParameters{
 		TierLimit:                appcfg.DefaultTierLimit,
@@ -139,3 +157,13 @@ func TestParameters_GetTieringParameters(t *testing.T) {
 	}
 	require.Equal(t, result, appcfg.GetTieringParameters(context.Background(), integration.SetupDB(t)))
 }
+
+func TestParameters_GetPasswordPolicyParameter(t *testing.T) {
+	result := appcfg.PasswordPolicyParameter{MinimumLength: appcfg.DefaultPasswordMinimumLength}
+	require.Equal(t, result, appcfg.GetPasswordPolicyParameter(context.Background(), integration.SetupDB(t)))
+}
+
+func TestParameters_GetAccountLockoutParameter(t *testing.T) {
+	result := appcfg.AccountLockoutParameter{Threshold: appcfg.DefaultAccountLockoutThreshold, Duration: appcfg.DefaultAccountLockoutDuration}
+	require.Equal(t, result, appcfg.GetAccountLockoutParameter(context.Background(), integration.SetupDB(t)))
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"time"
+
+	"github.com/specterops/dawgs/graph"
+)
+
+// AssetGroupSelectorRun records a single evaluation of a selector along with how its membership changed compared to
+// the previous evaluation. LimitReachedBy is the stage that hit ExpansionLimit, or 0 if selection completed.
+type AssetGroupSelectorRun struct {
+	ID              int64                        `json:"id" gorm:"primaryKey"`
+	SelectorId      int                          `json:"selector_id"`
+	AssetGroupTagId int                          `json:"asset_group_tag_id"`
+	StartedAt       time.Time                    `json:"started_at"`
+	DurationMs      int64                        `json:"duration_ms"`
+	NodeCount       int                          `json:"node_count"`
+	AddedCount      int                          `json:"added_count"`
+	RemovedCount    int                          `json:"removed_count"`
+	ExpansionLimit  int                          `json:"expansion_limit"`
+	LimitReachedBy  AssetGroupSelectorNodeSource `json:"limit_reached_by"`
+	AddedNodeIds    []graph.ID                   `json:"added_node_ids,omitempty" gorm:"-"`
+	RemovedNodeIds  []graph.ID                   `json:"removed_node_ids,omitempty" gorm:"-"`
+}
+
+type AssetGroupSelectorRuns []AssetGroupSelectorRun
+
+func (AssetGroupSelectorRun) TableName() string {
+	return "asset_group_tag_selector_runs"
+}
+
+func (s AssetGroupSelectorRun) IsSortable(criteria string) bool {
+	switch criteria {
+	case "started_at", "duration_ms", "node_count", "added_count", "removed_count":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AssetGroupSelectorRun) IsStringColumn(filter string) bool {
+	return false
+}
+
+func (s AssetGroupSelectorRun) ValidFilters() map[string][]FilterOperator {
+	numericOperators := []FilterOperator{Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals}
+	return map[string][]FilterOperator{
+		"started_at":       numericOperators,
+		"duration_ms":      numericOperators,
+		"node_count":       numericOperators,
+		"added_count":      numericOperators,
+		"removed_count":    numericOperators,
+		"limit_reached_by": {Equals, NotEquals},
+	}
+}
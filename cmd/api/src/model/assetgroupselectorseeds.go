@@ -0,0 +1,115 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+)
+
+// SelectorSeedValueParameter is the name of the cypher parameter that structured seed values are bound to.
+const SelectorSeedValueParameter = "value"
+
+var (
+	ErrInvalidSelectorSeed = errors.New("invalid selector seed")
+
+	selectorSeedIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// IsStructured reports whether seeds of this type are described by a simple value that is translated into a cypher
+// query for evaluation instead of being written as cypher by the user.
+func (s SelectorType) IsStructured() bool {
+	switch s {
+	case SelectorTypeGroupMembers, SelectorTypeOUContainment, SelectorTypePropertyMatch, SelectorTypeKind:
+		return true
+	default:
+		return false
+	}
+}
+
+// StructuredQuery translates a structured seed into a cypher query along with the parameters required to run it. The
+// user supplied value is always bound as a parameter; only property and kind names, which are validated as plain
+// identifiers, are written into the query text.
+func (s SelectorSeed) StructuredQuery() (string, map[string]any, error) {
+	if query, value, err := s.structuredQuery("$" + SelectorSeedValueParameter); err != nil {
+		return "", nil, err
+	} else if value == nil {
+		return query, nil, nil
+	} else {
+		return query, map[string]any{SelectorSeedValueParameter: *value}, nil
+	}
+}
+
+// StructuredQueryShape returns the structured query with its value parameter replaced by an empty string literal.
+// Cypher validation rejects parameters so this form is what gets measured against the query complexity limits.
+func (s SelectorSeed) StructuredQueryShape() (string, error) {
+	query, _, err := s.structuredQuery("''")
+	return query, err
+}
+
+func (s SelectorSeed) structuredQuery(valueExpression string) (string, *string, error) {
+	value := strings.TrimSpace(s.Value)
+
+	if value == "" {
+		return "", nil, fmt.Errorf("%w: value is required", ErrInvalidSelectorSeed)
+	}
+
+	switch s.Type {
+	case SelectorTypeGroupMembers:
+		// Membership is followed transitively through both AD and Azure group memberships
+		return fmt.Sprintf(
+			"MATCH (n)-[:%s|%s*1..]->(g) WHERE g.%s = %s RETURN n",
+			ad.MemberOf, azure.MemberOf, common.ObjectID, valueExpression,
+		), &value, nil
+
+	case SelectorTypeOUContainment:
+		// Distinguished names are compared case-insensitively as collectors do not agree on casing
+		upperValue := strings.ToUpper(value)
+		return fmt.Sprintf(
+			"MATCH (o)-[:%s*1..]->(n) WHERE toUpper(o.%s) = %s RETURN n",
+			ad.Contains, ad.DistinguishedName, valueExpression,
+		), &upperValue, nil
+
+	case SelectorTypePropertyMatch:
+		if property, pattern, found := strings.Cut(value, "="); !found {
+			return "", nil, fmt.Errorf("%w: property match must be in the form property=regex", ErrInvalidSelectorSeed)
+		} else if property = strings.TrimSpace(property); !selectorSeedIdentifierPattern.MatchString(property) {
+			return "", nil, fmt.Errorf("%w: invalid property name %q", ErrInvalidSelectorSeed, property)
+		} else if pattern == "" {
+			return "", nil, fmt.Errorf("%w: property match requires a regex", ErrInvalidSelectorSeed)
+		} else if _, err := regexp.Compile(pattern); err != nil {
+			return "", nil, fmt.Errorf("%w: invalid regex: %v", ErrInvalidSelectorSeed, err)
+		} else {
+			return fmt.Sprintf("MATCH (n) WHERE n.%s =~ %s RETURN n", property, valueExpression), &pattern, nil
+		}
+
+	case SelectorTypeKind:
+		if !selectorSeedIdentifierPattern.MatchString(value) {
+			return "", nil, fmt.Errorf("%w: invalid kind %q", ErrInvalidSelectorSeed, value)
+		}
+		return fmt.Sprintf("MATCH (n:%s) RETURN n", value), nil, nil
+
+	default:
+		return "", nil, fmt.Errorf("%w: seed type %d is not a structured seed type", ErrInvalidSelectorSeed, s.Type)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorType_IsStructured(t *testing.T) {
+	assert.False(t, model.SelectorTypeObjectId.IsStructured())
+	assert.False(t, model.SelectorTypeCypher.IsStructured())
+	assert.True(t, model.SelectorTypeGroupMembers.IsStructured())
+	assert.True(t, model.SelectorTypeOUContainment.IsStructured())
+	assert.True(t, model.SelectorTypePropertyMatch.IsStructured())
+	assert.True(t, model.SelectorTypeKind.IsStructured())
+}
+
+func TestSelectorSeed_StructuredQuery(t *testing.T) {
+	type testData struct {
+		name           string
+		seed           model.SelectorSeed
+		expectedQuery  string
+		expectedParams map[string]any
+		expectedErr    string
+	}
+
+	tests := []testData{
+		{
+			name:           "Group members",
+			seed:           model.SelectorSeed{Type: model.SelectorTypeGroupMembers, Value: " S-1-5-21-1-512 "},
+			expectedQuery:  "MATCH (n)-[:MemberOf|AZMemberOf*1..]->(g) WHERE g.objectid = $value RETURN n",
+			expectedParams: map[string]any{"value": "S-1-5-21-1-512"},
+		},
+		{
+			name:           "OU containment",
+			seed:           model.SelectorSeed{Type: model.SelectorTypeOUContainment, Value: "OU=Servers,DC=corp,DC=local"},
+			expectedQuery:  "MATCH (o)-[:Contains*1..]->(n) WHERE toUpper(o.distinguishedname) = $value RETURN n",
+			expectedParams: map[string]any{"value": "OU=SERVERS,DC=CORP,DC=LOCAL"},
+		},
+		{
+			name:           "Property match",
+			seed:           model.SelectorSeed{Type: model.SelectorTypePropertyMatch, Value: "samaccountname=^svc_.*'; DROP"},
+			expectedQuery:  "MATCH (n) WHERE n.samaccountname =~ $value RETURN n",
+			expectedParams: map[string]any{"value": "^svc_.*'; DROP"},
+		},
+		{
+			name:          "Kind",
+			seed:          model.SelectorSeed{Type: model.SelectorTypeKind, Value: "GithubRepository"},
+			expectedQuery: "MATCH (n:GithubRepository) RETURN n",
+		},
+		{
+			name:        "Empty value",
+			seed:        model.SelectorSeed{Type: model.SelectorTypeGroupMembers, Value: " "},
+			expectedErr: "value is required",
+		},
+		{
+			name:        "Property match without separator",
+			seed:        model.SelectorSeed{Type: model.SelectorTypePropertyMatch, Value: "samaccountname"},
+			expectedErr: "property=regex",
+		},
+		{
+			name:        "Property match with invalid property",
+			seed:        model.SelectorSeed{Type: model.SelectorTypePropertyMatch, Value: "name) RETURN n//=.*"},
+			expectedErr: "invalid property name",
+		},
+		{
+			name:        "Property match with invalid regex",
+			seed:        model.SelectorSeed{Type: model.SelectorTypePropertyMatch, Value: "name=(["},
+			expectedErr: "invalid regex",
+		},
+		{
+			name:        "Invalid kind",
+			seed:        model.SelectorSeed{Type: model.SelectorTypeKind, Value: "User) DETACH DELETE (n"},
+			expectedErr: "invalid kind",
+		},
+		{
+			name:        "Not a structured type",
+			seed:        model.SelectorSeed{Type: model.SelectorTypeCypher, Value: "MATCH (n) RETURN n"},
+			expectedErr: "not a structured seed type",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			query, params, err := testCase.seed.StructuredQuery()
+			if testCase.expectedErr != "" {
+				require.ErrorIs(t, err, model.ErrInvalidSelectorSeed)
+				assert.ErrorContains(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedQuery, query)
+				assert.Equal(t, testCase.expectedParams, params)
+			}
+		})
+	}
+}
+
+func TestSelectorSeed_StructuredQueryShape(t *testing.T) {
+	query, err := model.SelectorSeed{Type: model.SelectorTypePropertyMatch, Value: "name=^ADMIN"}.StructuredQueryShape()
+	require.NoError(t, err)
+	assert.Equal(t, "MATCH (n) WHERE n.name =~ '' RETURN n", query)
+}
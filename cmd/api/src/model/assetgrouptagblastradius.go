@@ -0,0 +1,178 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/dawgs/graph"
+)
+
+type BlastRadiusReportStatus string
+
+const (
+	BlastRadiusReportStatusPending  BlastRadiusReportStatus = "pending"
+	BlastRadiusReportStatusRunning  BlastRadiusReportStatus = "running"
+	BlastRadiusReportStatusComplete BlastRadiusReportStatus = "complete"
+	BlastRadiusReportStatusFailed   BlastRadiusReportStatus = "failed"
+)
+
+// BlastRadiusReport is a request to compute everything the members of the owned tag can reach. Reports are generated
+// asynchronously by the datapipe; when WaitForAnalysis is set the report is held until an analysis run completes after
+// the report was requested so that newly owned principals have been tagged.
+type BlastRadiusReport struct {
+	ID                     int64                   `json:"id" gorm:"primaryKey"`
+	Status                 BlastRadiusReportStatus `json:"status"`
+	StatusMessage          string                  `json:"status_message"`
+	WaitForAnalysis        bool                    `json:"wait_for_analysis"`
+	RequestedBy            string                  `json:"requested_by"`
+	OwnedCount             int                     `json:"owned_count"`
+	ReachableCount         int                     `json:"reachable_count"`
+	TierZeroReachableCount int                     `json:"tier_zero_reachable_count"`
+	Result                 types.JSONBObject       `json:"-"`
+	CreatedAt              time.Time               `json:"created_at"`
+	StartedAt              null.Time               `json:"started_at"`
+	CompletedAt            null.Time               `json:"completed_at"`
+}
+
+type BlastRadiusReports []BlastRadiusReport
+
+func (BlastRadiusReport) TableName() string {
+	return "asset_group_tag_blast_radius_reports"
+}
+
+func (s BlastRadiusReport) IsSortable(criteria string) bool {
+	switch criteria {
+	case "created_at", "completed_at", "reachable_count", "tier_zero_reachable_count":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s BlastRadiusReport) IsStringColumn(filter string) bool {
+	return filter == "status"
+}
+
+func (s BlastRadiusReport) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"status":     {Equals, NotEquals},
+		"created_at": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+	}
+}
+
+// BlastRadiusNode identifies a node in a blast radius report
+type BlastRadiusNode struct {
+	ID       graph.ID `json:"id"`
+	ObjectId string   `json:"object_id"`
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+}
+
+// BlastRadiusTier lists the members of a tier that are reachable from owned principals
+type BlastRadiusTier struct {
+	TagId          int               `json:"tag_id"`
+	Name           string            `json:"name"`
+	Position       int32             `json:"position"`
+	ReachableCount int               `json:"reachable_count"`
+	Nodes          []BlastRadiusNode `json:"nodes"`
+}
+
+// BlastRadiusPrincipal is an owned principal along with how much of the graph it can reach
+type BlastRadiusPrincipal struct {
+	BlastRadiusNode
+	ReachableCount         int `json:"reachable_count"`
+	TierZeroReachableCount int `json:"tier_zero_reachable_count"`
+}
+
+// BlastRadiusPath is the shortest path from an owned principal to a Tier Zero node. EdgeKinds[i] connects Nodes[i] to
+// Nodes[i+1].
+type BlastRadiusPath struct {
+	Target    BlastRadiusNode   `json:"target"`
+	Hops      int               `json:"hops"`
+	Nodes     []BlastRadiusNode `json:"nodes"`
+	EdgeKinds []string          `json:"edge_kinds"`
+}
+
+// BlastRadiusResult is the content of a completed blast radius report
+type BlastRadiusResult struct {
+	OwnedCount             int                    `json:"owned_count"`
+	ReachableCount         int                    `json:"reachable_count"`
+	UntieredReachableCount int                    `json:"untiered_reachable_count"`
+	Tiers                  []BlastRadiusTier      `json:"tiers"`
+	Owned                  []BlastRadiusPrincipal `json:"owned"`
+	TierZeroPaths          []BlastRadiusPath      `json:"tier_zero_paths"`
+}
+
+// WriteCSV writes one row per reachable tier member. Tier Zero rows carry their shortest path.
+func (s BlastRadiusResult) WriteCSV(writer io.Writer) error {
+	var (
+		csvWriter = csv.NewWriter(writer)
+		paths     = make(map[graph.ID]BlastRadiusPath, len(s.TierZeroPaths))
+	)
+
+	for _, path := range s.TierZeroPaths {
+		paths[path.Target.ID] = path
+	}
+
+	if err := csvWriter.Write([]string{"tier", "tier_position", "node_id", "object_id", "name", "kind", "hops", "path"}); err != nil {
+		return err
+	}
+
+	for _, tier := range s.Tiers {
+		for _, node := range tier.Nodes {
+			var hops, pathString string
+
+			if path, ok := paths[node.ID]; ok {
+				hops = strconv.Itoa(path.Hops)
+				pathString = path.String()
+			}
+
+			if err := csvWriter.Write([]string{tier.Name, strconv.Itoa(int(tier.Position)), node.ID.String(), node.ObjectId, node.Name, node.Kind, hops, pathString}); err != nil {
+				return err
+			}
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// String renders the path as name -[Kind]-> name
+func (s BlastRadiusPath) String() string {
+	var builder strings.Builder
+
+	for idx, node := range s.Nodes {
+		if idx > 0 {
+			builder.WriteString(" -[" + s.EdgeKinds[idx-1] + "]-> ")
+		}
+
+		if node.Name != "" {
+			builder.WriteString(node.Name)
+		} else {
+			builder.WriteString(node.ObjectId)
+		}
+	}
+
+	return builder.String()
+}
@@ -34,13 +34,18 @@ const (
 	AssetGroupActorSystem              = "SYSTEM"
 	AssetGroupTierZeroPosition         = 1
 	AssetGroupTierHygienePlaceholderId = 0
+	AssetGroupTagKindPrefix            = "Tag_"
 )
 
 type SelectorType int
 
 const (
-	SelectorTypeObjectId SelectorType = 1
-	SelectorTypeCypher   SelectorType = 2
+	SelectorTypeObjectId      SelectorType = 1
+	SelectorTypeCypher        SelectorType = 2
+	SelectorTypeGroupMembers  SelectorType = 3
+	SelectorTypeOUContainment SelectorType = 4
+	SelectorTypePropertyMatch SelectorType = 5
+	SelectorTypeKind          SelectorType = 6
 )
 
 type AssetGroupTagType int
@@ -68,6 +73,16 @@ const (
 	AssetGroupSelectorNodeSourceParent AssetGroupSelectorNodeSource = 3
 )
 
+const (
+	// AssetGroupSelectorNoExpansionLimit is the expansion limit of a selector without a limit of its own
+	AssetGroupSelectorNoExpansionLimit = -1
+	// AssetGroupSelectorUnprivilegedExpansionLimit is the highest limit that may be set on a selector, or removed from
+	// it, without permission to write the application configuration
+	AssetGroupSelectorUnprivilegedExpansionLimit = 100_000
+	// AssetGroupSelectorMaxExpansionLimit is the highest limit that may be set on a selector
+	AssetGroupSelectorMaxExpansionLimit = 1_000_000
+)
+
 type AssetGroupExpansionMethod int
 
 const (
@@ -118,7 +133,7 @@ func (s AssetGroupTag) ToKind() graph.Kind {
 }
 
 func (s AssetGroupTag) KindName() string {
-	return fmt.Sprintf("Tag_%s", strings.ReplaceAll(s.Name, " ", "_"))
+	return fmt.Sprintf("%s%s", AssetGroupTagKindPrefix, strings.ReplaceAll(s.Name, " ", "_"))
 }
 
 func (s AssetGroupTag) IsStringColumn(filter string) bool {
@@ -206,6 +221,11 @@ type AssetGroupTagSelector struct {
 	AutoCertify     null.Bool   `json:"auto_certify"`
 	IsDefault       bool        `json:"is_default"`
 	AllowDisable    bool        `json:"allow_disable"`
+	ExpansionLimit  null.Int32  `json:"expansion_limit"`
+
+	// LimitReachedBy is the stage that hit the expansion limit during the latest selection, or 0 if it completed
+	LimitReachedBy AssetGroupSelectorNodeSource `json:"limit_reached_by"`
+	LimitReachedAt null.Time                    `json:"limit_reached_at"`
 
 	Seeds []SelectorSeed `json:"seeds,omitempty" validate:"required" gorm:"-"`
 }
@@ -214,6 +234,15 @@ func (AssetGroupTagSelector) TableName() string {
 	return "asset_group_tag_selectors"
 }
 
+// GetExpansionLimit returns the maximum number of nodes the selector may select, or AssetGroupSelectorNoExpansionLimit
+// when the selector has no limit of its own
+func (s AssetGroupTagSelector) GetExpansionLimit() int {
+	if s.ExpansionLimit.Valid {
+		return int(s.ExpansionLimit.Int32)
+	}
+	return AssetGroupSelectorNoExpansionLimit
+}
+
 func (s AssetGroupTagSelector) AuditData() AuditData {
 	return AuditData{
 		"id":                 s.ID,
@@ -222,6 +251,7 @@ func (s AssetGroupTagSelector) AuditData() AuditData {
 		"description":        s.Description,
 		"auto_certify":       s.AutoCertify,
 		"is_default":         s.IsDefault,
+		"expansion_limit":    s.ExpansionLimit,
 	}
 }
 
@@ -231,16 +261,17 @@ func (s AssetGroupTagSelector) IsStringColumn(filter string) bool {
 
 func (s AssetGroupTagSelector) ValidFilters() map[string][]FilterOperator {
 	return map[string][]FilterOperator{
-		"auto_certify": {Equals, NotEquals},
-		"created_at":   {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
-		"created_by":   {Equals, NotEquals},
-		"description":  {Equals, NotEquals, ApproximatelyEquals},
-		"disabled_at":  {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
-		"disabled_by":  {Equals, NotEquals},
-		"is_default":   {Equals, NotEquals},
-		"name":         {Equals, NotEquals, ApproximatelyEquals},
-		"updated_at":   {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
-		"updated_by":   {Equals, NotEquals},
+		"auto_certify":     {Equals, NotEquals},
+		"created_at":       {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"created_by":       {Equals, NotEquals},
+		"description":      {Equals, NotEquals, ApproximatelyEquals},
+		"disabled_at":      {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"disabled_by":      {Equals, NotEquals},
+		"is_default":       {Equals, NotEquals},
+		"limit_reached_by": {Equals, NotEquals},
+		"name":             {Equals, NotEquals, ApproximatelyEquals},
+		"updated_at":       {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"updated_by":       {Equals, NotEquals},
 	}
 }
 
@@ -264,6 +295,40 @@ func (s AssetGroupSelectorNode) TableName() string {
 	return "asset_group_tag_selector_nodes"
 }
 
+// AssetGroupMemberCertification is the certification state of a node within a tag, collapsed across every selector
+// of the tag that selected it
+type AssetGroupMemberCertification struct {
+	NodeId          graph.ID                `json:"id"`
+	AssetGroupTagId int                     `json:"asset_group_tag_id"`
+	ObjectId        string                  `json:"object_id"`
+	EnvironmentId   string                  `json:"environment_id"`
+	PrimaryKind     string                  `json:"primary_kind"`
+	Name            string                  `json:"name"`
+	Certified       AssetGroupCertification `json:"certified"`
+	CertifiedBy     null.String             `json:"certified_by"`
+	CreatedAt       time.Time               `json:"created_at"`
+}
+
+type AssetGroupMemberCertifications []AssetGroupMemberCertification
+
+// AssetGroupCertificationUpdate describes a bulk certification or revocation of tag members. An AssetGroupTagId of 0
+// applies the update to every tag the members belong to.
+type AssetGroupCertificationUpdate struct {
+	AssetGroupTagId int                     `json:"asset_group_tag_id"`
+	MemberIds       []graph.ID              `json:"member_ids"`
+	Certification   AssetGroupCertification `json:"certification"`
+	Note            null.String             `json:"note"`
+}
+
+func (s AssetGroupCertificationUpdate) AuditData() AuditData {
+	return AuditData{
+		"asset_group_tag_id": s.AssetGroupTagId,
+		"member_ids":         s.MemberIds,
+		"certification":      s.Certification,
+		"note":               s.Note,
+	}
+}
+
 /*
 These are the relevant properties for asset group tags. This method serves to keep consistency across the feature
 */
@@ -0,0 +1,88 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/specterops/dawgs/graph"
+)
+
+// AssetGroupTagViolation is a finding where a member of a lower tier has an attack path into a member of a higher tier.
+// Direct edges are recorded with a single hop; multi-hop findings keep the kinds of every edge along the shortest path.
+type AssetGroupTagViolation struct {
+	ID             int64          `json:"id" gorm:"primaryKey"`
+	SourceTagId    int            `json:"source_tag_id"`
+	TargetTagId    int            `json:"target_tag_id"`
+	SourceNodeId   graph.ID       `json:"source_node_id"`
+	SourceObjectId string         `json:"source_object_id"`
+	SourceName     string         `json:"source_name"`
+	TargetNodeId   graph.ID       `json:"target_node_id"`
+	TargetObjectId string         `json:"target_object_id"`
+	TargetName     string         `json:"target_name"`
+	Hops           int            `json:"hops"`
+	EdgeKinds      pq.StringArray `json:"edge_kinds" gorm:"type:text[]"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type AssetGroupTagViolations []AssetGroupTagViolation
+
+func (AssetGroupTagViolation) TableName() string {
+	return "asset_group_tag_violations"
+}
+
+func (s AssetGroupTagViolation) IsSortable(criteria string) bool {
+	switch criteria {
+	case "source_tag_id", "target_tag_id", "source_name", "target_name", "hops", "created_at":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AssetGroupTagViolation) IsStringColumn(filter string) bool {
+	switch filter {
+	case "source_object_id", "source_name", "target_object_id", "target_name":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AssetGroupTagViolation) ValidFilters() map[string][]FilterOperator {
+	numericOperators := []FilterOperator{Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals}
+	return map[string][]FilterOperator{
+		"source_tag_id":    {Equals, NotEquals},
+		"target_tag_id":    {Equals, NotEquals},
+		"source_object_id": {Equals, NotEquals},
+		"source_name":      {Equals, NotEquals, ApproximatelyEquals},
+		"target_object_id": {Equals, NotEquals},
+		"target_name":      {Equals, NotEquals, ApproximatelyEquals},
+		"hops":             numericOperators,
+	}
+}
+
+// AssetGroupTagViolationCount summarizes the violations found from one tier into a higher tier
+type AssetGroupTagViolationCount struct {
+	SourceTagId int `json:"source_tag_id"`
+	TargetTagId int `json:"target_tag_id"`
+	Count       int `json:"count"`
+	DirectCount int `json:"direct_count"`
+}
+
+type AssetGroupTagViolationCounts []AssetGroupTagViolationCount
@@ -0,0 +1,168 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/types"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/dawgs/graph"
+)
+
+type AssetGroupTagWebhookEvent string
+
+const (
+	AssetGroupTagWebhookEventMembershipChanged AssetGroupTagWebhookEvent = "asset_group_tag.membership_changed"
+)
+
+type AssetGroupTagWebhookDeliveryStatus string
+
+const (
+	AssetGroupTagWebhookDeliveryStatusPending   AssetGroupTagWebhookDeliveryStatus = "pending"
+	AssetGroupTagWebhookDeliveryStatusSucceeded AssetGroupTagWebhookDeliveryStatus = "succeeded"
+	AssetGroupTagWebhookDeliveryStatusFailed    AssetGroupTagWebhookDeliveryStatus = "failed"
+)
+
+// AssetGroupTagWebhookMaxNodes is the number of added and removed nodes listed in a single membership change payload.
+// The counts in the payload always reflect the full change.
+const AssetGroupTagWebhookMaxNodes = 1000
+
+// AssetGroupTagWebhook is an HTTP endpoint notified whenever the members of a tag change. A webhook without an
+// AssetGroupTagId is notified for every tag. Payloads are signed with the secret, which is never returned after creation.
+type AssetGroupTagWebhook struct {
+	ID              int        `json:"id"`
+	Name            string     `json:"name"`
+	URL             string     `json:"url"`
+	Secret          string     `json:"-"`
+	AssetGroupTagId null.Int32 `json:"asset_group_tag_id"`
+	Enabled         bool       `json:"enabled"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CreatedBy       string     `json:"created_by"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	UpdatedBy       string     `json:"updated_by"`
+}
+
+type AssetGroupTagWebhooks []AssetGroupTagWebhook
+
+func (AssetGroupTagWebhook) TableName() string {
+	return "asset_group_tag_webhooks"
+}
+
+func (s AssetGroupTagWebhook) AuditData() AuditData {
+	return AuditData{
+		"id":                 s.ID,
+		"name":               s.Name,
+		"url":                s.URL,
+		"asset_group_tag_id": s.AssetGroupTagId,
+		"enabled":            s.Enabled,
+	}
+}
+
+func (s AssetGroupTagWebhook) IsStringColumn(filter string) bool {
+	return filter == "name" || filter == "url"
+}
+
+func (s AssetGroupTagWebhook) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"name":               {Equals, NotEquals, ApproximatelyEquals},
+		"url":                {Equals, NotEquals, ApproximatelyEquals},
+		"asset_group_tag_id": {Equals, NotEquals},
+		"enabled":            {Equals, NotEquals},
+	}
+}
+
+// AssetGroupTagWebhookDelivery is a single notification queued for a webhook. Deliveries are retried with backoff until
+// the endpoint accepts them or the attempts are exhausted.
+type AssetGroupTagWebhookDelivery struct {
+	ID              int64                              `json:"id"`
+	WebhookId       int                                `json:"webhook_id"`
+	AssetGroupTagId int                                `json:"asset_group_tag_id"`
+	Event           AssetGroupTagWebhookEvent          `json:"event"`
+	Payload         types.JSONBObject                  `json:"payload"`
+	Status          AssetGroupTagWebhookDeliveryStatus `json:"status"`
+	Attempts        int                                `json:"attempts"`
+	NextAttemptAt   null.Time                          `json:"next_attempt_at"`
+	LastStatusCode  null.Int32                         `json:"last_status_code"`
+	LastError       string                             `json:"last_error"`
+	CreatedAt       time.Time                          `json:"created_at"`
+	CompletedAt     null.Time                          `json:"completed_at"`
+}
+
+type AssetGroupTagWebhookDeliveries []AssetGroupTagWebhookDelivery
+
+func (AssetGroupTagWebhookDelivery) TableName() string {
+	return "asset_group_tag_webhook_deliveries"
+}
+
+func (s AssetGroupTagWebhookDelivery) IsStringColumn(filter string) bool {
+	return filter == "status" || filter == "event"
+}
+
+func (s AssetGroupTagWebhookDelivery) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"status":             {Equals, NotEquals},
+		"event":              {Equals, NotEquals},
+		"asset_group_tag_id": {Equals, NotEquals},
+		"created_at":         {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+	}
+}
+
+// AssetGroupTagWebhookDeliveryAttempt records the outcome of one attempt to send a delivery. StatusCode is unset when
+// the endpoint could not be reached.
+type AssetGroupTagWebhookDeliveryAttempt struct {
+	ID          int64      `json:"id"`
+	DeliveryId  int64      `json:"delivery_id"`
+	Attempt     int        `json:"attempt"`
+	StatusCode  null.Int32 `json:"status_code"`
+	Error       string     `json:"error"`
+	DurationMs  int64      `json:"duration_ms"`
+	AttemptedAt time.Time  `json:"attempted_at"`
+}
+
+type AssetGroupTagWebhookDeliveryAttempts []AssetGroupTagWebhookDeliveryAttempt
+
+func (AssetGroupTagWebhookDeliveryAttempt) TableName() string {
+	return "asset_group_tag_webhook_delivery_attempts"
+}
+
+// AssetGroupTagWebhookNode identifies a node that was added to or removed from a tag
+type AssetGroupTagWebhookNode struct {
+	NodeId      graph.ID `json:"node_id"`
+	ObjectId    string   `json:"object_id"`
+	Name        string   `json:"name"`
+	PrimaryKind string   `json:"primary_kind"`
+}
+
+// AssetGroupTagWebhookTag identifies the tag whose membership changed
+type AssetGroupTagWebhookTag struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	Type     AssetGroupTagType `json:"type"`
+	Position null.Int32        `json:"position"`
+}
+
+// AssetGroupTagMembershipChange is the payload delivered to webhooks when nodes are added to or removed from a tag
+type AssetGroupTagMembershipChange struct {
+	Event        AssetGroupTagWebhookEvent  `json:"event"`
+	OccurredAt   time.Time                  `json:"occurred_at"`
+	Tag          AssetGroupTagWebhookTag    `json:"tag"`
+	AddedCount   int                        `json:"added_count"`
+	RemovedCount int                        `json:"removed_count"`
+	Added        []AssetGroupTagWebhookNode `json:"added"`
+	Removed      []AssetGroupTagWebhookNode `json:"removed"`
+}
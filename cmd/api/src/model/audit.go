@@ -48,19 +48,34 @@ const (
 	AuditLogActionUpdateUser AuditLogAction = "UpdateUser"
 	AuditLogActionDeleteUser AuditLogAction = "DeleteUser"
 
+	AuditLogActionRevokeUserSession  AuditLogAction = "RevokeUserSession"
+	AuditLogActionRevokeUserSessions AuditLogAction = "RevokeUserSessions"
+
+	AuditLogActionCreateRole AuditLogAction = "CreateRole"
+	AuditLogActionUpdateRole AuditLogAction = "UpdateRole"
+	AuditLogActionDeleteRole AuditLogAction = "DeleteRole"
+
 	AuditLogActionCreateAssetGroup AuditLogAction = "CreateAssetGroup"
 	AuditLogActionUpdateAssetGroup AuditLogAction = "UpdateAssetGroup"
 	AuditLogActionDeleteAssetGroup AuditLogAction = "DeleteAssetGroup"
 
 	AuditLogActionDeleteAssetGroupSelector AuditLogAction = "DeleteAssetGroupSelector"
 
-	AuditLogActionCreateAuthToken AuditLogAction = "CreateAuthToken"
-	AuditLogActionDeleteAuthToken AuditLogAction = "DeleteAuthToken"
+	AuditLogActionCreateAuthToken  AuditLogAction = "CreateAuthToken"
+	AuditLogActionDeleteAuthToken  AuditLogAction = "DeleteAuthToken"
+	AuditLogActionDisableAuthToken AuditLogAction = "DisableAuthToken"
 
 	AuditLogActionCreateAuthSecret AuditLogAction = "CreateAuthSecret"
 	AuditLogActionUpdateAuthSecret AuditLogAction = "UpdateAuthSecret"
 	AuditLogActionDeleteAuthSecret AuditLogAction = "DeleteAuthSecret"
 
+	AuditLogActionCreateWebAuthnCredential AuditLogAction = "CreateWebAuthnCredential"
+	AuditLogActionDeleteWebAuthnCredential AuditLogAction = "DeleteWebAuthnCredential"
+	AuditLogActionUseWebAuthnCredential    AuditLogAction = "UseWebAuthnCredential"
+
+	AuditLogActionLockUserAccount   AuditLogAction = "LockUserAccount"
+	AuditLogActionUnlockUserAccount AuditLogAction = "UnlockUserAccount"
+
 	AuditLogActionCreateSAMLIdentityProvider AuditLogAction = "CreateSAMLIdentityProvider"
 	AuditLogActionUpdateSAMLIdentityProvider AuditLogAction = "UpdateSAMLIdentityProvider"
 
@@ -90,6 +105,11 @@ const (
 	AuditLogActionCreateAssetGroupTagSelector AuditLogAction = "CreateAssetGroupTagSelector"
 	AuditLogActionUpdateAssetGroupTagSelector AuditLogAction = "UpdateAssetGroupTagSelector"
 	AuditLogActionDeleteAssetGroupTagSelector AuditLogAction = "DeleteAssetGroupTagSelector"
+	AuditLogActionCertifyAssetGroupTagMembers AuditLogAction = "CertifyAssetGroupTagMembers"
+	AuditLogActionImportTieringConfiguration  AuditLogAction = "ImportTieringConfiguration"
+	AuditLogActionCreateAssetGroupTagWebhook  AuditLogAction = "CreateAssetGroupTagWebhook"
+	AuditLogActionUpdateAssetGroupTagWebhook  AuditLogAction = "UpdateAssetGroupTagWebhook"
+	AuditLogActionDeleteAssetGroupTagWebhook  AuditLogAction = "DeleteAssetGroupTagWebhook"
 
 	AuditLogActionCreateCustomNodeKind AuditLogAction = "CreateCustomNodeKind"
 	AuditLogActionUpdateCustomNodeKind AuditLogAction = "UpdateCustomNodeKind"
@@ -104,7 +124,13 @@ const (
 	AuditLogActionExportSavedQuery   AuditLogAction = "ExportSavedQuery"
 	AuditLogActionExportSavedQueries AuditLogAction = "ExportSavedQueries"
 
+	AuditLogActionShareSavedQuery      AuditLogAction = "ShareSavedQuery"
+	AuditLogActionUnshareSavedQuery    AuditLogAction = "UnshareSavedQuery"
+	AuditLogActionTransferSavedQueries AuditLogAction = "TransferSavedQueries"
+
 	AuditLogActionUpdateEnvironmentAccessList AuditLogAction = "UpdateEnvironmentAccessList"
+
+	AuditLogActionSSOMappingRoleChange AuditLogAction = "SSOMappingRoleChange"
 )
 
 // TODO embed Basic into this struct instead of declaring the ID and CreatedAt fields. This will require a migration
@@ -144,13 +144,33 @@ func (s Permissions) Has(other Permission) bool {
 	return false
 }
 
+func (s Permissions) Names() []string {
+	names := make([]string, len(s))
+
+	for idx, permission := range s {
+		names[idx] = permission.String()
+	}
+
+	return names
+}
+
+// Used by gorm to preload / instantiate the auth token FK'd tables data
+func AuthTokenAssociations() []string {
+	return []string{
+		"Permissions",
+	}
+}
+
 type AuthToken struct {
-	UserID     uuid.NullUUID `json:"user_id" gorm:"type:text"`
-	ClientID   uuid.NullUUID `json:"-"  gorm:"type:text"`
-	Name       null.String   `json:"name"`
-	Key        string        `json:"key,omitempty"`
-	HmacMethod string        `json:"hmac_method"`
-	LastAccess time.Time     `json:"last_access"`
+	UserID      uuid.NullUUID `json:"user_id" gorm:"type:text"`
+	ClientID    uuid.NullUUID `json:"-"  gorm:"type:text"`
+	Name        null.String   `json:"name"`
+	Key         string        `json:"key,omitempty"`
+	HmacMethod  string        `json:"hmac_method"`
+	LastAccess  time.Time     `json:"last_access"`
+	ExpiresAt   null.Time     `json:"expires_at"`
+	IsDisabled  bool          `json:"is_disabled"`
+	Permissions Permissions   `json:"permissions" gorm:"many2many:auth_tokens_permissions"`
 
 	Unique
 }
@@ -162,18 +182,34 @@ func (s AuthToken) AuditData() AuditData {
 		"client_id":   s.ClientID,
 		"name":        s.Name,
 		"last_access": s.LastAccess,
+		"expires_at":  s.ExpiresAt,
+		"is_disabled": s.IsDisabled,
+		"permissions": s.Permissions.Names(),
 	}
 }
 
+// IsScoped returns true if the token has been narrowed to a subset of its owner's permissions
+func (s AuthToken) IsScoped() bool {
+	return len(s.Permissions) > 0
+}
+
+// IsExpired returns true if the token has an expiration that is at or before the given time
+func (s AuthToken) IsExpired(now time.Time) bool {
+	return s.ExpiresAt.Valid && !now.Before(s.ExpiresAt.Time)
+}
+
 func (s AuthToken) StripKey() AuthToken {
 	return AuthToken{
-		UserID:     s.UserID,
-		ClientID:   s.ClientID,
-		Key:        "",
-		HmacMethod: s.HmacMethod,
-		LastAccess: s.LastAccess,
-		Unique:     s.Unique,
-		Name:       s.Name,
+		UserID:      s.UserID,
+		ClientID:    s.ClientID,
+		Key:         "",
+		HmacMethod:  s.HmacMethod,
+		LastAccess:  s.LastAccess,
+		ExpiresAt:   s.ExpiresAt,
+		IsDisabled:  s.IsDisabled,
+		Permissions: s.Permissions,
+		Unique:      s.Unique,
+		Name:        s.Name,
 	}
 }
 
@@ -183,6 +219,7 @@ func (s AuthTokens) IsSortable(column string) bool {
 	switch column {
 	case "name",
 		"last_access",
+		"expires_at",
 		"created_at",
 		"updated_at",
 		"deleted_at":
@@ -200,6 +237,8 @@ func (s AuthTokens) ValidFilters() map[string][]FilterOperator {
 		"hmac_method": {Equals, NotEquals},
 		"id":          {Equals, NotEquals},
 		"last_access": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"expires_at":  {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"is_disabled": {Equals, NotEquals},
 		"created_at":  {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
 		"updated_at":  {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
 		"deleted_at":  {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
@@ -258,6 +297,10 @@ type AuthSecret struct {
 	TOTPSecret    string    `json:"-"`
 	TOTPActivated bool      `json:"totp_activated"`
 
+	// FailedLoginCount and LockedUntil track consecutive failed logins and are only written by the lockout methods
+	FailedLoginCount int       `json:"failed_login_count"`
+	LockedUntil      null.Time `json:"locked_until"`
+
 	Serial
 }
 
@@ -266,6 +309,11 @@ func (s AuthSecret) Expired() bool {
 	return s.ExpiresAt.Before(time.Now().UTC())
 }
 
+// Locked returns true if the auth secret is temporarily locked after too many failed logins, false otherwise
+func (s AuthSecret) Locked() bool {
+	return s.LockedUntil.Valid && s.LockedUntil.Time.After(time.Now().UTC())
+}
+
 func (s AuthSecret) AuditData() AuditData {
 	return AuditData{
 		"id":                s.ID,
@@ -274,6 +322,59 @@ func (s AuthSecret) AuditData() AuditData {
 	}
 }
 
+// AuthSecretHistory is a digest that a user's secret previously held, kept so that the password policy can reject
+// reused secrets
+type AuthSecretHistory struct {
+	UserID       uuid.UUID `json:"-"`
+	Digest       string    `json:"-"`
+	DigestMethod string    `json:"digest_method"`
+
+	Serial
+}
+
+func (AuthSecretHistory) TableName() string {
+	return "auth_secret_history"
+}
+
+// WebAuthnCredential is a public key credential, such as a security key or passkey, that a local user registered as a
+// second authentication factor
+type WebAuthnCredential struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Name         string    `json:"name"`
+	CredentialID string    `json:"credential_id"` // Unpadded base64url encoding of the credential ID chosen by the authenticator
+	PublicKey    []byte    `json:"-"`             // COSE_Key encoding of the credential public key
+	SignCount    int64     `json:"sign_count"`
+	LastUsedAt   null.Time `json:"last_used_at"`
+
+	Serial
+}
+
+func (WebAuthnCredential) TableName() string {
+	return "webauthn_credentials"
+}
+
+func (s WebAuthnCredential) AuditData() AuditData {
+	return AuditData{
+		"id":            s.ID,
+		"user_id":       s.UserID,
+		"name":          s.Name,
+		"credential_id": s.CredentialID,
+	}
+}
+
+type WebAuthnCredentials []WebAuthnCredential
+
+// Find returns the credential with the given base64url encoded credential ID
+func (s WebAuthnCredentials) Find(credentialID string) (WebAuthnCredential, bool) {
+	for _, credential := range s {
+		if credential.CredentialID == credentialID {
+			return credential, true
+		}
+	}
+
+	return WebAuthnCredential{}, false
+}
+
 func RoleAssociations() []string {
 	return []string{
 		"Permissions",
@@ -284,14 +385,17 @@ type Role struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	Permissions Permissions `json:"permissions" gorm:"many2many:roles_permissions"`
+	BuiltIn     bool        `json:"built_in"`
 
 	Serial
 }
 
 func (s Role) AuditData() AuditData {
 	return AuditData{
-		"role_id":   s.ID,
-		"role_name": s.Name,
+		"role_id":          s.ID,
+		"role_name":        s.Name,
+		"role_built_in":    s.BuiltIn,
+		"role_permissions": s.Permissions.Names(),
 	}
 }
 
@@ -315,6 +419,7 @@ func (s Roles) ValidFilters() map[string][]FilterOperator {
 	return map[string][]FilterOperator{
 		"name":       {Equals, NotEquals},
 		"id":         {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"built_in":   {Equals, NotEquals},
 		"created_at": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
 		"updated_at": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
 		"deleted_at": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
@@ -572,6 +677,10 @@ type SessionFlagKey string
 
 const (
 	SessionFlagFedEULAAccepted SessionFlagKey = "fed_eula_accepted" // INFO: The FedEULA is only applicable to select enterprise installations
+
+	// SessionFlagWebAuthnEnrollmentRequired marks a session whose user must register a WebAuthn credential before the
+	// session grants anything beyond managing their own account
+	SessionFlagWebAuthnEnrollmentRequired SessionFlagKey = "webauthn_enrollment_required"
 )
 
 type UserSession struct {
@@ -581,6 +690,8 @@ type UserSession struct {
 	AuthProviderID   int32 // If SSO Session, this will be the child saml or oidc provider id
 	ExpiresAt        time.Time
 	Flags            types.JSONBBoolObject `json:"flags"`
+	IPAddress        string                // Client IP address of the login request that created the session
+	UserAgent        string                // User agent of the login request that created the session
 
 	BigSerial
 }
@@ -590,6 +701,15 @@ func (s UserSession) Expired() bool {
 	return s.ExpiresAt.Before(time.Now().UTC())
 }
 
+func (s UserSession) AuditData() AuditData {
+	return AuditData{
+		"id":                 s.ID,
+		"user_id":            s.UserID,
+		"auth_provider_type": s.AuthProviderType.String(),
+		"ip_address":         s.IPAddress,
+	}
+}
+
 // corresponding set function is cmd/api/src/database/auth.go:SetUserSessionFlag()
 func (s UserSession) GetFlag(key SessionFlagKey) bool {
 	return s.Flags[string(key)]
@@ -27,6 +27,7 @@ const (
 	DatapipeStatusPurging   DatapipeStatus = "purging"
 	DatapipeStatusPruning   DatapipeStatus = "pruning"
 	DatapipeStatusStarting  DatapipeStatus = "starting"
+	DatapipeStatusReporting DatapipeStatus = "reporting"
 )
 
 type DatapipeStatusWrapper struct {
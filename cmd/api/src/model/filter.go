@@ -109,10 +109,13 @@ type QueryParameterFilter struct {
 type QueryParameterFilters []QueryParameterFilter
 
 func (s QueryParameterFilter) BuildGDBNodeFilter() graph.Criteria {
-	var (
-		propertyRef = query.NodeProperty(s.Name)
-		value       = guessFilterValueType(s.Value)
-	)
+	return s.BuildGDBPropertyFilter(query.NodeProperty(s.Name))
+}
+
+// BuildGDBPropertyFilter builds the filter against the given property reference, allowing the filter to be applied to
+// entities other than the queried node such as the start or end node of a relationship
+func (s QueryParameterFilter) BuildGDBPropertyFilter(propertyRef graph.Criteria) graph.Criteria {
+	value := guessFilterValueType(s.Value)
 
 	// TODO: Investigate whether we can set the collected property for domains that originate from trusts in ParseDomainTrusts
 	switch {
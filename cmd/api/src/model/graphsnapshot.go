@@ -0,0 +1,120 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+)
+
+// GraphSnapshot records the state of the graph at the end of an analysis run as a set of node and edge fingerprints
+// keyed by the run's ID. A snapshot is only usable for diffing once it has completed. Snapshots only hold 64-bit hashes;
+// the details behind each hash are stored once and shared between every snapshot that references them.
+type GraphSnapshot struct {
+	ID          int64     `json:"id" gorm:"primaryKey"`
+	RunID       string    `json:"run_id"`
+	NodeCount   int64     `json:"node_count"`
+	EdgeCount   int64     `json:"edge_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt null.Time `json:"completed_at"`
+}
+
+func (GraphSnapshot) TableName() string {
+	return "graph_snapshots"
+}
+
+type GraphSnapshots []GraphSnapshot
+
+// GraphSnapshotNode is the fingerprint of a single node. Tags holds the space separated asset group tag kinds the node
+// carried at the time of the snapshot.
+type GraphSnapshotNode struct {
+	ObjectID string `json:"object_id"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Tags     string `json:"tags"`
+}
+
+// ObjectHash identifies the node across snapshots regardless of changes to its kind, name or tags
+func (s GraphSnapshotNode) ObjectHash() int64 {
+	return fingerprint(s.ObjectID)
+}
+
+// Fingerprint identifies this exact version of the node
+func (s GraphSnapshotNode) Fingerprint() int64 {
+	return fingerprint(s.ObjectID, s.Kind, s.Name, s.Tags)
+}
+
+type GraphSnapshotNodes []GraphSnapshotNode
+
+// GraphSnapshotEdge is the fingerprint of a single edge, identified by the object IDs of its endpoints and its kind
+type GraphSnapshotEdge struct {
+	StartObjectID string `json:"start_object_id"`
+	Kind          string `json:"kind"`
+	EndObjectID   string `json:"end_object_id"`
+}
+
+// Fingerprint identifies the edge across snapshots
+func (s GraphSnapshotEdge) Fingerprint() int64 {
+	return fingerprint(s.StartObjectID, s.Kind, s.EndObjectID)
+}
+
+type GraphSnapshotEdges []GraphSnapshotEdge
+
+// fingerprint hashes the given values with 64-bit FNV-1a. Values are NUL separated so that different splits of the
+// same bytes do not collide.
+func fingerprint(values ...string) int64 {
+	hash := fnv.New64a()
+
+	for _, value := range values {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+
+	return int64(hash.Sum64())
+}
+
+// GraphDiffFilter narrows a graph diff. Node kinds filter added and removed nodes by their primary kind, edge kinds
+// filter added and removed edges by kind, and a tag kind limits both to nodes carrying the tag and to edges with at
+// least one endpoint carrying it.
+type GraphDiffFilter struct {
+	NodeKinds []string
+	EdgeKinds []string
+	TagKind   string
+}
+
+type GraphDiffNodes struct {
+	Added        GraphSnapshotNodes `json:"added"`
+	AddedCount   int                `json:"added_count"`
+	Removed      GraphSnapshotNodes `json:"removed"`
+	RemovedCount int                `json:"removed_count"`
+}
+
+type GraphDiffEdges struct {
+	Added        GraphSnapshotEdges `json:"added"`
+	AddedCount   int                `json:"added_count"`
+	Removed      GraphSnapshotEdges `json:"removed"`
+	RemovedCount int                `json:"removed_count"`
+}
+
+type GraphDiff struct {
+	From  GraphSnapshot  `json:"from"`
+	To    GraphSnapshot  `json:"to"`
+	Nodes GraphDiffNodes `json:"nodes"`
+	Edges GraphDiffEdges `json:"edges"`
+}
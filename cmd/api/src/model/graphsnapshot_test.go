@@ -0,0 +1,52 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphSnapshotNode_Fingerprint(t *testing.T) {
+	var (
+		node    = model.GraphSnapshotNode{ObjectID: "S-1-5-21-1", Kind: "User", Name: "ALICE"}
+		renamed = model.GraphSnapshotNode{ObjectID: "S-1-5-21-1", Kind: "User", Name: "BOB"}
+		tagged  = model.GraphSnapshotNode{ObjectID: "S-1-5-21-1", Kind: "User", Name: "ALICE", Tags: "Tag_Tier_Zero"}
+	)
+
+	assert.Equal(t, node.Fingerprint(), model.GraphSnapshotNode{ObjectID: "S-1-5-21-1", Kind: "User", Name: "ALICE"}.Fingerprint())
+	assert.NotEqual(t, node.Fingerprint(), renamed.Fingerprint())
+	assert.NotEqual(t, node.Fingerprint(), tagged.Fingerprint())
+
+	// Changes to a node do not change its identity across snapshots
+	assert.Equal(t, node.ObjectHash(), renamed.ObjectHash())
+	assert.Equal(t, node.ObjectHash(), tagged.ObjectHash())
+	assert.NotEqual(t, node.ObjectHash(), model.GraphSnapshotNode{ObjectID: "S-1-5-21-2"}.ObjectHash())
+}
+
+func TestGraphSnapshotEdge_Fingerprint(t *testing.T) {
+	edge := model.GraphSnapshotEdge{StartObjectID: "1", Kind: "MemberOf", EndObjectID: "2"}
+
+	assert.Equal(t, edge.Fingerprint(), model.GraphSnapshotEdge{StartObjectID: "1", Kind: "MemberOf", EndObjectID: "2"}.Fingerprint())
+	assert.NotEqual(t, edge.Fingerprint(), model.GraphSnapshotEdge{StartObjectID: "2", Kind: "MemberOf", EndObjectID: "1"}.Fingerprint())
+	assert.NotEqual(t, edge.Fingerprint(), model.GraphSnapshotEdge{StartObjectID: "1", Kind: "AdminTo", EndObjectID: "2"}.Fingerprint())
+
+	// Values are separated so that shifting bytes between fields changes the fingerprint
+	assert.NotEqual(t, edge.Fingerprint(), model.GraphSnapshotEdge{StartObjectID: "1M", Kind: "emberOf", EndObjectID: "2"}.Fingerprint())
+}
@@ -99,3 +99,10 @@ type SearchResult struct {
 	DistinguishedName string `json:"distinguishedname"`
 	SystemTags        string `json:"system_tags"`
 }
+
+// PropertySearchResult is a SearchResult ranked by how closely the node's properties matched the search value
+type PropertySearchResult struct {
+	SearchResult
+	MatchedProperties []string `json:"matched_properties"`
+	Score             int      `json:"score"`
+}
@@ -22,6 +22,7 @@ import (
 	"log/slog"
 	"net/url"
 	"path"
+	"slices"
 
 	"github.com/crewjam/saml"
 	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
@@ -41,6 +42,8 @@ const (
 	XMLSOAPClaimsName         = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name"
 	XMLSOAPClaimsSurname      = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname"
 	MicrosoftClaimsRole       = "http://schemas.microsoft.com/ws/2008/06/identity/claims/role"
+	MicrosoftClaimsGroups     = "http://schemas.microsoft.com/ws/2008/06/identity/claims/groups"
+	SAMLAttributeGroups       = "groups"
 )
 
 var (
@@ -125,6 +128,10 @@ func (s SAMLProvider) roleAttributeNames() []string {
 	return []string{MicrosoftClaimsRole}
 }
 
+func (s SAMLProvider) groupAttributeNames() []string {
+	return []string{MicrosoftClaimsGroups, SAMLAttributeGroups}
+}
+
 func (s SAMLProvider) surnameAttributeNames() []string {
 	return []string{ObjectIDSurname, XMLSOAPClaimsSurname}
 }
@@ -189,6 +196,36 @@ func (s SAMLProvider) GetSAMLUserRolesFromAssertion(assertion *saml.Assertion) (
 	return roles
 }
 
+// GetSAMLUserGroupsFromAssertion May be empty if not present
+func (s SAMLProvider) GetSAMLUserGroupsFromAssertion(assertion *saml.Assertion) (groups []string) {
+	for _, attributeStatement := range assertion.AttributeStatements {
+		for _, attribute := range attributeStatement.Attributes {
+			if slices.Contains(s.groupAttributeNames(), attribute.Name) {
+				for _, value := range attribute.Values {
+					groups = append(groups, value.Value)
+				}
+			}
+		}
+	}
+
+	return groups
+}
+
+// GetSAMLClaimsFromAssertion returns every attribute of the assertion keyed by attribute name
+func (s SAMLProvider) GetSAMLClaimsFromAssertion(assertion *saml.Assertion) SSOClaims {
+	claims := SSOClaims{}
+
+	for _, attributeStatement := range assertion.AttributeStatements {
+		for _, attribute := range attributeStatement.Attributes {
+			for _, value := range attribute.Values {
+				claims[attribute.Name] = append(claims[attribute.Name], value.Value)
+			}
+		}
+	}
+
+	return claims
+}
+
 func (s SAMLProvider) GetSAMLUserSurnameFromAssertion(assertion *saml.Assertion) (string, error) {
 	return assertionFindString(assertion, s.surnameAttributeNames()...)
 }
@@ -19,6 +19,7 @@ package model
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
 )
 
 type SavedQueryScope string
@@ -30,11 +31,47 @@ const (
 	SavedQueryScopeAll    SavedQueryScope = "all"
 )
 
-// SavedQueriesPermissions represents the database model which allows users to share saved cypher queries
+// SavedQueriesPermissions represents the database model which allows users to share saved cypher queries. A query may
+// be shared to a user, to every user holding a role or publicly. Shares with CanEdit set also allow the recipients to
+// update and delete the query.
 type SavedQueriesPermissions struct {
 	SharedToUserID uuid.NullUUID `json:"shared_to_user_id"`
+	SharedToRoleID null.Int32    `json:"shared_to_role_id"`
 	QueryID        int64         `json:"query_id"`
 	Public         bool          `json:"public"`
+	CanEdit        bool          `json:"can_edit"`
 
 	BigSerial
 }
+
+// SavedQueryPermissionChange describes a change to who a saved query is shared with and is recorded in the audit log
+type SavedQueryPermissionChange struct {
+	QueryID int64
+	Public  bool
+	CanEdit bool
+	UserIDs []uuid.UUID
+	RoleIDs []int32
+}
+
+func (s SavedQueryPermissionChange) AuditData() AuditData {
+	return AuditData{
+		"query_id": s.QueryID,
+		"public":   s.Public,
+		"can_edit": s.CanEdit,
+		"user_ids": s.UserIDs,
+		"role_ids": s.RoleIDs,
+	}
+}
+
+// SavedQueryOwnershipTransfer records the saved queries of a user being handed to another user
+type SavedQueryOwnershipTransfer struct {
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+}
+
+func (s SavedQueryOwnershipTransfer) AuditData() AuditData {
+	return AuditData{
+		"from_user_id": s.FromUserID,
+		"to_user_id":   s.ToUserID,
+	}
+}
@@ -17,16 +17,133 @@
 package model
 
 import (
+	"cmp"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 type SSOProviderAutoProvisionConfig struct {
 	Enabled       bool  `json:"enabled"`
 	DefaultRoleId int32 `json:"default_role_id"`
 	RoleProvision bool  `json:"role_provision"`
+
+	// EnvironmentProvision grants auto provisioned users the environments mapped to their IdP group claims
+	EnvironmentProvision bool                            `json:"environment_provision"`
+	EnvironmentMappings  []SSOProviderEnvironmentMapping `json:"environment_mappings,omitempty"`
+
+	// MappingRules are evaluated on every login. When any are configured they decide the role of the user, falling back
+	// to DefaultRoleId when no rule matches.
+	MappingRules []SSOProviderMappingRule `json:"mapping_rules,omitempty"`
+}
+
+// SSOProviderEnvironmentMapping maps an IdP group claim to the environments, identified by domain SID or tenant ID, that
+// members of the group may access
+type SSOProviderEnvironmentMapping struct {
+	Group           string   `json:"group"`
+	AllEnvironments bool     `json:"all_environments"`
+	Environments    []string `json:"environments"`
+}
+
+// GetEnvironmentsForGroups resolves the environment access of a user from their group claims. Groups are matched without
+// regard to case. A user that belongs to no mapped group is granted no environments.
+func (s SSOProviderAutoProvisionConfig) GetEnvironmentsForGroups(groups []string) (bool, []string) {
+	var environments []string
+
+	for _, mapping := range s.EnvironmentMappings {
+		if !slices.ContainsFunc(groups, func(group string) bool { return strings.EqualFold(group, mapping.Group) }) {
+			continue
+		} else if mapping.AllEnvironments {
+			return true, nil
+		} else {
+			environments = append(environments, mapping.Environments...)
+		}
+	}
+
+	slices.Sort(environments)
+	return false, slices.Compact(environments)
+}
+
+// SSOClaims holds the claims, or SAML attributes, asserted by an IdP for a login keyed by claim name
+type SSOClaims map[string][]string
+
+// SSOProviderMappingRule maps a claim value, matched either exactly or by regular expression, to a role and optionally
+// to the environments a user may access. Rules with a lower priority take precedence; ties are broken by the order in
+// which the rules are configured.
+type SSOProviderMappingRule struct {
+	Priority int    `json:"priority"`
+	Claim    string `json:"claim"`
+	Value    string `json:"value,omitempty"`
+	Regex    string `json:"regex,omitempty"`
+	RoleId   int32  `json:"role_id"`
+
+	// Once any rule grants environments the rules decide environment access; a user matched by a rule that grants
+	// none is left with the provider's group environment mappings, or no environments when those are disabled
+	AllEnvironments bool     `json:"all_environments"`
+	Environments    []string `json:"environments,omitempty"`
+}
+
+// GrantsEnvironments returns true if the rule decides the environment access of the users it matches
+func (s SSOProviderMappingRule) GrantsEnvironments() bool {
+	return s.AllEnvironments || len(s.Environments) > 0
+}
+
+// ProvisionsEnvironments returns true if the environment access of users is managed by the provider, either through its
+// group environment mappings or through mapping rules that grant environments
+func (s SSOProviderAutoProvisionConfig) ProvisionsEnvironments() bool {
+	return s.Enabled && (s.EnvironmentProvision || slices.ContainsFunc(s.MappingRules, SSOProviderMappingRule.GrantsEnvironments))
+}
+
+// Matches returns true if any value of the rule's claim equals the rule's value or fully matches the rule's regular
+// expression. Claim names, values and regular expressions are all matched without regard to case.
+func (s SSOProviderMappingRule) Matches(claims SSOClaims) (bool, error) {
+	var pattern *regexp.Regexp
+
+	if s.Regex != "" {
+		if compiled, err := regexp.Compile("(?i)^(?:" + s.Regex + ")$"); err != nil {
+			return false, fmt.Errorf("invalid regex for claim %s: %w", s.Claim, err)
+		} else {
+			pattern = compiled
+		}
+	}
+
+	for name, values := range claims {
+		if !strings.EqualFold(name, s.Claim) {
+			continue
+		}
+
+		for _, value := range values {
+			if pattern != nil && pattern.MatchString(value) {
+				return true, nil
+			} else if pattern == nil && strings.EqualFold(value, s.Value) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// MatchMappingRule returns the rule with the highest precedence that matches the given claims
+func (s SSOProviderAutoProvisionConfig) MatchMappingRule(claims SSOClaims) (SSOProviderMappingRule, bool, error) {
+	rules := slices.Clone(s.MappingRules)
+	slices.SortStableFunc(rules, func(a, b SSOProviderMappingRule) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+
+	for _, rule := range rules {
+		if matched, err := rule.Matches(claims); err != nil {
+			return SSOProviderMappingRule{}, false, err
+		} else if matched {
+			return rule, true, nil
+		}
+	}
+
+	return SSOProviderMappingRule{}, false, nil
 }
 
 type SSOProviderConfig struct {
@@ -0,0 +1,95 @@
+// Copyright 2023 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSOProviderAutoProvisionConfig_GetEnvironmentsForGroups(t *testing.T) {
+	config := model.SSOProviderAutoProvisionConfig{
+		EnvironmentMappings: []model.SSOProviderEnvironmentMapping{
+			{Group: "Domain-A", Environments: []string{"S-1-5-21-2", "S-1-5-21-1"}},
+			{Group: "Domain-B", Environments: []string{"S-1-5-21-1"}},
+			{Group: "Admins", AllEnvironments: true},
+		},
+	}
+
+	t.Run("unions mapped environments", func(t *testing.T) {
+		allEnvironments, environments := config.GetEnvironmentsForGroups([]string{"domain-a", "Domain-B", "Other"})
+
+		require.False(t, allEnvironments)
+		require.Equal(t, []string{"S-1-5-21-1", "S-1-5-21-2"}, environments)
+	})
+
+	t.Run("grants all environments", func(t *testing.T) {
+		allEnvironments, environments := config.GetEnvironmentsForGroups([]string{"Domain-A", "ADMINS"})
+
+		require.True(t, allEnvironments)
+		require.Empty(t, environments)
+	})
+
+	t.Run("grants nothing without a mapped group", func(t *testing.T) {
+		allEnvironments, environments := config.GetEnvironmentsForGroups([]string{"Other"})
+
+		require.False(t, allEnvironments)
+		require.Empty(t, environments)
+	})
+}
+
+func TestSSOProviderAutoProvisionConfig_MatchMappingRule(t *testing.T) {
+	config := model.SSOProviderAutoProvisionConfig{
+		MappingRules: []model.SSOProviderMappingRule{
+			{Priority: 20, Claim: "groups", Regex: "tier-.*-analysts", RoleId: 3},
+			{Priority: 10, Claim: "groups", Value: "BloodHound-Admins", RoleId: 1},
+			{Priority: 20, Claim: "groups", Value: "Tier-0-Analysts", RoleId: 2},
+		},
+	}
+
+	t.Run("prefers the lowest priority", func(t *testing.T) {
+		rule, matched, err := config.MatchMappingRule(model.SSOClaims{"Groups": {"tier-0-analysts", "bloodhound-admins"}})
+
+		require.NoError(t, err)
+		require.True(t, matched)
+		require.Equal(t, int32(1), rule.RoleId)
+	})
+
+	t.Run("breaks ties by configured order", func(t *testing.T) {
+		rule, matched, err := config.MatchMappingRule(model.SSOClaims{"groups": {"Tier-0-Analysts"}})
+
+		require.NoError(t, err)
+		require.True(t, matched)
+		require.Equal(t, int32(3), rule.RoleId)
+	})
+
+	t.Run("anchors regular expressions", func(t *testing.T) {
+		_, matched, err := config.MatchMappingRule(model.SSOClaims{"groups": {"former-tier-0-analysts"}})
+
+		require.NoError(t, err)
+		require.False(t, matched)
+	})
+
+	t.Run("reports invalid regular expressions", func(t *testing.T) {
+		invalid := model.SSOProviderAutoProvisionConfig{MappingRules: []model.SSOProviderMappingRule{{Claim: "groups", Regex: "("}}}
+
+		_, _, err := invalid.MatchMappingRule(model.SSOClaims{"groups": {"Analysts"}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,512 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	TieringConfigurationVersion = 1
+
+	TieringConfigurationActionCreate = "create"
+	TieringConfigurationActionUpdate = "update"
+	TieringConfigurationActionDelete = "delete"
+
+	TieringConfigurationObjectTag      = "tag"
+	TieringConfigurationObjectSelector = "selector"
+
+	tieringConfigurationSeedTypeObjectId      = "object_id"
+	tieringConfigurationSeedTypeCypher        = "cypher"
+	tieringConfigurationSeedTypeGroupMembers  = "group_members"
+	tieringConfigurationSeedTypeOUContainment = "ou_containment"
+	tieringConfigurationSeedTypePropertyMatch = "property_match"
+	tieringConfigurationSeedTypeKind          = "kind"
+)
+
+var ErrInvalidTieringConfiguration = errors.New("invalid tiering configuration")
+
+// TieringConfiguration is a declarative, versioned description of every asset group tag and its selectors. Tags are
+// identified by name and selectors by name within their tag.
+type TieringConfiguration struct {
+	Version int                       `json:"version" yaml:"version"`
+	Tags    []TieringConfigurationTag `json:"tags" yaml:"tags"`
+}
+
+type TieringConfigurationTag struct {
+	Name            string                         `json:"name" yaml:"name"`
+	Type            string                         `json:"type" yaml:"type"`
+	Description     string                         `json:"description,omitempty" yaml:"description,omitempty"`
+	Position        *int32                         `json:"position,omitempty" yaml:"position,omitempty"`
+	RequireCertify  *bool                          `json:"require_certify,omitempty" yaml:"require_certify,omitempty"`
+	AnalysisEnabled *bool                          `json:"analysis_enabled,omitempty" yaml:"analysis_enabled,omitempty"`
+	Expansion       string                         `json:"expansion,omitempty" yaml:"expansion,omitempty"`
+	Selectors       []TieringConfigurationSelector `json:"selectors" yaml:"selectors"`
+}
+
+type TieringConfigurationSelector struct {
+	Name        string                     `json:"name" yaml:"name"`
+	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	AutoCertify bool                       `json:"auto_certify" yaml:"auto_certify"`
+	Disabled    bool                       `json:"disabled" yaml:"disabled"`
+	Default     bool                       `json:"default,omitempty" yaml:"default,omitempty"`
+	Seeds       []TieringConfigurationSeed `json:"seeds" yaml:"seeds"`
+
+	allowDisable bool
+}
+
+type TieringConfigurationSeed struct {
+	Type  string `json:"type" yaml:"type"`
+	Value string `json:"value" yaml:"value"`
+}
+
+type TieringConfigurationChange struct {
+	Action   string   `json:"action"`
+	Object   string   `json:"object"`
+	Tag      string   `json:"tag"`
+	Selector string   `json:"selector,omitempty"`
+	Fields   []string `json:"fields,omitempty"`
+}
+
+// TieringConfigurationPlan is the ordered set of changes required to move the current configuration to a desired one
+type TieringConfigurationPlan struct {
+	Changes   []TieringConfigurationChange `json:"changes"`
+	TierOrder []string                     `json:"tier_order"`
+	Labels    int                          `json:"-"`
+}
+
+func (s TieringConfigurationPlan) AuditData() AuditData {
+	return AuditData{
+		"changes":    s.Changes,
+		"tier_order": s.TierOrder,
+	}
+}
+
+func ParseAssetGroupTagType(name string) (AssetGroupTagType, bool) {
+	for _, tagType := range []AssetGroupTagType{AssetGroupTagTypeTier, AssetGroupTagTypeLabel, AssetGroupTagTypeOwned} {
+		if (AssetGroupTag{Type: tagType}).ToType() == name {
+			return tagType, true
+		}
+	}
+	return 0, false
+}
+
+func (s AssetGroupExpansionMethod) String() string {
+	switch s {
+	case AssetGroupExpansionMethodAll:
+		return "all"
+	case AssetGroupExpansionMethodChildren:
+		return "children"
+	case AssetGroupExpansionMethodParents:
+		return "parents"
+	default:
+		return "none"
+	}
+}
+
+func (s SelectorType) String() string {
+	switch s {
+	case SelectorTypeObjectId:
+		return tieringConfigurationSeedTypeObjectId
+	case SelectorTypeCypher:
+		return tieringConfigurationSeedTypeCypher
+	case SelectorTypeGroupMembers:
+		return tieringConfigurationSeedTypeGroupMembers
+	case SelectorTypeOUContainment:
+		return tieringConfigurationSeedTypeOUContainment
+	case SelectorTypePropertyMatch:
+		return tieringConfigurationSeedTypePropertyMatch
+	case SelectorTypeKind:
+		return tieringConfigurationSeedTypeKind
+	default:
+		return "unknown"
+	}
+}
+
+func ParseSelectorType(name string) (SelectorType, bool) {
+	switch name {
+	case tieringConfigurationSeedTypeObjectId:
+		return SelectorTypeObjectId, true
+	case tieringConfigurationSeedTypeCypher:
+		return SelectorTypeCypher, true
+	case tieringConfigurationSeedTypeGroupMembers:
+		return SelectorTypeGroupMembers, true
+	case tieringConfigurationSeedTypeOUContainment:
+		return SelectorTypeOUContainment, true
+	case tieringConfigurationSeedTypePropertyMatch:
+		return SelectorTypePropertyMatch, true
+	case tieringConfigurationSeedTypeKind:
+		return SelectorTypeKind, true
+	default:
+		return 0, false
+	}
+}
+
+// NewTieringConfiguration builds the configuration document for the given tags and their selectors, keyed by tag id
+func NewTieringConfiguration(tags AssetGroupTags, selectorsByTagId map[int]AssetGroupTagSelectors) TieringConfiguration {
+	config := TieringConfiguration{
+		Version: TieringConfigurationVersion,
+		Tags:    make([]TieringConfigurationTag, 0, len(tags)),
+	}
+
+	for _, tag := range tags {
+		configTag := TieringConfigurationTag{
+			Name:        tag.Name,
+			Type:        tag.ToType(),
+			Description: tag.Description,
+			Expansion:   tag.GetExpansionMethod().String(),
+			Selectors:   make([]TieringConfigurationSelector, 0, len(selectorsByTagId[tag.ID])),
+		}
+
+		if tag.Type == AssetGroupTagTypeTier {
+			position := tag.Position.ValueOrZero()
+			requireCertify := tag.RequireCertify.ValueOrZero()
+			analysisEnabled := tag.AnalysisEnabled.ValueOrZero()
+
+			configTag.Position = &position
+			configTag.RequireCertify = &requireCertify
+			configTag.AnalysisEnabled = &analysisEnabled
+		}
+
+		for _, selector := range selectorsByTagId[tag.ID] {
+			configSelector := TieringConfigurationSelector{
+				Name:         selector.Name,
+				Description:  selector.Description,
+				AutoCertify:  selector.AutoCertify.ValueOrZero(),
+				Disabled:     selector.DisabledAt.Valid,
+				Default:      selector.IsDefault,
+				Seeds:        make([]TieringConfigurationSeed, 0, len(selector.Seeds)),
+				allowDisable: selector.AllowDisable,
+			}
+
+			for _, seed := range selector.Seeds {
+				configSelector.Seeds = append(configSelector.Seeds, TieringConfigurationSeed{Type: seed.Type.String(), Value: seed.Value})
+			}
+
+			configTag.Selectors = append(configTag.Selectors, configSelector)
+		}
+
+		config.Tags = append(config.Tags, configTag)
+	}
+
+	return config
+}
+
+// ToSelectorSeeds converts the configuration seeds of a selector into model seeds
+func (s TieringConfigurationSelector) ToSelectorSeeds() []SelectorSeed {
+	seeds := make([]SelectorSeed, 0, len(s.Seeds))
+	for _, seed := range s.Seeds {
+		seedType, _ := ParseSelectorType(seed.Type)
+		seeds = append(seeds, SelectorSeed{Type: seedType, Value: seed.Value})
+	}
+	return seeds
+}
+
+func (s TieringConfigurationTag) isTier() bool {
+	return s.Type == AssetGroupTag{Type: AssetGroupTagTypeTier}.ToType()
+}
+
+// Validate checks that the document is structurally valid. It does not compare the document against existing state.
+func (s TieringConfiguration) Validate() error {
+	var (
+		tagNames       = make(map[string]struct{}, len(s.Tags))
+		tierPositions  []int32
+		tierCount      int
+		ownedTagCount  int
+		positionsGiven int
+	)
+
+	if s.Version != TieringConfigurationVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidTieringConfiguration, s.Version)
+	}
+
+	for _, tag := range s.Tags {
+		tagType, ok := ParseAssetGroupTagType(tag.Type)
+		if strings.TrimSpace(tag.Name) == "" {
+			return fmt.Errorf("%w: tag name is required", ErrInvalidTieringConfiguration)
+		} else if _, seen := tagNames[tag.Name]; seen {
+			return fmt.Errorf("%w: tag %s is defined more than once", ErrInvalidTieringConfiguration, tag.Name)
+		} else if !ok {
+			return fmt.Errorf("%w: tag %s has unknown type %q", ErrInvalidTieringConfiguration, tag.Name, tag.Type)
+		} else if tag.Expansion != "" && tag.Expansion != (AssetGroupTag{Type: tagType}).GetExpansionMethod().String() {
+			return fmt.Errorf("%w: tag %s expansion is determined by its type and must be %s", ErrInvalidTieringConfiguration, tag.Name, (AssetGroupTag{Type: tagType}).GetExpansionMethod().String())
+		}
+		tagNames[tag.Name] = struct{}{}
+
+		switch tagType {
+		case AssetGroupTagTypeTier:
+			tierCount++
+			if tag.Position != nil {
+				positionsGiven++
+				tierPositions = append(tierPositions, *tag.Position)
+			}
+		case AssetGroupTagTypeOwned:
+			ownedTagCount++
+			fallthrough
+		default:
+			if tag.Position != nil || tag.RequireCertify != nil || tag.AnalysisEnabled != nil {
+				return fmt.Errorf("%w: tag %s: position, require_certify and analysis_enabled are only allowed for tiers", ErrInvalidTieringConfiguration, tag.Name)
+			}
+		}
+
+		selectorNames := make(map[string]struct{}, len(tag.Selectors))
+		for _, selector := range tag.Selectors {
+			if strings.TrimSpace(selector.Name) == "" {
+				return fmt.Errorf("%w: tag %s has a selector without a name", ErrInvalidTieringConfiguration, tag.Name)
+			} else if _, seen := selectorNames[selector.Name]; seen {
+				return fmt.Errorf("%w: selector %s is defined more than once in tag %s", ErrInvalidTieringConfiguration, selector.Name, tag.Name)
+			} else if len(selector.Seeds) == 0 {
+				return fmt.Errorf("%w: selector %s in tag %s has no seeds", ErrInvalidTieringConfiguration, selector.Name, tag.Name)
+			}
+			selectorNames[selector.Name] = struct{}{}
+
+			for _, seed := range selector.Seeds {
+				if _, ok := ParseSelectorType(seed.Type); !ok {
+					return fmt.Errorf("%w: selector %s in tag %s has unknown seed type %q", ErrInvalidTieringConfiguration, selector.Name, tag.Name, seed.Type)
+				} else if seed.Type != selector.Seeds[0].Type {
+					return fmt.Errorf("%w: all seeds of selector %s in tag %s must be of the same type", ErrInvalidTieringConfiguration, selector.Name, tag.Name)
+				} else if strings.TrimSpace(seed.Value) == "" {
+					return fmt.Errorf("%w: selector %s in tag %s has an empty seed", ErrInvalidTieringConfiguration, selector.Name, tag.Name)
+				}
+			}
+		}
+	}
+
+	if ownedTagCount > 1 {
+		return fmt.Errorf("%w: only one owned tag may be defined", ErrInvalidTieringConfiguration)
+	}
+
+	// Positions are either omitted for every tier, in which case document order is used, or given for every tier
+	if positionsGiven > 0 {
+		if positionsGiven != tierCount {
+			return fmt.Errorf("%w: position must be set on every tier or on none", ErrInvalidTieringConfiguration)
+		}
+
+		slices.Sort(tierPositions)
+		for idx, position := range tierPositions {
+			if position != int32(idx+1) {
+				return fmt.Errorf("%w: tier positions must run from 1 to %d without gaps", ErrInvalidTieringConfiguration, tierCount)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s TieringConfiguration) orderedTiers() []TieringConfigurationTag {
+	var tiers []TieringConfigurationTag
+	for _, tag := range s.Tags {
+		if tag.isTier() {
+			tiers = append(tiers, tag)
+		}
+	}
+
+	slices.SortStableFunc(tiers, func(a, b TieringConfigurationTag) int {
+		if a.Position == nil || b.Position == nil {
+			return 0
+		}
+		return int(*a.Position - *b.Position)
+	})
+
+	return tiers
+}
+
+func seedsEqual(a, b []TieringConfigurationSeed) bool {
+	sortSeeds := func(seeds []TieringConfigurationSeed) []TieringConfigurationSeed {
+		sorted := slices.Clone(seeds)
+		slices.SortFunc(sorted, func(x, y TieringConfigurationSeed) int {
+			if c := strings.Compare(x.Type, y.Type); c != 0 {
+				return c
+			}
+			return strings.Compare(x.Value, y.Value)
+		})
+		return sorted
+	}
+
+	return slices.Equal(sortSeeds(a), sortSeeds(b))
+}
+
+func diffTieringConfigurationSelector(tagName string, current, desired TieringConfigurationSelector) ([]string, error) {
+	var fields []string
+
+	if current.Description != desired.Description {
+		fields = append(fields, "description")
+	}
+	if !seedsEqual(current.Seeds, desired.Seeds) {
+		fields = append(fields, "seeds")
+	}
+	if current.Default && len(fields) > 0 {
+		return nil, fmt.Errorf("%w: default selector %s in tag %s only supports changing auto_certify and disabled", ErrInvalidTieringConfiguration, current.Name, tagName)
+	}
+
+	if current.AutoCertify != desired.AutoCertify {
+		fields = append(fields, "auto_certify")
+	}
+	if current.Disabled != desired.Disabled {
+		if desired.Disabled && current.Default && !current.allowDisable {
+			return nil, fmt.Errorf("%w: selector %s in tag %s cannot be disabled", ErrInvalidTieringConfiguration, current.Name, tagName)
+		}
+		fields = append(fields, "disabled")
+	}
+
+	return fields, nil
+}
+
+// PlanTieringConfiguration computes the changes needed to move the current configuration to the desired one. Tags and
+// selectors that are missing from the desired configuration are only deleted when prune is set. Default selectors,
+// the owned tag and the tier in the first position are never deleted.
+func PlanTieringConfiguration(current, desired TieringConfiguration, prune bool) (TieringConfigurationPlan, error) {
+	var (
+		plan = TieringConfigurationPlan{
+			Changes:   []TieringConfigurationChange{},
+			TierOrder: []string{},
+		}
+		currentTags     = make(map[string]TieringConfigurationTag, len(current.Tags))
+		desiredTagNames = make(map[string]struct{}, len(desired.Tags))
+		updatedTags     = make(map[string]int)
+		currentTiers    = current.orderedTiers()
+	)
+
+	if err := desired.Validate(); err != nil {
+		return plan, err
+	}
+
+	for _, tag := range current.Tags {
+		currentTags[tag.Name] = tag
+	}
+
+	for _, desiredTag := range desired.Tags {
+		desiredTagNames[desiredTag.Name] = struct{}{}
+
+		if currentTag, exists := currentTags[desiredTag.Name]; !exists {
+			if tagType, _ := ParseAssetGroupTagType(desiredTag.Type); tagType == AssetGroupTagTypeOwned {
+				return plan, fmt.Errorf("%w: the owned tag cannot be created", ErrInvalidTieringConfiguration)
+			}
+
+			plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionCreate, Object: TieringConfigurationObjectTag, Tag: desiredTag.Name})
+			for _, selector := range desiredTag.Selectors {
+				plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionCreate, Object: TieringConfigurationObjectSelector, Tag: desiredTag.Name, Selector: selector.Name})
+			}
+		} else if currentTag.Type != desiredTag.Type {
+			return plan, fmt.Errorf("%w: tag %s cannot change type from %s to %s", ErrInvalidTieringConfiguration, desiredTag.Name, currentTag.Type, desiredTag.Type)
+		} else {
+			var fields []string
+			if currentTag.Description != desiredTag.Description {
+				fields = append(fields, "description")
+			}
+			if desiredTag.RequireCertify != nil && *desiredTag.RequireCertify != (currentTag.RequireCertify != nil && *currentTag.RequireCertify) {
+				fields = append(fields, "require_certify")
+			}
+			if desiredTag.AnalysisEnabled != nil && *desiredTag.AnalysisEnabled != (currentTag.AnalysisEnabled != nil && *currentTag.AnalysisEnabled) {
+				fields = append(fields, "analysis_enabled")
+			}
+			if len(fields) > 0 {
+				updatedTags[desiredTag.Name] = len(plan.Changes)
+				plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionUpdate, Object: TieringConfigurationObjectTag, Tag: desiredTag.Name, Fields: fields})
+			}
+
+			currentSelectors := make(map[string]TieringConfigurationSelector, len(currentTag.Selectors))
+			for _, selector := range currentTag.Selectors {
+				currentSelectors[selector.Name] = selector
+			}
+
+			for _, desiredSelector := range desiredTag.Selectors {
+				if currentSelector, exists := currentSelectors[desiredSelector.Name]; !exists {
+					plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionCreate, Object: TieringConfigurationObjectSelector, Tag: desiredTag.Name, Selector: desiredSelector.Name})
+				} else if fields, err := diffTieringConfigurationSelector(desiredTag.Name, currentSelector, desiredSelector); err != nil {
+					return plan, err
+				} else {
+					delete(currentSelectors, desiredSelector.Name)
+					if len(fields) > 0 {
+						plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionUpdate, Object: TieringConfigurationObjectSelector, Tag: desiredTag.Name, Selector: desiredSelector.Name, Fields: fields})
+					}
+				}
+			}
+
+			if prune {
+				// Iterate the current selectors to keep the plan order stable
+				for _, selector := range currentTag.Selectors {
+					if _, remaining := currentSelectors[selector.Name]; remaining && !selector.Default {
+						plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionDelete, Object: TieringConfigurationObjectSelector, Tag: desiredTag.Name, Selector: selector.Name})
+					}
+				}
+			}
+		}
+	}
+
+	var firstTierName string
+	if len(currentTiers) > 0 {
+		firstTierName = currentTiers[0].Name
+	}
+
+	// Tags left out of the document are kept unless pruning, in which case all but the protected tags are deleted
+	var keptTiers []string
+	for _, tag := range current.Tags {
+		if _, inDesired := desiredTagNames[tag.Name]; inDesired {
+			continue
+		}
+
+		protected := tag.Name == firstTierName || tag.Type == (AssetGroupTag{Type: AssetGroupTagTypeOwned}).ToType()
+		if prune && !protected {
+			plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionDelete, Object: TieringConfigurationObjectTag, Tag: tag.Name})
+		} else if tag.isTier() {
+			keptTiers = append(keptTiers, tag.Name)
+		} else if tag.Type == (AssetGroupTag{Type: AssetGroupTagTypeLabel}).ToType() {
+			plan.Labels++
+		}
+	}
+
+	for _, tag := range desired.Tags {
+		if tag.Type == (AssetGroupTag{Type: AssetGroupTagTypeLabel}).ToType() {
+			plan.Labels++
+		}
+	}
+
+	// Tiers kept from the current configuration keep their relative order after the tiers in the document. The
+	// first tier is always kept, so it is moved to the front when it was left out of the document.
+	for _, tier := range desired.orderedTiers() {
+		plan.TierOrder = append(plan.TierOrder, tier.Name)
+	}
+	for _, tier := range currentTiers {
+		if slices.Contains(keptTiers, tier.Name) {
+			if tier.Name == firstTierName {
+				plan.TierOrder = slices.Insert(plan.TierOrder, 0, tier.Name)
+			} else {
+				plan.TierOrder = append(plan.TierOrder, tier.Name)
+			}
+		}
+	}
+
+	if firstTierName != "" && (len(plan.TierOrder) == 0 || plan.TierOrder[0] != firstTierName) {
+		return plan, fmt.Errorf("%w: the tier in position %d must be %s", ErrInvalidTieringConfiguration, AssetGroupTierZeroPosition, firstTierName)
+	}
+
+	for idx, tierName := range plan.TierOrder {
+		if currentTag, exists := currentTags[tierName]; !exists || currentTag.Position == nil || *currentTag.Position == int32(idx+1) {
+			continue
+		} else if changeIdx, updated := updatedTags[tierName]; updated {
+			plan.Changes[changeIdx].Fields = append(plan.Changes[changeIdx].Fields, "position")
+		} else {
+			plan.Changes = append(plan.Changes, TieringConfigurationChange{Action: TieringConfigurationActionUpdate, Object: TieringConfigurationObjectTag, Tag: tierName, Fields: []string{"position"}})
+		}
+	}
+
+	return plan, nil
+}
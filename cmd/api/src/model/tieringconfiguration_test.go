@@ -0,0 +1,250 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/database/types/null"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func currentTieringConfiguration() model.TieringConfiguration {
+	var (
+		tags = model.AssetGroupTags{
+			{ID: 1, Type: model.AssetGroupTagTypeTier, Name: "Tier Zero", Position: null.Int32From(1), RequireCertify: null.BoolFrom(true), AnalysisEnabled: null.BoolFrom(true)},
+			{ID: 2, Type: model.AssetGroupTagTypeTier, Name: "Tier One", Position: null.Int32From(2), RequireCertify: null.BoolFrom(false), AnalysisEnabled: null.BoolFrom(false)},
+			{ID: 3, Type: model.AssetGroupTagTypeOwned, Name: "Owned"},
+			{ID: 4, Type: model.AssetGroupTagTypeLabel, Name: "Crown Jewels", Description: "important"},
+		}
+		selectorsByTagId = map[int]model.AssetGroupTagSelectors{
+			1: {
+				{ID: 1, AssetGroupTagId: 1, Name: "Domain Admins", IsDefault: true, AllowDisable: false, AutoCertify: null.BoolFrom(true), Seeds: []model.SelectorSeed{{Type: model.SelectorTypeCypher, Value: "MATCH (n:Group) RETURN n"}}},
+				{ID: 2, AssetGroupTagId: 1, Name: "Servers", AutoCertify: null.BoolFrom(false), Seeds: []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "S-1-2"}, {Type: model.SelectorTypeObjectId, Value: "S-1-1"}}},
+			},
+			4: {
+				{ID: 3, AssetGroupTagId: 4, Name: "Databases", AutoCertify: null.BoolFrom(false), Seeds: []model.SelectorSeed{{Type: model.SelectorTypeObjectId, Value: "S-1-3"}}},
+			},
+		}
+	)
+
+	return model.NewTieringConfiguration(tags, selectorsByTagId)
+}
+
+func TestNewTieringConfiguration(t *testing.T) {
+	config := currentTieringConfiguration()
+
+	require.Equal(t, model.TieringConfigurationVersion, config.Version)
+	require.Len(t, config.Tags, 4)
+
+	tierZero := config.Tags[0]
+	assert.Equal(t, "tier", tierZero.Type)
+	assert.Equal(t, "all", tierZero.Expansion)
+	require.NotNil(t, tierZero.Position)
+	assert.Equal(t, int32(1), *tierZero.Position)
+	require.Len(t, tierZero.Selectors, 2)
+	assert.True(t, tierZero.Selectors[0].Default)
+	assert.Equal(t, []model.TieringConfigurationSeed{{Type: "cypher", Value: "MATCH (n:Group) RETURN n"}}, tierZero.Selectors[0].Seeds)
+
+	owned := config.Tags[2]
+	assert.Equal(t, "owned", owned.Type)
+	assert.Equal(t, "none", owned.Expansion)
+	assert.Nil(t, owned.Position)
+
+	label := config.Tags[3]
+	assert.Equal(t, "children", label.Expansion)
+	assert.Nil(t, label.RequireCertify)
+	require.NoError(t, config.Validate())
+}
+
+func TestTieringConfiguration_Validate(t *testing.T) {
+	position := int32(1)
+
+	for _, tc := range []struct {
+		name   string
+		modify func(config *model.TieringConfiguration)
+		errMsg string
+	}{
+		{
+			name:   "unsupported version",
+			modify: func(config *model.TieringConfiguration) { config.Version = 2 },
+			errMsg: "unsupported version 2",
+		},
+		{
+			name:   "duplicate tag",
+			modify: func(config *model.TieringConfiguration) { config.Tags = append(config.Tags, config.Tags[1]) },
+			errMsg: "tag Tier One is defined more than once",
+		},
+		{
+			name:   "unknown type",
+			modify: func(config *model.TieringConfiguration) { config.Tags[3].Type = "badge" },
+			errMsg: "unknown type",
+		},
+		{
+			name:   "mismatched expansion",
+			modify: func(config *model.TieringConfiguration) { config.Tags[3].Expansion = "all" },
+			errMsg: "expansion is determined by its type",
+		},
+		{
+			name:   "tier fields on label",
+			modify: func(config *model.TieringConfiguration) { config.Tags[3].Position = &position },
+			errMsg: "only allowed for tiers",
+		},
+		{
+			name:   "mixed seed types",
+			modify: func(config *model.TieringConfiguration) { config.Tags[0].Selectors[1].Seeds[0].Type = "cypher" },
+			errMsg: "must be of the same type",
+		},
+		{
+			name:   "missing seeds",
+			modify: func(config *model.TieringConfiguration) { config.Tags[0].Selectors[1].Seeds = nil },
+			errMsg: "has no seeds",
+		},
+		{
+			name:   "partial positions",
+			modify: func(config *model.TieringConfiguration) { config.Tags[1].Position = nil },
+			errMsg: "position must be set on every tier or on none",
+		},
+		{
+			name: "position gap",
+			modify: func(config *model.TieringConfiguration) {
+				gap := int32(3)
+				config.Tags[1].Position = &gap
+			},
+			errMsg: "without gaps",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := currentTieringConfiguration()
+			tc.modify(&config)
+
+			err := config.Validate()
+			require.ErrorIs(t, err, model.ErrInvalidTieringConfiguration)
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestPlanTieringConfiguration(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		plan, err := model.PlanTieringConfiguration(currentTieringConfiguration(), currentTieringConfiguration(), true)
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.Equal(t, []string{"Tier Zero", "Tier One"}, plan.TierOrder)
+		assert.Equal(t, 1, plan.Labels)
+	})
+
+	t.Run("seed order is ignored", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		seeds := desired.Tags[0].Selectors[1].Seeds
+		seeds[0], seeds[1] = seeds[1], seeds[0]
+
+		plan, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+	})
+
+	t.Run("creates, updates and reorders", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		desired.Tags[1].Description = "second"
+		desired.Tags[1].Selectors = []model.TieringConfigurationSelector{{Name: "Workstations", Seeds: []model.TieringConfigurationSeed{{Type: "object_id", Value: "S-1-9"}}}}
+		desired.Tags[0].Selectors[1].AutoCertify = true
+
+		one, two := int32(1), int32(2)
+		desired.Tags[1].Position = nil
+		desired.Tags[0].Position = nil
+		desired.Tags = append(desired.Tags, model.TieringConfigurationTag{Name: "Tier Two", Type: "tier", Selectors: []model.TieringConfigurationSelector{}})
+		desired.Tags[0].Position, desired.Tags[4].Position, desired.Tags[1].Position = &one, &two, func() *int32 { three := int32(3); return &three }()
+
+		plan, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Tier Zero", "Tier Two", "Tier One"}, plan.TierOrder)
+		assert.Equal(t, []model.TieringConfigurationChange{
+			{Action: "update", Object: "selector", Tag: "Tier Zero", Selector: "Servers", Fields: []string{"auto_certify"}},
+			{Action: "update", Object: "tag", Tag: "Tier One", Fields: []string{"description", "position"}},
+			{Action: "create", Object: "selector", Tag: "Tier One", Selector: "Workstations"},
+			{Action: "create", Object: "tag", Tag: "Tier Two"},
+		}, plan.Changes)
+	})
+
+	t.Run("omitted tags are kept unless pruning", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		desired.Tags = desired.Tags[:1]
+		desired.Tags[0].Selectors = desired.Tags[0].Selectors[:0]
+
+		plan, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.Equal(t, []string{"Tier Zero", "Tier One"}, plan.TierOrder)
+
+		plan, err = model.PlanTieringConfiguration(currentTieringConfiguration(), desired, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Tier Zero"}, plan.TierOrder)
+		assert.Equal(t, 0, plan.Labels)
+		assert.Equal(t, []model.TieringConfigurationChange{
+			{Action: "delete", Object: "selector", Tag: "Tier Zero", Selector: "Servers"},
+			{Action: "delete", Object: "tag", Tag: "Tier One"},
+			{Action: "delete", Object: "tag", Tag: "Crown Jewels"},
+		}, plan.Changes)
+	})
+
+	t.Run("first tier cannot move", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		one, two := int32(1), int32(2)
+		desired.Tags[0].Position, desired.Tags[1].Position = &two, &one
+
+		_, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.ErrorIs(t, err, model.ErrInvalidTieringConfiguration)
+		require.ErrorContains(t, err, "the tier in position 1 must be Tier Zero")
+	})
+
+	t.Run("type cannot change", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		desired.Tags[1].Type = "label"
+		desired.Tags[1].Expansion = ""
+		desired.Tags[1].Position, desired.Tags[1].RequireCertify, desired.Tags[1].AnalysisEnabled = nil, nil, nil
+
+		_, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.ErrorContains(t, err, "cannot change type")
+	})
+
+	t.Run("owned tag cannot be created", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		desired.Tags[2].Name = "Owned Objects"
+
+		_, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.ErrorContains(t, err, "the owned tag cannot be created")
+	})
+
+	t.Run("default selector seeds are read only", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		desired.Tags[0].Selectors[0].Seeds = []model.TieringConfigurationSeed{{Type: "cypher", Value: "MATCH (n:User) RETURN n"}}
+
+		_, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.ErrorContains(t, err, "only supports changing auto_certify and disabled")
+	})
+
+	t.Run("default selector cannot be disabled when not allowed", func(t *testing.T) {
+		desired := currentTieringConfiguration()
+		desired.Tags[0].Selectors[0].Disabled = true
+
+		_, err := model.PlanTieringConfiguration(currentTieringConfiguration(), desired, false)
+		require.ErrorContains(t, err, "cannot be disabled")
+	})
+}
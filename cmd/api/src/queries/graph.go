@@ -26,6 +26,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -47,9 +48,12 @@ import (
 	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
 	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
 	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/bloodhound/packages/go/slicesext"
+	"github.com/specterops/dawgs/cardinality"
 	"github.com/specterops/dawgs/cypher/analyzer"
 	"github.com/specterops/dawgs/cypher/frontend"
 	"github.com/specterops/dawgs/cypher/models/cypher/format"
+	"github.com/specterops/dawgs/drivers/pg"
 	"github.com/specterops/dawgs/graph"
 	"github.com/specterops/dawgs/ops"
 	"github.com/specterops/dawgs/query"
@@ -64,12 +68,35 @@ const (
 
 	DefaultQueryFitnessLowerBoundSelector = -3
 	DefaultQueryFitnessLowerBoundExplore  = -7
+
+	// MaxShortestPathNodeSetSize caps the number of nodes either side of a shortest path search between node sets may
+	// resolve to
+	MaxShortestPathNodeSetSize = 1000
+
+	// MaxShortestPathFrontierSize caps the number of newly reached nodes a single depth of a shortest path search with
+	// exclusions may expand to
+	MaxShortestPathFrontierSize = 10000
+
+	// MaxShortestPathResults caps the number of paths a shortest path search with exclusions may return
+	MaxShortestPathResults = 1000
 )
 
+// DefaultPropertySearchFields are the node properties searched when no fields are selected, in order of precedence
+var DefaultPropertySearchFields = []string{
+	common.Name.String(),
+	common.ObjectID.String(),
+	common.Description.String(),
+	common.Email.String(),
+	ad.DistinguishedName.String(),
+	ad.ServicePrincipalNames.String(),
+}
+
 var (
 	ErrUnsupportedDataType   = errors.New("unsupported result type for this query")
 	ErrGraphUnsupported      = errors.New("type 'graph' is not supported for this endpoint")
 	ErrCypherQueryTooComplex = errors.New("cypher query is too complex and is likely to result in poor or unstable database performance")
+	ErrNodeSetTooLarge       = errors.New("node set is too large")
+	ErrPathSearchTooLarge    = errors.New("path search is too large")
 )
 
 type EntityQueryParameters struct {
@@ -80,6 +107,27 @@ type EntityQueryParameters struct {
 	Limit         int
 	PathDelegate  any
 	ListDelegate  any
+
+	// NodeFilter and PathFilter, when set, narrow the results to what the requester may read. They are applied after
+	// caching so that cached results are shared between requesters.
+	NodeFilter func(graph.NodeSet) graph.NodeSet
+	PathFilter func(graph.PathSet) graph.PathSet
+}
+
+func (s EntityQueryParameters) filterNodes(nodes graph.NodeSet) graph.NodeSet {
+	if s.NodeFilter == nil {
+		return nodes
+	}
+
+	return s.NodeFilter(nodes)
+}
+
+func (s EntityQueryParameters) filterPaths(paths graph.PathSet) graph.PathSet {
+	if s.PathFilter == nil {
+		return paths
+	}
+
+	return s.PathFilter(paths)
 }
 
 func GetEntityObjectIDFromRequestPath(request *http.Request) (string, error) {
@@ -134,7 +182,10 @@ type Graph interface {
 	GetAssetGroupComboNode(ctx context.Context, owningObjectID string, assetGroupTag string) (map[string]any, error)
 	GetAssetGroupNodes(ctx context.Context, assetGroupTag string, isSystemGroup bool) (graph.NodeSet, error)
 	GetAllShortestPaths(ctx context.Context, startNodeID string, endNodeID string, filter graph.Criteria) (graph.PathSet, error)
-	SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, nameQuery string, skip int, limit int) ([]model.SearchResult, error)
+	GetAllShortestPathsWithExclusions(ctx context.Context, startNodeID string, endNodeID string, filter graph.Criteria, exclusion graph.Criteria) (graph.PathSet, error)
+	GetAllShortestPathsBetweenNodeSets(ctx context.Context, sourceCriteria graph.Criteria, targetCriteria graph.Criteria, filter graph.Criteria) (graph.PathSet, error)
+	SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, nameQuery string, skip int, limit int, additionalFilters ...graph.Criteria) ([]model.SearchResult, error)
+	SearchNodesByProperties(ctx context.Context, nodeKinds graph.Kinds, fields []string, searchValue string, skip int, limit int, additionalFilters ...graph.Criteria) ([]model.PropertySearchResult, error)
 	SearchByNameOrObjectID(ctx context.Context, searchValue string, searchType string) (graph.NodeSet, error)
 	GetADEntityQueryResult(ctx context.Context, params EntityQueryParameters, cacheEnabled bool) (any, int, error)
 	GetEntityByObjectId(ctx context.Context, objectID string, kinds ...graph.Kind) (*graph.Node, error)
@@ -278,6 +329,248 @@ func (s *GraphQuery) GetAllShortestPaths(ctx context.Context, startNodeID string
 	})
 }
 
+// GetAllShortestPathsWithExclusions returns all shortest paths between the two nodes that do not traverse a node
+// matching the exclusion criteria. The exclusion is evaluated against the far end of every traversed relationship and
+// must therefore reference query.End(). The end node itself is never excluded.
+//
+// Databases only apply relationship criteria to the endpoints of an all shortest paths match, so the search is instead
+// driven here as a level-by-level breadth-first expansion that filters every hop. ErrPathSearchTooLarge is returned when
+// a depth reaches more than MaxShortestPathFrontierSize new nodes or more than MaxShortestPathResults paths are found.
+func (s *GraphQuery) GetAllShortestPathsWithExclusions(ctx context.Context, startNodeID string, endNodeID string, filter graph.Criteria, exclusion graph.Criteria) (graph.PathSet, error) {
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "GetAllShortestPathsWithExclusions")()
+
+	var paths graph.PathSet
+
+	return paths, s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if startNode, err := analysis.FetchNodeByObjectID(tx, startNodeID); err != nil {
+			return err
+		} else if endNode, err := analysis.FetchNodeByObjectID(tx, endNodeID); err != nil {
+			return err
+		} else if startNode.ID == endNode.ID {
+			return nil
+		} else {
+			var hopCriteria []graph.Criteria
+
+			if filter != nil {
+				hopCriteria = append(hopCriteria, filter)
+			}
+
+			if exclusion != nil {
+				hopCriteria = append(hopCriteria, query.Or(
+					query.Equals(query.EndID(), endNode.ID),
+					query.Not(exclusion),
+				))
+			}
+
+			predecessors, err := fetchShortestPathPredecessors(tx, startNode.ID, endNode.ID, hopCriteria)
+			if err != nil {
+				return err
+			}
+
+			var (
+				pathEdges [][]*graph.Relationship
+				nodeIDs   = cardinality.NewBitmap64With(startNode.ID.Uint64())
+			)
+
+			if err := predecessors.Walk(startNode.ID, endNode.ID, MaxShortestPathResults, func(edges []*graph.Relationship) {
+				for _, edge := range edges {
+					nodeIDs.Add(edge.EndID.Uint64())
+				}
+
+				pathEdges = append(pathEdges, edges)
+			}); err != nil {
+				return err
+			} else if len(pathEdges) == 0 {
+				return nil
+			} else if nodes, err := ops.FetchNodeSet(tx.Nodes().Filter(query.InIDs(query.NodeID(), graph.DuplexToGraphIDs(nodeIDs)...))); err != nil {
+				return err
+			} else {
+				for _, edges := range pathEdges {
+					path := graph.AllocatePath(len(edges))
+					path.Nodes[0] = nodes[startNode.ID]
+
+					for idx, edge := range edges {
+						path.Edges[idx] = edge
+						path.Nodes[idx+1] = nodes[edge.EndID]
+					}
+
+					paths.AddPath(path)
+				}
+
+				return nil
+			}
+		}
+	})
+}
+
+// shortestPathPredecessors maps every node reached by a breadth-first search to the relationships that first reached it
+// from the previous depth
+type shortestPathPredecessors map[graph.ID][]*graph.Relationship
+
+// fetchShortestPathPredecessors expands outbound from the start node one depth at a time until the end node is reached
+// or no further nodes can be reached. The search is abandoned once a depth reaches more than MaxShortestPathFrontierSize
+// new nodes.
+func fetchShortestPathPredecessors(tx graph.Transaction, startID graph.ID, endID graph.ID, hopCriteria []graph.Criteria) (shortestPathPredecessors, error) {
+	var (
+		predecessors = shortestPathPredecessors{}
+		visited      = cardinality.NewBitmap64With(startID.Uint64())
+		frontier     = []graph.ID{startID}
+	)
+
+	for depth := 1; len(frontier) > 0 && !visited.Contains(endID.Uint64()); depth++ {
+		var (
+			reached      = cardinality.NewBitmap64()
+			nextFrontier []graph.ID
+			criteria     = append([]graph.Criteria{query.InIDs(query.StartID(), frontier...)}, hopCriteria...)
+		)
+
+		if err := tx.Relationships().Filter(query.And(criteria...)).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+			for relationship := range cursor.Chan() {
+				if visited.Contains(relationship.EndID.Uint64()) {
+					continue
+				}
+
+				if reached.CheckedAdd(relationship.EndID.Uint64()) {
+					nextFrontier = append(nextFrontier, relationship.EndID)
+				}
+
+				predecessors[relationship.EndID] = append(predecessors[relationship.EndID], relationship)
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return nil, err
+		}
+
+		if len(nextFrontier) > MaxShortestPathFrontierSize {
+			return nil, fmt.Errorf("%w: more than %d nodes reached at depth %d", ErrPathSearchTooLarge, MaxShortestPathFrontierSize, depth)
+		}
+
+		visited.Or(reached)
+		frontier = nextFrontier
+	}
+
+	return predecessors, nil
+}
+
+// Walk calls the delegate with the ordered edges of every shortest path from the start node to the end node. The walk
+// stops and ErrPathSearchTooLarge is returned once more than limit paths are found.
+func (s shortestPathPredecessors) Walk(startID graph.ID, endID graph.ID, limit int, delegate func(edges []*graph.Relationship)) error {
+	var (
+		walked int
+		walk   func(nodeID graph.ID, reversedEdges []*graph.Relationship) error
+	)
+
+	walk = func(nodeID graph.ID, reversedEdges []*graph.Relationship) error {
+		if nodeID == startID {
+			if walked++; walked > limit {
+				return fmt.Errorf("%w: more than %d shortest paths", ErrPathSearchTooLarge, limit)
+			}
+
+			edges := slices.Clone(reversedEdges)
+			slices.Reverse(edges)
+
+			delegate(edges)
+			return nil
+		}
+
+		for _, edge := range s[nodeID] {
+			if err := walk(edge.StartID, append(reversedEdges, edge)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if _, reached := s[endID]; reached {
+		return walk(endID, nil)
+	}
+
+	return nil
+}
+
+// GetAllShortestPathsBetweenNodeSets returns the deduplicated set of all shortest paths from any node matching
+// sourceCriteria to any node matching targetCriteria. ErrNodeSetTooLarge is returned when either side matches more than
+// MaxShortestPathNodeSetSize nodes.
+func (s *GraphQuery) GetAllShortestPathsBetweenNodeSets(ctx context.Context, sourceCriteria graph.Criteria, targetCriteria graph.Criteria, filter graph.Criteria) (graph.PathSet, error) {
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "GetAllShortestPathsBetweenNodeSets")()
+
+	var paths graph.PathSet
+
+	return paths, s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if sourceNodeIDs, err := fetchNodeSetIDs(tx, sourceCriteria, "sources"); err != nil {
+			return err
+		} else if targetNodeIDs, err := fetchNodeSetIDs(tx, targetCriteria, "targets"); err != nil {
+			return err
+		} else if len(sourceNodeIDs) == 0 || len(targetNodeIDs) == 0 {
+			return nil
+		} else {
+			var (
+				seenPaths = make(pathKeySet)
+				criteria  = []graph.Criteria{
+					query.InIDs(query.StartID(), sourceNodeIDs...),
+					query.InIDs(query.EndID(), targetNodeIDs...),
+				}
+			)
+
+			if filter != nil {
+				criteria = append(criteria, filter)
+			}
+
+			return tx.Relationships().Filter(query.And(criteria...)).FetchAllShortestPaths(func(cursor graph.Cursor[graph.Path]) error {
+				for path := range cursor.Chan() {
+					if len(path.Edges) == 0 {
+						continue
+					}
+
+					// A node may be a member of both sets which can yield the same path more than once
+					if pathKey := pathEdgeKey(path); !seenPaths.Contains(pathKey) {
+						seenPaths.Add(pathKey)
+						paths.AddPath(path)
+					}
+				}
+
+				return cursor.Error()
+			})
+		}
+	})
+}
+
+// fetchNodeSetIDs fetches the IDs of the nodes matching criteria, reading at most one node past the set size limit
+func fetchNodeSetIDs(tx graph.Transaction, criteria graph.Criteria, setName string) ([]graph.ID, error) {
+	if nodeIDs, err := ops.FetchNodeIDs(tx.Nodes().Filter(criteria).Limit(MaxShortestPathNodeSetSize + 1)); err != nil {
+		return nil, err
+	} else if len(nodeIDs) > MaxShortestPathNodeSetSize {
+		return nil, fmt.Errorf("%w: %s match more than %d nodes", ErrNodeSetTooLarge, setName, MaxShortestPathNodeSetSize)
+	} else {
+		return nodeIDs, nil
+	}
+}
+
+type pathKeySet map[string]struct{}
+
+func (s pathKeySet) Add(key string) {
+	s[key] = struct{}{}
+}
+
+func (s pathKeySet) Contains(key string) bool {
+	_, found := s[key]
+	return found
+}
+
+// pathEdgeKey builds a key that uniquely identifies a path by its ordered edge IDs
+func pathEdgeKey(path graph.Path) string {
+	var builder strings.Builder
+
+	for _, edge := range path.Edges {
+		builder.WriteString(edge.ID.String())
+		builder.WriteByte(',')
+	}
+
+	return builder.String()
+}
+
 // the following negation clause matches nodes that have both ADLocalGroup and Group labels, but excludes nodes that only have the ADLocalGroup label.
 // equivalent cypher: MATCH (n) WHERE NOT (n:ADLocalGroup AND NOT n:Group)
 var groupFilter = query.Not(
@@ -336,7 +629,7 @@ func formatSearchResults(exactResults []model.SearchResult, fuzzyResults []model
 	return searchResults[skip:end]
 }
 
-func (s *GraphQuery) SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, name string, skip int, limit int) ([]model.SearchResult, error) {
+func (s *GraphQuery) SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, name string, skip int, limit int, additionalFilters ...graph.Criteria) ([]model.SearchResult, error) {
 	var (
 		exactResults  []model.SearchResult
 		fuzzyResults  []model.SearchResult
@@ -345,13 +638,17 @@ func (s *GraphQuery) SearchNodesByName(ctx context.Context, nodeKinds graph.Kind
 
 	for _, kind := range nodeKinds {
 		if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
-			if exactMatchNodes, err := ops.FetchNodes(tx.Nodes().Filter(SearchNodeByKindAndEqualsNameCriteria(kind, formattedName))); err != nil {
+			exactCriteria := append([]graph.Criteria{SearchNodeByKindAndEqualsNameCriteria(kind, formattedName)}, additionalFilters...)
+
+			if exactMatchNodes, err := ops.FetchNodes(tx.Nodes().Filter(query.And(exactCriteria...))); err != nil {
 				return err
 			} else {
 				exactResults = append(exactResults, nodesToSearchResult(exactMatchNodes...)...)
 			}
 
-			if fuzzyMatchNodes, err := ops.FetchNodes(tx.Nodes().Filter(searchNodeByKindAndContainsName(kind, formattedName))); err != nil {
+			fuzzyCriteria := append([]graph.Criteria{searchNodeByKindAndContainsName(kind, formattedName)}, additionalFilters...)
+
+			if fuzzyMatchNodes, err := ops.FetchNodes(tx.Nodes().Filter(query.And(fuzzyCriteria...))); err != nil {
 				return err
 			} else {
 				fuzzyResults = append(fuzzyResults, nodesToSearchResult(fuzzyMatchNodes...)...)
@@ -366,6 +663,181 @@ func (s *GraphQuery) SearchNodesByName(ctx context.Context, nodeKinds graph.Kind
 	return formatSearchResults(exactResults, fuzzyResults, limit, skip), nil
 }
 
+// SearchNodesByProperties searches the given node properties for the search value and ranks the results. Exact matches
+// rank above prefix matches which rank above substring matches, and within each tier nodes are ordered by name. Each
+// tier is counted and paged in the database so that only the requested page of nodes is fetched. Only the PostgreSQL
+// driver backs the searched properties with trigram indexes; on Neo4j an arbitrary property scan is too costly, so the
+// search falls back to matching name and objectid as SearchNodesByName does.
+func (s *GraphQuery) SearchNodesByProperties(ctx context.Context, nodeKinds graph.Kinds, fields []string, searchValue string, skip int, limit int, additionalFilters ...graph.Criteria) ([]model.PropertySearchResult, error) {
+	if !pg.IsPostgreSQLGraph(s.Graph) {
+		if len(nodeKinds) == 0 {
+			nodeKinds = graph.Kinds{ad.Entity, azure.Entity}
+		}
+
+		if results, err := s.SearchNodesByName(ctx, nodeKinds, searchValue, skip, limit, additionalFilters...); err != nil {
+			return nil, err
+		} else {
+			return slicesext.Map(results, func(result model.SearchResult) model.PropertySearchResult {
+				return model.PropertySearchResult{SearchResult: result}
+			}), nil
+		}
+	}
+
+	if len(fields) == 0 {
+		fields = DefaultPropertySearchFields
+	}
+
+	var (
+		results      []model.PropertySearchResult
+		baseCriteria = []graph.Criteria{groupFilter}
+	)
+
+	if len(nodeKinds) > 0 {
+		baseCriteria = append(baseCriteria, query.KindIn(query.Node(), nodeKinds...))
+	}
+
+	baseCriteria = append(baseCriteria, additionalFilters...)
+
+	if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		for _, tierCriteria := range propertySearchTiers(fields, searchValue) {
+			if limit <= 0 {
+				break
+			}
+
+			tierQuery := tx.Nodes().Filter(query.And(append([]graph.Criteria{tierCriteria}, baseCriteria...)...))
+
+			if count, err := tierQuery.Count(); err != nil {
+				return err
+			} else if int64(skip) >= count {
+				skip -= int(count)
+				continue
+			}
+
+			if nodes, err := ops.FetchNodes(tierQuery.OrderBy(query.Order(query.NodeProperty(common.Name.String()), query.Ascending())).Offset(skip).Limit(limit)); err != nil {
+				return err
+			} else {
+				for _, node := range nodes {
+					// Nodes matched by the database are always returned; the rank only reports the matched properties
+					result, _ := rankPropertySearchResult(node, fields, searchValue)
+					results = append(results, result)
+				}
+
+				skip = 0
+				limit -= len(nodes)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// propertySearchTiers returns the criteria for exact, prefix and substring matches of the search value in precedence
+// order. Each tier excludes the tiers before it so that paging through the tiers in turn never repeats a node.
+func propertySearchTiers(fields []string, searchValue string) []graph.Criteria {
+	var (
+		exactCriteria = propertySearchCriteria(fields, searchValue, func(reference graph.Criteria, value string) graph.Criteria {
+			return query.Equals(reference, value)
+		})
+		prefixCriteria = propertySearchCriteria(fields, searchValue, func(reference graph.Criteria, value string) graph.Criteria {
+			return query.StringStartsWith(reference, value)
+		})
+		containsCriteria = propertySearchCriteria(fields, searchValue, func(reference graph.Criteria, value string) graph.Criteria {
+			return query.StringContains(reference, value)
+		})
+	)
+
+	return []graph.Criteria{
+		exactCriteria,
+		query.And(prefixCriteria, query.Not(exactCriteria)),
+		query.And(containsCriteria, query.Not(prefixCriteria)),
+	}
+}
+
+// propertySearchCriteria matches the search value as given and upper cased, since AD names and distinguished names are
+// stored upper cased. Plain comparisons are used so that the PostgreSQL trigram indexes can serve the query. Each
+// comparison is guarded by the property existing so that a missing property never turns a negated tier into null.
+func propertySearchCriteria(fields []string, searchValue string, compare func(reference graph.Criteria, value string) graph.Criteria) graph.Criteria {
+	var (
+		criteria    []graph.Criteria
+		searchTerms = []string{searchValue}
+	)
+
+	if upperSearchValue := strings.ToUpper(searchValue); upperSearchValue != searchValue {
+		searchTerms = append(searchTerms, upperSearchValue)
+	}
+
+	for _, field := range fields {
+		for _, searchTerm := range searchTerms {
+			criteria = append(criteria, query.And(
+				query.Exists(query.NodeProperty(field)),
+				compare(query.NodeProperty(field), searchTerm),
+			))
+		}
+	}
+
+	return query.Or(criteria...)
+}
+
+// propertyMatchScore returns 3 for an exact match, 2 for a prefix match, 1 for a substring match and 0 otherwise
+func propertyMatchScore(value, searchValue string) int {
+	value = strings.ToLower(value)
+
+	switch {
+	case value == searchValue:
+		return 3
+	case strings.HasPrefix(value, searchValue):
+		return 2
+	case strings.Contains(value, searchValue):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func rankPropertySearchResult(node *graph.Node, fields []string, searchValue string) (model.PropertySearchResult, bool) {
+	var (
+		result = model.PropertySearchResult{
+			SearchResult: nodeToSearchResult(node),
+		}
+		lowerSearchValue = strings.ToLower(searchValue)
+	)
+
+	for idx, field := range fields {
+		var (
+			weight    = len(fields) - idx
+			bestScore = 0
+		)
+
+		switch typedValue := node.Properties.Get(field).Any().(type) {
+		case string:
+			bestScore = propertyMatchScore(typedValue, lowerSearchValue)
+
+		case []any:
+			for _, element := range typedValue {
+				if elementStr, isString := element.(string); isString {
+					bestScore = max(bestScore, propertyMatchScore(elementStr, lowerSearchValue))
+				}
+			}
+
+		case []string:
+			for _, element := range typedValue {
+				bestScore = max(bestScore, propertyMatchScore(element, lowerSearchValue))
+			}
+		}
+
+		if bestScore > 0 {
+			result.MatchedProperties = append(result.MatchedProperties, field)
+			result.Score += bestScore * weight
+		}
+	}
+
+	return result, len(result.MatchedProperties) > 0
+}
+
 type PreparedQuery struct {
 	query         string
 	StrippedQuery string
@@ -944,7 +1416,7 @@ func (s *GraphQuery) runListQuery(ctx context.Context, node *graph.Node, params
 
 	if result, err := s.runMaybeCachedEntityQuery(ctx, node, params, cacheEnabled); err != nil {
 		return nil, 0, err
-	} else if skip > result.Len() {
+	} else if result = params.filterNodes(result); skip > result.Len() {
 		return nil, 0, fmt.Errorf(utils.ErrorInvalidSkip, skip)
 	} else {
 		if skip+limit > result.Len() {
@@ -957,16 +1429,16 @@ func (s *GraphQuery) runListQuery(ctx context.Context, node *graph.Node, params
 
 func (s *GraphQuery) runCountQuery(ctx context.Context, node *graph.Node, params EntityQueryParameters, cacheEnabled bool) (any, int, error) {
 	result, err := s.runMaybeCachedEntityQuery(ctx, node, params, cacheEnabled)
-	return nil, result.Len(), err
+	return nil, params.filterNodes(result).Len(), err
 }
 
-func runPathQuery(ctx context.Context, db graph.Database, node *graph.Node, pathDelegate any) (map[string]any, int, error) {
+func runPathQuery(ctx context.Context, db graph.Database, node *graph.Node, params EntityQueryParameters) (map[string]any, int, error) {
 	var (
 		result graph.PathSet
 		err    error
 	)
 
-	switch typedDelegate := pathDelegate.(type) {
+	switch typedDelegate := params.PathDelegate.(type) {
 	case analysis.PathDelegate:
 		err = db.ReadTransaction(ctx, func(tx graph.Transaction) error {
 			if fetchedResult, err := typedDelegate(tx, node); err != nil {
@@ -986,6 +1458,7 @@ func runPathQuery(ctx context.Context, db graph.Database, node *graph.Node, path
 	if err != nil {
 		return nil, 0, err
 	} else {
+		result = params.filterPaths(result)
 		return bloodhoundgraph.PathSetToBloodHoundGraph(result), result.Len(), nil
 	}
 }
@@ -994,7 +1467,7 @@ func (s *GraphQuery) GetEntityResults(ctx context.Context, node *graph.Node, par
 	// Graph type isn't currently under a caching model and is handled separately from other supported RequestedTypes
 	switch params.RequestedType {
 	case model.DataTypeGraph:
-		return runPathQuery(ctx, s.Graph, node, params.PathDelegate)
+		return runPathQuery(ctx, s.Graph, node, params)
 	case model.DataTypeList:
 		return s.runListQuery(ctx, node, params, cacheEnabled)
 	case model.DataTypeCount:
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +28,8 @@ import (
 	graph_mocks "github.com/specterops/bloodhound/cmd/api/src/vendormocks/dawgs/graph"
 	"github.com/specterops/bloodhound/packages/go/cache"
 	"github.com/specterops/bloodhound/packages/go/graphschema"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/dawgs/cypher/models/cypher/format"
 	"github.com/specterops/dawgs/graph"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -260,3 +263,144 @@ func Test_nodesToSearchResult_default(t *testing.T) {
 	require.Equal(t, expectedObjectId, actual[0].ObjectID)
 	require.Equal(t, expectedDistinguishedName, actual[0].DistinguishedName)
 }
+
+func Test_ShortestPathPredecessorsWalk(t *testing.T) {
+	var (
+		// 1 -> 2 -> 4 and 1 -> 3 -> 4 are both shortest paths from 1 to 4
+		startToA = graph.NewRelationship(10, 1, 2, nil, ad.MemberOf)
+		startToB = graph.NewRelationship(11, 1, 3, nil, ad.MemberOf)
+		aToEnd   = graph.NewRelationship(12, 2, 4, nil, ad.GenericAll)
+		bToEnd   = graph.NewRelationship(13, 3, 4, nil, ad.GenericAll)
+
+		predecessors = shortestPathPredecessors{
+			2: {startToA},
+			3: {startToB},
+			4: {aToEnd, bToEnd},
+		}
+		walked [][]*graph.Relationship
+	)
+
+	require.NoError(t, predecessors.Walk(1, 4, 2, func(edges []*graph.Relationship) {
+		walked = append(walked, edges)
+	}))
+
+	require.Equal(t, [][]*graph.Relationship{
+		{startToA, aToEnd},
+		{startToB, bToEnd},
+	}, walked)
+
+	t.Run("unreached end node", func(t *testing.T) {
+		require.NoError(t, predecessors.Walk(1, 5, 2, func(edges []*graph.Relationship) {
+			t.Fatal("no path should be walked")
+		}))
+	})
+
+	t.Run("path limit exceeded", func(t *testing.T) {
+		var limited [][]*graph.Relationship
+
+		err := predecessors.Walk(1, 4, 1, func(edges []*graph.Relationship) {
+			limited = append(limited, edges)
+		})
+
+		require.ErrorIs(t, err, ErrPathSearchTooLarge)
+		require.Len(t, limited, 1)
+	})
+}
+
+func Test_RankPropertySearchResult(t *testing.T) {
+	var (
+		fields = []string{"name", "description", "serviceprincipalnames"}
+		node   = graph.NewNode(1, graph.AsProperties(map[string]any{
+			"name":                  "SVC_SQL@TESTLAB.LOCAL",
+			"description":           "svc_sql service account",
+			"serviceprincipalnames": []any{"MSSQLSvc/db01.testlab.local:1433", "svc_sql/db01"},
+		}), ad.User)
+	)
+
+	t.Run("ranks by match quality and field order", func(t *testing.T) {
+		result, matched := rankPropertySearchResult(node, fields, "SVC_SQL")
+
+		require.True(t, matched)
+		require.Equal(t, []string{"name", "description", "serviceprincipalnames"}, result.MatchedProperties)
+		// name prefix (2 * 3) + description prefix (2 * 2) + spn prefix (2 * 1)
+		require.Equal(t, 12, result.Score)
+	})
+
+	t.Run("matches array elements", func(t *testing.T) {
+		result, matched := rankPropertySearchResult(node, fields, "mssqlsvc")
+
+		require.True(t, matched)
+		require.Equal(t, []string{"serviceprincipalnames"}, result.MatchedProperties)
+		require.Equal(t, 2, result.Score)
+	})
+
+	t.Run("exact match outranks contains", func(t *testing.T) {
+		exact, _ := rankPropertySearchResult(node, []string{"description"}, "svc_sql service account")
+		contains, _ := rankPropertySearchResult(node, []string{"description"}, "service")
+
+		require.Greater(t, exact.Score, contains.Score)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, matched := rankPropertySearchResult(node, fields, "not present")
+		require.False(t, matched)
+	})
+}
+
+func Test_PropertySearchTiers(t *testing.T) {
+	var (
+		tiers    = propertySearchTiers([]string{"name"}, "SVC")
+		rendered []string
+	)
+
+	for _, tier := range tiers {
+		output := &strings.Builder{}
+
+		require.NoError(t, format.NewCypherEmitter(false).WriteExpression(output, tier))
+		rendered = append(rendered, output.String())
+	}
+
+	// Each tier after the first excludes the tier before it so that paging through them never repeats a node
+	require.Equal(t, []string{
+		"(n.name is not null and n.name = $)",
+		"(n.name is not null and n.name starts with $) and not ((n.name is not null and n.name = $))",
+		"(n.name is not null and n.name contains $) and not ((n.name is not null and n.name starts with $))",
+	}, rendered)
+}
+
+func Test_runListQuery_NodeFilter(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = graph_mocks.NewMockDatabase(mockCtrl)
+		node     = graph.NewNode(0, graph.NewProperties(), ad.Group)
+		kept     = graph.NewNode(1, graph.AsProperties(map[string]any{"objectid": "kept", "name": "KEPT"}), ad.User)
+		dropped  = graph.NewNode(2, graph.AsProperties(map[string]any{"objectid": "dropped", "name": "DROPPED"}), ad.User)
+
+		graphQueryInst = &GraphQuery{Graph: mockDB}
+		params         = EntityQueryParameters{
+			RequestedType: model.DataTypeList,
+			Limit:         10,
+			ListDelegate: func(tx graph.Transaction, node *graph.Node, skip, limit int) (graph.NodeSet, error) {
+				return graph.NewNodeSet(kept, dropped), nil
+			},
+			NodeFilter: func(nodes graph.NodeSet) graph.NodeSet {
+				return graph.NewNodeSet(nodes.Get(kept.ID))
+			},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(nil)
+	}).Times(2)
+
+	results, count, err := graphQueryInst.runListQuery(context.Background(), node, params, false)
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+	require.Len(t, results, 1)
+	require.Equal(t, "kept", results[0].ObjectID)
+
+	_, count, err = graphQueryInst.runCountQuery(context.Background(), node, params, false)
+	require.Nil(t, err)
+	require.Equal(t, 1, count)
+}
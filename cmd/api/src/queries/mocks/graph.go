@@ -196,6 +196,36 @@ func (mr *MockGraphMockRecorder) GetAllShortestPaths(ctx, startNodeID, endNodeID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllShortestPaths", reflect.TypeOf((*MockGraph)(nil).GetAllShortestPaths), ctx, startNodeID, endNodeID, filter)
 }
 
+// GetAllShortestPathsBetweenNodeSets mocks base method.
+func (m *MockGraph) GetAllShortestPathsBetweenNodeSets(ctx context.Context, sourceCriteria, targetCriteria, filter graph.Criteria) (graph.PathSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllShortestPathsBetweenNodeSets", ctx, sourceCriteria, targetCriteria, filter)
+	ret0, _ := ret[0].(graph.PathSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllShortestPathsBetweenNodeSets indicates an expected call of GetAllShortestPathsBetweenNodeSets.
+func (mr *MockGraphMockRecorder) GetAllShortestPathsBetweenNodeSets(ctx, sourceCriteria, targetCriteria, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllShortestPathsBetweenNodeSets", reflect.TypeOf((*MockGraph)(nil).GetAllShortestPathsBetweenNodeSets), ctx, sourceCriteria, targetCriteria, filter)
+}
+
+// GetAllShortestPathsWithExclusions mocks base method.
+func (m *MockGraph) GetAllShortestPathsWithExclusions(ctx context.Context, startNodeID, endNodeID string, filter, exclusion graph.Criteria) (graph.PathSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllShortestPathsWithExclusions", ctx, startNodeID, endNodeID, filter, exclusion)
+	ret0, _ := ret[0].(graph.PathSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllShortestPathsWithExclusions indicates an expected call of GetAllShortestPathsWithExclusions.
+func (mr *MockGraphMockRecorder) GetAllShortestPathsWithExclusions(ctx, startNodeID, endNodeID, filter, exclusion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllShortestPathsWithExclusions", reflect.TypeOf((*MockGraph)(nil).GetAllShortestPathsWithExclusions), ctx, startNodeID, endNodeID, filter, exclusion)
+}
+
 // GetAssetGroupComboNode mocks base method.
 func (m *MockGraph) GetAssetGroupComboNode(ctx context.Context, owningObjectID, assetGroupTag string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -376,18 +406,43 @@ func (mr *MockGraphMockRecorder) SearchByNameOrObjectID(ctx, searchValue, search
 }
 
 // SearchNodesByName mocks base method.
-func (m *MockGraph) SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, nameQuery string, skip, limit int) ([]model.SearchResult, error) {
+func (m *MockGraph) SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, nameQuery string, skip, limit int, additionalFilters ...graph.Criteria) ([]model.SearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchNodesByName", ctx, nodeKinds, nameQuery, skip, limit)
+	varargs := []any{ctx, nodeKinds, nameQuery, skip, limit}
+	for _, a := range additionalFilters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchNodesByName", varargs...)
 	ret0, _ := ret[0].([]model.SearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchNodesByName indicates an expected call of SearchNodesByName.
-func (mr *MockGraphMockRecorder) SearchNodesByName(ctx, nodeKinds, nameQuery, skip, limit any) *gomock.Call {
+func (mr *MockGraphMockRecorder) SearchNodesByName(ctx, nodeKinds, nameQuery, skip, limit any, additionalFilters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, nodeKinds, nameQuery, skip, limit}, additionalFilters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNodesByName", reflect.TypeOf((*MockGraph)(nil).SearchNodesByName), varargs...)
+}
+
+// SearchNodesByProperties mocks base method.
+func (m *MockGraph) SearchNodesByProperties(ctx context.Context, nodeKinds graph.Kinds, fields []string, searchValue string, skip, limit int, additionalFilters ...graph.Criteria) ([]model.PropertySearchResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, nodeKinds, fields, searchValue, skip, limit}
+	for _, a := range additionalFilters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchNodesByProperties", varargs...)
+	ret0, _ := ret[0].([]model.PropertySearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchNodesByProperties indicates an expected call of SearchNodesByProperties.
+func (mr *MockGraphMockRecorder) SearchNodesByProperties(ctx, nodeKinds, fields, searchValue, skip, limit any, additionalFilters ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNodesByName", reflect.TypeOf((*MockGraph)(nil).SearchNodesByName), ctx, nodeKinds, nameQuery, skip, limit)
+	varargs := append([]any{ctx, nodeKinds, fields, searchValue, skip, limit}, additionalFilters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNodesByProperties", reflect.TypeOf((*MockGraph)(nil).SearchNodesByProperties), varargs...)
 }
 
 // UpdateSelectorTags mocks base method.
@@ -33,6 +33,7 @@ import (
 	"github.com/specterops/bloodhound/cmd/api/src/daemons/api/toolapi"
 	"github.com/specterops/bloodhound/cmd/api/src/daemons/datapipe"
 	"github.com/specterops/bloodhound/cmd/api/src/daemons/gc"
+	"github.com/specterops/bloodhound/cmd/api/src/daemons/webhook"
 	"github.com/specterops/bloodhound/cmd/api/src/database"
 	"github.com/specterops/bloodhound/cmd/api/src/model/appcfg"
 	"github.com/specterops/bloodhound/cmd/api/src/queries"
@@ -132,6 +133,7 @@ func Entrypoint(ctx context.Context, cfg config.Configuration, connections boots
 		return []daemons.Daemon{
 			bhapi.NewDaemon(cfg, routerInst.Handler()),
 			gc.NewDataPruningDaemon(connections.RDMS),
+			webhook.NewDaemon(connections.RDMS),
 			datapipeDaemon,
 		}, nil
 	}
@@ -0,0 +1,213 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run go.uber.org/mock/mockgen -copyright_file=../../../../../LICENSE.header -destination=./mocks/mock.go -package=mocks . GraphSnapshotData
+package graphsnapshot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/cmd/api/src/model"
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/bloodhound/packages/go/analysis/tiering"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/ops"
+	"github.com/specterops/dawgs/query"
+)
+
+// batchSize is the number of node or edge fingerprints written to the database at a time
+const batchSize = 10_000
+
+type GraphSnapshotData interface {
+	CreateGraphSnapshot(ctx context.Context, runID string) (model.GraphSnapshot, error)
+	AppendGraphSnapshotNodes(ctx context.Context, snapshotID int64, nodes model.GraphSnapshotNodes) error
+	AppendGraphSnapshotEdges(ctx context.Context, snapshotID int64, edges model.GraphSnapshotEdges) error
+	CompleteGraphSnapshot(ctx context.Context, snapshotID int64) (model.GraphSnapshot, error)
+	DeleteGraphSnapshotsExceptLatest(ctx context.Context, keep int) error
+}
+
+// SaveGraphSnapshot fingerprints every node and edge in the graph under a new run ID so that the graph can later be
+// diffed against the state of other analysis runs. Only the latest retention snapshots are kept, see
+// appcfg.GetGraphSnapshotsParameter.
+func SaveGraphSnapshot(ctx context.Context, db GraphSnapshotData, graphDB graph.Database, retention int) (model.GraphSnapshot, error) {
+	slog.InfoContext(ctx, "Started Graph Snapshot")
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "Successfully Completed Graph Snapshot")()
+
+	runID, err := uuid.NewV4()
+	if err != nil {
+		return model.GraphSnapshot{}, fmt.Errorf("could not generate graph snapshot run id: %w", err)
+	}
+
+	snapshot, err := db.CreateGraphSnapshot(ctx, runID.String())
+	if err != nil {
+		return snapshot, fmt.Errorf("could not create graph snapshot: %w", err)
+	}
+
+	if err := graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		return tx.Nodes().Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			batch := make(model.GraphSnapshotNodes, 0, batchSize)
+
+			for node := range cursor.Chan() {
+				if objectID, err := node.Properties.Get(common.ObjectID.String()).String(); err != nil || objectID == "" {
+					continue
+				} else {
+					batch = append(batch, NodeFingerprint(objectID, node))
+				}
+
+				if len(batch) == batchSize {
+					if err := db.AppendGraphSnapshotNodes(ctx, snapshot.ID, batch); err != nil {
+						return err
+					}
+
+					batch = batch[:0]
+				}
+			}
+
+			if err := cursor.Error(); err != nil {
+				return err
+			}
+
+			return db.AppendGraphSnapshotNodes(ctx, snapshot.ID, batch)
+		})
+	}); err != nil {
+		return snapshot, fmt.Errorf("could not save graph snapshot nodes: %w", err)
+	}
+
+	// Edges are paged by ID so that only the endpoints of a single batch are held in memory at a time
+	if err := graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		var lastEdgeID *graph.ID
+
+		for {
+			if edges, err := fetchEdgeBatch(tx, lastEdgeID); err != nil {
+				return err
+			} else if len(edges) == 0 {
+				return nil
+			} else if batch, err := edgeFingerprints(tx, edges); err != nil {
+				return err
+			} else if err := db.AppendGraphSnapshotEdges(ctx, snapshot.ID, batch); err != nil {
+				return err
+			} else {
+				lastEdgeID = &edges[len(edges)-1].ID
+			}
+		}
+	}); err != nil {
+		return snapshot, fmt.Errorf("could not save graph snapshot edges: %w", err)
+	}
+
+	if snapshot, err = db.CompleteGraphSnapshot(ctx, snapshot.ID); err != nil {
+		return snapshot, fmt.Errorf("could not complete graph snapshot: %w", err)
+	} else if err := db.DeleteGraphSnapshotsExceptLatest(ctx, retention); err != nil {
+		return snapshot, fmt.Errorf("could not prune graph snapshots: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// fetchEdgeBatch fetches the next batch of edges ordered by ID, starting after lastEdgeID if set
+func fetchEdgeBatch(tx graph.Transaction, lastEdgeID *graph.ID) ([]graph.RelationshipKindsResult, error) {
+	var (
+		edges     = make([]graph.RelationshipKindsResult, 0, batchSize)
+		edgeQuery = tx.Relationships()
+	)
+
+	if lastEdgeID != nil {
+		edgeQuery = edgeQuery.Filter(query.GreaterThan(query.RelationshipID(), *lastEdgeID))
+	}
+
+	return edges, edgeQuery.OrderBy(query.Order(query.RelationshipID(), query.Ascending())).Limit(batchSize).FetchKinds(func(cursor graph.Cursor[graph.RelationshipKindsResult]) error {
+		for next := range cursor.Chan() {
+			edges = append(edges, next)
+		}
+
+		return cursor.Error()
+	})
+}
+
+// edgeFingerprints fingerprints edges by the object IDs of their endpoints since graph IDs are not stable across
+// ingests. Edges with an endpoint lacking an object ID are skipped.
+func edgeFingerprints(tx graph.Transaction, edges []graph.RelationshipKindsResult) (model.GraphSnapshotEdges, error) {
+	var (
+		endpoints    = graph.NewNodeSet()
+		fingerprints = make(model.GraphSnapshotEdges, 0, len(edges))
+	)
+
+	for _, edge := range edges {
+		endpoints.Add(graph.NewNode(edge.StartID, graph.NewProperties()))
+		endpoints.Add(graph.NewNode(edge.EndID, graph.NewProperties()))
+	}
+
+	if err := ops.FetchNodeProperties(tx, endpoints, []string{common.ObjectID.String()}); err != nil {
+		return nil, err
+	}
+
+	objectID := func(nodeID graph.ID) string {
+		if node := endpoints.Get(nodeID); node != nil {
+			value, _ := node.Properties.GetOrDefault(common.ObjectID.String(), "").String()
+			return value
+		}
+
+		return ""
+	}
+
+	for _, edge := range edges {
+		if startObjectID, endObjectID := objectID(edge.StartID), objectID(edge.EndID); startObjectID != "" && endObjectID != "" && edge.Kind != nil {
+			fingerprints = append(fingerprints, model.GraphSnapshotEdge{
+				StartObjectID: startObjectID,
+				Kind:          edge.Kind.String(),
+				EndObjectID:   endObjectID,
+			})
+		}
+	}
+
+	return fingerprints, nil
+}
+
+// NodeFingerprint builds the snapshot fingerprint of a node. Tags are the node's asset group tag kinds, including the
+// legacy tier zero and owned system tags, sorted so that fingerprints of the same node are comparable between runs.
+func NodeFingerprint(objectID string, node *graph.Node) model.GraphSnapshotNode {
+	var tags []string
+
+	for _, kind := range node.Kinds {
+		if strings.HasPrefix(kind.String(), model.AssetGroupTagKindPrefix) {
+			tags = append(tags, kind.String())
+		}
+	}
+
+	if tiering.IsTierZero(node) && !slices.Contains(tags, tiering.StrTagTierZero) {
+		tags = append(tags, tiering.StrTagTierZero)
+	}
+
+	if tiering.IsOwned(node) && !slices.Contains(tags, tiering.StrTagOwned) {
+		tags = append(tags, tiering.StrTagOwned)
+	}
+
+	slices.Sort(tags)
+	name, _ := node.Properties.GetOrDefault(common.Name.String(), "").String()
+
+	return model.GraphSnapshotNode{
+		ObjectID: objectID,
+		Kind:     analysis.GetNodeKindDisplayLabel(node),
+		Name:     name,
+		Tags:     strings.Join(tags, " "),
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphsnapshot_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cmd/api/src/services/graphsnapshot"
+	"github.com/specterops/bloodhound/packages/go/analysis/tiering"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeFingerprint(t *testing.T) {
+	t.Run("Tag kinds are sorted", func(t *testing.T) {
+		node := graph.NewNode(1, graph.AsProperties(map[string]any{
+			common.Name.String(): "ALICE@TESTLAB.LOCAL",
+		}), ad.Entity, ad.User, graph.StringKind("Tag_Tier_Zero"), graph.StringKind("Tag_Custom"))
+
+		fingerprint := graphsnapshot.NodeFingerprint("S-1-5-21-1", node)
+		require.Equal(t, "S-1-5-21-1", fingerprint.ObjectID)
+		require.Equal(t, ad.User.String(), fingerprint.Kind)
+		require.Equal(t, "ALICE@TESTLAB.LOCAL", fingerprint.Name)
+		require.Equal(t, "Tag_Custom Tag_Tier_Zero", fingerprint.Tags)
+	})
+
+	t.Run("System tags are included", func(t *testing.T) {
+		node := graph.NewNode(1, graph.AsProperties(map[string]any{
+			common.SystemTags.String(): ad.AdminTierZero + " " + ad.Owned,
+		}), ad.Entity, ad.Computer)
+
+		fingerprint := graphsnapshot.NodeFingerprint("S-1-5-21-2", node)
+		require.Equal(t, "", fingerprint.Name)
+		require.Equal(t, tiering.StrTagOwned+" "+tiering.StrTagTierZero, fingerprint.Tags)
+	})
+
+	t.Run("Untagged node", func(t *testing.T) {
+		node := graph.NewNode(1, graph.NewProperties(), ad.Entity, ad.Group)
+		require.Equal(t, "", graphsnapshot.NodeFingerprint("S-1-5-21-3", node).Tags)
+	})
+}
@@ -40,6 +40,8 @@ public static readonly string Email = "email";
 public static readonly string IsInherited = "isinherited";
 public static readonly string CompositionID = "compositionid";
 public static readonly string PrimaryKind = "primarykind";
+public static readonly string InboundExposure = "inboundexposure";
+public static readonly string OutboundImpact = "outboundimpact";
 public static readonly string AdminCount = "admincount";
 public static readonly string CASecurityCollected = "casecuritycollected";
 public static readonly string CAName = "caname";
@@ -72,6 +74,7 @@ public static readonly string Enforced = "enforced";
 public static readonly string Department = "department";
 public static readonly string HasCrossCertificatePair = "hascrosscertificatepair";
 public static readonly string HasSPN = "hasspn";
+public static readonly string ServicePrincipalNames = "serviceprincipalnames";
 public static readonly string UnconstrainedDelegation = "unconstraineddelegation";
 public static readonly string LastLogon = "lastlogon";
 public static readonly string LastLogonTimestamp = "lastlogontimestamp";
@@ -250,6 +250,13 @@ HasSPN: types.#StringEnum & {
 	representation: "hasspn"
 }
 
+ServicePrincipalNames: types.#StringEnum & {
+	symbol:         "ServicePrincipalNames"
+	schema:         "ad"
+	name:           "Service Principal Names"
+	representation: "serviceprincipalnames"
+}
+
 HasLAPS: types.#StringEnum & {
 	symbol:         "HasLAPS"
 	schema:         "ad"
@@ -1031,6 +1038,7 @@ Properties: [
 	Department,
 	HasCrossCertificatePair,
 	HasSPN,
+	ServicePrincipalNames,
 	UnconstrainedDelegation,
 	LastLogon,
 	LastLogonTimestamp,
@@ -164,6 +164,20 @@ PrimaryKind: types.#StringEnum & {
 	representation: "primarykind"
 }
 
+InboundExposure: types.#StringEnum & {
+	symbol:         "InboundExposure"
+	schema:         "common"
+	name:           "Inbound Exposure"
+	representation: "inboundexposure"
+}
+
+OutboundImpact: types.#StringEnum & {
+	symbol:         "OutboundImpact"
+	schema:         "common"
+	name:           "Outbound Impact"
+	representation: "outboundimpact"
+}
+
 Properties: [
 	ObjectID,
 	Name,
@@ -183,7 +197,9 @@ Properties: [
 	Email,
 	IsInherited,
 	CompositionID,
-	PrimaryKind
+	PrimaryKind,
+	InboundExposure,
+	OutboundImpact
 ]
 
 // Kinds
@@ -0,0 +1,129 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/query"
+)
+
+const (
+	// DefaultMaxOutboundAdjacencyEdges caps the number of relationships a GraphOutboundAdjacency holds in memory
+	DefaultMaxOutboundAdjacencyEdges = 2_000_000
+
+	outboundAdjacencyBatchSize = 10_000
+)
+
+var ErrAdjacencyTooLarge = errors.New("outbound adjacency is too large")
+
+// OutboundEdge is a traversable relationship leaving a node
+type OutboundEdge struct {
+	EndID graph.ID
+	Kind  graph.Kind
+}
+
+// OutboundAdjacency resolves the outbound traversable relationships of the nodes a traversal reaches. Traversals pass
+// each frontier to Load before reading the relationships of its nodes with Outbound.
+type OutboundAdjacency interface {
+	Load(ctx context.Context, nodeIDs []graph.ID) error
+	Outbound(nodeID graph.ID) []OutboundEdge
+}
+
+// StaticOutboundAdjacency is an OutboundAdjacency that is already held in memory
+type StaticOutboundAdjacency map[graph.ID][]OutboundEdge
+
+func (s StaticOutboundAdjacency) Load(ctx context.Context, nodeIDs []graph.ID) error {
+	return nil
+}
+
+func (s StaticOutboundAdjacency) Outbound(nodeID graph.ID) []OutboundEdge {
+	return s[nodeID]
+}
+
+// GraphOutboundAdjacency fetches the outbound AD and Azure pathfinding relationships of the nodes a traversal reaches,
+// one frontier at a time. Only the relationships of nodes that have been loaded are held in memory, and Load fails with
+// ErrAdjacencyTooLarge once more than maxEdges relationships have been fetched.
+type GraphOutboundAdjacency struct {
+	db       graph.Database
+	maxEdges int
+	numEdges int
+	loaded   cardinality.Duplex[uint64]
+	outbound map[graph.ID][]OutboundEdge
+}
+
+func NewGraphOutboundAdjacency(db graph.Database, maxEdges int) *GraphOutboundAdjacency {
+	return &GraphOutboundAdjacency{
+		db:       db,
+		maxEdges: maxEdges,
+		loaded:   cardinality.NewBitmap64(),
+		outbound: map[graph.ID][]OutboundEdge{},
+	}
+}
+
+func (s *GraphOutboundAdjacency) Load(ctx context.Context, nodeIDs []graph.ID) error {
+	var (
+		pending   []graph.ID
+		traversal = append(ad.PathfindingRelationships(), azure.PathfindingRelationships()...)
+	)
+
+	for _, nodeID := range nodeIDs {
+		if s.loaded.CheckedAdd(nodeID.Uint64()) {
+			pending = append(pending, nodeID)
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	return s.db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		for batch := range slices.Chunk(pending, outboundAdjacencyBatchSize) {
+			if err := tx.Relationships().Filter(query.And(
+				query.InIDs(query.StartID(), batch...),
+				query.KindIn(query.Relationship(), traversal...),
+			)).FetchKinds(func(cursor graph.Cursor[graph.RelationshipKindsResult]) error {
+				for next := range cursor.Chan() {
+					if next.StartID == next.EndID {
+						continue
+					} else if s.numEdges++; s.numEdges > s.maxEdges {
+						return fmt.Errorf("%w: more than %d relationships", ErrAdjacencyTooLarge, s.maxEdges)
+					}
+
+					s.outbound[next.StartID] = append(s.outbound[next.StartID], OutboundEdge{EndID: next.EndID, Kind: next.Kind})
+				}
+
+				return cursor.Error()
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *GraphOutboundAdjacency) Outbound(nodeID graph.ID) []OutboundEdge {
+	return s.outbound[nodeID]
+}
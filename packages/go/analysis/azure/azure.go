@@ -98,6 +98,16 @@ func GetDescendentKinds(kind graph.Kind) []graph.Kind {
 	return nil
 }
 
+// AzureRoleScopeKinds returns the AzureRM role assignment edges that grant control over the targeted scope and, by
+// inheritance, every resource contained beneath it.
+func AzureRoleScopeKinds() graph.Kinds {
+	return []graph.Kind{
+		azure.Owner,
+		azure.UserAccessAdministrator,
+		azure.Contributor,
+	}
+}
+
 func AzureNonDescentKinds() graph.Kinds {
 	return []graph.Kind{
 		azure.MemberOf,
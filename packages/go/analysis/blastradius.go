@@ -0,0 +1,217 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/specterops/bloodhound/packages/go/analysis/impact"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+)
+
+// BlastRadiusPath is the shortest path from an owned node to a target node. Kinds[i] is the kind of the relationship
+// between NodeIDs[i] and NodeIDs[i+1].
+type BlastRadiusPath struct {
+	NodeIDs []graph.ID
+	Kinds   graph.Kinds
+}
+
+// BlastRadius describes everything reachable from a set of owned nodes
+type BlastRadius struct {
+	// Reachable contains every node reachable from at least one owned node, excluding the owned nodes themselves
+	Reachable cardinality.Duplex[uint64]
+
+	// ReachableByOwned contains the nodes reachable from each owned node, excluding the owned node itself
+	ReachableByOwned map[graph.ID]cardinality.Duplex[uint64]
+
+	// ShortestPaths contains the shortest path from the closest owned node to every reachable target node
+	ShortestPaths map[graph.ID]BlastRadiusPath
+}
+
+// ComputeBlastRadius resolves what each owned node can reach through the outbound adjacency using an
+// impact.PathAggregator and finds the shortest path from any owned node to each of the given target nodes. Only the
+// relationships of nodes reachable from the owned nodes are loaded.
+func ComputeBlastRadius(ctx context.Context, outbound OutboundAdjacency, owned []graph.ID, targets cardinality.Duplex[uint64]) (BlastRadius, error) {
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "ComputeBlastRadius")()
+
+	var (
+		ownedIDs = cardinality.NewBitmap64With(graph.IDsToUint64Slice(owned)...)
+		result   = BlastRadius{
+			Reachable:        cardinality.NewBitmap64(),
+			ReachableByOwned: make(map[graph.ID]cardinality.Duplex[uint64], len(owned)),
+		}
+	)
+
+	aggregator, err := aggregateOutboundPaths(ctx, outbound, owned)
+	if err != nil {
+		return result, err
+	}
+
+	for _, ownedID := range owned {
+		reachable := aggregator.Cardinality(ownedID.Uint64()).(cardinality.Duplex[uint64])
+		reachable.Remove(ownedID.Uint64())
+
+		result.ReachableByOwned[ownedID] = reachable
+		result.Reachable.Or(reachable)
+	}
+
+	// Owned nodes are already compromised so they are not part of the blast radius
+	ownedIDs.Each(func(ownedID uint64) bool {
+		result.Reachable.Remove(ownedID)
+		return true
+	})
+
+	result.ShortestPaths, err = shortestOutboundPaths(ctx, outbound, owned, targets)
+	return result, err
+}
+
+// aggregateOutboundPaths walks the outbound adjacency breadth first from each owned node, one depth at a time, encoding
+// terminal paths and shortcuts to already traversed nodes so that the aggregator can resolve the full reach of every
+// owned node
+func aggregateOutboundPaths(ctx context.Context, outbound OutboundAdjacency, owned []graph.ID) (impact.PathAggregator, error) {
+	var (
+		aggregator = impact.NewAggregator(func() cardinality.Provider[uint64] {
+			return cardinality.NewBitmap64()
+		})
+		traversed = cardinality.NewBitmap64()
+		roots     = slices.Clone(owned)
+	)
+
+	// Traversal order affects which paths are encoded as shortcuts so keep it stable between runs
+	slices.Sort(roots)
+
+	for _, rootID := range roots {
+		if !traversed.CheckedAdd(rootID.Uint64()) {
+			continue
+		}
+
+		frontier := []*graph.PathSegment{graph.NewRootPathSegment(graph.NewNode(rootID, graph.NewProperties()))}
+
+		for len(frontier) > 0 {
+			var next []*graph.PathSegment
+
+			if err := outbound.Load(ctx, pathSegmentNodeIDs(frontier)); err != nil {
+				return aggregator, err
+			}
+
+			for _, segment := range frontier {
+				var nextSegments []*graph.PathSegment
+
+				for _, edge := range outbound.Outbound(segment.Node.ID) {
+					nextSegment := segment.Descend(
+						graph.NewNode(edge.EndID, graph.NewProperties()),
+						graph.NewRelationship(0, segment.Node.ID, edge.EndID, graph.NewProperties(), edge.Kind),
+					)
+
+					if traversed.CheckedAdd(edge.EndID.Uint64()) {
+						nextSegments = append(nextSegments, nextSegment)
+					} else {
+						aggregator.AddShortcut(nextSegment)
+					}
+				}
+
+				// Is this path terminal?
+				if len(nextSegments) == 0 {
+					aggregator.AddPath(segment)
+				}
+
+				next = append(next, nextSegments...)
+			}
+
+			frontier = next
+		}
+	}
+
+	return aggregator, nil
+}
+
+func pathSegmentNodeIDs(segments []*graph.PathSegment) []graph.ID {
+	nodeIDs := make([]graph.ID, len(segments))
+
+	for idx, segment := range segments {
+		nodeIDs[idx] = segment.Node.ID
+	}
+
+	return nodeIDs
+}
+
+// shortestOutboundPaths runs a single breadth first search seeded with every owned node and records the path taken to
+// reach each target node
+func shortestOutboundPaths(ctx context.Context, outbound OutboundAdjacency, owned []graph.ID, targets cardinality.Duplex[uint64]) (map[graph.ID]BlastRadiusPath, error) {
+	type parent struct {
+		nodeID graph.ID
+		kind   graph.Kind
+	}
+
+	var (
+		paths    = map[graph.ID]BlastRadiusPath{}
+		parents  = make(map[graph.ID]parent, len(owned))
+		frontier = make([]graph.ID, 0, len(owned))
+	)
+
+	for _, ownedID := range owned {
+		if _, seen := parents[ownedID]; !seen {
+			parents[ownedID] = parent{nodeID: ownedID}
+			frontier = append(frontier, ownedID)
+		}
+	}
+
+	for len(frontier) > 0 {
+		var next []graph.ID
+
+		if err := outbound.Load(ctx, frontier); err != nil {
+			return nil, err
+		}
+
+		for _, nodeID := range frontier {
+			for _, edge := range outbound.Outbound(nodeID) {
+				if _, seen := parents[edge.EndID]; seen {
+					continue
+				}
+
+				parents[edge.EndID] = parent{nodeID: nodeID, kind: edge.Kind}
+				next = append(next, edge.EndID)
+
+				if targets.Contains(edge.EndID.Uint64()) {
+					var path BlastRadiusPath
+
+					for cursor := edge.EndID; ; cursor = parents[cursor].nodeID {
+						path.NodeIDs = append(path.NodeIDs, cursor)
+
+						if parents[cursor].nodeID == cursor {
+							break
+						}
+
+						path.Kinds = append(path.Kinds, parents[cursor].kind)
+					}
+
+					slices.Reverse(path.NodeIDs)
+					slices.Reverse(path.Kinds)
+					paths[edge.EndID] = path
+				}
+			}
+		}
+
+		frontier = next
+	}
+
+	return paths, nil
+}
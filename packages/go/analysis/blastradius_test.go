@@ -0,0 +1,90 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeBlastRadius(t *testing.T) {
+	var (
+		// 1 -> 2 -> 3 -> 4, 2 -> 5, 5 -> 4, 6 -> 2, 3 <-> 7, 8 -> 9
+		outbound = analysis.StaticOutboundAdjacency{
+			1: {{EndID: 2, Kind: ad.MemberOf}},
+			2: {{EndID: 3, Kind: ad.GenericAll}, {EndID: 5, Kind: ad.AdminTo}},
+			3: {{EndID: 4, Kind: ad.DCSync}, {EndID: 7, Kind: ad.Owns}},
+			5: {{EndID: 4, Kind: ad.HasSession}},
+			6: {{EndID: 2, Kind: ad.MemberOf}},
+			7: {{EndID: 3, Kind: ad.GenericWrite}},
+			8: {{EndID: 9, Kind: ad.AdminTo}},
+		}
+		tierZero = cardinality.NewBitmap64With(4, 9)
+	)
+
+	result, err := analysis.ComputeBlastRadius(context.Background(), outbound, []graph.ID{6, 1}, tierZero)
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []uint64{2, 3, 4, 5, 7}, result.Reachable.Slice())
+	require.ElementsMatch(t, []uint64{2, 3, 4, 5, 7}, result.ReachableByOwned[1].Slice())
+	require.ElementsMatch(t, []uint64{2, 3, 4, 5, 7}, result.ReachableByOwned[6].Slice())
+
+	require.Len(t, result.ShortestPaths, 1)
+	path := result.ShortestPaths[4]
+	require.Len(t, path.NodeIDs, 4)
+	require.Contains(t, []graph.ID{1, 6}, path.NodeIDs[0])
+	require.Equal(t, []graph.ID{2, 3, 4}, path.NodeIDs[1:])
+	require.Equal(t, graph.Kinds{ad.MemberOf, ad.GenericAll, ad.DCSync}, path.Kinds)
+}
+
+func TestComputeBlastRadius_OwnedNodesAreExcluded(t *testing.T) {
+	var (
+		// 1 -> 2 -> 3 where 1 and 2 are both owned
+		outbound = analysis.StaticOutboundAdjacency{
+			1: {{EndID: 2, Kind: ad.MemberOf}},
+			2: {{EndID: 3, Kind: ad.GenericAll}},
+		}
+	)
+
+	result, err := analysis.ComputeBlastRadius(context.Background(), outbound, []graph.ID{1, 2}, cardinality.NewBitmap64With(2, 3))
+	require.NoError(t, err)
+
+	require.Equal(t, []uint64{3}, result.Reachable.Slice())
+	require.ElementsMatch(t, []uint64{2, 3}, result.ReachableByOwned[1].Slice())
+	require.Equal(t, []uint64{3}, result.ReachableByOwned[2].Slice())
+	require.Equal(t, analysis.BlastRadiusPath{NodeIDs: []graph.ID{2, 3}, Kinds: graph.Kinds{ad.GenericAll}}, result.ShortestPaths[3])
+}
+
+// exhaustedOutboundAdjacency fails every load as if the adjacency budget had run out
+type exhaustedOutboundAdjacency struct {
+	analysis.StaticOutboundAdjacency
+}
+
+func (s exhaustedOutboundAdjacency) Load(ctx context.Context, nodeIDs []graph.ID) error {
+	return analysis.ErrAdjacencyTooLarge
+}
+
+func TestComputeBlastRadius_AdjacencyTooLarge(t *testing.T) {
+	_, err := analysis.ComputeBlastRadius(context.Background(), exhaustedOutboundAdjacency{}, []graph.ID{1}, cardinality.NewBitmap64())
+	require.ErrorIs(t, err, analysis.ErrAdjacencyTooLarge)
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/specterops/bloodhound/packages/go/analysis/impact"
+	"github.com/specterops/bloodhound/packages/go/analysis/tiering"
+	"github.com/specterops/bloodhound/packages/go/bhlog/measure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/ad"
+	"github.com/specterops/bloodhound/packages/go/graphschema/azure"
+	"github.com/specterops/bloodhound/packages/go/graphschema/common"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+	"github.com/specterops/dawgs/ops"
+	"github.com/specterops/dawgs/query"
+)
+
+// ExposurePrincipalKinds are the node kinds counted towards a node's inbound exposure
+var ExposurePrincipalKinds = graph.Kinds{ad.User, ad.Computer, ad.Group, azure.User, azure.Group, azure.ServicePrincipal}
+
+// exposureWriteBatchSize is the number of nodes updated per write transaction when storing exposure metrics
+const exposureWriteBatchSize = 5_000
+
+// ExposureMetrics contains the per-node counts computed by PostExposureMetrics. Nodes with a count of zero are omitted.
+type ExposureMetrics struct {
+	// InboundExposure is the number of principals with a path to a target node
+	InboundExposure map[graph.ID]int
+
+	// OutboundImpact is the number of Tier Zero nodes reachable from a principal
+	OutboundImpact map[graph.ID]int
+}
+
+// PostExposureMetrics computes inbound exposure for target nodes, those carrying one of targetKinds or the Tier Zero
+// system tag, and outbound impact for principals. The counts are written to the node properties
+// common.InboundExposure and common.OutboundImpact; counts left over from a previous run are cleared.
+func PostExposureMetrics(ctx context.Context, db graph.Database, targetKinds graph.Kinds) (ExposureMetrics, error) {
+	defer measure.ContextMeasure(ctx, slog.LevelInfo, "PostExposureMetrics")()
+
+	var (
+		inbound        = map[graph.ID][]graph.ID{}
+		principals     cardinality.Duplex[uint64]
+		targets        cardinality.Duplex[uint64]
+		tierZero       cardinality.Duplex[uint64]
+		traversal      = append(ad.PathfindingRelationships(), azure.PathfindingRelationships()...)
+		tierZeroFilter = query.Or(
+			query.KindIn(query.Node(), tiering.KindTagTierZero),
+			query.StringContains(query.NodeProperty(common.SystemTags.String()), ad.AdminTierZero),
+		)
+		targetFilter = tierZeroFilter
+	)
+
+	if len(targetKinds) > 0 {
+		targetFilter = query.Or(query.KindIn(query.Node(), targetKinds...), tierZeroFilter)
+	}
+
+	if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if err := tx.Relationships().Filter(query.KindIn(query.Relationship(), traversal...)).FetchTriples(func(cursor graph.Cursor[graph.RelationshipTripleResult]) error {
+			for next := range cursor.Chan() {
+				if next.StartID != next.EndID {
+					inbound[next.EndID] = append(inbound[next.EndID], next.StartID)
+				}
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return err
+		} else if principalIDs, err := ops.FetchNodeIDs(tx.Nodes().Filter(query.KindIn(query.Node(), ExposurePrincipalKinds...))); err != nil {
+			return err
+		} else if targetIDs, err := ops.FetchNodeIDs(tx.Nodes().Filter(targetFilter)); err != nil {
+			return err
+		} else if tierZeroIDs, err := ops.FetchNodeIDs(tx.Nodes().Filter(tierZeroFilter)); err != nil {
+			return err
+		} else {
+			principals = cardinality.NewBitmap64With(graph.IDsToUint64Slice(principalIDs)...)
+			targets = cardinality.NewBitmap64With(graph.IDsToUint64Slice(targetIDs)...)
+			tierZero = cardinality.NewBitmap64With(graph.IDsToUint64Slice(tierZeroIDs)...)
+			return nil
+		}
+	}); err != nil {
+		return ExposureMetrics{}, fmt.Errorf("failed fetching exposure inputs: %w", err)
+	}
+
+	slog.InfoContext(ctx, fmt.Sprintf("Computing exposure metrics for %d target nodes", targets.Cardinality()))
+
+	metrics := AggregateExposureMetrics(inbound, principals, targets, tierZero)
+
+	if err := ClearExposureMetrics(ctx, db); err != nil {
+		return metrics, err
+	} else if err := writeNodeCounts(ctx, db, common.InboundExposure, metrics.InboundExposure); err != nil {
+		return metrics, fmt.Errorf("failed writing inbound exposure: %w", err)
+	} else if err := writeNodeCounts(ctx, db, common.OutboundImpact, metrics.OutboundImpact); err != nil {
+		return metrics, fmt.Errorf("failed writing outbound impact: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// ClearExposureMetrics removes the exposure metric properties written by an earlier run of PostExposureMetrics
+func ClearExposureMetrics(ctx context.Context, db graph.Database) error {
+	if err := db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		staleProperties := graph.NewProperties()
+		staleProperties.Delete(common.InboundExposure.String())
+		staleProperties.Delete(common.OutboundImpact.String())
+
+		return tx.Nodes().Filter(query.Or(
+			query.IsNotNull(query.NodeProperty(common.InboundExposure.String())),
+			query.IsNotNull(query.NodeProperty(common.OutboundImpact.String())),
+		)).Update(staleProperties)
+	}); err != nil {
+		return fmt.Errorf("failed clearing exposure metrics: %w", err)
+	}
+
+	return nil
+}
+
+// writeNodeCounts sets the given count property on each node. Nodes are grouped by count so that each distinct value
+// only costs a single update per batch of exposureWriteBatchSize nodes, and each batch is written in its own
+// transaction.
+func writeNodeCounts(ctx context.Context, db graph.Database, property common.Property, counts map[graph.ID]int) error {
+	nodesByCount := map[int][]graph.ID{}
+
+	for nodeID, count := range counts {
+		nodesByCount[count] = append(nodesByCount[count], nodeID)
+	}
+
+	for count, nodeIDs := range nodesByCount {
+		properties := graph.NewProperties()
+		properties.Set(property.String(), count)
+
+		for batch := range slices.Chunk(nodeIDs, exposureWriteBatchSize) {
+			if err := db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+				return tx.Nodes().Filter(query.InIDs(query.NodeID(), batch...)).Update(properties)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// AggregateExposureMetrics encodes the inbound paths of every target node into an impact.PathAggregator and resolves
+// the exposure and impact counts from the resulting cardinalities. The inbound adjacency list maps each node to the
+// start nodes of its traversable inbound relationships. Only nodes in targets are given an inbound exposure and only
+// principals are given an outbound impact; tierZero is expected to be a subset of targets.
+func AggregateExposureMetrics(inbound map[graph.ID][]graph.ID, principals, targets, tierZero cardinality.Duplex[uint64]) ExposureMetrics {
+	var (
+		roots   = make([]graph.ID, 0, targets.Cardinality())
+		metrics = ExposureMetrics{
+			InboundExposure: map[graph.ID]int{},
+			OutboundImpact:  map[graph.ID]int{},
+		}
+	)
+
+	targets.Each(func(targetID uint64) bool {
+		if _, hasInbound := inbound[graph.ID(targetID)]; hasInbound {
+			roots = append(roots, graph.ID(targetID))
+		}
+
+		return true
+	})
+
+	aggregator := aggregateInboundPaths(inbound, roots)
+
+	for _, targetID := range roots {
+		exposure := aggregator.Cardinality(targetID.Uint64()).(cardinality.Duplex[uint64])
+		exposure.Remove(targetID.Uint64())
+		exposure.And(principals)
+
+		if count := exposure.Cardinality(); count > 0 {
+			metrics.InboundExposure[targetID] = int(count)
+		}
+
+		if tierZero.Contains(targetID.Uint64()) {
+			exposure.Each(func(nodeID uint64) bool {
+				metrics.OutboundImpact[graph.ID(nodeID)]++
+				return true
+			})
+		}
+	}
+
+	return metrics
+}
+
+// aggregateInboundPaths walks the inbound adjacency list breadth first from each root that has not yet been traversed,
+// encoding terminal paths and shortcuts to already traversed nodes in the same manner as group membership resolution.
+func aggregateInboundPaths(inbound map[graph.ID][]graph.ID, roots []graph.ID) impact.PathAggregator {
+	var (
+		aggregator = impact.NewAggregator(func() cardinality.Provider[uint64] {
+			return cardinality.NewBitmap64()
+		})
+		traversed = cardinality.NewBitmap64()
+	)
+
+	// Traversal order affects which paths are encoded as shortcuts so keep it stable between runs
+	slices.Sort(roots)
+
+	for _, rootID := range roots {
+		if traversed.Contains(rootID.Uint64()) {
+			continue
+		}
+
+		frontier := []*graph.PathSegment{graph.NewRootPathSegment(graph.NewNode(rootID, graph.NewProperties()))}
+
+		for len(frontier) > 0 {
+			var (
+				segment      = frontier[0]
+				nextSegments []*graph.PathSegment
+			)
+
+			frontier = frontier[1:]
+
+			for _, startID := range inbound[segment.Node.ID] {
+				nextSegment := segment.Descend(
+					graph.NewNode(startID, graph.NewProperties()),
+					graph.NewRelationship(0, startID, segment.Node.ID, graph.NewProperties(), nil),
+				)
+
+				if traversed.CheckedAdd(startID.Uint64()) {
+					nextSegments = append(nextSegments, nextSegment)
+				} else {
+					aggregator.AddShortcut(nextSegment)
+				}
+			}
+
+			// Is this path terminal?
+			if len(nextSegments) == 0 {
+				aggregator.AddPath(segment)
+			}
+
+			frontier = append(frontier, nextSegments...)
+		}
+	}
+
+	return aggregator
+}
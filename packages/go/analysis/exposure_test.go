@@ -0,0 +1,99 @@
+// Copyright 2026 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/packages/go/analysis"
+	"github.com/specterops/dawgs/cardinality"
+	"github.com/specterops/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateExposureMetrics(t *testing.T) {
+	var (
+		// 1 -> 2, 5 -> 2, 2 <-> 3, 3 -> 4, 6 -> 4, 7 <-> 8, 8 -> 4
+		inbound = map[graph.ID][]graph.ID{
+			2: {1, 5, 3},
+			3: {2},
+			4: {3, 6, 8},
+			7: {8},
+			8: {7},
+		}
+		principals = cardinality.NewBitmap64With(1, 2, 3, 5, 6, 7, 8)
+		targets    = cardinality.NewBitmap64With(2, 3, 4, 7, 8)
+		tierZero   = cardinality.NewBitmap64With(4)
+	)
+
+	metrics := analysis.AggregateExposureMetrics(inbound, principals, targets, tierZero)
+
+	require.Equal(t, map[graph.ID]int{
+		2: 3,
+		3: 3,
+		4: 7,
+		7: 1,
+		8: 1,
+	}, metrics.InboundExposure)
+
+	require.Equal(t, map[graph.ID]int{
+		1: 1,
+		2: 1,
+		3: 1,
+		5: 1,
+		6: 1,
+		7: 1,
+		8: 1,
+	}, metrics.OutboundImpact)
+}
+
+func TestAggregateExposureMetrics_PrincipalsOnly(t *testing.T) {
+	var (
+		// 1 -> 2 -> 3 where 2 is not a principal, e.g. an OU
+		inbound = map[graph.ID][]graph.ID{
+			2: {1},
+			3: {2},
+		}
+		principals = cardinality.NewBitmap64With(1, 3)
+		targets    = cardinality.NewBitmap64With(2, 3)
+		tierZero   = cardinality.NewBitmap64()
+	)
+
+	metrics := analysis.AggregateExposureMetrics(inbound, principals, targets, tierZero)
+
+	require.Equal(t, map[graph.ID]int{2: 1, 3: 1}, metrics.InboundExposure)
+	require.Empty(t, metrics.OutboundImpact)
+}
+
+func TestAggregateExposureMetrics_TargetsOnly(t *testing.T) {
+	var (
+		// 1 -> 2 -> 3 -> 4 where only 4 is tagged and 2 is not a principal
+		inbound = map[graph.ID][]graph.ID{
+			2: {1},
+			3: {2},
+			4: {3},
+		}
+		principals = cardinality.NewBitmap64With(1, 3)
+		targets    = cardinality.NewBitmap64With(4)
+		tierZero   = cardinality.NewBitmap64With(4)
+	)
+
+	metrics := analysis.AggregateExposureMetrics(inbound, principals, targets, tierZero)
+
+	require.Equal(t, map[graph.ID]int{4: 2}, metrics.InboundExposure)
+	require.Equal(t, map[graph.ID]int{1: 1, 3: 1}, metrics.OutboundImpact)
+}
@@ -66,3 +66,20 @@ func searchTierNodesRel(tieringEnabled bool, tierKinds ...graph.Kind) graph.Crit
 		return query.StringContains(query.StartProperty(common.SystemTags.String()), ad.AdminTierZero)
 	}
 }
+
+// SearchTagNodes returns criteria matching nodes that carry the given asset group tag kind. When tiering is disabled
+// only the legacy tier zero and owned system tags can be matched.
+func SearchTagNodes(tieringEnabled bool, tagKind graph.Kind) graph.Criteria {
+	if tieringEnabled {
+		return query.Kind(query.Node(), tagKind)
+	}
+
+	switch tagKind {
+	case KindTagTierZero:
+		return SearchTierNodes(tieringEnabled)
+	case KindTagOwned:
+		return query.StringContains(query.NodeProperty(common.SystemTags.String()), ad.Owned)
+	default:
+		return query.Kind(query.Node(), tagKind)
+	}
+}
@@ -68,10 +68,12 @@ const (
 	IsInherited     Property = "isinherited"
 	CompositionID   Property = "compositionid"
 	PrimaryKind     Property = "primarykind"
+	InboundExposure Property = "inboundexposure"
+	OutboundImpact  Property = "outboundimpact"
 )
 
 func AllProperties() []Property {
-	return []Property{ObjectID, Name, DisplayName, Description, OwnerObjectID, Collected, OperatingSystem, SystemTags, UserTags, LastSeen, LastCollected, WhenCreated, Enabled, PasswordLastSet, Title, Email, IsInherited, CompositionID, PrimaryKind}
+	return []Property{ObjectID, Name, DisplayName, Description, OwnerObjectID, Collected, OperatingSystem, SystemTags, UserTags, LastSeen, LastCollected, WhenCreated, Enabled, PasswordLastSet, Title, Email, IsInherited, CompositionID, PrimaryKind, InboundExposure, OutboundImpact}
 }
 func ParseProperty(source string) (Property, error) {
 	switch source {
@@ -113,6 +115,10 @@ func ParseProperty(source string) (Property, error) {
 		return CompositionID, nil
 	case "primarykind":
 		return PrimaryKind, nil
+	case "inboundexposure":
+		return InboundExposure, nil
+	case "outboundimpact":
+		return OutboundImpact, nil
 	default:
 		return "", errors.New("Invalid enumeration value: " + source)
 	}
@@ -157,6 +163,10 @@ func (s Property) String() string {
 		return string(CompositionID)
 	case PrimaryKind:
 		return string(PrimaryKind)
+	case InboundExposure:
+		return string(InboundExposure)
+	case OutboundImpact:
+		return string(OutboundImpact)
 	default:
 		return "Invalid enumeration case: " + string(s)
 	}
@@ -201,6 +211,10 @@ func (s Property) Name() string {
 		return "Composition ID"
 	case PrimaryKind:
 		return "Primary Kind"
+	case InboundExposure:
+		return "Inbound Exposure"
+	case OutboundImpact:
+		return "Outbound Impact"
 	default:
 		return "Invalid enumeration case: " + string(s)
 	}
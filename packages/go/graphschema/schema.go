@@ -69,6 +69,23 @@ func CombinedGraphSchema(name string) graph.Graph {
 				Field: azure.TenantID.String(),
 				Type:  graph.BTreeIndex,
 			},
+			// The following text search indexes back property search
+			{
+				Field: common.Description.String(),
+				Type:  graph.TextSearchIndex,
+			},
+			{
+				Field: common.Email.String(),
+				Type:  graph.TextSearchIndex,
+			},
+			{
+				Field: ad.DistinguishedName.String(),
+				Type:  graph.TextSearchIndex,
+			},
+			{
+				Field: ad.ServicePrincipalNames.String(),
+				Type:  graph.TextSearchIndex,
+			},
 		},
 	}
 }
@@ -29,6 +29,9 @@ var (
 	StartNode         = newParam("start_node", nil)
 	EndNode           = newParam("end_node", nil)
 	RelationshipKinds = newParam("relationship_kinds", containsPredicate)
+	ExcludeNodes      = newParam("exclude_nodes", nil)
+	ExcludeKinds      = newParam("exclude_kinds", listPredicate)
+	ExcludeProperty   = newParam("exclude_property", propertyPredicate)
 )
 
 // param is an immutable path or query parameter
@@ -20,4 +20,6 @@ import "regexp"
 
 var (
 	containsPredicate = regexp.MustCompile(`^(in|nin):(\w+)(,\s*\w+)*$`)
+	listPredicate     = regexp.MustCompile(`^(\w+)(,\s*\w+)*$`)
+	propertyPredicate = regexp.MustCompile(`^(\w+):(eq|neq|gt|gte|lt|lte|olderthan):([\w.\-]+)$`)
 )
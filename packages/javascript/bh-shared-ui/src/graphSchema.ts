@@ -385,6 +385,7 @@ export enum ActiveDirectoryKindProperties {
     Department = 'department',
     HasCrossCertificatePair = 'hascrosscertificatepair',
     HasSPN = 'hasspn',
+    ServicePrincipalNames = 'serviceprincipalnames',
     UnconstrainedDelegation = 'unconstraineddelegation',
     LastLogon = 'lastlogon',
     LastLogonTimestamp = 'lastlogontimestamp',
@@ -555,6 +556,8 @@ export function ActiveDirectoryKindPropertiesToDisplay(value: ActiveDirectoryKin
             return 'Has Cross Certificate Pair';
         case ActiveDirectoryKindProperties.HasSPN:
             return 'Has SPN';
+        case ActiveDirectoryKindProperties.ServicePrincipalNames:
+            return 'Service Principal Names';
         case ActiveDirectoryKindProperties.UnconstrainedDelegation:
             return 'Allows Unconstrained Delegation';
         case ActiveDirectoryKindProperties.LastLogon:
@@ -1921,6 +1924,8 @@ export enum CommonKindProperties {
     IsInherited = 'isinherited',
     CompositionID = 'compositionid',
     PrimaryKind = 'primarykind',
+    InboundExposure = 'inboundexposure',
+    OutboundImpact = 'outboundimpact',
 }
 export function CommonKindPropertiesToDisplay(value: CommonKindProperties): string | undefined {
     switch (value) {
@@ -1962,6 +1967,10 @@ export function CommonKindPropertiesToDisplay(value: CommonKindProperties): stri
             return 'Composition ID';
         case CommonKindProperties.PrimaryKind:
             return 'Primary Kind';
+        case CommonKindProperties.InboundExposure:
+            return 'Inbound Exposure';
+        case CommonKindProperties.OutboundImpact:
+            return 'Outbound Impact';
         default:
             return undefined;
     }